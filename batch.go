@@ -0,0 +1,116 @@
+package passcheck
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/safemem"
+)
+
+// maxLineBytes bounds the line buffer used by [CheckReader]: enough bytes
+// for MaxPasswordLength runes of any UTF-8 width.
+const maxLineBytes = MaxPasswordLength * utf8.UTFMax
+
+// CheckBatch evaluates many passwords under one configuration, for offline
+// audits such as scanning a plaintext dump during a migration. It is
+// [CheckManyParallel] with cfg.Parallelism workers.
+//
+// Results are returned in input order: results[i] is the result for
+// passwords[i] and equals CheckWithConfig(passwords[i], cfg). Configured
+// hooks run on the worker goroutines and must be safe for concurrent use.
+// CheckBatch returns an error only if cfg is invalid.
+func CheckBatch(passwords []string, cfg Config) ([]Result, error) {
+	return CheckManyParallel(passwords, cfg, cfg.Parallelism)
+}
+
+// CheckManyParallel evaluates many passwords under one configuration using
+// a bounded pool of workers goroutines (runtime.GOMAXPROCS(0) when workers
+// is zero or negative; 1 checks serially). cfg is validated once, and the
+// built-in dictionaries and keyboard tables are immutable and shared by
+// all workers without locking.
+//
+// Results are returned in input order: results[i] is the result for
+// passwords[i] and equals CheckWithConfig(passwords[i], cfg). Hooks and an
+// HIBPChecker run on the worker goroutines and must be safe for concurrent
+// use. HIBP lookups benefit most from extra workers, since each one spends
+// most of its time waiting on the network; for purely local checks more
+// workers than CPUs gains nothing. CheckManyParallel returns an error only
+// if cfg is invalid.
+func CheckManyParallel(passwords []string, cfg Config, workers int) ([]Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(passwords))
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(passwords))
+
+	ctx := context.Background()
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = checkValidated(ctx, passwords[i], cfg)
+			}
+		}()
+	}
+	for i := range passwords {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// CheckReader reads newline-delimited passwords from r and calls fn with
+// the 1-based line number and the result of checking that line under cfg,
+// for auditing a leaked credentials file without loading it into memory.
+//
+// Lines may end in "\n" or "\r\n"; blank lines are skipped but still
+// counted. Each line buffer is zeroed after it is checked, as in
+// [CheckBytes]. Lines are expected to be UTF-8; malformed lines are still
+// checked and produce a valid Result. Lines longer than MaxPasswordLength
+// runes are truncated, as with every check.
+//
+// CheckReader returns an error if cfg is invalid or reading from r fails;
+// it returns nil at end of input.
+func CheckReader(r io.Reader, cfg Config, fn func(line int, result Result)) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	br := bufio.NewReaderSize(r, maxLineBytes)
+
+	for line := 1; ; line++ {
+		buf, err := br.ReadSlice('\n')
+		password := bytes.TrimRight(buf, "\r\n")
+		if len(password) > 0 {
+			fn(line, checkValidated(ctx, string(password), cfg))
+		}
+		safemem.Zero(buf)
+
+		// Discard the remainder of an over-long line.
+		for errors.Is(err, bufio.ErrBufferFull) {
+			buf, err = br.ReadSlice('\n')
+			safemem.Zero(buf)
+		}
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package passcheck
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckBatch_InputOrder(t *testing.T) {
+	passwords := []string{"", "password", "qwerty123", "Xk9$mP2!vR7@nL4&wQ", "aaaBBB111"}
+	for i := 0; i < 50; i++ {
+		passwords = append(passwords, fmt.Sprintf("Batch%d!pw%d", i, i*7))
+	}
+
+	for _, parallelism := range []int{0, 1, 3, 100} {
+		cfg := DefaultConfig()
+		cfg.Parallelism = parallelism
+		results, err := CheckBatch(passwords, cfg)
+		if err != nil {
+			t.Fatalf("Parallelism=%d: %v", parallelism, err)
+		}
+		if len(results) != len(passwords) {
+			t.Fatalf("Parallelism=%d: got %d results, want %d", parallelism, len(results), len(passwords))
+		}
+		for i, pw := range passwords {
+			want, _ := CheckWithConfig(pw, cfg)
+			if !reflect.DeepEqual(results[i], want) {
+				t.Errorf("Parallelism=%d: results[%d] for %q = %+v, want %+v", parallelism, i, pw, results[i], want)
+			}
+		}
+	}
+}
+
+func TestCheckBatch_Empty(t *testing.T) {
+	results, err := CheckBatch(nil, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("got %d results, want 0", len(results))
+	}
+}
+
+func TestCheckBatch_InvalidConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Parallelism = -1
+	if _, err := CheckBatch([]string{"password"}, cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestCheckManyParallel_MatchesSerial(t *testing.T) {
+	passwords := []string{"", "password", "Summer2026!", "dlrowolleh", "Xk9$mP2!vR7@nL4&wQ", "correct horse battery staple"}
+	for i := 0; i < 200; i++ {
+		passwords = append(passwords, fmt.Sprintf("Acme%dstaff!%d", i, i*13))
+	}
+	cfg := DefaultConfig()
+	cfg.HotList = []string{"summer2026!"}
+	cfg.ForbiddenPatterns = []string{`(?i)staff`}
+	cfg.ContextWords = []string{"acme"}
+	cfg.PassphraseMode = true
+	cfg.HIBPResult = &HIBPCheckResult{Breached: true, Count: 2}
+
+	serial, err := CheckManyParallel(passwords, cfg, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, workers := range []int{0, -1, 4, 64} {
+		parallel, err := CheckManyParallel(passwords, cfg, workers)
+		if err != nil {
+			t.Fatalf("workers=%d: %v", workers, err)
+		}
+		if !reflect.DeepEqual(parallel, serial) {
+			t.Errorf("workers=%d: parallel results differ from serial", workers)
+		}
+	}
+	for i, pw := range passwords[:6] {
+		want, _ := CheckWithConfig(pw, cfg)
+		if !reflect.DeepEqual(serial[i], want) {
+			t.Errorf("serial[%d] for %q = %+v, want %+v", i, pw, serial[i], want)
+		}
+	}
+}
+
+func TestCheckManyParallel_InvalidConfig(t *testing.T) {
+	if _, err := CheckManyParallel([]string{"password"}, Config{}, 2); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func BenchmarkCheckBatch(b *testing.B) {
+	passwords := make([]string, 1000)
+	for i := range passwords {
+		passwords[i] = fmt.Sprintf("Bench%dpass!%d", i, i%97)
+	}
+	cfg := DefaultConfig()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = CheckBatch(passwords, cfg)
+	}
+}
+
+func TestCheckReader(t *testing.T) {
+	input := "password\r\nXk9$mP2!vR7@nL4&wQ\n\nqwerty123"
+	var lines []int
+	var results []Result
+	err := CheckReader(strings.NewReader(input), DefaultConfig(), func(line int, r Result) {
+		lines = append(lines, line)
+		results = append(results, r)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 4}; !reflect.DeepEqual(lines, want) {
+		t.Fatalf("lines = %v, want %v", lines, want)
+	}
+	for i, pw := range []string{"password", "Xk9$mP2!vR7@nL4&wQ", "qwerty123"} {
+		want, _ := CheckWithConfig(pw, DefaultConfig())
+		if !reflect.DeepEqual(results[i], want) {
+			t.Errorf("line %d: result = %+v, want %+v", lines[i], results[i], want)
+		}
+	}
+}
+
+func TestCheckReader_MalformedAndLongLines(t *testing.T) {
+	long := strings.Repeat("a", maxLineBytes+100)
+	input := "ok\xff\xfepass\n" + long + "\nafter"
+	var lines []int
+	err := CheckReader(strings.NewReader(input), DefaultConfig(), func(line int, r Result) {
+		lines = append(lines, line)
+		if r.Verdict == "" {
+			t.Errorf("line %d: empty verdict", line)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %v, want %v", lines, want)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("disk error") }
+
+func TestCheckReader_Errors(t *testing.T) {
+	noop := func(int, Result) {}
+	if err := CheckReader(strings.NewReader("x"), Config{}, noop); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("err = %v, want ErrInvalidConfig", err)
+	}
+	if err := CheckReader(failingReader{}, DefaultConfig(), noop); err == nil || err.Error() != "disk error" {
+		t.Errorf("err = %v, want read error", err)
+	}
+}
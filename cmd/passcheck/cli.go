@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/rafaelsanzio/passcheck"
+	"github.com/rafaelsanzio/passcheck/report"
 )
 
 // Exit codes returned by [run].
@@ -17,15 +19,39 @@ const (
 	exitUsageError = 2 // invalid arguments
 )
 
+// policies maps the --policy names to their preset configurations.
+var policies = map[string]func() passcheck.Config{
+	"nist":          passcheck.NISTConfig,
+	"owasp":         passcheck.OWASPConfig,
+	"pci-dss":       passcheck.PCIDSSConfig,
+	"enterprise":    passcheck.EnterpriseConfig,
+	"user-friendly": passcheck.UserFriendlyConfig,
+}
+
+// policyNames returns the valid --policy names, sorted.
+func policyNames() []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
 // options holds the parsed CLI flags and arguments.
 type options struct {
 	password  string
+	stdin     bool   // read the password from stdin
+	file      string // audit newline-delimited passwords from this file
 	json      bool
+	csv       bool // with --file: emit CSV instead of text lines
 	verbose   bool
+	explain   bool // print the scoring math
 	noColor   bool
 	help      bool
 	showVer   bool
-	minLength int // 0 = use default
+	minLength int    // 0 = use default
+	policy    string // preset name; empty = DefaultConfig
 }
 
 // errWriter wraps an io.Writer and records the first write error.
@@ -65,10 +91,16 @@ func parseArgs(args []string) (options, error) {
 		// Parse flags (unless we've seen "--").
 		if !flagsDone && strings.HasPrefix(arg, "-") {
 			switch {
+			case arg == "--stdin":
+				opts.stdin = true
 			case arg == "--json":
 				opts.json = true
+			case arg == "--csv":
+				opts.csv = true
 			case arg == "--verbose" || arg == "-v":
 				opts.verbose = true
+			case arg == "--explain":
+				opts.explain = true
 			case arg == "--no-color":
 				opts.noColor = true
 			case arg == "--help" || arg == "-h":
@@ -82,6 +114,17 @@ func parseArgs(args []string) (options, error) {
 					return opts, fmt.Errorf("invalid --min-length value: %q (must be a positive integer)", val)
 				}
 				opts.minLength = n
+			case strings.HasPrefix(arg, "--file="):
+				opts.file = strings.TrimPrefix(arg, "--file=")
+				if opts.file == "" {
+					return opts, fmt.Errorf("invalid --file value: path required")
+				}
+			case strings.HasPrefix(arg, "--policy="):
+				val := strings.TrimPrefix(arg, "--policy=")
+				if _, ok := policies[val]; !ok {
+					return opts, fmt.Errorf("invalid --policy value: %q (valid: %s)", val, strings.Join(policyNames(), ", "))
+				}
+				opts.policy = val
 			default:
 				return opts, fmt.Errorf("unknown flag: %s\nRun 'passcheck --help' for usage", arg)
 			}
@@ -95,14 +138,28 @@ func parseArgs(args []string) (options, error) {
 		opts.password = arg
 	}
 
+	if opts.stdin && opts.password != "" {
+		return opts, fmt.Errorf("--stdin cannot be combined with a password argument")
+	}
+	if opts.file != "" && (opts.stdin || opts.password != "") {
+		return opts, fmt.Errorf("--file cannot be combined with --stdin or a password argument")
+	}
+	if opts.csv && (opts.file == "" || opts.json) {
+		return opts, fmt.Errorf("--csv requires --file and cannot be combined with --json")
+	}
+	if opts.explain && (opts.json || opts.file != "") {
+		return opts, fmt.Errorf("--explain cannot be combined with --json or --file")
+	}
+
 	return opts, nil
 }
 
 // run executes the CLI logic and returns the exit code.
 //
-// stdout and stderr are the output writers; envNoColor reflects
-// whether the NO_COLOR environment variable is set.
-func run(stdout, stderr io.Writer, args []string, envNoColor bool) int {
+// stdin supplies the password for --stdin; stdout and stderr are the
+// output writers; envNoColor reflects whether the NO_COLOR environment
+// variable is set.
+func run(stdin io.Reader, stdout, stderr io.Writer, args []string, envNoColor bool) int {
 	ew := &errWriter{w: stderr}
 
 	opts, parseErr := parseArgs(args)
@@ -132,14 +189,11 @@ func run(stdout, stderr io.Writer, args []string, envNoColor bool) int {
 		return exitOK
 	}
 
-	if opts.password == "" {
-		_, _ = fmt.Fprintln(ew, "Error: password argument required")
-		_, _ = fmt.Fprintln(ew, "Run 'passcheck --help' for usage")
-		return exitError
-	}
-
-	// Build config from defaults + CLI overrides.
+	// Build config from defaults or the selected preset + CLI overrides.
 	cfg := passcheck.DefaultConfig()
+	if opts.policy != "" {
+		cfg = policies[opts.policy]()
+	}
 	if opts.minLength > 0 {
 		cfg.MinLength = opts.minLength
 	}
@@ -147,6 +201,29 @@ func run(stdout, stderr io.Writer, args []string, envNoColor bool) int {
 		cfg.MaxIssues = 0 // show all issues
 	}
 
+	if opts.file != "" {
+		return runFile(stdout, stderr, opts, cfg)
+	}
+
+	if opts.stdin {
+		pw, readErr := readPassword(stdin, stderr)
+		if readErr != nil {
+			_, _ = fmt.Fprintf(ew, "Error: %v\n", readErr)
+			return exitError
+		}
+		opts.password = pw
+	}
+
+	if opts.password == "" {
+		_, _ = fmt.Fprintln(ew, "Error: password argument required (or use --stdin)")
+		_, _ = fmt.Fprintln(ew, "Run 'passcheck --help' for usage")
+		return exitError
+	}
+
+	if opts.explain {
+		return runExplain(stdout, stderr, opts, cfg, !opts.noColor && !envNoColor)
+	}
+
 	result, checkErr := passcheck.CheckWithConfig(opts.password, cfg)
 	if checkErr != nil {
 		_, _ = fmt.Fprintf(ew, "Error: %v\n", checkErr)
@@ -168,56 +245,51 @@ func run(stdout, stderr io.Writer, args []string, envNoColor bool) int {
 // printResult writes the formatted human-readable result and returns any
 // write error encountered.
 func printResult(w io.Writer, r passcheck.Result, opts options, useColor bool) error {
-	ew := &errWriter{w: w}
-
-	// Score line with visual meter.
-	_, _ = fmt.Fprintf(ew, "Score:   %s\n", scoreMeter(r.Score, useColor))
+	return report.Write(w, r, report.ReportOptions{Color: useColor, Verbose: opts.verbose})
+}
 
-	// Verdict with color.
-	verdict := r.Verdict
-	if useColor {
-		verdict = colorize(r.Verdict, verdictColor(r.Verdict))
+// runExplain checks opts.password and prints the result followed by the
+// scoring math that produced its score.
+func runExplain(stdout, stderr io.Writer, opts options, cfg passcheck.Config, useColor bool) int {
+	ew := &errWriter{w: stderr}
+	df, err := passcheck.CheckDetailed(opts.password, cfg)
+	if err != nil {
+		_, _ = fmt.Fprintf(ew, "Error: %v\n", err)
+		return exitError
 	}
-	_, _ = fmt.Fprintf(ew, "Verdict: %s\n", verdict)
-
-	// Entropy (always in verbose, otherwise only when there are no issues).
-	if opts.verbose {
-		_, _ = fmt.Fprintf(ew, "Entropy: %.2f bits\n", r.Entropy)
-	} else {
-		_, _ = fmt.Fprintf(ew, "Entropy: %.1f bits\n", r.Entropy)
+	printErr := printResult(stdout, df.Result, opts, useColor)
+	if printErr == nil {
+		printErr = printExplanation(stdout, df.Explain())
 	}
-
-	// Issues.
-	if len(r.Issues) > 0 {
-		if opts.verbose {
-			_, _ = fmt.Fprintf(ew, "\nIssues (%d):\n", len(r.Issues))
-		} else {
-			_, _ = fmt.Fprintln(ew, "\nIssues:")
-		}
-		for _, iss := range r.Issues {
-			marker := "  - "
-			if useColor {
-				marker = "  " + colorize("-", ansiRed) + " "
-			}
-			_, _ = fmt.Fprintf(ew, "%s%s\n", marker, iss.Message)
-		}
+	if printErr != nil {
+		_, _ = fmt.Fprintf(ew, "Error writing output: %v\n", printErr)
+		return exitError
 	}
+	return exitOK
+}
 
-	// Strengths / suggestions.
-	if len(r.Suggestions) > 0 {
-		_, _ = fmt.Fprintln(ew, "\nStrengths:")
-		for _, s := range r.Suggestions {
-			marker := "  + "
-			if useColor {
-				marker = "  " + colorize("+", ansiGreen) + " "
-			}
-			_, _ = fmt.Fprintf(ew, "%s%s\n", marker, s)
-		}
-	}
+// printExplanation writes the steps of a score trace and returns any
+// write error encountered.
+func printExplanation(w io.Writer, t passcheck.ScoreTrace) error {
+	ew := &errWriter{w: w}
 
-	if len(r.Issues) == 0 && len(r.Suggestions) == 0 {
-		_, _ = fmt.Fprintln(ew, "\nNo issues found.")
+	_, _ = fmt.Fprintln(ew, "\nScore math:")
+	_, _ = fmt.Fprintf(ew, "  Entropy base:     %+6d  (%.2f bits → %.2f)\n", int(t.EntropyScore), t.Entropy, t.EntropyScore)
+	_, _ = fmt.Fprintf(ew, "  Length bonus:     %+6d\n", t.LengthBonus)
+	_, _ = fmt.Fprintf(ew, "  Charset bonus:    %+6d\n", t.CharsetBonus)
+	if t.PassphraseBonus != 0 {
+		_, _ = fmt.Fprintf(ew, "  Passphrase bonus: %+6d\n", t.PassphraseBonus)
+	}
+	for _, p := range t.Penalties {
+		_, _ = fmt.Fprintf(ew, "  Penalty:          %6.1f  %s: %s\n", -p.Points, p.Issue.Code, p.Issue.Message)
+	}
+	_, _ = fmt.Fprintf(ew, "  Total penalty:    %6d\n", -t.Penalty)
+	_, _ = fmt.Fprintf(ew, "  Raw score:        %6d\n", t.Raw)
+	_, _ = fmt.Fprintf(ew, "  Clamped (0-100):  %6d\n", t.Clamped)
+	if t.Cap != "" {
+		_, _ = fmt.Fprintf(ew, "  Capped:           %6d  (%s)\n", t.Score, t.Cap)
 	}
+	_, _ = fmt.Fprintf(ew, "  Final score:      %6d\n", t.Score)
 
 	return ew.err
 }
@@ -240,12 +312,23 @@ func printHelp(w io.Writer) error {
 
 Usage:
   passcheck <password> [flags]
+  passcheck --stdin [flags]
+  passcheck --file=PATH [flags]
 
 Flags:
+  --stdin             Read the password from stdin instead of the arguments
+  --file=PATH         Check each line of PATH; with --json, emit one JSON
+                      object per line (JSONL)
   --json              Output result as JSON
-  --verbose, -v       Show all issues and extra details
+  --csv               With --file, emit CSV rows: line,score,verdict,entropy,
+                      issue_codes (codes joined by "|")
+  --verbose, -v       Show all issues and extra details (incl. keyspace and crack time)
+  --explain           Show how the score was computed: entropy base, each
+                      bonus, each penalty and the issue behind it
   --no-color          Disable colored output
   --min-length=N      Set minimum password length (default: 12)
+  --policy=NAME       Start from a preset policy: nist, owasp, pci-dss,
+                      enterprise, or user-friendly
   --version           Show version
   --help, -h          Show this help message
 
@@ -256,7 +339,12 @@ Examples:
   passcheck "MyP@ssw0rd123!"
   passcheck "qwerty" --json
   passcheck "short" --min-length=8 --verbose
+  passcheck "qwerty123" --explain
+  passcheck "MyP@ssw0rd123!" --policy=pci-dss --json
+  passcheck --file=passwords.txt --json | jq -c 'select(.score < 50)'
+  passcheck --file=passwords.txt --csv > audit.csv
   passcheck -- "-dashpassword"
+  printf '%%s\n' "$PASSWORD" | passcheck --stdin --json
 `, version)
 	return err
 }
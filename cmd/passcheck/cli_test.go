@@ -2,7 +2,11 @@ package main
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -151,13 +155,85 @@ func TestParseArgs_Empty(t *testing.T) {
 	}
 }
 
+func TestParseArgs_Stdin(t *testing.T) {
+	opts, err := parseArgs([]string{"--stdin", "--json"})
+	assertNoError(t, err)
+	if !opts.stdin {
+		t.Error("--stdin should set stdin=true")
+	}
+
+	if _, err := parseArgs([]string{"--stdin", "pw"}); err == nil {
+		t.Error("--stdin with a password argument should be rejected")
+	}
+}
+
+func TestParseArgs_Policy(t *testing.T) {
+	opts, err := parseArgs([]string{"pw", "--policy=pci-dss"})
+	assertNoError(t, err)
+	if opts.policy != "pci-dss" {
+		t.Errorf("policy = %q, want %q", opts.policy, "pci-dss")
+	}
+
+	_, err = parseArgs([]string{"pw", "--policy=hipaa"})
+	if err == nil {
+		t.Fatal("unknown policy should be rejected")
+	}
+	for _, name := range policyNames() {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q should list %q", err, name)
+		}
+	}
+}
+
+func TestParseArgs_File(t *testing.T) {
+	opts, err := parseArgs([]string{"--file=pw.txt", "--json"})
+	assertNoError(t, err)
+	if opts.file != "pw.txt" {
+		t.Errorf("file = %q, want %q", opts.file, "pw.txt")
+	}
+
+	for _, args := range [][]string{{"--file="}, {"--file=pw.txt", "pw"}, {"--file=pw.txt", "--stdin"}} {
+		if _, err := parseArgs(args); err == nil {
+			t.Errorf("parseArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestParseArgs_CSV(t *testing.T) {
+	opts, err := parseArgs([]string{"--file=pw.txt", "--csv"})
+	assertNoError(t, err)
+	if !opts.csv {
+		t.Error("--csv should set csv=true")
+	}
+
+	for _, args := range [][]string{{"pw", "--csv"}, {"--file=pw.txt", "--csv", "--json"}} {
+		if _, err := parseArgs(args); err == nil {
+			t.Errorf("parseArgs(%q) should fail", args)
+		}
+	}
+}
+
+func TestParseArgs_Explain(t *testing.T) {
+	opts, err := parseArgs([]string{"pw", "--explain"})
+	assertNoError(t, err)
+	if !opts.explain {
+		t.Error("--explain should set explain=true")
+	}
+
+	for _, args := range [][]string{{"pw", "--explain", "--json"}, {"--file=pw.txt", "--explain"}} {
+		if _, err := parseArgs(args); err == nil {
+			t.Errorf("parseArgs(%q) should fail", args)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // run (integration)
 // ---------------------------------------------------------------------------
 
 func TestRun_Help(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"--help"}, false)
+	code := run(nil, &stdout, &stderr, []string{"--help"}, false)
 	if code != 0 {
 		t.Errorf("help should exit 0, got %d", code)
 	}
@@ -168,7 +244,7 @@ func TestRun_Help(t *testing.T) {
 
 func TestRun_Version(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"--version"}, false)
+	code := run(nil, &stdout, &stderr, []string{"--version"}, false)
 	if code != 0 {
 		t.Errorf("version should exit 0, got %d", code)
 	}
@@ -179,7 +255,7 @@ func TestRun_Version(t *testing.T) {
 
 func TestRun_NoPassword(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{}, false)
+	code := run(nil, &stdout, &stderr, []string{}, false)
 	if code != 1 {
 		t.Errorf("no password should exit 1, got %d", code)
 	}
@@ -190,7 +266,7 @@ func TestRun_NoPassword(t *testing.T) {
 
 func TestRun_UnknownFlag(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"--bad"}, false)
+	code := run(nil, &stdout, &stderr, []string{"--bad"}, false)
 	if code != 2 {
 		t.Errorf("unknown flag should exit 2, got %d", code)
 	}
@@ -198,7 +274,7 @@ func TestRun_UnknownFlag(t *testing.T) {
 
 func TestRun_StrongPassword(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"Xk9$mP2!vR7@nL4&wQzB", "--no-color"}, false)
+	code := run(nil, &stdout, &stderr, []string{"Xk9$mP2!vR7@nL4&wQzB", "--no-color"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -216,7 +292,7 @@ func TestRun_StrongPassword(t *testing.T) {
 
 func TestRun_WeakPassword(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"password", "--no-color"}, false)
+	code := run(nil, &stdout, &stderr, []string{"password", "--no-color"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -231,7 +307,7 @@ func TestRun_WeakPassword(t *testing.T) {
 
 func TestRun_JSONOutput(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"password", "--json"}, false)
+	code := run(nil, &stdout, &stderr, []string{"password", "--json"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -253,7 +329,7 @@ func TestRun_JSONOutput(t *testing.T) {
 
 func TestRun_VerboseOutput(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"qwerty", "--verbose", "--no-color"}, false)
+	code := run(nil, &stdout, &stderr, []string{"qwerty", "--verbose", "--no-color"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -268,9 +344,89 @@ func TestRun_VerboseOutput(t *testing.T) {
 	}
 }
 
+func TestRun_Explain(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(nil, &stdout, &stderr, []string{"qwerty123", "--explain", "--no-color"}, false)
+	if code != exitOK {
+		t.Fatalf("expected exit 0, got %d (stderr: %s)", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"Verdict:", "Score math:", "Entropy base:", "Charset bonus:", "PATTERN_KEYBOARD", "Raw score:", "Final score:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("--explain output missing %q:\n%s", want, out)
+		}
+	}
+
+	// Normal output is unchanged without the flag.
+	stdout.Reset()
+	run(nil, &stdout, &stderr, []string{"qwerty123", "--no-color"}, false)
+	if strings.Contains(stdout.String(), "Score math:") {
+		t.Errorf("output without --explain should not include the score math:\n%s", stdout.String())
+	}
+}
+
+func TestRun_VerboseKeyspace(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	run(nil, &stdout, &stderr, []string{"Xk9$mP2!vR7@nL4&", "--verbose", "--no-color"}, false)
+	out := stdout.String()
+	if !regexp.MustCompile(`(?m)^Keyspace: ≈ 2\^\d+ guesses; ~\S.* at 10\^10/s$`).MatchString(out) {
+		t.Errorf("verbose output should include keyspace line: %s", out)
+	}
+	if strings.Contains(out, "\033[") {
+		t.Errorf("keyspace line should respect --no-color: %q", out)
+	}
+
+	stdout.Reset()
+	run(nil, &stdout, &stderr, []string{"Xk9$mP2!vR7@nL4&", "--no-color"}, false)
+	if strings.Contains(stdout.String(), "Keyspace:") {
+		t.Errorf("non-verbose output should not include keyspace line: %s", stdout.String())
+	}
+}
+
+func TestRun_CrackTime(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	run(nil, &stdout, &stderr, []string{"abc", "--verbose", "--no-color"}, false)
+	if !strings.Contains(stdout.String(), "; ~instant at 10^10/s") {
+		t.Errorf("verbose output should include crack time: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	run(nil, &stdout, &stderr, []string{"abc", "--json"}, false)
+	for _, want := range []string{`"crack_time_seconds":`, `"crack_time_display": "instant"`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("JSON output should include %s: %s", want, stdout.String())
+		}
+	}
+}
+
+func TestRun_EntropyBreakdown(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	run(nil, &stdout, &stderr, []string{"qwerty123", "--verbose", "--no-color"}, false)
+	out := stdout.String()
+	for _, want := range []string{"Base charset:", "Pattern reduction:", "Markov adjustment:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output should include %q: %s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	run(nil, &stdout, &stderr, []string{"qwerty123", "--json"}, false)
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(stdout.Bytes(), &raw); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	var b passcheck.EntropyBreakdown
+	if err := json.Unmarshal(raw["entropy_breakdown"], &b); err != nil {
+		t.Fatalf("entropy_breakdown missing or invalid: %v\nOutput: %s", err, stdout.String())
+	}
+	if b.FinalEntropy <= 0 || b.BaseCharsetEntropy < b.FinalEntropy {
+		t.Errorf("unexpected breakdown: %+v", b)
+	}
+}
+
 func TestRun_NoColor(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"password", "--no-color"}, false)
+	code := run(nil, &stdout, &stderr, []string{"password", "--no-color"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -281,7 +437,7 @@ func TestRun_NoColor(t *testing.T) {
 
 func TestRun_EnvNoColor(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"password"}, true /* envNoColor */)
+	code := run(nil, &stdout, &stderr, []string{"password"}, true /* envNoColor */)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -292,7 +448,7 @@ func TestRun_EnvNoColor(t *testing.T) {
 
 func TestRun_ColorEnabled(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"password"}, false)
+	code := run(nil, &stdout, &stderr, []string{"password"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -305,7 +461,7 @@ func TestRun_ColorEnabled(t *testing.T) {
 func TestRun_CustomMinLength(t *testing.T) {
 	var stdout, stderr bytes.Buffer
 	// "aB3!xY" (6 chars) — passes with min-length=6.
-	code := run(&stdout, &stderr, []string{"aB3!xY", "--min-length=6", "--no-color"}, false)
+	code := run(nil, &stdout, &stderr, []string{"aB3!xY", "--min-length=6", "--no-color"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
@@ -317,104 +473,185 @@ func TestRun_CustomMinLength(t *testing.T) {
 
 func TestRun_DashPassword(t *testing.T) {
 	var stdout, stderr bytes.Buffer
-	code := run(&stdout, &stderr, []string{"--", "-secret-"}, false)
+	code := run(nil, &stdout, &stderr, []string{"--", "-secret-"}, false)
 	if code != 0 {
 		t.Errorf("expected exit 0, got %d", code)
 	}
 }
 
-// ---------------------------------------------------------------------------
-// color helpers
-// ---------------------------------------------------------------------------
+func TestRun_Stdin(t *testing.T) {
+	for _, input := range []string{"qwerty\n", "qwerty\r\n", "qwerty"} {
+		var stdout, stderr bytes.Buffer
+		code := run(strings.NewReader(input), &stdout, &stderr, []string{"--stdin", "--json"}, false)
+		if code != 0 {
+			t.Fatalf("%q: expected exit 0, got %d (stderr: %q)", input, code, stderr.String())
+		}
+		want, _ := passcheck.CheckWithConfig("qwerty", passcheck.DefaultConfig())
+		var got passcheck.Result
+		if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+			t.Fatalf("%q: invalid JSON: %v", input, err)
+		}
+		if got.Score != want.Score {
+			t.Errorf("%q: score = %d, want %d", input, got.Score, want.Score)
+		}
+		if stderr.Len() != 0 {
+			t.Errorf("%q: piped stdin should not prompt, got stderr %q", input, stderr.String())
+		}
+	}
+}
 
-func TestVerdictColor(t *testing.T) {
-	tests := []struct {
-		verdict string
-		want    string
-	}{
-		{"Very Weak", ansiRed + ansiBold},
-		{"Weak", ansiRed},
-		{"Okay", ansiYellow},
-		{"Strong", ansiGreen},
-		{"Very Strong", ansiGreen + ansiBold},
-		{"Unknown", ""},
-	}
-	for _, tt := range tests {
-		t.Run(tt.verdict, func(t *testing.T) {
-			if got := verdictColor(tt.verdict); got != tt.want {
-				t.Errorf("verdictColor(%q) = %q, want %q", tt.verdict, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestScoreColor(t *testing.T) {
-	tests := []struct {
-		score int
-		want  string
-	}{
-		{0, ansiRed + ansiBold},
-		{20, ansiRed + ansiBold},
-		{21, ansiRed},
-		{40, ansiRed},
-		{41, ansiYellow},
-		{60, ansiYellow},
-		{61, ansiGreen},
-		{80, ansiGreen},
-		{81, ansiGreen + ansiBold},
-		{100, ansiGreen + ansiBold},
-	}
-	for _, tt := range tests {
-		if got := scoreColor(tt.score); got != tt.want {
-			t.Errorf("scoreColor(%d) = %q, want %q", tt.score, got, tt.want)
+func TestRun_StdinEmpty(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(strings.NewReader("\n"), &stdout, &stderr, []string{"--stdin"}, false)
+	if code != 1 {
+		t.Errorf("empty stdin should exit 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "no password read from stdin") {
+		t.Errorf("should show error, got: %q", stderr.String())
+	}
+}
+
+func TestRun_Policy(t *testing.T) {
+	presets := map[string]passcheck.Config{
+		"nist":          passcheck.NISTConfig(),
+		"owasp":         passcheck.OWASPConfig(),
+		"pci-dss":       passcheck.PCIDSSConfig(),
+		"enterprise":    passcheck.EnterpriseConfig(),
+		"user-friendly": passcheck.UserFriendlyConfig(),
+	}
+	const pw = "correcthorse1"
+	for name, cfg := range presets {
+		var stdout, stderr bytes.Buffer
+		code := run(nil, &stdout, &stderr, []string{pw, "--policy=" + name, "--json"}, false)
+		if code != 0 {
+			t.Fatalf("%s: expected exit 0, got %d (stderr: %q)", name, code, stderr.String())
+		}
+		var got passcheck.Result
+		if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+			t.Fatalf("%s: invalid JSON: %v", name, err)
+		}
+		want, _ := passcheck.CheckWithConfig(pw, cfg)
+		if got.Score != want.Score || got.MeetsPolicy != want.MeetsPolicy {
+			t.Errorf("%s: score %d, meets policy %v; want %d, %v", name, got.Score, got.MeetsPolicy, want.Score, want.MeetsPolicy)
 		}
 	}
 }
 
-func TestScoreMeter_NoColor(t *testing.T) {
-	meter := scoreMeter(80, false)
-	if !strings.Contains(meter, "80/100") {
-		t.Errorf("meter should contain '80/100': %s", meter)
+func TestRun_PolicyWithMinLength(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	// PCI-DSS requires 12 characters; --min-length is applied on top.
+	run(nil, &stdout, &stderr, []string{"aB3!xY7#", "--policy=pci-dss", "--min-length=8", "--json"}, false)
+	var got passcheck.Result
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, iss := range got.Issues {
+		if iss.Code == passcheck.CodeRuleTooShort {
+			t.Errorf("--min-length should override the preset minimum: %v", got.Issues)
+		}
 	}
-	if !strings.Contains(meter, "████████") {
-		t.Errorf("meter should have 8 filled blocks: %s", meter)
+}
+
+func TestRun_InvalidPolicy(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(nil, &stdout, &stderr, []string{"pw", "--policy=hipaa"}, false)
+	if code != 2 {
+		t.Errorf("invalid policy should exit 2, got %d", code)
 	}
-	if !strings.Contains(meter, "░░") {
-		t.Errorf("meter should have 2 empty blocks: %s", meter)
+	if !strings.Contains(stderr.String(), "pci-dss") {
+		t.Errorf("error should list valid policies, got: %q", stderr.String())
 	}
 }
 
-func TestScoreMeter_WithColor(t *testing.T) {
-	meter := scoreMeter(80, true)
-	if !strings.Contains(meter, "\033[") {
-		t.Error("colored meter should contain ANSI codes")
+func TestRun_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords.txt")
+	if err := os.WriteFile(path, []byte("dragon\n\r\nXk9$mP2!vR7@nL4&wQ\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(nil, &stdout, &stderr, []string{"--file=" + path, "--json"}, false)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d (stderr: %q)", code, stderr.String())
 	}
-	if !strings.Contains(meter, "80/100") {
-		t.Errorf("meter should contain score: %s", meter)
+	if strings.Contains(stdout.String(), "dragon") {
+		t.Errorf("output must not contain the password: %s", stdout.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %d: %q", len(lines), stdout.String())
+	}
+	wantLines := []int{1, 3}
+	for i, l := range lines {
+		var got struct {
+			Line  int `json:"line"`
+			Score int `json:"score"`
+		}
+		if err := json.Unmarshal([]byte(l), &got); err != nil {
+			t.Fatalf("line %d: invalid JSON: %v", i, err)
+		}
+		if got.Line != wantLines[i] {
+			t.Errorf("line = %d, want %d", got.Line, wantLines[i])
+		}
 	}
 }
 
-func TestScoreMeter_Zero(t *testing.T) {
-	meter := scoreMeter(0, false)
-	if !strings.Contains(meter, "0/100") {
-		t.Errorf("zero meter should show 0/100: %s", meter)
+func TestRun_FileText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords.txt")
+	if err := os.WriteFile(path, []byte("dragon\n"), 0o600); err != nil {
+		t.Fatal(err)
 	}
-	if !strings.Contains(meter, "░░░░░░░░░░") {
-		t.Errorf("zero meter should be all empty: %s", meter)
+
+	var stdout, stderr bytes.Buffer
+	run(nil, &stdout, &stderr, []string{"--file=" + path}, false)
+	if got := stdout.String(); !strings.HasPrefix(got, "1: ") || strings.Contains(got, "dragon") {
+		t.Errorf("unexpected output %q", got)
 	}
 }
 
-func TestScoreMeter_Full(t *testing.T) {
-	meter := scoreMeter(100, false)
-	if !strings.Contains(meter, "██████████") {
-		t.Errorf("full meter should be all filled: %s", meter)
+func TestRun_FileCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords.txt")
+	if err := os.WriteFile(path, []byte("dragon\n\nXk9$mP2!vR7@nL4&wQ\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(nil, &stdout, &stderr, []string{"--file=" + path, "--csv"}, false)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d (stderr: %q)", code, stderr.String())
+	}
+	if strings.Contains(stdout.String(), "dragon") {
+		t.Errorf("output must not contain the password: %s", stdout.String())
+	}
+
+	records, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %q", records)
+	}
+	if got := strings.Join(records[0], ","); got != "line,score,verdict,entropy,issue_codes" {
+		t.Errorf("header = %q", got)
+	}
+	weak := records[1]
+	if weak[0] != "1" || !strings.Contains(weak[4], passcheck.CodeDictCommonPassword) {
+		t.Errorf("row for line 1 = %q", weak)
+	}
+	if records[2][0] != "3" {
+		t.Errorf("row for line 3 = %q", records[2])
 	}
 }
 
-func TestColorize(t *testing.T) {
-	result := colorize("hello", ansiRed)
-	if result != ansiRed+"hello"+ansiReset {
-		t.Errorf("colorize: got %q", result)
+func TestRun_FileMissing(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(nil, &stdout, &stderr, []string{"--file=" + filepath.Join(t.TempDir(), "missing.txt")}, false)
+	if code != 1 {
+		t.Errorf("missing file should exit 1, got %d", code)
+	}
+	if stderr.Len() == 0 {
+		t.Error("missing file should report an error")
 	}
 }
 
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// disableEcho is not supported on this platform; the password is read
+// with the terminal's echo unchanged.
+func disableEcho(*os.File) (restore func(), err error) {
+	return nil, errors.New("disabling terminal echo is not supported")
+}
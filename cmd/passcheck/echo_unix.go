@@ -0,0 +1,24 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+// disableEcho turns off echo on the terminal f and returns a function that
+// turns it back on. It uses stty(1) to keep the CLI free of dependencies.
+func disableEcho(f *os.File) (restore func(), err error) {
+	if err := stty(f, "-echo"); err != nil {
+		return nil, err
+	}
+	return func() { _ = stty(f, "echo") }, nil
+}
+
+// stty runs stty(1) with arg against the terminal f.
+func stty(f *os.File, arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = f
+	return cmd.Run()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelsanzio/passcheck"
+)
+
+// lineResult is one line of --file --json output: the check result of a
+// password together with its line number. The password itself is never
+// written.
+type lineResult struct {
+	Line int `json:"line"`
+	passcheck.Result
+}
+
+// csvHeader is the header row of --file --csv output.
+var csvHeader = []string{"line", "score", "verdict", "entropy", "issue_codes"}
+
+// csvRecord returns the --file --csv row of a result: its line number,
+// score, verdict, entropy, and issue codes joined by "|". The password
+// itself is never written.
+func csvRecord(line int, r passcheck.Result) []string {
+	codes := make([]string, len(r.Issues))
+	for i, iss := range r.Issues {
+		codes[i] = iss.Code
+	}
+	return []string{
+		strconv.Itoa(line),
+		strconv.Itoa(r.Score),
+		r.Verdict,
+		strconv.FormatFloat(r.Entropy, 'f', 2, 64),
+		strings.Join(codes, "|"),
+	}
+}
+
+// runFile checks every line of opts.file under cfg and writes one result
+// per non-blank line to stdout: a JSON object per line with --json, a CSV
+// row after a header row with --csv, otherwise a short summary line.
+// Issue messages are redacted so no part of a password is printed back.
+//
+// Failures to write a line are reported on stderr and the remaining lines
+// are still checked; only a file that cannot be opened or read makes
+// runFile return a non-zero exit code.
+func runFile(stdout, stderr io.Writer, opts options, cfg passcheck.Config) int {
+	ew := &errWriter{w: stderr}
+
+	f, err := os.Open(opts.file)
+	if err != nil {
+		_, _ = fmt.Fprintf(ew, "Error: %v\n", err)
+		return exitError
+	}
+	defer func() { _ = f.Close() }()
+
+	cfg.RedactSensitive = true
+	enc := json.NewEncoder(stdout)
+	cw := csv.NewWriter(stdout)
+	if opts.csv {
+		_ = cw.Write(csvHeader)
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			_, _ = fmt.Fprintf(ew, "Error writing CSV header: %v\n", err)
+		}
+	}
+	err = passcheck.CheckReader(f, cfg, func(line int, r passcheck.Result) {
+		var writeErr error
+		switch {
+		case opts.json:
+			writeErr = enc.Encode(lineResult{Line: line, Result: r})
+		case opts.csv:
+			_ = cw.Write(csvRecord(line, r))
+			cw.Flush()
+			writeErr = cw.Error()
+		default:
+			_, writeErr = fmt.Fprintf(stdout, "%d: %d/100 %s\n", line, r.Score, r.Verdict)
+		}
+		if writeErr != nil {
+			_, _ = fmt.Fprintf(ew, "Error writing line %d: %v\n", line, writeErr)
+		}
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(ew, "Error: reading %s: %v\n", opts.file, err)
+		return exitError
+	}
+	return exitOK
+}
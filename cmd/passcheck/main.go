@@ -6,6 +6,7 @@
 //	passcheck "MyP@ssw0rd123!"
 //	passcheck "qwerty" --json
 //	passcheck "short" --min-length=8 --verbose
+//	passcheck --stdin
 package main
 
 import "os"
@@ -15,5 +16,5 @@ var version = "dev"
 
 func main() {
 	envNoColor := os.Getenv("NO_COLOR") != ""
-	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:], envNoColor))
+	os.Exit(run(os.Stdin, os.Stdout, os.Stderr, os.Args[1:], envNoColor))
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// errNoStdinPassword is returned by readPassword when stdin holds no
+// password.
+var errNoStdinPassword = errors.New("no password read from stdin")
+
+// readPassword reads a single line from stdin and returns it without the
+// trailing newline. When stdin is a terminal it prompts on stderr and turns
+// off terminal echo while the password is typed; when stdin is piped it
+// reads silently.
+func readPassword(stdin io.Reader, stderr io.Writer) (string, error) {
+	if stdin == nil {
+		return "", errNoStdinPassword
+	}
+	if f, ok := stdin.(*os.File); ok && isTerminal(f) {
+		_, _ = fmt.Fprint(stderr, "Password: ")
+		if restore, err := disableEcho(f); err == nil {
+			defer restore()
+		}
+		// The newline typed by the user is not echoed either.
+		defer func() { _, _ = fmt.Fprintln(stderr) }()
+	}
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading stdin: %w", err)
+	}
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	if line == "" {
+		return "", errNoStdinPassword
+	}
+	return line, nil
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a
+// pipe or a file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,76 @@
+package passcheck
+
+import (
+	"fmt"
+
+	"github.com/rafaelsanzio/passcheck/internal/entropy"
+)
+
+// composition records the length and character classes of a checked
+// password, so that [Result.MissingComposition] can be evaluated against
+// any Config without the password itself.
+type composition struct {
+	length   int
+	charsets entropy.CharsetInfo
+}
+
+// MissingComposition returns the smallest set of additions that would
+// satisfy cfg's composition rules for the checked password, as short
+// phrases for UX hints, e.g. ["1 uppercase letter", "1 symbol",
+// "2 more characters"]. Required classes come first, in the order
+// uppercase, lowercase, digit, symbol, followed by the characters still
+// needed to reach cfg.MinLength once the class additions are counted.
+//
+// When cfg.MinCharClasses is set, the Require* flags are ignored and the
+// class additions are a single phrase such as "2 more character types".
+//
+// It returns nil when nothing is missing, and for a Result that was not
+// produced by a check (e.g. one decoded from JSON).
+func (r Result) MissingComposition(cfg Config) []string {
+	c := r.composition
+	if c == nil {
+		return nil
+	}
+
+	var missing []string
+	if cfg.MinCharClasses > 0 {
+		more := max(cfg.MinCharClasses-c.charsets.SetCount(), 0)
+		switch {
+		case more == 1:
+			missing = append(missing, "1 more character type")
+		case more > 1:
+			missing = append(missing, fmt.Sprintf("%d more character types", more))
+		}
+		return appendLength(missing, cfg.MinLength-c.length-more)
+	}
+
+	added := 0
+	classes := []struct {
+		required, present bool
+		name              string
+	}{
+		{cfg.RequireUpper, c.charsets.HasUpper, "uppercase letter"},
+		{cfg.RequireLower, c.charsets.HasLower, "lowercase letter"},
+		{cfg.RequireDigit, c.charsets.HasDigit, "digit"},
+		{cfg.RequireSymbol, c.charsets.AnySymbol(), "symbol"},
+	}
+	for _, cl := range classes {
+		if cl.required && !cl.present {
+			missing = append(missing, "1 "+cl.name)
+			added++
+		}
+	}
+	return appendLength(missing, cfg.MinLength-c.length-added)
+}
+
+// appendLength appends the phrase for more characters still needed to
+// reach the minimum length, if any.
+func appendLength(missing []string, more int) []string {
+	switch {
+	case more == 1:
+		missing = append(missing, "1 more character")
+	case more > 1:
+		missing = append(missing, fmt.Sprintf("%d more characters", more))
+	}
+	return missing
+}
@@ -1,8 +1,17 @@
 package passcheck
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rafaelsanzio/passcheck/internal/entropy"
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/patterns"
+	"github.com/rafaelsanzio/passcheck/internal/pwhash"
 )
 
 // ErrInvalidConfig is returned when the configuration fails validation.
@@ -17,6 +26,13 @@ const MaxCustomWordsSize = 100_000
 // Config.CustomPasswords. See MaxCustomWordsSize for the rationale.
 const MaxCustomPasswordsSize = 100_000
 
+// DefaultEmojiPoolSize is the number of emoji credited per emoji grapheme
+// when Config.EmojiPoolSize is zero.
+const DefaultEmojiPoolSize = entropy.PoolEmoji
+
+// DefaultMinEditDistance is the minimum edit distance from each of
+// Config.PreviousPasswords used when Config.MinEditDistance is zero.
+const DefaultMinEditDistance = 4
 
 // HIBPCheckResult is a pre-computed result from an HIBP (Have I Been Pwned) lookup.
 // When Config.HIBPResult is set, the library uses it instead of calling HIBPChecker.
@@ -25,6 +41,14 @@ type HIBPCheckResult struct {
 	Count    int
 }
 
+// Observer receives every check result for metrics, e.g. a score
+// histogram or counters by verdict and issue code. See [Config.Observer].
+type Observer interface {
+	// ObserveCheck is called with the result of a check and how long the
+	// check took, including any ConstantTimeMode padding.
+	ObserveCheck(result Result, duration time.Duration)
+}
+
 // Config holds configuration options for password strength checking.
 //
 // Use [DefaultConfig] to obtain a Config with recommended defaults, then
@@ -38,6 +62,16 @@ type Config struct {
 	// MinLength is the minimum number of runes required (default: 12).
 	MinLength int
 
+	// MaxLength, when positive, is the maximum number of runes allowed. A
+	// longer password is rejected with a single RULE_TOO_LONG issue and a
+	// score of 0, without further analysis, so users learn about a storage
+	// limit such as bcrypt's 72 bytes before it silently cuts their
+	// password (for bcrypt, 72 runes is exact only for ASCII input). It must
+	// be between MinLength and MaxPasswordLength; inputs beyond
+	// MaxPasswordLength are still truncated for safety. Default: 0 (no
+	// limit; long inputs are silently truncated).
+	MaxLength int
+
 	// RequireUpper requires at least one uppercase letter (default: true).
 	RequireUpper bool
 
@@ -50,18 +84,152 @@ type Config struct {
 	// RequireSymbol requires at least one symbol character (default: true).
 	RequireSymbol bool
 
+	// MinCharClasses, when positive, requires at least this many of the
+	// four character classes — uppercase, lowercase, digit, symbol — for
+	// "N of 4" policies. The Require* flags are then ignored, and a
+	// shortfall is reported as a single RULE_INSUFFICIENT_CLASSES issue
+	// that fails MeetsPolicy. Must be between 0 and 4. Default: 0 (use the
+	// Require* flags).
+	MinCharClasses int
+
 	// MaxRepeats is the maximum number of consecutive identical characters
 	// allowed before an issue is reported (default: 3).
 	MaxRepeats int
 
+	// MinUniqueChars is the minimum number of distinct characters (runes,
+	// not bytes) a password must contain. Fewer are reported as
+	// RULE_LOW_DIVERSITY and fail MeetsPolicy, catching passwords such as
+	// "Aaaaaaaaaaaa1!" that game the composition rules. Must be >= 0.
+	// Default: 0 (disabled).
+	MinUniqueChars int
+
+	// FlagNumericOnly reports passwords made entirely of digits, such as
+	// "482915736201", as RULE_NUMERIC_ONLY, failing MeetsPolicy. A long
+	// numeric password satisfies MinLength but draws from only ten symbols
+	// (entropy reflects that pool) and is the first target of PIN-aware
+	// attacks. Default: true.
+	FlagNumericOnly bool
+
+	// CustomRules lists organization-specific rules, such as "must start
+	// with a letter", run after the built-in rules. Their issues are
+	// reported and scored in the rule category and fail MeetsPolicy; see
+	// [Rule] and [RegexRule]. Each rule receives the password truncated to
+	// MaxPasswordLength runes. Custom rules are not serialized by
+	// MarshalJSON. Default: nil.
+	CustomRules []Rule
+
+	// AllowWhitespace, when true, stops reporting whitespace as
+	// RULE_WHITESPACE and counts it toward the character pool in entropy,
+	// as NIST SP 800-63B recommends for passphrases. Control characters are
+	// still reported. When nil, whitespace is allowed if PassphraseMode is
+	// set and reported otherwise; set it to false to report whitespace even
+	// in passphrase mode. Default: nil.
+	AllowWhitespace *bool
+
 	// PatternMinLength is the minimum length for keyboard and sequence
 	// pattern detection (default: 4).
 	PatternMinLength int
 
+	// KeyboardLayouts lists the keyboard layouts checked for keyboard walks:
+	// any of KeyboardLayoutQWERTY, KeyboardLayoutAZERTY,
+	// KeyboardLayoutQWERTZ, and KeyboardLayoutDvorak. Add the layouts your
+	// users type on, e.g. AZERTY for French users so "azertyuiop" is caught.
+	// Nil or empty means QWERTY only. Unknown names fail Validate().
+	KeyboardLayouts []string
+
+	// ForbiddenPatterns lists regular expressions (RE2 syntax, see package
+	// regexp) that the password must not match, such as a company name or
+	// a ticket-number format. Patterns run against the password as given;
+	// prefix them with (?i) for case-insensitive matching. Each match is
+	// reported as PATTERN_FORBIDDEN without revealing the pattern. Invalid
+	// expressions fail Validate(). Default: nil.
+	ForbiddenPatterns []string
+
+	// ForbiddenPatternIsFatal, when true, makes any ForbiddenPatterns match
+	// an absolute failure: Score is forced to 0, Verdict to "Very Weak" (or
+	// the first VerdictBands label), and MeetsPolicy to false, in addition to
+	// the PATTERN_FORBIDDEN issue.
+	//
+	// Precedence among switches that override the score: a fatal forbidden
+	// pattern wins over everything else; otherwise a HotList match caps the
+	// verdict at "Weak" (or the weak band of VerdictBands); otherwise the
+	// score maps to a verdict through VerdictBands or VerdictThresholds.
+	//
+	// Disabling CategoryPattern skips ForbiddenPatterns unless this is set:
+	// a fatal forbidden pattern is still checked and reported, since it is
+	// policy rather than a heuristic. Default: false.
+	ForbiddenPatternIsFatal bool
+
+	// DetectCurrentYear, when true, flags passwords containing the current
+	// or prior year (e.g. "Summer2026!") as PATTERN_DATE with a note that
+	// it is a likely recency pattern — the most common date people put in
+	// passwords. Default: false.
+	DetectCurrentYear bool
+
+	// CurrentYear is the year DetectCurrentYear treats as current. Zero
+	// means time.Now().Year(); set it to keep checks deterministic in
+	// tests. Must be >= 0. Default: 0.
+	CurrentYear int
+
+	// MinEntropy is the minimum final entropy, in bits, a password must
+	// have; below it a RULE_LOW_ENTROPY issue is reported and the password
+	// fails MeetsPolicy, whatever its length. The threshold applies to the
+	// entropy after the configured EntropyMode's reductions (i.e.
+	// Result.Entropy): with EntropyModePatternAware and a 50-bit minimum,
+	// "aaaaaaaaaaaaaaaaaaaa" fails while a random 8-character password
+	// passes. Must be >= 0. Default: 0 (disabled).
+	MinEntropy float64
+
+	// DisabledCategories lists issue categories whose checks are skipped
+	// entirely: any of CategoryRule, CategoryPattern, CategoryDictionary,
+	// CategoryContext, CategoryBreach, and CategoryHistory. A disabled
+	// phase does no work, reports no issues, and adds no score penalty;
+	// e.g. a passphrase-focused product can disable CategoryRule to drop
+	// composition requirements while keeping dictionary and breach checks.
+	// Disabling CategoryPattern also leaves pattern-aware entropy
+	// unreduced; fatal ForbiddenPatterns still apply (see
+	// ForbiddenPatternIsFatal). Unknown names fail Validate(). Default: nil.
+	DisabledCategories []string
+
 	// MaxIssues is the maximum number of issues returned in the result.
-	// Set to 0 for no limit (default: 5).
+	// Set to 0 for no limit (default: 5). To return no issues at all, use
+	// SuppressAllIssues; 0 keeps its historical "unlimited" meaning.
 	MaxIssues int
 
+	// IssueOrder lists issue categories (CategoryBreach, CategoryRule, ...)
+	// in the order their issues should appear in Result.Issues, overriding
+	// the default most-severe-first order; e.g. a signup form may list
+	// CategoryRule first so composition requirements are shown before
+	// anything else. Issues within a category, and those of unlisted
+	// categories, which follow the listed ones, stay sorted by severity.
+	// MaxIssues applies after reordering. Purely presentational: Score,
+	// Verdict, and MeetsPolicy are unaffected. Unknown names fail
+	// Validate(). Default: nil (severity order).
+	IssueOrder []string
+
+	// SuppressAllIssues, when true, returns Result.Issues and
+	// Result.Improvements as non-nil empty slices for score-only callers.
+	// Findings are still collected and still affect Score, Verdict, and
+	// MeetsPolicy. Default: false.
+	SuppressAllIssues bool
+
+	// FriendlyMessages, when true, replaces the message of the top
+	// (highest-priority) issue with a longer plain-language explanation
+	// suited to consumer products, e.g. "Avoid number or letter runs like
+	// 1234 or abcd — try unrelated words instead." Codes are unchanged.
+	// This changes tone and length, not language. Default: false (concise
+	// messages).
+	FriendlyMessages bool
+
+	// Locale is a BCP-47 language tag (e.g. "pt-BR") selecting translated
+	// issue and suggestion messages registered with [RegisterMessages].
+	// Messages without a translation, and all messages for an unknown
+	// locale, stay in English; "pt-BR" falls back to "pt" before English.
+	// Issue codes are never translated. When FriendlyMessages is also set,
+	// a translation for the top issue wins over the English explanation.
+	// Default: "" (English).
+	Locale string
+
 	// CustomPasswords is an optional list of additional passwords to check
 	// against during dictionary checks. Entries are matched case-insensitively.
 	// Nil or empty means use only the built-in common password list.
@@ -69,6 +237,24 @@ type Config struct {
 	// error for larger lists to prevent algorithmic DoS on long passwords.
 	CustomPasswords []string
 
+	// PasswordSet is an optional prebuilt blocklist, typically loaded once
+	// with [LoadPasswordList] and shared across checks. Unlike
+	// CustomPasswords, which is scanned on every call, lookups are O(1), so
+	// it suits blocklists with millions of entries. It is checked in
+	// addition to the built-in list and CustomPasswords. Default: nil.
+	PasswordSet *PasswordSet
+
+	// HotList is an optional list of currently trending passwords, such as
+	// those published by threat-intel feeds as recently breached. It is
+	// kept separate from CustomPasswords so that emerging threats can be
+	// pushed without editing the static blocklist. Entries are matched
+	// exactly and case-insensitively. A match is reported as DICT_TRENDING,
+	// ranked ahead of all other dictionary issues, and caps the verdict at
+	// "Weak" regardless of score; with VerdictBands, at the band just below
+	// the middle one (the second of five, the first of three or four).
+	// Must not exceed MaxCustomPasswordsSize entries. Default: nil.
+	HotList []string
+
 	// CustomWords is an optional list of additional words to detect as
 	// substrings during dictionary checks. Entries are matched
 	// case-insensitively. Words shorter than 4 characters are ignored.
@@ -77,46 +263,153 @@ type Config struct {
 	// error for larger lists to prevent algorithmic DoS on long passwords.
 	CustomWords []string
 
+	// MaxCustomEntries is an optional soft cap on the combined number of
+	// CustomPasswords and CustomWords entries. Custom passwords are matched
+	// by linear scan, so very large in-memory lists degrade every check;
+	// setting a cap makes Validate() reject such configs early instead.
+	// For large blocklists, load them once into PasswordSet (see
+	// [LoadPasswordList]) or into an offline Bloom filter used as
+	// HIBPChecker (see hibp.NewBloomChecker). Default: 0 (unlimited,
+	// subject to MaxCustomPasswordsSize and MaxCustomWordsSize).
+	MaxCustomEntries int
+
 	// ContextWords is an optional list of user-specific terms to detect
 	// in passwords (e.g., username, email, company name). Entries are
 	// matched case-insensitively and checked for exact matches, substrings,
-	// and leetspeak variants. Words shorter than 3 characters are ignored.
-	// Email addresses are automatically parsed to extract individual components.
+	// and leetspeak variants. Words shorter than ContextMinWordLen
+	// characters are ignored.
+	// Email addresses are automatically parsed to extract individual components,
+	// camelCase handles are split into words, and phone numbers such as
+	// "555-123-4567" also match their digits typed together.
 	// Nil or empty means no context-aware checking is performed.
 	ContextWords []string
 
+	// ContextMinWordLen is the minimum length of a context word, or of a
+	// part extracted from an email address, for it to be checked. Lower it
+	// to 2 to block short organization names such as "HP" or "GE"; note
+	// that two-character words occur in many unrelated passwords, so this
+	// increases false positives. Must be >= 0. Default: 0 (3 characters).
+	ContextMinWordLen int
+
+	// PreviousPasswords is an optional list of the user's prior passwords,
+	// supplied in plaintext only for the duration of a password change,
+	// to reject rotations that keep most of an old password. Comparisons
+	// are case-insensitive; working copies are zeroed after use, and no
+	// part of a previous password ever appears in an issue. Do not log or
+	// persist a Config holding them. Default: nil.
+	PreviousPasswords []string
+
+	// MaxHistorySubstring is the longest substring, in runes, that the
+	// password may share with any of PreviousPasswords (case-insensitive).
+	// A longer shared substring is reported as HISTORY_SHARED_SUBSTRING,
+	// catching "same base, new suffix" rotations such as "MyDogMax1" →
+	// "MyDogMax2024". Zero disables the check. Must be >= 0. Default: 0.
+	MaxHistorySubstring int
+
+	// MinEditDistance is the minimum Levenshtein distance, in runes, that
+	// the password must keep from each of PreviousPasswords
+	// (case-insensitive). A closer password, such as "Summer2025" after
+	// "Summer2024", is reported as RULE_TOO_SIMILAR. Must be >= 0.
+	// Default: 0 (DefaultMinEditDistance when PreviousPasswords is set).
+	MinEditDistance int
+
+	// CurrentPasswordHash is the stored hash of the user's current
+	// password: Argon2 (argon2id, argon2i, argon2d) in PHC string format,
+	// or bcrypt ($2a$, $2b$, $2y$). When set, a password that verifies
+	// against it is reported as HISTORY_REUSE and fails MeetsPolicy; see
+	// [EqualsHashed]. Verification pays the hash's full cost on every
+	// check, so set it on password-change requests only, not for
+	// keystroke feedback. Unparseable hashes, and hashes below the minimum
+	// costs of [EqualsHashed], fail Validate(). Default: "".
+	CurrentPasswordHash string
+
 	// DisableLeet disables leetspeak normalization during dictionary
-	// checks. When true, substitutions like @ → a, 0 → o, $ → s are
-	// not applied, and only the plain password is checked against
-	// dictionaries. Default: false (leet normalization enabled).
+	// checks and passphrase detection. When true, substitutions like
+	// @ → a, 0 → o, $ → s are not applied, only the plain password is
+	// checked against dictionaries, and leet-styled words such as "H0rs3"
+	// do not count as passphrase words. Default: false (leet normalization
+	// enabled).
 	DisableLeet bool
 
+	// DetectReversed enables detection of common words spelled backwards
+	// (e.g. "nogard" for "dragon"), reported as DICT_REVERSED_WORD.
+	// Default: true.
+	DetectReversed bool
+
+	// DetectSubstrings enables detection of passwords that are themselves
+	// part of a longer common password, such as "assword12" from
+	// "password123" or "2345678" from "12345678", reported as
+	// DICT_PASSWORD_SUBSTRING. Only passwords of at least MinLength runes
+	// are checked, against the built-in list and CustomPasswords (not
+	// PasswordSet). Default: false.
+	DetectSubstrings bool
+
+	// FuzzyDictionary enables detection of passwords one edit (an added,
+	// removed, or changed character) away from a common password, such as
+	// "passw0rd1" or "qwerrty", reported as DICT_NEAR_COMMON. Exact and
+	// leetspeak matches are reported as such instead. Passwords shorter
+	// than 6 runes are not compared, and only the built-in list and
+	// CustomPasswords are searched (not PasswordSet). Off by default for
+	// its cost, though it stays well under a millisecond per check.
+	// Default: false.
+	FuzzyDictionary bool
+
+	// DetectMangled enables detection of common passwords altered by the
+	// canonical rules of password-cracking tools: a capitalized first
+	// letter or leetspeak, followed by a suffix of an optional symbol, up
+	// to four digits, and another optional symbol ("Dragon2024!",
+	// "P@ssw0rd1", "monkey!12"). Prefixes and other case changes are not
+	// undone. It is reported as DICT_MANGLED_COMMON quoting the base
+	// password, and replaces the PATTERN_AFFIXED and PATTERN_SUBSTITUTION
+	// findings it covers. The rules are undone rather than generated, so
+	// the check costs a few dozen lookups. Default: false.
+	DetectMangled bool
+
+	// NormalizeUnicode folds visually equivalent Unicode spellings before
+	// the pattern, dictionary, and entropy phases: combining accents are
+	// composed ("e" + U+0301 → "é"), compatibility forms such as fullwidth
+	// letters, ligatures, and circled or mathematical letters are replaced
+	// by their NFKC equivalents, and Cyrillic and Greek letters that look
+	// like Latin ones are folded onto them, so "pаssword" with a Cyrillic
+	// "а" is still caught as common. Length and character-set rules, and
+	// issue matches, still use the password as typed. Default: false.
+	NormalizeUnicode bool
+
 	// HIBPChecker is an optional checker for the Have I Been Pwned (HIBP)
 	// breach database. When set, the password is checked via k-anonymity
 	// (only a 5-character prefix of its SHA-1 hash is sent). If the
 	// password is found and the count meets HIBPMinOccurrences, an
 	// HIBP_BREACHED issue is added. On network or API errors, the check
 	// is skipped (graceful degradation). Use the hibp package to obtain
-	// a Client that implements this interface.
+	// a Client that implements this interface, or a BloomChecker for
+	// offline checks against a prebuilt Bloom filter. A checker that also
+	// has CheckContext and CheckConstantTimeContext methods, as
+	// *hibp.Client does, is called with the check's context, so
+	// [CheckWithContext] can cancel the lookup.
 	HIBPChecker interface {
 		Check(password string) (breached bool, count int, err error)
 	}
 
 	// HIBPMinOccurrences is the minimum breach count required to report
 	// an HIBP_BREACHED issue. Only used when HIBPChecker or HIBPResult is set.
+	// A breached result with a count of 0, meaning the count is unknown (as
+	// from hibp.BloomChecker), is always reported.
 	// Default: 1 (report if found in any breach).
 	HIBPMinOccurrences int
 
 	// HIBPResult, when non-nil, is used instead of calling HIBPChecker. This
 	// allows callers (e.g. browser WASM) to perform the HIBP lookup outside Go
-	// and pass the result in, avoiding blocking or CORS issues. When set,
-	// HIBPChecker is ignored for this check.
+	// and pass the result in, avoiding blocking or CORS issues; hibp.HashPrefix
+	// and hibp.ParseRange compute the range prefix and parse the response
+	// without network access. When set, HIBPChecker is ignored for this check.
 	HIBPResult *HIBPCheckResult
 
 	// ConstantTimeMode, when true, uses constant-time string comparison and
 	// substring checks in dictionary lookups so that response time does not
 	// leak whether the password matched a blocklist entry or where it matched.
-	// Default: false (faster, non-constant-time lookups).
+	// When HIBPChecker is a *hibp.Client, the breach range response is also
+	// compared in constant time. Default: false (faster, non-constant-time
+	// lookups).
 	//
 	// WARNING: ConstantTimeMode reduces timing leakage from branch-dependent
 	// early exits, but does NOT guarantee wall-clock constant time on real
@@ -130,7 +423,10 @@ type Config struct {
 	// password is detected as a passphrase (has at least MinWords distinct words),
 	// word-based entropy is used instead of character-based entropy, and dictionary
 	// penalties are reduced. Word boundaries are detected using spaces, hyphens,
-	// camelCase, and snake_case. Default: false (standard password scoring).
+	// camelCase, and snake_case. Low-diversity words (e.g. "aaaa", "abab") and
+	// password tokens (e.g. "qwerty", "letmein") do not count as words; when an attempted passphrase
+	// contains them, a PASSPHRASE_WEAK_WORDS issue is reported. Default: false
+	// (standard password scoring).
 	PassphraseMode bool
 
 	// MinWords is the minimum number of distinct words required to consider a
@@ -157,6 +453,25 @@ type Config struct {
 	// layers Markov-chain analysis on top of Advanced.
 	EntropyMode EntropyMode
 
+	// MarkovModel, when non-nil, replaces the built-in transition heuristics
+	// of EntropyModePatternAware with probabilities learned from a corpus
+	// (see [LoadMarkovModel]). Ignored in other entropy modes. Default: nil.
+	MarkovModel *MarkovModel
+
+	// EmojiPoolSize is the number of emoji an attacker is assumed to
+	// choose from for each emoji in the password. Each emoji grapheme,
+	// such as "👍🏽" or a family joined with U+200D, adds log2 of it in
+	// bits to the entropy of the other characters. Zero means
+	// DefaultEmojiPoolSize (1400, roughly the number of single code point
+	// emoji); lower it for attackers who try only popular emoji. Default: 0.
+	EmojiPoolSize int
+
+	// GuessesPerSecond is the attacker rate assumed for
+	// Result.CrackTimeSeconds. Zero means DefaultGuessesPerSecond (1e10,
+	// an offline attack on a fast hash); use a lower rate such as 1e4 for
+	// a slow hash like bcrypt. Default: 0.
+	GuessesPerSecond float64
+
 	// PenaltyWeights allows customization of penalty multipliers and entropy
 	// weight for scoring. When nil, default weights are used (all multipliers = 1.0).
 	// Organizations can adjust these to prioritize different security concerns.
@@ -170,13 +485,62 @@ type Config struct {
 	// are used. See [VerdictThresholds] for field details.
 	VerdictThresholds *VerdictThresholds
 
+	// VerdictBands, when non-nil, replaces the verdict labels and their
+	// score boundaries entirely, e.g. for a three-tier UI, and takes
+	// precedence over VerdictThresholds. Bands must be sorted by MinScore,
+	// start at 0, and have non-empty labels; each band covers scores up to
+	// the next band's MinScore. The trending-password cap moves to the band
+	// just below the middle one (see HotList). See [VerdictBand].
+	VerdictBands []VerdictBand
+
+	// CharsetBonusModel selects how the charset-diversity bonus grows with
+	// the number of character types present. CharsetBonusLinear (default)
+	// awards the same credit for every type beyond the first;
+	// CharsetBonusDiminishing shrinks the credit for each additional type so
+	// that adding a single symbol to tick the fourth box earns little.
+	// Empty or unrecognized values use CharsetBonusLinear.
+	CharsetBonusModel CharsetBonusModel
+
 	// RedactSensitive, when true, masks potential password substrings in
 	// issue messages (e.g., "Contains common word: '***'"). This prevents
 	// sensitive substrings from being inadvertently logged or persisted.
 	// Default: false (full messages returned).
 	RedactSensitive bool
-}
 
+	// KeySalt is the secret key used by [ResultKey] to derive cache and
+	// telemetry keys. Set it to the same random value on every instance
+	// of a deployment to get stable keys across processes; keep it secret,
+	// since anyone holding it can brute-force passwords from their keys.
+	// When empty, a random per-process key is used. Default: nil.
+	KeySalt []byte
+
+	// OnResult, OnIssue, and OnFailure are optional observability hooks
+	// called after every check with the context passed to
+	// [CheckWithContext] or [CheckWithConfigContext] (context.Background()
+	// for the other Check functions), so request IDs, tenants, or trace
+	// spans can travel with the analysis. OnResult receives every result,
+	// OnIssue each reported issue, and OnFailure results that do not meet
+	// policy. The library never places the password in the context. Hooks
+	// run synchronously on the calling goroutine; keep them fast. Default:
+	// nil.
+	OnResult  func(ctx context.Context, result Result)
+	OnIssue   func(ctx context.Context, iss Issue)
+	OnFailure func(ctx context.Context, result Result)
+
+	// Observer, when non-nil, is called at the end of every check that
+	// produces a result — including passwords truncated to
+	// MaxPasswordLength — for metrics without log parsing. It receives the
+	// result and duration, never the password. It is not called when
+	// Validate fails. Like the hooks, it runs synchronously on the calling
+	// goroutine; keep it fast, and safe for concurrent use when checks run
+	// in parallel (e.g. [CheckBatch]). Default: nil.
+	Observer Observer
+
+	// Parallelism is the maximum number of passwords [CheckBatch] checks
+	// concurrently. Zero means runtime.GOMAXPROCS(0). Must be >= 0.
+	// Single-password checks ignore it. Default: 0.
+	Parallelism int
+}
 
 // PenaltyWeights allows customization of penalty multipliers and entropy weight
 // for password strength scoring. All weights default to 1.0 when nil or when
@@ -192,30 +556,50 @@ type Config struct {
 type PenaltyWeights struct {
 	// RuleViolation multiplies penalties for rule violations (length, charset, etc.).
 	// Default: 1.0 (PenaltyPerRule = 5 per violation).
-	RuleViolation float64
+	RuleViolation float64 `json:"rule_violation"`
 
 	// PatternMatch multiplies penalties for pattern detections (keyboard walks, sequences).
 	// Default: 1.0 (PenaltyPerPattern = 10 per pattern).
-	PatternMatch float64
+	PatternMatch float64 `json:"pattern_match"`
 
 	// DictionaryMatch multiplies penalties for dictionary matches (common passwords, words).
 	// Default: 1.0 (PenaltyPerDictMatch = 15 per match).
-	DictionaryMatch float64
+	DictionaryMatch float64 `json:"dictionary_match"`
 
-	// ContextMatch multiplies penalties for context-aware detections (username, email).
+	// ContextMatch multiplies penalties for context-aware detections (username, email)
+	// and for password-history findings.
 	// Default: 1.0 (PenaltyPerContext = 20 per match).
-	ContextMatch float64
+	ContextMatch float64 `json:"context_match"`
 
 	// HIBPBreach multiplies penalties for HIBP breach database matches.
 	// Default: 1.0 (PenaltyPerHIBP = 25 per breach).
-	HIBPBreach float64
+	HIBPBreach float64 `json:"hibp_breach"`
 
 	// EntropyWeight multiplies the base score derived from entropy.
 	// Default: 1.0 (entropy contributes fully to base score).
 	// Values < 1.0 reduce entropy influence; values > 1.0 increase it.
-	EntropyWeight float64
+	EntropyWeight float64 `json:"entropy_weight"`
 }
 
+// Keyboard layout names for Config.KeyboardLayouts.
+const (
+	KeyboardLayoutQWERTY = patterns.LayoutQWERTY
+	KeyboardLayoutAZERTY = patterns.LayoutAZERTY
+	KeyboardLayoutQWERTZ = patterns.LayoutQWERTZ
+	KeyboardLayoutDvorak = patterns.LayoutDvorak
+)
+
+// Issue category names, as reported in Issue.Category and accepted by
+// Config.DisabledCategories and Config.IssueOrder.
+const (
+	CategoryRule       = issue.CategoryRule
+	CategoryPattern    = issue.CategoryPattern
+	CategoryDictionary = issue.CategoryDictionary
+	CategoryContext    = issue.CategoryContext
+	CategoryBreach     = issue.CategoryBreach
+	CategoryHistory    = issue.CategoryHistory
+)
+
 // EntropyMode specifies the entropy calculation method.
 type EntropyMode string
 
@@ -236,6 +620,19 @@ const (
 	EntropyModePatternAware EntropyMode = "pattern-aware"
 )
 
+// CharsetBonusModel specifies how the charset-diversity bonus scales.
+type CharsetBonusModel string
+
+const (
+	// CharsetBonusLinear awards 3 points per character type beyond the
+	// first (0, 3, 6, 9 for 1–4 types).
+	CharsetBonusLinear CharsetBonusModel = "linear"
+
+	// CharsetBonusDiminishing awards a shrinking marginal credit per
+	// additional type (0, 3, 5, 6 for 1–4 types).
+	CharsetBonusDiminishing CharsetBonusModel = "diminishing"
+)
+
 // DefaultConfig returns the recommended configuration with sensible
 // defaults for general-purpose password validation.
 func DefaultConfig() Config {
@@ -251,6 +648,8 @@ func DefaultConfig() Config {
 		MinWords:         4,
 		WordDictSize:     7776,
 		EntropyMode:      EntropyModeAdvanced,
+		DetectReversed:   true,
+		FlagNumericOnly:  true,
 	}
 }
 
@@ -266,12 +665,31 @@ func (c Config) Validate() error {
 	}
 	checks := []check{
 		{c.MinLength >= 1, fmt.Sprintf("MinLength must be >= 1, got %d", c.MinLength)},
+		{c.MaxLength == 0 || c.MaxLength >= c.MinLength && c.MaxLength <= MaxPasswordLength, fmt.Sprintf("MaxLength must be 0 or between MinLength (%d) and %d, got %d", c.MinLength, MaxPasswordLength, c.MaxLength)},
 		{c.MaxRepeats >= 2, fmt.Sprintf("MaxRepeats must be >= 2, got %d", c.MaxRepeats)},
+		{c.MinCharClasses >= 0 && c.MinCharClasses <= 4, fmt.Sprintf("MinCharClasses must be between 0 and 4, got %d", c.MinCharClasses)},
+		{c.MinUniqueChars >= 0, fmt.Sprintf("MinUniqueChars must be >= 0, got %d", c.MinUniqueChars)},
 		{c.PatternMinLength >= 3, fmt.Sprintf("PatternMinLength must be >= 3, got %d", c.PatternMinLength)},
 		{c.MaxIssues >= 0, fmt.Sprintf("MaxIssues must be >= 0, got %d", c.MaxIssues)},
 		{c.MinExecutionTimeMs >= 0, fmt.Sprintf("MinExecutionTimeMs must be >= 0, got %d", c.MinExecutionTimeMs)},
+		{c.EmojiPoolSize >= 0, fmt.Sprintf("EmojiPoolSize must be >= 0, got %d", c.EmojiPoolSize)},
+		{c.GuessesPerSecond >= 0, fmt.Sprintf("GuessesPerSecond must be >= 0, got %g", c.GuessesPerSecond)},
 		{len(c.CustomPasswords) <= MaxCustomPasswordsSize, fmt.Sprintf("CustomPasswords must have at most %d entries, got %d", MaxCustomPasswordsSize, len(c.CustomPasswords))},
+		{len(c.HotList) <= MaxCustomPasswordsSize, fmt.Sprintf("HotList must have at most %d entries, got %d", MaxCustomPasswordsSize, len(c.HotList))},
 		{len(c.CustomWords) <= MaxCustomWordsSize, fmt.Sprintf("CustomWords must have at most %d entries, got %d", MaxCustomWordsSize, len(c.CustomWords))},
+		{c.MaxCustomEntries >= 0, fmt.Sprintf("MaxCustomEntries must be >= 0, got %d", c.MaxCustomEntries)},
+		{c.MinEntropy >= 0, fmt.Sprintf("MinEntropy must be >= 0, got %g", c.MinEntropy)},
+		{c.CurrentYear >= 0, fmt.Sprintf("CurrentYear must be >= 0, got %d", c.CurrentYear)},
+		{c.ContextMinWordLen >= 0, fmt.Sprintf("ContextMinWordLen must be >= 0, got %d", c.ContextMinWordLen)},
+		{c.MaxHistorySubstring >= 0, fmt.Sprintf("MaxHistorySubstring must be >= 0, got %d", c.MaxHistorySubstring)},
+		{c.MinEditDistance >= 0, fmt.Sprintf("MinEditDistance must be >= 0, got %d", c.MinEditDistance)},
+		{c.Parallelism >= 0, fmt.Sprintf("Parallelism must be >= 0, got %d", c.Parallelism)},
+	}
+	if n := len(c.CustomPasswords) + len(c.CustomWords); c.MaxCustomEntries > 0 && n > c.MaxCustomEntries {
+		checks = append(checks, check{false, fmt.Sprintf(
+			"CustomPasswords and CustomWords have %d entries, exceeding MaxCustomEntries %d; "+
+				"for large blocklists use PasswordSet (LoadPasswordList) or an offline Bloom filter as HIBPChecker (hibp.NewBloomChecker) instead",
+			n, c.MaxCustomEntries)})
 	}
 
 	if c.PassphraseMode {
@@ -281,6 +699,32 @@ func (c Config) Validate() error {
 		)
 	}
 
+	if c.CurrentPasswordHash != "" {
+		err := pwhash.Check(c.CurrentPasswordHash)
+		checks = append(checks, check{err == nil, fmt.Sprintf("CurrentPasswordHash is not a supported password hash: %v", err)})
+	}
+
+	for i, expr := range c.ForbiddenPatterns {
+		_, err := compilePattern(expr)
+		checks = append(checks, check{err == nil, fmt.Sprintf("ForbiddenPatterns[%d] is not a valid regular expression: %v", i, err)})
+	}
+
+	for i, r := range c.CustomRules {
+		checks = append(checks, check{r != nil, fmt.Sprintf("CustomRules[%d] is nil", i)})
+	}
+
+	for _, name := range c.KeyboardLayouts {
+		checks = append(checks, check{patterns.IsKnownLayout(name), fmt.Sprintf("KeyboardLayouts contains unknown layout %q", name)})
+	}
+
+	for _, name := range c.DisabledCategories {
+		checks = append(checks, check{isKnownCategory(name), fmt.Sprintf("DisabledCategories contains unknown category %q", name)})
+	}
+
+	for _, name := range c.IssueOrder {
+		checks = append(checks, check{isKnownCategory(name), fmt.Sprintf("IssueOrder contains unknown category %q", name)})
+	}
+
 	for _, k := range checks {
 		if !k.ok {
 			return fmt.Errorf("%w: %s", ErrInvalidConfig, k.msg)
@@ -297,9 +741,80 @@ func (c Config) Validate() error {
 			return err
 		}
 	}
+	if c.VerdictBands != nil {
+		if err := validateVerdictBands(c.VerdictBands); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// StricterThan reports whether c is at least as strict as other in every
+// hard requirement, so that any password accepted under c is also accepted
+// under other's rules. Use it when rolling out a new policy to confirm it
+// introduces no regressions.
+//
+// The compared dimensions are MinLength (higher is stricter), MaxLength
+// (a lower positive limit is stricter than a higher one or none), the
+// character classes required by the Require* flags or MinCharClasses
+// (see below), MaxRepeats (lower is stricter),
+// MinUniqueChars (higher is stricter), FlagNumericOnly (set is stricter),
+// PatternMinLength (lower detects more patterns and is stricter), and
+// MinEntropy (higher is stricter). Equal configurations are considered
+// stricter than each other.
+//
+// For character classes, c must guarantee every class other requires and
+// at least as many classes in total. A MinCharClasses policy guarantees
+// its count but no particular class (all of them when it is 4), so
+// MinCharClasses: 3 is not stricter than a policy with RequireUpper
+// alone, while MinCharClasses: 4 is stricter than any Require* flags.
+//
+// Score-based aspects — PenaltyWeights, VerdictThresholds, entropy
+// settings, and custom or context word lists — change how passwords are
+// scored rather than which ones meet policy, and are not compared.
+func (c Config) StricterThan(other Config) bool {
+	requires := func(mine, theirs bool) bool { return mine || !theirs }
+	mine, myCount := c.requiredClasses()
+	theirs, theirCount := other.requiredClasses()
+	return c.MinLength >= other.MinLength &&
+		c.maxLength() <= other.maxLength() &&
+		requires(mine[0], theirs[0]) &&
+		requires(mine[1], theirs[1]) &&
+		requires(mine[2], theirs[2]) &&
+		requires(mine[3], theirs[3]) &&
+		myCount >= theirCount &&
+		c.MaxRepeats <= other.MaxRepeats &&
+		c.MinUniqueChars >= other.MinUniqueChars &&
+		requires(c.FlagNumericOnly, other.FlagNumericOnly) &&
+		c.PatternMinLength <= other.PatternMinLength &&
+		c.MinEntropy >= other.MinEntropy
+}
+
+// maxLength returns the longest password c accepts, with no limit
+// reported as MaxPasswordLength, the length at which input is truncated.
+func (c Config) maxLength() int {
+	if c.MaxLength > 0 {
+		return c.MaxLength
+	}
+	return MaxPasswordLength
+}
+
+// requiredClasses returns which of the character classes upper, lower,
+// digit, and symbol every password meeting c's policy contains, and how
+// many classes it contains at least.
+func (c Config) requiredClasses() (required [4]bool, count int) {
+	if c.MinCharClasses > 0 {
+		all := c.MinCharClasses == 4
+		return [4]bool{all, all, all, all}, c.MinCharClasses
+	}
+	required = [4]bool{c.RequireUpper, c.RequireLower, c.RequireDigit, c.RequireSymbol}
+	for _, r := range required {
+		if r {
+			count++
+		}
+	}
+	return required, count
+}
 
 // Validate checks that all penalty weights are non-negative.
 // Zero values are treated as defaults (1.0) during scoring.
@@ -348,20 +863,20 @@ func (w *PenaltyWeights) Validate() error {
 type VerdictThresholds struct {
 	// VeryWeakMax is the highest score that produces the "Very Weak" verdict.
 	// Default: 20.
-	VeryWeakMax int
+	VeryWeakMax int `json:"very_weak_max"`
 
 	// WeakMax is the highest score that produces the "Weak" verdict.
 	// Must be > VeryWeakMax. Default: 40.
-	WeakMax int
+	WeakMax int `json:"weak_max"`
 
 	// OkayMax is the highest score that produces the "Okay" verdict.
 	// Must be > WeakMax. Default: 60.
-	OkayMax int
+	OkayMax int `json:"okay_max"`
 
 	// StrongMax is the highest score that produces the "Strong" verdict.
 	// Scores above StrongMax produce "Very Strong".
 	// Must be > OkayMax and < 100. Default: 80.
-	StrongMax int
+	StrongMax int `json:"strong_max"`
 }
 
 // Validate checks that the threshold values form a valid strictly increasing
@@ -385,3 +900,66 @@ func (t *VerdictThresholds) Validate() error {
 	}
 	return nil
 }
+
+// VerdictBand is one tier of [Config.VerdictBands]: scores from MinScore up
+// to the next band's MinScore (or 100 for the last band) produce Label.
+//
+// Example — a three-tier scale:
+//
+//	cfg.VerdictBands = []passcheck.VerdictBand{
+//	    {MinScore: 0, Label: "Weak"},
+//	    {MinScore: 50, Label: "Fair"},
+//	    {MinScore: 80, Label: "Strong"},
+//	}
+type VerdictBand struct {
+	MinScore int    `json:"min_score"`
+	Label    string `json:"label"`
+}
+
+// validateVerdictBands checks that bands start at 0, are strictly
+// increasing within [0, 100], and have non-empty labels, so that every
+// score maps to exactly one band.
+func validateVerdictBands(bands []VerdictBand) error {
+	if len(bands) == 0 {
+		return fmt.Errorf("%w: VerdictBands must contain at least one band", ErrInvalidConfig)
+	}
+	if bands[0].MinScore != 0 {
+		return fmt.Errorf("%w: VerdictBands[0].MinScore must be 0, got %d", ErrInvalidConfig, bands[0].MinScore)
+	}
+	for i, b := range bands {
+		if strings.TrimSpace(b.Label) == "" {
+			return fmt.Errorf("%w: VerdictBands[%d].Label must not be empty", ErrInvalidConfig, i)
+		}
+		if b.MinScore > 100 {
+			return fmt.Errorf("%w: VerdictBands[%d].MinScore must be <= 100, got %d", ErrInvalidConfig, i, b.MinScore)
+		}
+		if i > 0 && b.MinScore <= bands[i-1].MinScore {
+			return fmt.Errorf("%w: VerdictBands[%d].MinScore (%d) must be > VerdictBands[%d].MinScore (%d)",
+				ErrInvalidConfig, i, b.MinScore, i-1, bands[i-1].MinScore)
+		}
+	}
+	return nil
+}
+
+// isKnownCategory reports whether name is an issue category.
+func isKnownCategory(name string) bool {
+	switch name {
+	case CategoryRule, CategoryPattern, CategoryDictionary, CategoryContext, CategoryBreach, CategoryHistory:
+		return true
+	}
+	return false
+}
+
+// whitespaceAllowed resolves AllowWhitespace, which defaults to
+// PassphraseMode when unset.
+func (c Config) whitespaceAllowed() bool {
+	if c.AllowWhitespace != nil {
+		return *c.AllowWhitespace
+	}
+	return c.PassphraseMode
+}
+
+// categoryEnabled reports whether the checks of category are enabled.
+func (c Config) categoryEnabled(category string) bool {
+	return !slices.Contains(c.DisabledCategories, category)
+}
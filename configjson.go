@@ -0,0 +1,256 @@
+package passcheck
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// configJSON is the serialized form of [Config]. It holds every value
+// field; the HIBP checker and result, PasswordSet, MarkovModel,
+// PreviousPasswords, CurrentPasswordHash, KeySalt, the callbacks, and the
+// Observer are runtime-only or secret and are omitted.
+type configJSON struct {
+	MinLength               int                `json:"min_length"`
+	MaxLength               int                `json:"max_length,omitempty"`
+	RequireUpper            bool               `json:"require_upper"`
+	RequireLower            bool               `json:"require_lower"`
+	RequireDigit            bool               `json:"require_digit"`
+	RequireSymbol           bool               `json:"require_symbol"`
+	MaxRepeats              int                `json:"max_repeats"`
+	MinCharClasses          int                `json:"min_char_classes"`
+	MinUniqueChars          int                `json:"min_unique_chars"`
+	FlagNumericOnly         bool               `json:"flag_numeric_only"`
+	AllowWhitespace         *bool              `json:"allow_whitespace,omitempty"`
+	PatternMinLength        int                `json:"pattern_min_length"`
+	KeyboardLayouts         []string           `json:"keyboard_layouts,omitempty"`
+	ForbiddenPatterns       []string           `json:"forbidden_patterns,omitempty"`
+	ForbiddenPatternIsFatal bool               `json:"forbidden_pattern_is_fatal"`
+	DetectCurrentYear       bool               `json:"detect_current_year"`
+	CurrentYear             int                `json:"current_year"`
+	MinEntropy              float64            `json:"min_entropy"`
+	DisabledCategories      []string           `json:"disabled_categories,omitempty"`
+	MaxIssues               int                `json:"max_issues"`
+	IssueOrder              []string           `json:"issue_order,omitempty"`
+	SuppressAllIssues       bool               `json:"suppress_all_issues"`
+	FriendlyMessages        bool               `json:"friendly_messages"`
+	Locale                  string             `json:"locale,omitempty"`
+	CustomPasswords         []string           `json:"custom_passwords,omitempty"`
+	HotList                 []string           `json:"hot_list,omitempty"`
+	CustomWords             []string           `json:"custom_words,omitempty"`
+	MaxCustomEntries        int                `json:"max_custom_entries"`
+	ContextWords            []string           `json:"context_words,omitempty"`
+	ContextMinWordLen       int                `json:"context_min_word_len"`
+	MaxHistorySubstring     int                `json:"max_history_substring"`
+	MinEditDistance         int                `json:"min_edit_distance"`
+	DisableLeet             bool               `json:"disable_leet"`
+	DetectReversed          bool               `json:"detect_reversed"`
+	DetectSubstrings        bool               `json:"detect_substrings"`
+	FuzzyDictionary         bool               `json:"fuzzy_dictionary"`
+	DetectMangled           bool               `json:"detect_mangled"`
+	NormalizeUnicode        bool               `json:"normalize_unicode"`
+	HIBPMinOccurrences      int                `json:"hibp_min_occurrences"`
+	ConstantTimeMode        bool               `json:"constant_time_mode"`
+	PassphraseMode          bool               `json:"passphrase_mode"`
+	MinWords                int                `json:"min_words"`
+	WordDictSize            int                `json:"word_dict_size"`
+	MinExecutionTimeMs      int                `json:"min_execution_time_ms"`
+	EntropyMode             EntropyMode        `json:"entropy_mode"`
+	EmojiPoolSize           int                `json:"emoji_pool_size"`
+	GuessesPerSecond        float64            `json:"guesses_per_second"`
+	PenaltyWeights          *PenaltyWeights    `json:"penalty_weights,omitempty"`
+	VerdictThresholds       *VerdictThresholds `json:"verdict_thresholds,omitempty"`
+	VerdictBands            []VerdictBand      `json:"verdict_bands,omitempty"`
+	CharsetBonusModel       CharsetBonusModel  `json:"charset_bonus_model,omitempty"`
+	RedactSensitive         bool               `json:"redact_sensitive"`
+	Parallelism             int                `json:"parallelism"`
+}
+
+// MarshalJSON encodes the serializable fields of c as a JSON object with
+// snake_case keys, for storing policies in configuration files or serving
+// them from a config API. CustomRules, HIBPChecker, HIBPResult,
+// PasswordSet, MarkovModel, PreviousPasswords, CurrentPasswordHash,
+// KeySalt, the OnResult, OnIssue, and OnFailure hooks, and Observer are
+// not encoded.
+func (c Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toConfigJSON(c))
+}
+
+// UnmarshalJSON decodes a JSON object produced by [Config.MarshalJSON].
+// Keys absent from data take their [DefaultConfig] values, so a partial
+// object such as {"min_length": 14} yields the default policy with a longer
+// minimum length. Fields that are not serialized (HIBPChecker, hooks, etc.)
+// keep their current values in c. The decoded Config is not validated;
+// call [Config.Validate] before use.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	j := toConfigJSON(DefaultConfig())
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	j.applyTo(c)
+	return nil
+}
+
+// toConfigJSON copies the serializable fields of c.
+func toConfigJSON(c Config) configJSON {
+	return configJSON{
+		MinLength:               c.MinLength,
+		MaxLength:               c.MaxLength,
+		RequireUpper:            c.RequireUpper,
+		RequireLower:            c.RequireLower,
+		RequireDigit:            c.RequireDigit,
+		RequireSymbol:           c.RequireSymbol,
+		MaxRepeats:              c.MaxRepeats,
+		MinCharClasses:          c.MinCharClasses,
+		MinUniqueChars:          c.MinUniqueChars,
+		FlagNumericOnly:         c.FlagNumericOnly,
+		AllowWhitespace:         c.AllowWhitespace,
+		PatternMinLength:        c.PatternMinLength,
+		KeyboardLayouts:         c.KeyboardLayouts,
+		ForbiddenPatterns:       c.ForbiddenPatterns,
+		ForbiddenPatternIsFatal: c.ForbiddenPatternIsFatal,
+		DetectCurrentYear:       c.DetectCurrentYear,
+		CurrentYear:             c.CurrentYear,
+		MinEntropy:              c.MinEntropy,
+		DisabledCategories:      c.DisabledCategories,
+		MaxIssues:               c.MaxIssues,
+		IssueOrder:              c.IssueOrder,
+		SuppressAllIssues:       c.SuppressAllIssues,
+		FriendlyMessages:        c.FriendlyMessages,
+		Locale:                  c.Locale,
+		CustomPasswords:         c.CustomPasswords,
+		HotList:                 c.HotList,
+		CustomWords:             c.CustomWords,
+		MaxCustomEntries:        c.MaxCustomEntries,
+		ContextWords:            c.ContextWords,
+		ContextMinWordLen:       c.ContextMinWordLen,
+		MaxHistorySubstring:     c.MaxHistorySubstring,
+		MinEditDistance:         c.MinEditDistance,
+		DisableLeet:             c.DisableLeet,
+		DetectReversed:          c.DetectReversed,
+		DetectSubstrings:        c.DetectSubstrings,
+		FuzzyDictionary:         c.FuzzyDictionary,
+		DetectMangled:           c.DetectMangled,
+		NormalizeUnicode:        c.NormalizeUnicode,
+		HIBPMinOccurrences:      c.HIBPMinOccurrences,
+		ConstantTimeMode:        c.ConstantTimeMode,
+		PassphraseMode:          c.PassphraseMode,
+		MinWords:                c.MinWords,
+		WordDictSize:            c.WordDictSize,
+		MinExecutionTimeMs:      c.MinExecutionTimeMs,
+		EntropyMode:             c.EntropyMode,
+		EmojiPoolSize:           c.EmojiPoolSize,
+		GuessesPerSecond:        c.GuessesPerSecond,
+		PenaltyWeights:          c.PenaltyWeights,
+		VerdictThresholds:       c.VerdictThresholds,
+		VerdictBands:            c.VerdictBands,
+		CharsetBonusModel:       c.CharsetBonusModel,
+		RedactSensitive:         c.RedactSensitive,
+		Parallelism:             c.Parallelism,
+	}
+}
+
+// applyTo copies the decoded fields into c.
+func (j configJSON) applyTo(c *Config) {
+	c.MinLength = j.MinLength
+	c.MaxLength = j.MaxLength
+	c.RequireUpper = j.RequireUpper
+	c.RequireLower = j.RequireLower
+	c.RequireDigit = j.RequireDigit
+	c.RequireSymbol = j.RequireSymbol
+	c.MaxRepeats = j.MaxRepeats
+	c.MinCharClasses = j.MinCharClasses
+	c.MinUniqueChars = j.MinUniqueChars
+	c.FlagNumericOnly = j.FlagNumericOnly
+	c.AllowWhitespace = j.AllowWhitespace
+	c.PatternMinLength = j.PatternMinLength
+	c.KeyboardLayouts = j.KeyboardLayouts
+	c.ForbiddenPatterns = j.ForbiddenPatterns
+	c.ForbiddenPatternIsFatal = j.ForbiddenPatternIsFatal
+	c.DetectCurrentYear = j.DetectCurrentYear
+	c.CurrentYear = j.CurrentYear
+	c.MinEntropy = j.MinEntropy
+	c.DisabledCategories = j.DisabledCategories
+	c.MaxIssues = j.MaxIssues
+	c.IssueOrder = j.IssueOrder
+	c.SuppressAllIssues = j.SuppressAllIssues
+	c.FriendlyMessages = j.FriendlyMessages
+	c.Locale = j.Locale
+	c.CustomPasswords = j.CustomPasswords
+	c.HotList = j.HotList
+	c.CustomWords = j.CustomWords
+	c.MaxCustomEntries = j.MaxCustomEntries
+	c.ContextWords = j.ContextWords
+	c.ContextMinWordLen = j.ContextMinWordLen
+	c.MaxHistorySubstring = j.MaxHistorySubstring
+	c.MinEditDistance = j.MinEditDistance
+	c.DisableLeet = j.DisableLeet
+	c.DetectReversed = j.DetectReversed
+	c.DetectSubstrings = j.DetectSubstrings
+	c.FuzzyDictionary = j.FuzzyDictionary
+	c.DetectMangled = j.DetectMangled
+	c.NormalizeUnicode = j.NormalizeUnicode
+	c.HIBPMinOccurrences = j.HIBPMinOccurrences
+	c.ConstantTimeMode = j.ConstantTimeMode
+	c.PassphraseMode = j.PassphraseMode
+	c.MinWords = j.MinWords
+	c.WordDictSize = j.WordDictSize
+	c.MinExecutionTimeMs = j.MinExecutionTimeMs
+	c.EntropyMode = j.EntropyMode
+	c.EmojiPoolSize = j.EmojiPoolSize
+	c.GuessesPerSecond = j.GuessesPerSecond
+	c.PenaltyWeights = j.PenaltyWeights
+	c.VerdictThresholds = j.VerdictThresholds
+	c.VerdictBands = j.VerdictBands
+	c.CharsetBonusModel = j.CharsetBonusModel
+	c.RedactSensitive = j.RedactSensitive
+	c.Parallelism = j.Parallelism
+}
+
+// PolicyID returns a short, stable identifier of the policy c describes:
+// the first 16 hex digits of a SHA-256 hash of its policy fields, which
+// are the serialized fields (see [Config.MarshalJSON]) except ContextWords,
+// plus what each of CustomRules checks. Configs with equal policy fields
+// share an ID, and changing one, such as MinLength or a custom rule's
+// pattern, changes it. Per-user and runtime fields (ContextWords,
+// PreviousPasswords, CurrentPasswordHash, HIBPChecker, HIBPResult,
+// PasswordSet, MarkovModel, KeySalt, hooks) do not contribute.
+//
+// Store the ID with saved results to tie each verdict to the exact policy
+// that produced it. IDs are stable for a given library version; a release
+// that adds Config fields may change them.
+func (c Config) PolicyID() string {
+	j := toConfigJSON(c)
+	j.ContextWords = nil
+	lists := [...][]string{j.CustomPasswords, j.HotList, j.CustomWords}
+	j.CustomPasswords, j.HotList, j.CustomWords = nil, nil, nil
+	rules := make([]string, len(c.CustomRules))
+	for i, r := range c.CustomRules {
+		rules[i] = ruleIdentity(r)
+	}
+	// Every field is plain data, so encoding cannot fail.
+	fields, _ := json.Marshal(struct {
+		Policy      configJSON
+		CustomRules []string
+	}{j, rules})
+
+	// The large lists are hashed directly, length-prefixed and in batches,
+	// which is much cheaper than encoding them.
+	h := sha256.New()
+	h.Write(fields)
+	buf := make([]byte, 0, 4096)
+	for _, list := range lists {
+		buf = binary.BigEndian.AppendUint64(buf, uint64(len(list)))
+		for _, s := range list {
+			buf = binary.BigEndian.AppendUint64(buf, uint64(len(s)))
+			buf = append(buf, s...)
+			if len(buf) >= 4096 {
+				h.Write(buf)
+				buf = buf[:0]
+			}
+		}
+	}
+	h.Write(buf)
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
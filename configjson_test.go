@@ -0,0 +1,231 @@
+package passcheck
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// nonSerializedConfigFields lists the Config fields MarshalJSON omits.
+var nonSerializedConfigFields = map[string]bool{
+	"CustomRules":         true,
+	"PasswordSet":         true,
+	"MarkovModel":         true,
+	"PreviousPasswords":   true,
+	"CurrentPasswordHash": true,
+	"HIBPChecker":         true,
+	"HIBPResult":          true,
+	"Observer":            true,
+	"KeySalt":             true,
+	"OnResult":            true,
+	"OnIssue":             true,
+	"OnFailure":           true,
+}
+
+func fullConfig() Config {
+	return Config{
+		MinLength:               16,
+		MaxLength:               64,
+		RequireUpper:            true,
+		RequireLower:            false,
+		RequireDigit:            true,
+		RequireSymbol:           false,
+		MaxRepeats:              2,
+		MinCharClasses:          3,
+		MinUniqueChars:          6,
+		FlagNumericOnly:         true,
+		AllowWhitespace:         new(bool),
+		PatternMinLength:        5,
+		KeyboardLayouts:         []string{KeyboardLayoutAZERTY},
+		ForbiddenPatterns:       []string{`(?i)acme`},
+		ForbiddenPatternIsFatal: true,
+		DetectCurrentYear:       true,
+		CurrentYear:             2026,
+		MinEntropy:              40,
+		DisabledCategories:      []string{CategoryContext},
+		MaxIssues:               7,
+		IssueOrder:              []string{CategoryRule, CategoryBreach},
+		SuppressAllIssues:       true,
+		FriendlyMessages:        true,
+		Locale:                  "pt-BR",
+		CustomPasswords:         []string{"hunter2"},
+		HotList:                 []string{"summer2026"},
+		CustomWords:             []string{"acme"},
+		MaxCustomEntries:        50,
+		ContextWords:            []string{"alice"},
+		ContextMinWordLen:       2,
+		MaxHistorySubstring:     6,
+		MinEditDistance:         5,
+		DisableLeet:             true,
+		DetectReversed:          false,
+		DetectSubstrings:        true,
+		FuzzyDictionary:         true,
+		DetectMangled:           true,
+		NormalizeUnicode:        true,
+		HIBPMinOccurrences:      3,
+		ConstantTimeMode:        true,
+		PassphraseMode:          true,
+		MinWords:                5,
+		WordDictSize:            2048,
+		MinExecutionTimeMs:      10,
+		EntropyMode:             EntropyModePatternAware,
+		EmojiPoolSize:           200,
+		GuessesPerSecond:        1e4,
+		PenaltyWeights:          &PenaltyWeights{RuleViolation: 2, PatternMatch: 1.5, DictionaryMatch: 0.5, ContextMatch: 3, HIBPBreach: 4, EntropyWeight: 0.8},
+		VerdictThresholds:       &VerdictThresholds{VeryWeakMax: 10, WeakMax: 30, OkayMax: 50, StrongMax: 70},
+		VerdictBands:            []VerdictBand{{MinScore: 0, Label: "Weak"}, {MinScore: 60, Label: "Good"}},
+		CharsetBonusModel:       CharsetBonusDiminishing,
+		RedactSensitive:         true,
+		Parallelism:             4,
+	}
+}
+
+func TestConfig_JSONRoundTrip(t *testing.T) {
+	cfg := fullConfig()
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, decoded) {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", decoded, cfg)
+	}
+}
+
+func TestConfig_JSONCoversAllFields(t *testing.T) {
+	// Guards against new Config fields being silently dropped: every field
+	// must be either serialized or listed in nonSerializedConfigFields.
+	data, err := json.Marshal(fullConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		t.Fatal(err)
+	}
+	typ := reflect.TypeOf(Config{})
+	if want := typ.NumField() - len(nonSerializedConfigFields); len(keys) != want {
+		t.Errorf("serialized %d fields, want %d; update configJSON or nonSerializedConfigFields", len(keys), want)
+	}
+	for name := range nonSerializedConfigFields {
+		if _, ok := typ.FieldByName(name); !ok {
+			t.Errorf("nonSerializedConfigFields lists unknown field %q", name)
+		}
+	}
+}
+
+func TestConfig_JSONOmitsRuntimeFields(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HIBPResult = &HIBPCheckResult{Breached: true, Count: 3}
+	cfg.PreviousPasswords = []string{"OldSecret!1"}
+	cfg.KeySalt = []byte("salt")
+	cfg.OnResult = func(context.Context, Result) {}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal with runtime fields: %v", err)
+	}
+	for _, leaked := range []string{"OldSecret", "salt", "breached"} {
+		if strings.Contains(strings.ToLower(string(data)), strings.ToLower(leaked)) {
+			t.Errorf("serialized config contains %q: %s", leaked, data)
+		}
+	}
+
+	// Unmarshaling keeps runtime fields already set on the receiver.
+	target := Config{HIBPResult: cfg.HIBPResult}
+	if err := json.Unmarshal([]byte(`{"min_length": 20}`), &target); err != nil {
+		t.Fatal(err)
+	}
+	if target.HIBPResult != cfg.HIBPResult {
+		t.Error("UnmarshalJSON should not reset HIBPResult")
+	}
+}
+
+func TestConfig_UnmarshalPartialUsesDefaults(t *testing.T) {
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"min_length": 14, "require_symbol": false, "penalty_weights": {"dictionary_match": 2}}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultConfig()
+	want.MinLength = 14
+	want.RequireSymbol = false
+	want.PenaltyWeights = &PenaltyWeights{DictionaryMatch: 2}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("partial decode = %+v, want %+v", cfg, want)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("decoded partial config should be valid: %v", err)
+	}
+}
+
+func TestConfig_UnmarshalInvalidJSON(t *testing.T) {
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"min_length": "long"}`), &cfg); err == nil {
+		t.Error("expected error for a mistyped field")
+	}
+}
+
+func TestConfig_PolicyID(t *testing.T) {
+	a, b := DefaultConfig(), DefaultConfig()
+	if a.PolicyID() != b.PolicyID() {
+		t.Error("identical configs must share a PolicyID")
+	}
+	if len(a.PolicyID()) != 16 {
+		t.Errorf("PolicyID length = %d, want 16", len(a.PolicyID()))
+	}
+
+	b.MinLength++
+	if a.PolicyID() == b.PolicyID() {
+		t.Error("changing MinLength must change the PolicyID")
+	}
+
+	c := DefaultConfig()
+	c.HIBPResult = &HIBPCheckResult{Breached: true}
+	c.PreviousPasswords = []string{"OldSecret!1"}
+	c.OnResult = func(context.Context, Result) {}
+	c.ContextWords = []string{"alice", "alice@example.com"}
+	if c.PolicyID() != a.PolicyID() {
+		t.Error("runtime-only and per-user fields must not affect the PolicyID")
+	}
+
+	c = DefaultConfig()
+	c.CustomRules = []Rule{RegexRule("ORG_CODENAME", "No codenames", regexp.MustCompile(`bluebird`), false)}
+	d := DefaultConfig()
+	d.CustomRules = []Rule{RegexRule("ORG_CODENAME", "No codenames", regexp.MustCompile(`nightjar`), false)}
+	if c.PolicyID() == a.PolicyID() || c.PolicyID() == d.PolicyID() {
+		t.Error("custom rules must contribute to the PolicyID")
+	}
+
+	// Large lists: equal contents share an ID whichever slice holds them.
+	c, d = DefaultConfig(), DefaultConfig()
+	c.CustomPasswords = []string{"acme2024", "acme2025"}
+	d.CustomPasswords = []string{"acme2024", "acme2025"}
+	if c.PolicyID() != d.PolicyID() || c.PolicyID() == a.PolicyID() {
+		t.Error("CustomPasswords must contribute to the PolicyID by content")
+	}
+	d.CustomPasswords = []string{"acme2024", "acme2026"}
+	if c.PolicyID() == d.PolicyID() {
+		t.Error("different CustomPasswords must change the PolicyID")
+	}
+	before := c.PolicyID()
+	c.CustomPasswords[1] = "acme2026"
+	if c.PolicyID() == before || c.PolicyID() != d.PolicyID() {
+		t.Error("editing CustomPasswords in place must change the PolicyID")
+	}
+
+	r1, _ := CheckWithConfig("Xk9$mP2!vR7@", a)
+	r2, _ := CheckWithConfig("password", a)
+	if r1.PolicyID != a.PolicyID() || r2.PolicyID != r1.PolicyID {
+		t.Errorf("Result.PolicyID = %q, %q; want %q", r1.PolicyID, r2.PolicyID, a.PolicyID())
+	}
+	r3, _ := CheckWithConfig("Xk9$mP2!vR7@", b)
+	if r3.PolicyID == r1.PolicyID {
+		t.Error("results under different policies must carry different IDs")
+	}
+}
@@ -0,0 +1,57 @@
+package passcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rafaelsanzio/passcheck/internal/yaml"
+)
+
+// ParseConfig reads a YAML policy document from r and returns the Config
+// it describes, applied on top of [DefaultConfig] and validated.
+//
+// Keys are the snake_case names used by [Config.MarshalJSON], e.g.:
+//
+//	min_length: 14
+//	require_symbol: false
+//	keyboard_layouts: [qwerty, azerty]
+//	penalty_weights:
+//	  dictionary_match: 2
+//
+// Unknown keys are an error, so typos in policy files are caught at
+// startup. Fields that cannot be serialized (HIBPChecker, hooks, etc.) are
+// left unset. Quote strings containing " #" or starting with YAML
+// indicators such as "*" or "&"; anchors, tags, and multi-line scalars are
+// not supported.
+//
+// Validation failures wrap [ErrInvalidConfig].
+func ParseConfig(r io.Reader) (Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Config{}, fmt.Errorf("passcheck: read config: %w", err)
+	}
+	doc, err := yaml.Parse(data)
+	if err != nil {
+		return Config{}, fmt.Errorf("passcheck: parse config: %w", err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return Config{}, fmt.Errorf("passcheck: parse config: %w", err)
+	}
+
+	j := toConfigJSON(DefaultConfig())
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&j); err != nil {
+		return Config{}, fmt.Errorf("passcheck: parse config: %w", err)
+	}
+
+	cfg := DefaultConfig()
+	j.applyTo(&cfg)
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,102 @@
+package passcheck
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	const policy = `# password-policy.yaml
+min_length: 14
+require_symbol: false
+keyboard_layouts: [qwerty, azerty]
+forbidden_patterns:
+  - "(?i)acme"
+entropy_mode: pattern-aware
+penalty_weights:
+  dictionary_match: 2
+verdict_thresholds:
+  very_weak_max: 10
+  weak_max: 30
+  okay_max: 50
+  strong_max: 70
+`
+	cfg, err := ParseConfig(strings.NewReader(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultConfig()
+	want.MinLength = 14
+	want.RequireSymbol = false
+	want.KeyboardLayouts = []string{KeyboardLayoutQWERTY, KeyboardLayoutAZERTY}
+	want.ForbiddenPatterns = []string{"(?i)acme"}
+	want.EntropyMode = EntropyModePatternAware
+	want.PenaltyWeights = &PenaltyWeights{DictionaryMatch: 2}
+	want.VerdictThresholds = &VerdictThresholds{VeryWeakMax: 10, WeakMax: 30, OkayMax: 50, StrongMax: 70}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("ParseConfig =\n%+v\nwant\n%+v", cfg, want)
+	}
+}
+
+func TestParseConfig_VerdictBands(t *testing.T) {
+	const policy = `verdict_bands:
+  - min_score: 0
+    label: Rejected
+  - min_score: 50
+    label: "Acceptable: review"
+  - min_score: 80
+    label: Excellent
+`
+	cfg, err := ParseConfig(strings.NewReader(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []VerdictBand{{0, "Rejected"}, {50, "Acceptable: review"}, {80, "Excellent"}}
+	if !reflect.DeepEqual(cfg.VerdictBands, want) {
+		t.Fatalf("VerdictBands = %+v, want %+v", cfg.VerdictBands, want)
+	}
+
+	// The YAML policy and its JSON encoding describe the same Config.
+	data, err := cfg.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Config
+	if err := back.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, cfg) {
+		t.Errorf("JSON round trip =\n%+v\nwant\n%+v", back, cfg)
+	}
+}
+
+func TestParseConfig_EmptyIsDefault(t *testing.T) {
+	cfg, err := ParseConfig(strings.NewReader("# nothing overridden\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("empty policy = %+v, want DefaultConfig", cfg)
+	}
+}
+
+func TestParseConfig_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":        "min_lenght: 14\n",
+		"unknown nested key": "penalty_weights:\n  dictionary: 2\n",
+		"wrong type":         "min_length: long\n",
+		"invalid yaml":       "min_length 14\n",
+	}
+	for name, src := range tests {
+		if _, err := ParseConfig(strings.NewReader(src)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	_, err := ParseConfig(strings.NewReader("min_length: 0\n"))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("invalid value: err = %v, want ErrInvalidConfig", err)
+	}
+}
@@ -0,0 +1,66 @@
+package passcheck
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultGuessesPerSecond is the attacker rate assumed for crack-time
+// estimates: an offline attack against a fast, unsalted hash on commodity
+// GPUs.
+const DefaultGuessesPerSecond = 1e10
+
+// Seconds per crack-time display unit. A month is 31 days, as in zxcvbn.
+const (
+	secondsPerMinute  = 60
+	secondsPerHour    = 60 * secondsPerMinute
+	secondsPerDay     = 24 * secondsPerHour
+	secondsPerMonth   = 31 * secondsPerDay
+	secondsPerYear    = 365 * secondsPerDay
+	secondsPerCentury = 100 * secondsPerYear
+)
+
+// crackTime returns the seconds needed to try all 2^entropyBits guesses at
+// guessesPerSecond (DefaultGuessesPerSecond when zero) and its display
+// form. Seconds are capped at math.MaxFloat64 so the result always
+// encodes as JSON.
+func crackTime(entropyBits, guessesPerSecond float64) (seconds float64, display string) {
+	if guessesPerSecond <= 0 {
+		guessesPerSecond = DefaultGuessesPerSecond
+	}
+	seconds = min(math.Pow(2, entropyBits)/guessesPerSecond, math.MaxFloat64)
+	return seconds, crackTimeDisplay(seconds)
+}
+
+// crackTimeDisplay renders seconds in the largest unit that keeps the
+// value at or above one, from "instant" through "N seconds", "N minutes",
+// "N hours", "N days", "N months", and "N years" to "centuries".
+func crackTimeDisplay(seconds float64) string {
+	if seconds < 1 {
+		return "instant"
+	}
+	if seconds >= secondsPerCentury {
+		return "centuries"
+	}
+	units := []struct {
+		size             float64
+		singular, plural string
+	}{
+		{secondsPerYear, "year", "years"},
+		{secondsPerMonth, "month", "months"},
+		{secondsPerDay, "day", "days"},
+		{secondsPerHour, "hour", "hours"},
+		{secondsPerMinute, "minute", "minutes"},
+		{1, "second", "seconds"},
+	}
+	for _, u := range units {
+		if seconds >= u.size {
+			n := math.Floor(seconds / u.size)
+			if n == 1 {
+				return "1 " + u.singular
+			}
+			return fmt.Sprintf("%.0f %s", n, u.plural)
+		}
+	}
+	return "instant"
+}
@@ -0,0 +1,73 @@
+package passcheck
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestCrackTimeDisplay(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "instant"},
+		{0.5, "instant"},
+		{1, "1 second"},
+		{59, "59 seconds"},
+		{60, "1 minute"},
+		{3 * secondsPerHour, "3 hours"},
+		{3*secondsPerDay + 5, "3 days"},
+		{2 * secondsPerMonth, "2 months"},
+		{5 * secondsPerYear, "5 years"},
+		{99 * secondsPerYear, "99 years"},
+		{secondsPerCentury, "centuries"},
+		{math.MaxFloat64, "centuries"},
+	}
+	for _, tt := range tests {
+		if got := crackTimeDisplay(tt.seconds); got != tt.want {
+			t.Errorf("crackTimeDisplay(%g) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestCrackTime(t *testing.T) {
+	sec, _ := crackTime(40, 0)
+	if want := math.Pow(2, 40) / DefaultGuessesPerSecond; sec != want {
+		t.Errorf("crackTime(40, 0) = %g, want %g", sec, want)
+	}
+	sec, display := crackTime(40, 1e4)
+	if want := math.Pow(2, 40) / 1e4; sec != want || display != "3 years" {
+		t.Errorf("crackTime(40, 1e4) = %g, %q, want %g, %q", sec, display, want, "3 years")
+	}
+	if sec, display := crackTime(2000, 1); sec != math.MaxFloat64 || display != "centuries" {
+		t.Errorf("crackTime(2000, 1) = %g, %q, want MaxFloat64, centuries", sec, display)
+	}
+}
+
+func TestCheckWithConfig_CrackTime(t *testing.T) {
+	cfg := DefaultConfig()
+	fast, _ := CheckWithConfig("Xk9$mP2!vR7@", cfg)
+	if fast.CrackTimeSeconds <= 0 || fast.CrackTimeDisplay == "" {
+		t.Fatalf("crack time not set: %g, %q", fast.CrackTimeSeconds, fast.CrackTimeDisplay)
+	}
+
+	cfg.GuessesPerSecond = 1e4
+	slow, _ := CheckWithConfig("Xk9$mP2!vR7@", cfg)
+	if slow.CrackTimeSeconds <= fast.CrackTimeSeconds {
+		t.Errorf("slower attacker should take longer: %g <= %g", slow.CrackTimeSeconds, fast.CrackTimeSeconds)
+	}
+
+	weak, _ := CheckWithConfig("abc", DefaultConfig())
+	if weak.CrackTimeDisplay != "instant" {
+		t.Errorf("weak password crack time = %q, want instant", weak.CrackTimeDisplay)
+	}
+	if _, err := json.Marshal(weak); err != nil {
+		t.Errorf("Marshal: %v", err)
+	}
+
+	cfg.GuessesPerSecond = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("negative GuessesPerSecond should be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package passcheck
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// Rule is an organization-specific password rule, run by [CheckWithConfig]
+// after the built-in rules when listed in Config.CustomRules.
+//
+// Check receives the password truncated to [MaxPasswordLength] runes and
+// returns an issue for each violation, or none. Its issues are reported in
+// the rule category: Category is overwritten with [CategoryRule], and each
+// issue costs a rule penalty and fails MeetsPolicy. Severity must be zero
+// or between [SeverityLow] and [SeverityHigh]; zero and values below the
+// range become SeverityLow, like the built-in rules, and values above it
+// become SeverityHigh. MatchStart and MatchEnd, when set, are rune offsets
+// into the password, and Match is filled in from them. Choose codes that
+// cannot collide with the built-in ones, e.g. with an "ORG_" prefix.
+//
+// Check may be called concurrently and must not retain the password.
+type Rule interface {
+	Check(password string) []Issue
+}
+
+// RegexRule returns a [Rule] that reports one issue with the given code
+// and message when pattern matches the password, or, with mustMatch, when
+// it does not. A match is reported with its span, so UIs can highlight it.
+//
+//	codename := passcheck.RegexRule("ORG_CODENAME", "Do not use project codenames",
+//		regexp.MustCompile(`(?i)bluebird|nightjar`), false)
+//	startLetter := passcheck.RegexRule("ORG_START_LETTER", "Start with a letter",
+//		regexp.MustCompile(`^\pL`), true)
+//
+// The message is shown as is; avoid quoting the pattern in it, since
+// messages are visible to the user.
+func RegexRule(code, message string, pattern *regexp.Regexp, mustMatch bool) Rule {
+	return regexRule{code: code, message: message, pattern: pattern, mustMatch: mustMatch}
+}
+
+// regexRule is the [Rule] returned by [RegexRule].
+type regexRule struct {
+	code, message string
+	pattern       *regexp.Regexp
+	mustMatch     bool
+}
+
+// Check implements [Rule].
+func (r regexRule) Check(password string) []Issue {
+	if r.pattern == nil {
+		return nil
+	}
+	loc := r.pattern.FindStringIndex(password)
+	switch {
+	case r.mustMatch && loc == nil:
+		return []Issue{{Code: r.code, Message: r.message}}
+	case !r.mustMatch && loc != nil:
+		start := utf8.RuneCountInString(password[:loc[0]])
+		end := start + utf8.RuneCountInString(password[loc[0]:loc[1]])
+		return []Issue{{Code: r.code, Message: r.message, MatchStart: start, MatchEnd: end}}
+	}
+	return nil
+}
+
+// ruleIdentity returns a string identifying what r checks, for
+// fingerprinting configs: the code, message, and pattern of a [RegexRule],
+// or the type and formatted value of any other rule.
+func ruleIdentity(r Rule) string {
+	if rr, ok := r.(regexRule); ok {
+		pattern := ""
+		if rr.pattern != nil {
+			pattern = rr.pattern.String()
+		}
+		return fmt.Sprintf("regex:%q:%q:%q:%t", rr.code, rr.message, pattern, rr.mustMatch)
+	}
+	return fmt.Sprintf("%T:%+v", r, r)
+}
+
+// checkCustomRules runs each rule against the truncated password pw and
+// returns their issues in the rule category.
+func checkCustomRules(pw string, custom []Rule) []issue.Issue {
+	var out []issue.Issue
+	for _, r := range custom {
+		for _, iss := range r.Check(pw) {
+			severity := int(min(max(iss.Severity, SeverityLow), SeverityHigh))
+			out = append(out, issue.New(iss.Code, iss.Message, CategoryRule, severity).At(iss.MatchStart, iss.MatchEnd))
+		}
+	}
+	return out
+}
@@ -0,0 +1,131 @@
+package passcheck
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// ruleFunc adapts a function to the Rule interface.
+type ruleFunc func(password string) []Issue
+
+func (f ruleFunc) Check(password string) []Issue { return f(password) }
+
+func findIssue(issues []Issue, code string) (Issue, bool) {
+	for _, iss := range issues {
+		if iss.Code == code {
+			return iss, true
+		}
+	}
+	return Issue{}, false
+}
+
+func TestCheckWithConfig_CustomRules_RegexRule(t *testing.T) {
+	const pw = "Xk9$Bluebird!vR7@nL4"
+	base, _ := CheckWithConfig(pw, DefaultConfig())
+
+	cfg := DefaultConfig()
+	cfg.CustomRules = []Rule{
+		RegexRule("ORG_CODENAME", "Do not use project codenames", regexp.MustCompile(`(?i)bluebird`), false),
+		RegexRule("ORG_START_LETTER", "Start with a letter", regexp.MustCompile(`^\pL`), true),
+	}
+	r, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss, ok := findIssue(r.Issues, "ORG_CODENAME")
+	if !ok {
+		t.Fatalf("expected ORG_CODENAME, got %+v", r.Issues)
+	}
+	if iss.Category != CategoryRule || iss.Severity != SeverityLow {
+		t.Errorf("Category = %q, Severity = %d, want rule and low", iss.Category, iss.Severity)
+	}
+	if iss.Match != "Bluebird" || iss.MatchStart != 4 || iss.MatchEnd != 12 {
+		t.Errorf("Match = %q [%d, %d), want Bluebird [4, 12)", iss.Match, iss.MatchStart, iss.MatchEnd)
+	}
+	if r.Has("ORG_START_LETTER") {
+		t.Error("ORG_START_LETTER should not fire for a password starting with a letter")
+	}
+	if !base.MeetsPolicy || r.MeetsPolicy {
+		t.Errorf("MeetsPolicy = %v (base %v), want the custom rule to fail it", r.MeetsPolicy, base.MeetsPolicy)
+	}
+	withRules, _ := CheckDetailed(pw, cfg)
+	without, _ := CheckDetailed(pw, DefaultConfig())
+	if got, want := withRules.Explain().Penalty, without.Explain().Penalty+5; got != want {
+		t.Errorf("Penalty = %d, want %d (one more rule penalty)", got, want)
+	}
+
+	r, _ = CheckWithConfig("9"+pw, cfg)
+	if iss, ok := findIssue(r.Issues, "ORG_START_LETTER"); !ok || iss.Match != "" {
+		t.Errorf("mustMatch: got %+v, want ORG_START_LETTER without a match", r.Issues)
+	}
+}
+
+func TestCheckWithConfig_CustomRules_Rule(t *testing.T) {
+	var seen int
+	cfg := DefaultConfig()
+	cfg.MaxIssues = 0
+	cfg.CustomRules = []Rule{ruleFunc(func(pw string) []Issue {
+		seen = utf8.RuneCountInString(pw)
+		return []Issue{{Code: "ORG_CUSTOM", Message: "Custom", Category: CategoryBreach, Severity: SeverityHigh}}
+	})}
+
+	r, err := CheckWithConfig(strings.Repeat("aB3$", MaxPasswordLength), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen != MaxPasswordLength {
+		t.Errorf("rule saw %d runes, want the truncated %d", seen, MaxPasswordLength)
+	}
+	iss, ok := findIssue(r.Issues, "ORG_CUSTOM")
+	if !ok || iss.Category != CategoryRule || iss.Severity != SeverityHigh {
+		t.Errorf("got %+v, want ORG_CUSTOM in the rule category with high severity", iss)
+	}
+
+	cfg.DisabledCategories = []string{CategoryRule}
+	if r, _ := CheckWithConfig("Xk9$mP2!vR7@nL4&", cfg); r.Has("ORG_CUSTOM") {
+		t.Error("custom rules should not run when the rule category is disabled")
+	}
+}
+
+func TestCheckWithConfig_CustomRules_SeverityOutOfRange(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CustomRules = []Rule{ruleFunc(func(string) []Issue {
+		return []Issue{
+			{Code: "ORG_NEGATIVE", Message: "Negative", Severity: -2},
+			{Code: "ORG_HUGE", Message: "Huge", Severity: 7},
+		}
+	})}
+	r, err := CheckWithConfig("Xk9$mP2!vR7@nL4&", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for code, want := range map[string]Severity{"ORG_NEGATIVE": SeverityLow, "ORG_HUGE": SeverityHigh} {
+		if iss, ok := findIssue(r.Issues, code); !ok || iss.Severity != want {
+			t.Errorf("%s: got %+v, want severity %s", code, iss, want)
+		}
+	}
+}
+
+func TestDetailedFindings_ScoreUnder_CustomRules(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CustomRules = []Rule{RegexRule("ORG_CODENAME", "Do not use project codenames", regexp.MustCompile(`(?i)bluebird`), false)}
+	df, err := CheckDetailed("Xk9$Bluebird!vR7@nL4", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.MinLength = 8
+	if r := df.ScoreUnder(cfg); !r.Has("ORG_CODENAME") {
+		t.Errorf("ScoreUnder dropped the custom rule issue: %+v", r.Issues)
+	}
+}
+
+func TestConfig_Validate_NilCustomRule(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CustomRules = []Rule{nil}
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Validate() = %v, want ErrInvalidConfig", err)
+	}
+}
@@ -0,0 +1,160 @@
+package passcheck
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/rules"
+	"github.com/rafaelsanzio/passcheck/internal/safemem"
+	"github.com/rafaelsanzio/passcheck/internal/scoring"
+)
+
+// DetailedFindings is the outcome of [CheckDetailed]: the [Result] together
+// with the raw findings of each analysis phase and the intermediate state
+// needed to re-score them under another configuration.
+//
+// DetailedFindings never stores the full password. It keeps the password's
+// length, character-set summary, runs of repeated characters, entropy, and
+// the issues found — the same information already exposed through issues —
+// plus the password's stem for [DetailedFindings.StemResult]. Treat a
+// DetailedFindings value as sensitive and do not log or persist it.
+type DetailedFindings struct {
+	// Result is the check result under the configuration passed to
+	// [CheckDetailed].
+	Result Result
+
+	// Rules, Patterns, Dictionary, Context, HIBP, and History hold the raw
+	// findings of each phase, before deduplication and the MaxIssues limit.
+	Rules      []Issue
+	Patterns   []Issue
+	Dictionary []Issue
+	Context    []Issue
+	HIBP       []Issue
+	History    []Issue
+
+	findings findings
+
+	// stem is the password with trailing digits and symbols removed, or
+	// empty when there was nothing to strip. cfg is kept to score it.
+	stem string
+	cfg  Config
+}
+
+// CheckDetailed evaluates a password like [CheckWithConfig] and additionally
+// returns the per-phase findings. It returns an error if cfg is invalid.
+//
+// Use the returned value with [DetailedFindings.ScoreUnder] to evaluate the
+// same password under other policies without rescanning it.
+func CheckDetailed(password string, cfg Config) (DetailedFindings, error) {
+	if err := cfg.Validate(); err != nil {
+		return DetailedFindings{}, err
+	}
+	start := time.Now()
+
+	f := analyze(password, cfg)
+	df := DetailedFindings{
+		Result:     f.result(cfg),
+		Rules:      toPublicIssues(f.issues.Rules, cfg.RedactSensitive),
+		Patterns:   toPublicIssues(f.issues.Patterns, cfg.RedactSensitive),
+		Dictionary: toPublicIssues(f.issues.Dictionary, cfg.RedactSensitive),
+		Context:    toPublicIssues(f.issues.Context, cfg.RedactSensitive),
+		HIBP:       toPublicIssues(f.issues.HIBP, cfg.RedactSensitive),
+		History:    toPublicIssues(f.issues.History, cfg.RedactSensitive),
+		findings:   f,
+		cfg:        cfg,
+	}
+	if pw := truncate(password); stripSuffix(pw) != pw {
+		df.stem = stripSuffix(pw)
+	}
+
+	if cfg.ConstantTimeMode && cfg.MinExecutionTimeMs > 0 {
+		safemem.SleepRemaining(start, cfg.MinExecutionTimeMs)
+	}
+	return df, nil
+}
+
+// ScoreUnder re-scores the collected findings under cfg without rescanning
+// the password, for bulk what-if analysis such as "how many passwords fail
+// if MinLength rises to 14?".
+//
+// Rule checks (MinLength, Require*, MinCharClasses, MaxRepeats,
+// MinUniqueChars, FlagNumericOnly, MinEntropy), PenaltyWeights,
+// VerdictThresholds, CharsetBonusModel, MaxIssues, IssueOrder, and
+// RedactSensitive are fully re-evaluated, so the result matches
+// [CheckWithConfig] under cfg when only those fields differ. CustomRules
+// findings are reused as collected. Pattern, dictionary, context, and breach
+// findings, entropy, and passphrase detection are reused as collected;
+// changes to the options that drive them (e.g. PatternMinLength,
+// CustomWords, ContextWords, EntropyMode) are not reflected. AllowWhitespace
+// re-evaluates the whitespace rule but not entropy. Positive suggestions are
+// reused too, so a different Locale translates the issues but not the
+// suggestions. Categories in cfg.DisabledCategories are dropped, but a
+// category disabled when the findings were collected cannot be re-enabled. A
+// password rejected by MaxLength was never analyzed and stays rejected.
+//
+// ScoreUnder returns the zero Result if cfg is invalid.
+func (df DetailedFindings) ScoreUnder(cfg Config) Result {
+	if err := cfg.Validate(); err != nil {
+		return Result{}
+	}
+	f := df.findings
+	if !f.tooLong {
+		ro := ruleOptions(cfg)
+		f.issues.Rules = append(rules.CheckProfile(f.profile, ro), rules.CheckEntropy(f.entropy, ro)...)
+		f.issues.Rules = append(f.issues.Rules, f.custom...)
+	}
+	f.issues = dropDisabled(f.issues, cfg)
+	return f.result(cfg)
+}
+
+// StemResult strips trailing digits and symbols from the password (e.g.
+// "dragon123!" → "dragon") and returns the stem together with its check
+// result under the configuration passed to [CheckDetailed]. UIs can contrast
+// the stem's score with the full result to show that appended digits and
+// symbols add little strength.
+//
+// When the password has no trailing digits or symbols, or consists only of
+// them, base is empty and r is df.Result.
+//
+// The stem is never sent to the HIBP checker, and an HIBPResult computed
+// for the full password is not applied to it, so r has no breach findings.
+//
+// base is a substring of the password: return it to the caller only and
+// never log or persist it.
+func (df DetailedFindings) StemResult() (base string, r Result) {
+	if df.stem == "" {
+		return "", df.Result
+	}
+	cfg := df.cfg
+	cfg.HIBPChecker, cfg.HIBPResult = nil, nil
+	return df.stem, analyze(df.stem, cfg).result(cfg)
+}
+
+// dropDisabled clears the findings of categories disabled in cfg.
+func dropDisabled(set scoring.IssueSet, cfg Config) scoring.IssueSet {
+	for _, c := range []struct {
+		category string
+		issues   *[]issue.Issue
+	}{
+		{CategoryRule, &set.Rules},
+		{CategoryPattern, &set.Patterns},
+		{CategoryDictionary, &set.Dictionary},
+		{CategoryContext, &set.Context},
+		{CategoryBreach, &set.HIBP},
+		{CategoryHistory, &set.History},
+	} {
+		if !cfg.categoryEnabled(c.category) {
+			*c.issues = nil
+		}
+	}
+	return set
+}
+
+// stripSuffix removes trailing runes that are not letters.
+func stripSuffix(password string) string {
+	return strings.TrimRightFunc(password, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
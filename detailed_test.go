@@ -0,0 +1,159 @@
+package passcheck
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCheckDetailed_MatchesCheckWithConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, pw := range []string{"", "password", "qwerty123", "Xk9$mP2!vR7@nL4&wQ", "aaaBBB111"} {
+		df, err := CheckDetailed(pw, cfg)
+		if err != nil {
+			t.Fatalf("CheckDetailed(%q): %v", pw, err)
+		}
+		want, _ := CheckWithConfig(pw, cfg)
+		if !reflect.DeepEqual(df.Result, want) {
+			t.Errorf("CheckDetailed(%q).Result = %+v, want %+v", pw, df.Result, want)
+		}
+	}
+}
+
+func TestCheckDetailed_PerPhaseFindings(t *testing.T) {
+	df, err := CheckDetailed("qwerty", DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(df.Rules) == 0 {
+		t.Error("expected rule findings for a short password")
+	}
+	if len(df.Patterns) == 0 {
+		t.Error("expected pattern findings for a keyboard walk")
+	}
+	if len(df.Dictionary) == 0 {
+		t.Error("expected dictionary findings for a common password")
+	}
+	for _, iss := range df.Rules {
+		if iss.Category != "rule" {
+			t.Errorf("Rules contains %q issue %s", iss.Category, iss.Code)
+		}
+	}
+}
+
+func TestCheckDetailed_InvalidConfig(t *testing.T) {
+	_, err := CheckDetailed("password", Config{})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestDetailedFindings_ScoreUnder(t *testing.T) {
+	base := DefaultConfig()
+
+	longer := DefaultConfig()
+	longer.MinLength = 14
+
+	relaxed := DefaultConfig()
+	relaxed.MinLength = 8
+	relaxed.RequireSymbol = false
+	relaxed.MaxRepeats = 4
+
+	weighted := DefaultConfig()
+	weighted.PenaltyWeights = &PenaltyWeights{RuleViolation: 2, DictionaryMatch: 0.5}
+	weighted.VerdictThresholds = &VerdictThresholds{VeryWeakMax: 10, WeakMax: 30, OkayMax: 50, StrongMax: 70}
+	weighted.CharsetBonusModel = CharsetBonusDiminishing
+	weighted.MaxIssues = 2
+	weighted.RedactSensitive = true
+
+	noRules := DefaultConfig()
+	noRules.DisabledCategories = []string{CategoryRule}
+
+	configs := map[string]Config{
+		"same":     base,
+		"longer":   longer,
+		"relaxed":  relaxed,
+		"weighted": weighted,
+		"noRules":  noRules,
+	}
+	passwords := []string{"Xk9$mP2!vR7@", "password123", "aaaBcd1234!", "qwerty", "Xk9$mP2!vR7@nL4&wQ"}
+
+	for _, pw := range passwords {
+		df, err := CheckDetailed(pw, base)
+		if err != nil {
+			t.Fatalf("CheckDetailed(%q): %v", pw, err)
+		}
+		for name, cfg := range configs {
+			want, err := CheckWithConfig(pw, cfg)
+			if err != nil {
+				t.Fatalf("CheckWithConfig(%q, %s): %v", pw, name, err)
+			}
+			got := df.ScoreUnder(cfg)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ScoreUnder(%s) for %q = %+v, want %+v", name, pw, got, want)
+			}
+		}
+	}
+}
+
+func TestDetailedFindings_ScoreUnder_InvalidConfig(t *testing.T) {
+	df, _ := CheckDetailed("Xk9$mP2!vR7@", DefaultConfig())
+	if got := df.ScoreUnder(Config{}); !reflect.DeepEqual(got, Result{}) {
+		t.Errorf("ScoreUnder(invalid) = %+v, want zero Result", got)
+	}
+}
+
+func TestDetailedFindings_StemResult(t *testing.T) {
+	df, err := CheckDetailed("dragon123!", DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, r := df.StemResult()
+	if base != "dragon" {
+		t.Fatalf("base = %q, want %q", base, "dragon")
+	}
+	if r.Verdict != VerdictVeryWeak {
+		t.Errorf("stem verdict = %q (score %d), want %q", r.Verdict, r.Score, VerdictVeryWeak)
+	}
+	if r.Score > df.Result.Score {
+		t.Errorf("stem score %d should not exceed full score %d", r.Score, df.Result.Score)
+	}
+	want, _ := CheckWithConfig("dragon", DefaultConfig())
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("stem result = %+v, want %+v", r, want)
+	}
+}
+
+func TestDetailedFindings_StemResult_SkipsHIBP(t *testing.T) {
+	var checked []string
+	cfg := DefaultConfig()
+	cfg.HIBPChecker = hibpCheckerFunc(func(pw string) (bool, int, error) {
+		checked = append(checked, pw)
+		return true, 100, nil
+	})
+	df, err := CheckDetailed("dragon123!", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked = nil
+	_, r := df.StemResult()
+	if len(checked) != 0 {
+		t.Errorf("StemResult sent %d lookups to the HIBP checker", len(checked))
+	}
+	if r.Has(CodeHIBPBreached) {
+		t.Errorf("stem result has %s: %v", CodeHIBPBreached, r.Issues)
+	}
+}
+
+func TestDetailedFindings_StemResult_NothingToStrip(t *testing.T) {
+	for _, pw := range []string{"dragon", "123456!", ""} {
+		df, _ := CheckDetailed(pw, DefaultConfig())
+		base, r := df.StemResult()
+		if base != "" {
+			t.Errorf("%q: base = %q, want empty", pw, base)
+		}
+		if !reflect.DeepEqual(r, df.Result) {
+			t.Errorf("%q: StemResult should return the full result", pw)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package passcheck
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/dictionary"
+	"github.com/rafaelsanzio/passcheck/internal/passphrase"
+)
+
+// Input types reported in [Result.DetectedType].
+const (
+	// TypePassword is a conventional password.
+	TypePassword = "password"
+
+	// TypePassphrase is a sequence of words, e.g. "correct-horse-battery-staple".
+	TypePassphrase = "passphrase"
+
+	// TypeToken is a machine-generated string such as a hex digest, a
+	// base64-encoded secret, or an API key.
+	TypeToken = "token"
+)
+
+// Thresholds for input type detection.
+const (
+	minHexTokenLen    = 16 // hex digests and keys are at least 64 bits
+	minBase64TokenLen = 20 // shorter base64-looking strings are usually passwords
+	minTokenSwitches  = 3  // letter/digit alternations expected in random encodings
+	minTypeWords      = 4  // words needed to look like a passphrase when MinWords is unset
+	minTypeWordLen    = 3  // shorter fragments (e.g. "xk" in "Xk9$") are not words
+)
+
+// detectType infers what kind of secret password looks like. It is advisory
+// and never affects scoring. The heuristics, in order:
+//
+//  1. Token: no whitespace, and either at least minHexTokenLen hex digits or
+//     at least minBase64TokenLen characters from the base64/base64url
+//     alphabet (plus '.' for JWTs and '=' padding) with upper, lower, and
+//     digits. Either way letters and digits must alternate at least
+//     minTokenSwitches times, as they do in random encodings but rarely in
+//     human-chosen strings like "Summer2024".
+//  2. Passphrase: at least MinWords (4 when unset) distinct words of three
+//     or more letters, as found by passphrase detection.
+//  3. Password: everything else.
+//
+// detected is the passphrase detection result when PassphraseMode is on,
+// or nil to detect here.
+func detectType(password string, cfg Config, detected *passphrase.Info) string {
+	if looksLikeToken(password) {
+		return TypeToken
+	}
+	minWords := cfg.MinWords
+	if minWords < 1 {
+		minWords = minTypeWords
+	}
+	if detected == nil {
+		info := passphrase.DetectWith(password, passphrase.Options{
+			MinWords:      minWords,
+			IsCommon:      dictionary.IsPasswordToken,
+			NormalizeLeet: !cfg.DisableLeet,
+		})
+		detected = &info
+	}
+	words := 0
+	for _, w := range detected.Words {
+		if utf8.RuneCountInString(w) >= minTypeWordLen && isAlpha(w) {
+			words++
+		}
+	}
+	if words >= minWords {
+		return TypePassphrase
+	}
+	return TypePassword
+}
+
+// looksLikeToken reports whether password looks like a hex or base64
+// encoded, machine-generated string.
+func looksLikeToken(password string) bool {
+	var hex, upper, lower, digit, other int
+	switches := 0
+	prevDigit, prevSet := false, false
+	for _, r := range password {
+		switch {
+		case r >= '0' && r <= '9':
+			digit++
+		case r >= 'a' && r <= 'z':
+			lower++
+		case r >= 'A' && r <= 'Z':
+			upper++
+		case r == '+' || r == '/' || r == '-' || r == '_' || r == '.' || r == '=':
+			other++
+			continue
+		default:
+			return false
+		}
+		if unicode.Is(unicode.ASCII_Hex_Digit, r) {
+			hex++
+		}
+		isDigit := r >= '0' && r <= '9'
+		if prevSet && isDigit != prevDigit {
+			switches++
+		}
+		prevDigit, prevSet = isDigit, true
+	}
+	if switches < minTokenSwitches {
+		return false
+	}
+	n := upper + lower + digit
+	if other == 0 && hex == n && n >= minHexTokenLen {
+		return true
+	}
+	return n+other >= minBase64TokenLen && upper > 0 && lower > 0 && digit > 0
+}
+
+// isAlpha reports whether s consists only of letters.
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
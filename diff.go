@@ -0,0 +1,56 @@
+package passcheck
+
+// ResultDiff describes how a check result changed from one check to the
+// next, as returned by [DiffResults]. Live-feedback UIs can use it to
+// narrate progress, e.g. "you fixed RULE_TOO_SHORT, +12 points".
+type ResultDiff struct {
+	// AddedCodes and RemovedCodes list the issue codes present in only the
+	// current or only the previous result, in the order they appear there.
+	AddedCodes   []string `json:"added_codes"`
+	RemovedCodes []string `json:"removed_codes"`
+
+	// ScoreDelta is the current score minus the previous one.
+	ScoreDelta int `json:"score_delta"`
+
+	// VerdictFrom and VerdictTo are the previous and current verdicts;
+	// they are equal when the verdict did not change.
+	VerdictFrom string `json:"verdict_from"`
+	VerdictTo   string `json:"verdict_to"`
+}
+
+// VerdictChanged reports whether the verdict differs between the results.
+func (d ResultDiff) VerdictChanged() bool {
+	return d.VerdictFrom != d.VerdictTo
+}
+
+// DiffResults compares two results, typically of successive keystrokes,
+// without re-running any checks. Codes are compared across prev.Issues and
+// curr.Issues, so a code can also appear or disappear because of the
+// MaxIssues limit; set MaxIssues to 0 when every change matters. Unlike
+// [IncrementalDelta], changed messages for the same code are not reported.
+func DiffResults(prev, curr Result) ResultDiff {
+	return ResultDiff{
+		AddedCodes:   missingCodes(curr.Issues, prev.Issues),
+		RemovedCodes: missingCodes(prev.Issues, curr.Issues),
+		ScoreDelta:   curr.Score - prev.Score,
+		VerdictFrom:  prev.Verdict,
+		VerdictTo:    curr.Verdict,
+	}
+}
+
+// missingCodes returns the distinct codes of issues that do not occur in
+// other, in order of first appearance.
+func missingCodes(issues, other []Issue) []string {
+	seen := make(map[string]bool, len(other))
+	for _, iss := range other {
+		seen[iss.Code] = true
+	}
+	var out []string
+	for _, iss := range issues {
+		if !seen[iss.Code] {
+			seen[iss.Code] = true
+			out = append(out, iss.Code)
+		}
+	}
+	return out
+}
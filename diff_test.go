@@ -0,0 +1,59 @@
+package passcheck
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffResults(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxIssues = 0
+	prev, err := CheckWithConfig("dragon", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	curr, err := CheckWithConfig("dragon-Violin-Kettle-42", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := DiffResults(prev, curr)
+	if !slices.Contains(d.RemovedCodes, CodeRuleTooShort) {
+		t.Errorf("RemovedCodes = %v, want %s", d.RemovedCodes, CodeRuleTooShort)
+	}
+	for _, code := range d.RemovedCodes {
+		if curr.Has(code) || !prev.Has(code) {
+			t.Errorf("removed code %s should be in prev only", code)
+		}
+	}
+	for _, code := range d.AddedCodes {
+		if prev.Has(code) || !curr.Has(code) {
+			t.Errorf("added code %s should be in curr only", code)
+		}
+	}
+	if d.ScoreDelta != curr.Score-prev.Score || d.ScoreDelta <= 0 {
+		t.Errorf("ScoreDelta = %d, want %d", d.ScoreDelta, curr.Score-prev.Score)
+	}
+	if d.VerdictFrom != prev.Verdict || d.VerdictTo != curr.Verdict || !d.VerdictChanged() {
+		t.Errorf("verdict %q → %q, want %q → %q", d.VerdictFrom, d.VerdictTo, prev.Verdict, curr.Verdict)
+	}
+
+	// Reversing the arguments swaps the lists and negates the delta.
+	back := DiffResults(curr, prev)
+	if !slices.Equal(back.AddedCodes, d.RemovedCodes) || !slices.Equal(back.RemovedCodes, d.AddedCodes) || back.ScoreDelta != -d.ScoreDelta {
+		t.Errorf("reversed diff %+v does not mirror %+v", back, d)
+	}
+
+	same := DiffResults(curr, curr)
+	if same.AddedCodes != nil || same.RemovedCodes != nil || same.ScoreDelta != 0 || same.VerdictChanged() {
+		t.Errorf("diff of a result with itself = %+v, want no change", same)
+	}
+}
+
+func TestDiffResults_DistinctCodes(t *testing.T) {
+	prev := Result{Issues: []Issue{{Code: CodeDictCommonWord}, {Code: CodeDictCommonWord}, {Code: CodeRuleNoDigit}}}
+	curr := Result{Issues: []Issue{{Code: CodeRuleNoDigit}}}
+	if d := DiffResults(prev, curr); !slices.Equal(d.RemovedCodes, []string{CodeDictCommonWord}) {
+		t.Errorf("RemovedCodes = %v, want one %s", d.RemovedCodes, CodeDictCommonWord)
+	}
+}
@@ -38,7 +38,7 @@ func ExampleCheckWithConfig() {
 	fmt.Printf("Score: %d\n", result.Score)
 	fmt.Printf("Verdict: %s\n", result.Verdict)
 	// Output:
-	// Score: 8
+	// Score: 3
 	// Verdict: Very Weak
 }
 
@@ -0,0 +1,123 @@
+package passcheck
+
+import (
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/scoring"
+)
+
+// ScoreTrace explains how a [Result] score was computed, step by step:
+//
+//	Raw   = int(EntropyScore) + LengthBonus + CharsetBonus + PassphraseBonus − Penalty
+//	Score = clamp(Raw, 0, 100), then capped as described by Cap
+//
+// Obtain one with [DetailedFindings.Explain]. It is meant for teaching and
+// debugging the scoring model; the formula may change between releases.
+type ScoreTrace struct {
+	// Entropy is the password's entropy in bits, and EntropyScore the base
+	// score derived from it: Entropy × 100 / 128, scaled by
+	// PenaltyWeights.EntropyWeight. Only its integer part enters Raw.
+	Entropy      float64 `json:"entropy"`
+	EntropyScore float64 `json:"entropy_score"`
+
+	// LengthBonus, CharsetBonus, and PassphraseBonus are the points
+	// awarded for length beyond MinLength, character-set diversity, and a
+	// detected passphrase.
+	LengthBonus     int `json:"length_bonus"`
+	CharsetBonus    int `json:"charset_bonus"`
+	PassphraseBonus int `json:"passphrase_bonus"`
+
+	// Penalties lists the points each issue costs, before deduplication
+	// and the MaxIssues limit. Penalty is their sum truncated to an
+	// integer.
+	Penalties []PenaltyTrace `json:"penalties"`
+	Penalty   int            `json:"penalty"`
+
+	// Raw is the score before clamping, and Clamped is Raw clamped to
+	// 0–100.
+	Raw     int `json:"raw"`
+	Clamped int `json:"clamped"`
+
+	// Score is the final score, equal to Result.Score: Clamped, unless a
+	// cap applies, named by Cap. A trending password is capped at the top
+	// of the Weak band ("trending password"), a fatal forbidden pattern
+	// scores 0 ("forbidden pattern"), and so does a password over
+	// MaxLength ("too long").
+	Score int    `json:"score"`
+	Cap   string `json:"cap,omitempty"`
+}
+
+// PenaltyTrace is the cost of one issue in a [ScoreTrace].
+type PenaltyTrace struct {
+	Issue  Issue   `json:"issue"`
+	Points float64 `json:"points"`
+}
+
+// Explain returns the step-by-step computation of df.Result.Score.
+func (df DetailedFindings) Explain() ScoreTrace {
+	return df.findings.explain(df.cfg)
+}
+
+// explain traces the scoring formula for the findings under cfg and
+// applies the caps that override it.
+func (f findings) explain(cfg Config) ScoreTrace {
+	t := f.trace(cfg)
+
+	st := ScoreTrace{
+		Entropy:         t.Entropy,
+		EntropyScore:    t.Base,
+		LengthBonus:     t.LengthBonus,
+		CharsetBonus:    t.CharsetBonus,
+		PassphraseBonus: t.PassphraseBonus,
+		Penalties:       make([]PenaltyTrace, len(t.Penalties)),
+		Penalty:         t.Penalty,
+		Raw:             t.Raw,
+		Clamped:         t.Score,
+		Score:           t.Score,
+	}
+	internal := make([]issue.Issue, len(t.Penalties))
+	for i, p := range t.Penalties {
+		internal[i] = p.Issue
+	}
+	for i, iss := range toPublicIssues(internal, cfg.RedactSensitive) {
+		st.Penalties[i] = PenaltyTrace{Issue: iss, Points: t.Penalties[i].Points}
+	}
+
+	st.Score, st.Cap = f.capScore(t.Score, cfg)
+	return st
+}
+
+// score returns the final score of the findings under cfg, as explain
+// does, without building the public trace.
+func (f findings) score(cfg Config) int {
+	score, _ := f.capScore(f.trace(cfg).Score, cfg)
+	return score
+}
+
+// trace runs the scoring formula for the findings under cfg.
+func (f findings) trace(cfg Config) scoring.Trace {
+	return scoring.TraceProfile(f.entropy, f.profile, f.issues, scoring.Options{
+		MinLength:    cfg.MinLength,
+		Passphrase:   f.passphrase,
+		Weights:      mapWeights(cfg.PenaltyWeights),
+		CharsetModel: scoring.CharsetModel(cfg.CharsetBonusModel),
+	})
+}
+
+// capScore applies the caps that override the clamped score and returns
+// the capped score with the name of the cap applied, if any.
+func (f findings) capScore(score int, cfg Config) (int, string) {
+	capName := ""
+	// A trending password is never better than Weak, whatever its score.
+	if limit := trendingCap(cfg); containsCode(f.issues.Dictionary, issue.CodeDictTrending) && score > limit {
+		score, capName = limit, "trending password"
+	}
+	// A fatal forbidden pattern overrides every other adjustment.
+	if cfg.ForbiddenPatternIsFatal && containsCode(f.issues.Patterns, issue.CodePatternForbidden) {
+		score, capName = 0, "forbidden pattern"
+	}
+	// So does a password rejected for exceeding MaxLength.
+	if f.tooLong {
+		score, capName = 0, "too long"
+	}
+	return score, capName
+}
@@ -0,0 +1,62 @@
+package passcheck
+
+import "testing"
+
+func TestDetailedFindings_Explain(t *testing.T) {
+	for _, pw := range []string{"", "password", "qwerty123", "Xk9$mP2!vR7@nL4&wQ", "correct horse battery staple"} {
+		df, err := CheckDetailed(pw, DefaultConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+		st := df.Explain()
+		if st.Score != df.Result.Score {
+			t.Errorf("%q: Score = %d, want Result.Score %d", pw, st.Score, df.Result.Score)
+		}
+		if st.Entropy != df.Result.Entropy {
+			t.Errorf("%q: Entropy = %v, want %v", pw, st.Entropy, df.Result.Entropy)
+		}
+		if got := int(st.EntropyScore) + st.LengthBonus + st.CharsetBonus + st.PassphraseBonus - st.Penalty; got != st.Raw {
+			t.Errorf("%q: Raw = %d, want %d", pw, st.Raw, got)
+		}
+		if st.Cap != "" {
+			t.Errorf("%q: unexpected cap %q", pw, st.Cap)
+		}
+	}
+}
+
+func TestDetailedFindings_Explain_Penalties(t *testing.T) {
+	df, _ := CheckDetailed("qwerty123", DefaultConfig())
+	st := df.Explain()
+	var found bool
+	for _, p := range st.Penalties {
+		if p.Issue.Code == CodePatternKeyboard {
+			found = true
+			if p.Points <= 0 {
+				t.Errorf("keyboard pattern costs %v points, want > 0", p.Points)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected a %s penalty, got %+v", CodePatternKeyboard, st.Penalties)
+	}
+}
+
+func TestDetailedFindings_Explain_Caps(t *testing.T) {
+	const pw = "Xk9$mP2!vR7@nL4&wQ"
+
+	cfg := DefaultConfig()
+	cfg.HotList = []string{"xk9$mp2!vr7@nl4&wq"}
+	df, _ := CheckDetailed(pw, cfg)
+	st := df.Explain()
+	if st.Cap != "trending password" || st.Score >= st.Clamped || st.Score != df.Result.Score {
+		t.Errorf("trending: Cap = %q, Score = %d, Clamped = %d, Result.Score = %d", st.Cap, st.Score, st.Clamped, df.Result.Score)
+	}
+
+	cfg = DefaultConfig()
+	cfg.ForbiddenPatterns = []string{"nL4"}
+	cfg.ForbiddenPatternIsFatal = true
+	df, _ = CheckDetailed(pw, cfg)
+	if st := df.Explain(); st.Cap != "forbidden pattern" || st.Score != 0 {
+		t.Errorf("forbidden: Cap = %q, Score = %d, want forbidden pattern and 0", st.Cap, st.Score)
+	}
+}
@@ -0,0 +1,309 @@
+package passcheck
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// ErrGenerateFailed is returned by [Generate] when no candidate satisfied
+// the configuration within the attempt limit, e.g. because ForbiddenPatterns
+// or a breach checker rejects everything generated.
+var ErrGenerateFailed = errors.New("passcheck: could not generate a password satisfying the configuration")
+
+// Character sets used by [Generate]. Whitespace is never generated.
+const (
+	genUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	genLower   = "abcdefghijklmnopqrstuvwxyz"
+	genDigits  = "0123456789"
+	genSymbols = "!#$%&*+-=?@^_~"
+
+	// genConsonants and genVowels build the pronounceable words of a
+	// generated passphrase.
+	genConsonants = "bdfgklmnprstvz"
+	genVowels     = "aeiou"
+)
+
+const (
+	// genMinLength is the minimum length of a generated password, so that
+	// short MinLength policies still get a strong result.
+	genMinLength = 16
+
+	// genMinWords is the minimum number of words in a generated passphrase.
+	genMinWords = 4
+
+	// genSyllables is the number of consonant-vowel syllables per
+	// passphrase word, about 18 bits of entropy per word.
+	genSyllables = 3
+
+	// genMaxAttempts bounds how many candidates Generate tries.
+	genMaxAttempts = 100
+
+	// genMaxWords bounds how many words a passphrase grows to while
+	// collecting MinUniqueChars distinct characters.
+	genMaxWords = 16
+)
+
+// Generate returns a random password that meets cfg's policy and scores
+// "Strong" or better under cfg, for a one-click "generate password" action.
+//
+// Without PassphraseMode the password has max(MinLength, MinUniqueChars,
+// 16) characters, at most MaxLength, drawn from uppercase letters,
+// lowercase letters, digits, and symbols, with at least one of each; the
+// first MinUniqueChars are all different. With PassphraseMode it is a
+// passphrase of at least MinWords (and at least four) pronounceable words
+// joined by "-", capitalized when RequireUpper is set and with a digit
+// appended when RequireDigit is set, long enough to reach MinLength and
+// MinUniqueChars distinct characters; the last word is shortened if needed
+// to stay within MaxLength. When MaxLength leaves no room for 16
+// characters or for the minimum number of words, or MinUniqueChars asks
+// for more distinct characters than the output can hold, Generate returns
+// an error wrapping [ErrGenerateFailed] without trying.
+//
+// Every candidate is checked with cfg and rejected unless it meets policy
+// and scores above the "Okay" band; if none passes within 100 attempts
+// Generate returns [ErrGenerateFailed]. Because candidates are checked with
+// cfg, a configured HIBPChecker is called for each one; the OnResult,
+// OnIssue, and OnFailure hooks and the Observer are not.
+//
+// rand supplies the randomness; nil means crypto/rand.Reader. The output
+// is a deterministic function of the bytes read, so tests can pass a fixed
+// reader. It returns an error if cfg is invalid or rand fails.
+func Generate(cfg Config, rand io.Reader) (string, error) {
+	if err := cfg.Validate(); err != nil {
+		return "", err
+	}
+	if rand == nil {
+		rand = crand.Reader
+	}
+	g := generator{rand: rand, cfg: cfg}
+	if err := g.fits(); err != nil {
+		return "", err
+	}
+	// Candidates are not results the caller asked about; keep them out of
+	// the hooks and metrics.
+	verify := cfg
+	verify.OnResult, verify.OnIssue, verify.OnFailure, verify.Observer = nil, nil, nil, nil
+	for range genMaxAttempts {
+		var pw string
+		var err error
+		if cfg.PassphraseMode {
+			pw, err = g.passphrase()
+		} else {
+			pw, err = g.password()
+		}
+		if err != nil {
+			return "", fmt.Errorf("passcheck: generate: %w", err)
+		}
+		if r := checkValidated(context.Background(), pw, verify); r.MeetsPolicy && r.Score > okayMax(cfg.VerdictThresholds) {
+			return pw, nil
+		}
+	}
+	return "", ErrGenerateFailed
+}
+
+// generator draws candidates for [Generate].
+type generator struct {
+	rand io.Reader
+	cfg  Config
+}
+
+// fits reports an error wrapping ErrGenerateFailed when cfg.MaxLength is
+// too short for any candidate, or cfg.MinUniqueChars too large.
+func (g generator) fits() error {
+	if limit := g.uniqueLimit(); g.cfg.MinUniqueChars > limit {
+		return fmt.Errorf("%w: MinUniqueChars %d exceeds the %d different characters a generated password can hold", ErrGenerateFailed, g.cfg.MinUniqueChars, limit)
+	}
+	maxLen := g.cfg.MaxLength
+	if maxLen == 0 {
+		return nil
+	}
+	if !g.cfg.PassphraseMode {
+		if maxLen < genMinLength {
+			return fmt.Errorf("%w: MaxLength %d is below the %d characters of a generated password", ErrGenerateFailed, maxLen, genMinLength)
+		}
+		return nil
+	}
+	words := g.minWords()
+	if need := words*(2*genSyllables+1) - 1 + g.digitLen(); maxLen < need {
+		return fmt.Errorf("%w: MaxLength %d is below the %d characters of a %d-word passphrase", ErrGenerateFailed, maxLen, need, words)
+	}
+	return nil
+}
+
+// uniqueLimit returns the most distinct characters a candidate can hold.
+func (g generator) uniqueLimit() int {
+	if !g.cfg.PassphraseMode {
+		n := len(genUpper) + len(genLower) + len(genDigits) + len(genSymbols)
+		if g.cfg.MaxLength > 0 {
+			n = min(n, g.cfg.MaxLength)
+		}
+		return n
+	}
+	// Words start with a consonant, the only letters ever capitalized.
+	n := len(genConsonants) + len(genVowels) + 1 + g.digitLen()
+	if g.cfg.RequireUpper {
+		n += len(genConsonants)
+	}
+	return n
+}
+
+// minWords returns the minimum number of words in a passphrase.
+func (g generator) minWords() int {
+	return max(g.cfg.MinWords, genMinWords)
+}
+
+// digitLen returns the number of digits appended to a passphrase.
+func (g generator) digitLen() int {
+	if g.cfg.RequireDigit {
+		return 1
+	}
+	return 0
+}
+
+// passwordLength returns the length of a generated password:
+// max(MinLength, MinUniqueChars, genMinLength), at most MaxLength.
+func (g generator) passwordLength() int {
+	n := max(g.cfg.MinLength, g.cfg.MinUniqueChars, genMinLength)
+	if g.cfg.MaxLength > 0 {
+		n = min(n, g.cfg.MaxLength)
+	}
+	return n
+}
+
+// password returns a random password with at least one character from
+// each character set, whose first MinUniqueChars characters are drawn
+// without repeats.
+func (g generator) password() (string, error) {
+	sets := []string{genUpper, genLower, genDigits, genSymbols}
+	all := strings.Join(sets, "")
+	pw := make([]byte, g.passwordLength())
+	for i := range pw {
+		set := all
+		if i < len(sets) {
+			set = sets[i]
+		}
+		if i < g.cfg.MinUniqueChars {
+			set = unused(set, pw[:i])
+		}
+		c, err := g.pick(set)
+		if err != nil {
+			return "", err
+		}
+		pw[i] = c
+	}
+	// Fisher–Yates shuffle so the guaranteed characters are not in front.
+	for i := len(pw) - 1; i > 0; i-- {
+		j, err := g.intn(i + 1)
+		if err != nil {
+			return "", err
+		}
+		pw[i], pw[j] = pw[j], pw[i]
+	}
+	return string(pw), nil
+}
+
+// passphrase returns hyphen-separated pronounceable words. A word that
+// would overrun MaxLength is shortened to fit and ends the passphrase, even
+// if MinUniqueChars is not reached yet; Generate then rejects the candidate
+// and tries again.
+func (g generator) passphrase() (string, error) {
+	var words []string
+	length := g.digitLen() - 1 // no separator before the first word
+	for len(words) < g.minWords() || length < g.cfg.MinLength ||
+		len(words) < genMaxWords && distinct(words) < g.cfg.MinUniqueChars {
+		// room is the space left for the next word after its separator;
+		// fits guarantees the minimum words never run out of it.
+		room := g.cfg.MaxLength - length - 1
+		if g.cfg.MaxLength > 0 && room <= 0 {
+			break
+		}
+		w, err := g.word()
+		if err != nil {
+			return "", err
+		}
+		if g.cfg.MaxLength > 0 && len(w) > room {
+			w = w[:room]
+		}
+		if g.cfg.RequireUpper {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		words = append(words, w)
+		length += len(w) + 1
+	}
+	if g.cfg.RequireDigit {
+		d, err := g.pick(genDigits)
+		if err != nil {
+			return "", err
+		}
+		words[len(words)-1] += string(d)
+	}
+	return strings.Join(words, "-"), nil
+}
+
+// word returns a pronounceable word of genSyllables consonant-vowel pairs.
+func (g generator) word() (string, error) {
+	var b strings.Builder
+	for range genSyllables {
+		for _, set := range []string{genConsonants, genVowels} {
+			c, err := g.pick(set)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// unused returns the bytes of set that do not occur in used.
+func unused(set string, used []byte) string {
+	return strings.Map(func(r rune) rune {
+		if bytes.IndexByte(used, byte(r)) >= 0 {
+			return -1
+		}
+		return r
+	}, set)
+}
+
+// distinct returns the number of different characters in words joined by
+// "-".
+func distinct(words []string) int {
+	var seen [256]bool
+	n := 0
+	if len(words) > 1 {
+		seen['-'], n = true, 1
+	}
+	for _, w := range words {
+		for i := range len(w) {
+			if !seen[w[i]] {
+				seen[w[i]] = true
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// pick returns a uniformly random byte of set.
+func (g generator) pick(set string) (byte, error) {
+	i, err := g.intn(len(set))
+	if err != nil {
+		return 0, err
+	}
+	return set[i], nil
+}
+
+// intn returns a uniformly random int in [0, n).
+func (g generator) intn(n int) (int, error) {
+	v, err := crand.Int(g.rand, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
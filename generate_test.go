@@ -0,0 +1,180 @@
+package passcheck
+
+import (
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestGenerate_MeetsPolicy(t *testing.T) {
+	passphrase := DefaultConfig()
+	passphrase.PassphraseMode = true
+	long := DefaultConfig()
+	long.MinLength = 24
+
+	configs := map[string]Config{
+		"default":      DefaultConfig(),
+		"nist":         NISTConfig(),
+		"pci":          PCIDSSConfig(),
+		"owasp":        OWASPConfig(),
+		"enterprise":   EnterpriseConfig(),
+		"userfriendly": UserFriendlyConfig(),
+		"passphrase":   passphrase,
+		"long":         long,
+	}
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			for range 20 {
+				pw, err := Generate(cfg, nil)
+				if err != nil {
+					t.Fatalf("Generate: %v", err)
+				}
+				r, _ := CheckWithConfig(pw, cfg)
+				if !r.MeetsPolicy || (r.Verdict != VerdictStrong && r.Verdict != VerdictVeryStrong) {
+					t.Fatalf("Generate() = %q: score %d, verdict %q, meets policy %t", pw, r.Score, r.Verdict, r.MeetsPolicy)
+				}
+				if len(pw) < cfg.MinLength {
+					t.Fatalf("Generate() = %q, shorter than MinLength %d", pw, cfg.MinLength)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Passphrase(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PassphraseMode = true
+	cfg.MinWords = 5
+	pw, err := Generate(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words := strings.Split(pw, "-"); len(words) < 5 {
+		t.Errorf("Generate() = %q, want at least 5 words", pw)
+	}
+}
+
+func TestGenerate_MaxLength(t *testing.T) {
+	password := DefaultConfig()
+	password.MaxLength = 18
+	passphrase := DefaultConfig()
+	passphrase.PassphraseMode = true
+	passphrase.MinLength = 30
+	passphrase.MaxLength = 32
+
+	for name, cfg := range map[string]Config{"password": password, "passphrase": passphrase} {
+		t.Run(name, func(t *testing.T) {
+			for range 20 {
+				pw, err := Generate(cfg, nil)
+				if err != nil {
+					t.Fatalf("Generate: %v", err)
+				}
+				if len(pw) < cfg.MinLength || len(pw) > cfg.MaxLength {
+					t.Fatalf("Generate() = %q (%d chars), want %d to %d", pw, len(pw), cfg.MinLength, cfg.MaxLength)
+				}
+				if r, _ := CheckWithConfig(pw, cfg); !r.MeetsPolicy {
+					t.Fatalf("Generate() = %q does not meet policy: %v", pw, r.Issues)
+				}
+			}
+		})
+	}
+
+	password.MaxLength = 14
+	passphrase.MinLength = 12
+	passphrase.MaxLength = 20
+	for name, cfg := range map[string]Config{"password": password, "passphrase": passphrase} {
+		if _, err := Generate(cfg, nil); !errors.Is(err, ErrGenerateFailed) || !strings.Contains(err.Error(), "MaxLength") {
+			t.Errorf("%s with MaxLength %d: err = %v, want ErrGenerateFailed naming MaxLength", name, cfg.MaxLength, err)
+		}
+	}
+}
+
+func TestGenerate_PassphraseMaxLengthWithMinUniqueChars(t *testing.T) {
+	narrow := DefaultConfig()
+	narrow.PassphraseMode = true
+	narrow.MinLength = 12
+	narrow.MaxLength = 27
+	narrow.MinUniqueChars = 15
+	digit := narrow
+	digit.MaxLength = 28
+	digit.RequireDigit = true
+
+	for name, cfg := range map[string]Config{"narrow": narrow, "digit": digit} {
+		t.Run(name, func(t *testing.T) {
+			for seed := range byte(50) {
+				pw, err := Generate(cfg, rand.NewChaCha8([32]byte{seed}))
+				if err != nil {
+					if !errors.Is(err, ErrGenerateFailed) {
+						t.Fatalf("Generate: %v", err)
+					}
+					continue
+				}
+				if len(pw) > cfg.MaxLength {
+					t.Fatalf("Generate() = %q (%d chars), longer than MaxLength %d", pw, len(pw), cfg.MaxLength)
+				}
+				if r, _ := CheckWithConfig(pw, cfg); !r.MeetsPolicy {
+					t.Fatalf("Generate() = %q does not meet policy: %v", pw, r.Issues)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_MinUniqueChars(t *testing.T) {
+	password := DefaultConfig()
+	password.MinUniqueChars = 20
+	passphrase := DefaultConfig()
+	passphrase.PassphraseMode = true
+	passphrase.MinUniqueChars = 18
+
+	for name, cfg := range map[string]Config{"password": password, "passphrase": passphrase} {
+		t.Run(name, func(t *testing.T) {
+			for range 20 {
+				pw, err := Generate(cfg, nil)
+				if err != nil {
+					t.Fatalf("Generate: %v", err)
+				}
+				if r, _ := CheckWithConfig(pw, cfg); !r.MeetsPolicy || r.Has(CodeRuleLowDiversity) {
+					t.Fatalf("Generate() = %q does not meet policy: %v", pw, r.Issues)
+				}
+			}
+		})
+	}
+
+	password.MinUniqueChars = 100
+	if _, err := Generate(password, nil); !errors.Is(err, ErrGenerateFailed) || !strings.Contains(err.Error(), "MinUniqueChars") {
+		t.Errorf("MinUniqueChars 100: err = %v, want ErrGenerateFailed naming MinUniqueChars", err)
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	reader := func() *rand.ChaCha8 { return rand.NewChaCha8([32]byte{1, 2, 3}) }
+	a, err := Generate(DefaultConfig(), reader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Generate(DefaultConfig(), reader())
+	if a != b {
+		t.Errorf("same random source gave %q and %q", a, b)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	if _, err := Generate(Config{}, nil); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("invalid config: err = %v, want ErrInvalidConfig", err)
+	}
+
+	readErr := errors.New("no entropy")
+	if _, err := Generate(DefaultConfig(), iotest.ErrReader(readErr)); !errors.Is(err, readErr) {
+		t.Errorf("failing reader: err = %v, want %v", err, readErr)
+	}
+
+	cfg := DefaultConfig()
+	cfg.ForbiddenPatterns = []string{"."}
+	cfg.ForbiddenPatternIsFatal = true
+	if _, err := Generate(cfg, nil); !errors.Is(err, ErrGenerateFailed) {
+		t.Errorf("unsatisfiable config: err = %v, want ErrGenerateFailed", err)
+	}
+}
@@ -0,0 +1,38 @@
+package passcheck
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rafaelsanzio/passcheck/internal/pwhash"
+	"github.com/rafaelsanzio/passcheck/internal/safemem"
+)
+
+// ErrInvalidHash is returned by [EqualsHashed] when the stored hash cannot
+// be parsed or uses an unsupported algorithm.
+var ErrInvalidHash = errors.New("passcheck: invalid password hash")
+
+// EqualsHashed reports whether candidate is the password stored in hash,
+// so a password change can reject the current password without the
+// plaintext. hash may be an Argon2 hash (argon2id, argon2i, argon2d) in
+// PHC string format, e.g. "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>",
+// or a bcrypt hash ($2a$, $2b$, $2y$). Verification is implemented with the
+// standard library only and compares in constant time; the working copy
+// of candidate is zeroed before returning.
+//
+// Cost parameters in hash are bounded above, and must reach the minimums
+// OWASP recommends for new hashes: bcrypt cost 10, or Argon2 memory of at
+// least 7 MiB with memory × passes of at least 35 MiB. An out-of-range,
+// malformed, or unsupported hash returns an error wrapping
+// [ErrInvalidHash].
+// [CheckWithConfig] performs the same verification when
+// Config.CurrentPasswordHash is set, reporting a match as HISTORY_REUSE.
+func EqualsHashed(candidate, hash string) (bool, error) {
+	buf := []byte(candidate)
+	defer safemem.Zero(buf)
+	ok, err := pwhash.Verify(buf, hash)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	return ok, nil
+}
@@ -0,0 +1,59 @@
+package passcheck
+
+import (
+	"errors"
+	"testing"
+)
+
+// Hashes of "Tr0ub4dor&3" generated with golang.org/x/crypto.
+const (
+	testArgon2idHash = "$argon2id$v=19$m=9216,t=4,p=1$c29tZXNhbHQtMTZieXRlcw$tN7bTT9CFQDsNQ/0P0SiRP+HVIhn2VMPn4Bx18yvwn4"
+	testBcryptHash   = "$2a$10$h9UyaS0LerXKJe2rZNVl7OYm6PvsNdNl5YxEIpoObWh4jZGd7X8Vu"
+)
+
+func TestEqualsHashed(t *testing.T) {
+	for _, hash := range []string{testArgon2idHash, testBcryptHash} {
+		ok, err := EqualsHashed("Tr0ub4dor&3", hash)
+		if err != nil || !ok {
+			t.Errorf("EqualsHashed(match, %.12s…) = %v, %v; want true", hash, ok, err)
+		}
+		ok, err = EqualsHashed("Tr0ub4dor&4", hash)
+		if err != nil || ok {
+			t.Errorf("EqualsHashed(mismatch, %.12s…) = %v, %v; want false", hash, ok, err)
+		}
+	}
+}
+
+func TestEqualsHashed_InvalidHash(t *testing.T) {
+	for _, hash := range []string{
+		"", "5f4dcc3b5aa765d61d8327deb882cf99", "$argon2id$v=19$m=64$c2FsdA$aGFzaA", "$2a$99$short",
+		"$2a$04$0tmfPkuNuGcqoe2qY5Tf0u2Ku0GtaZnvtRLDSYc695uJJGvgVVKHO", // below the minimum cost
+	} {
+		if _, err := EqualsHashed("password", hash); !errors.Is(err, ErrInvalidHash) {
+			t.Errorf("EqualsHashed(%q) err = %v, want ErrInvalidHash", hash, err)
+		}
+	}
+}
+
+func TestCheckWithConfig_CurrentPasswordHash(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CurrentPasswordHash = testArgon2idHash
+
+	r, err := CheckWithConfig("Tr0ub4dor&3", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Has(CodeHistoryReuse) || r.MeetsPolicy {
+		t.Errorf("current password should be rejected as reuse: %v", r.Issues)
+	}
+
+	r, _ = CheckWithConfig("Xk9$mP2!vR7@nL4&wQ", cfg)
+	if r.Has(CodeHistoryReuse) {
+		t.Error("a different password should not be reported as reuse")
+	}
+
+	cfg.CurrentPasswordHash = "$argon2id$garbage"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Validate() = %v, want ErrInvalidConfig", err)
+	}
+}
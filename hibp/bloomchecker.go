@@ -0,0 +1,195 @@
+package hibp
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// bloomMagic starts every serialized Bloom filter read by NewBloomChecker.
+const bloomMagic = "PCBF"
+
+// bloomHeaderLen is the size of the serialized header: the magic, m as a
+// big-endian uint64, and k as a big-endian uint32.
+const bloomHeaderLen = len(bloomMagic) + 8 + 4
+
+// maxBloomHashes bounds k. Optimal filters use far fewer hash functions;
+// larger values indicate a corrupt header.
+const maxBloomHashes = 64
+
+// maxBloomBits bounds m at 16 GiB of bitset, several times the full HIBP
+// set at a one-in-a-million false-positive rate. Larger values indicate a
+// corrupt or hostile header.
+const maxBloomBits = 1 << 37
+
+// bloomReadChunk is how much of the bitset NewBloomChecker reads at a time
+// from a stream whose length is unknown, so memory grows with the data
+// actually present rather than with the size the header claims.
+const bloomReadChunk = 64 << 20
+
+// ErrInvalidBloomFilter is returned by NewBloomChecker when the stream is
+// not a serialized Bloom filter.
+var ErrInvalidBloomFilter = errors.New("hibp: invalid bloom filter")
+
+// NewEmptyBloomFilter returns a Bloom filter with m bits and k hash
+// functions and no hashes added. Use [OptimalBloomParams] to size it, then
+// [BloomFilter.Add] and [BloomFilter.WriteTo] to build a file for
+// [NewBloomChecker].
+func NewEmptyBloomFilter(m uint64, k uint) *BloomFilter {
+	return &BloomFilter{bitset: make([]byte, (m+7)/8), m: m, k: k}
+}
+
+// OptimalBloomParams returns the number of bits m and hash functions k
+// that minimize the size of a filter holding n hashes with a false-positive
+// rate of at most p (0 < p < 1). For the full HIBP set (~1 billion hashes)
+// at p = 0.001, m is about 1.8 GB and k is 10.
+func OptimalBloomParams(n uint64, p float64) (m uint64, k uint) {
+	if n == 0 {
+		n = 1
+	}
+	bits := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	m = uint64(max(bits, 8))
+	k = uint(max(math.Round(float64(m)/float64(n)*math.Ln2), 1))
+	return m, k
+}
+
+// Add inserts a 40-character SHA-1 hex hash into the filter. Hashes of any
+// other length are ignored.
+func (f *BloomFilter) Add(hash string) {
+	hash = strings.ToLower(hash)
+	if len(hash) != SHA1HexLen {
+		return
+	}
+	h := sha1.Sum([]byte(hash))
+	h1 := binary.BigEndian.Uint64(h[:8])
+	h2 := binary.BigEndian.Uint64(h[8:16])
+	for i := uint(0); i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		f.bitset[idx/8] |= 1 << (idx % 8)
+	}
+}
+
+// WriteTo writes the filter in the format read by [NewBloomChecker]: the
+// magic "PCBF", m as a big-endian uint64, k as a big-endian uint32, and the
+// ceil(m/8)-byte bitset.
+func (f *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	var header [bloomHeaderLen]byte
+	copy(header[:], bloomMagic)
+	binary.BigEndian.PutUint64(header[len(bloomMagic):], f.m)
+	binary.BigEndian.PutUint32(header[len(bloomMagic)+8:], uint32(f.k))
+	n, err := w.Write(header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	nb, err := w.Write(f.bitset)
+	return int64(n + nb), err
+}
+
+// BloomChecker checks passwords against a prebuilt Bloom filter of breached
+// SHA-1 hashes, with no network calls. It implements the
+// passcheck.Config.HIBPChecker interface and is safe for concurrent use.
+//
+// A Bloom filter never misses a hash that was added, but it reports hashes
+// that were not added with a small false-positive probability that grows
+// as the filter fills: about (1 - e^(-kn/m))^k for n hashes in m bits with
+// k hash functions. A false positive rejects a password that was never
+// breached, so size the filter for the rate you can tolerate with
+// [OptimalBloomParams]; each halving of the rate costs about 1.44 extra
+// bits per hash. The filter does not store breach counts.
+type BloomChecker struct {
+	filter *BloomFilter
+}
+
+// NewBloomChecker loads a Bloom filter written by [BloomFilter.WriteTo]
+// from r. It returns an error wrapping [ErrInvalidBloomFilter] if the
+// header is malformed or claims more than 2^37 bits, or the read error if
+// r ends early. When r reports its remaining length (a *bytes.Reader,
+// *strings.Reader, or seekable file), a header claiming more bits than r
+// holds is rejected before anything is allocated, with an error wrapping
+// both ErrInvalidBloomFilter and io.ErrUnexpectedEOF.
+func NewBloomChecker(r io.Reader) (*BloomChecker, error) {
+	var header [bloomHeaderLen]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", ErrInvalidBloomFilter, err)
+	}
+	if string(header[:len(bloomMagic)]) != bloomMagic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidBloomFilter)
+	}
+	m := binary.BigEndian.Uint64(header[len(bloomMagic):])
+	k := binary.BigEndian.Uint32(header[len(bloomMagic)+8:])
+	if m == 0 || m > maxBloomBits || k == 0 || k > maxBloomHashes {
+		return nil, fmt.Errorf("%w: m=%d k=%d", ErrInvalidBloomFilter, m, k)
+	}
+	size := (m + 7) / 8
+	if n, ok := remaining(r); ok && n < size {
+		return nil, fmt.Errorf("%w: m=%d needs %d bytes, %d remain: %w", ErrInvalidBloomFilter, m, size, n, io.ErrUnexpectedEOF)
+	}
+	bitset, err := readBitset(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return &BloomChecker{filter: &BloomFilter{bitset: bitset, m: m, k: uint(k)}}, nil
+}
+
+// remaining returns the number of unread bytes in r, if r can tell.
+func remaining(r io.Reader) (uint64, bool) {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return uint64(v.Len()), true
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if _, serr := v.Seek(cur, io.SeekStart); err != nil || serr != nil || end < cur {
+			return 0, false
+		}
+		return uint64(end - cur), true
+	}
+	return 0, false
+}
+
+// readBitset reads exactly size bytes from r in chunks of at most
+// bloomReadChunk.
+func readBitset(r io.Reader, size uint64) ([]byte, error) {
+	buf := make([]byte, 0, min(size, bloomReadChunk))
+	for uint64(len(buf)) < size {
+		chunk := int(min(size-uint64(len(buf)), bloomReadChunk))
+		buf = slices.Grow(buf, chunk)
+		n, err := io.ReadFull(r, buf[len(buf):len(buf)+chunk])
+		buf = buf[:len(buf)+n]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// Check reports whether the SHA-1 hash of password is probably in the
+// filter. count is always 0: the filter records presence, not how often
+// the password was breached. passcheck reports such a result regardless
+// of Config.HIBPMinOccurrences.
+func (b *BloomChecker) Check(password string) (breached bool, count int, err error) {
+	if password == "" {
+		return false, 0, nil
+	}
+	return b.CheckHash(sha1Hash(password))
+}
+
+// CheckHash is like Check but takes a pre-computed 40-character SHA-1 hex
+// string. If hash is not 40 hex chars, it returns (false, 0, error).
+func (b *BloomChecker) CheckHash(hash string) (breached bool, count int, err error) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if len(hash) != SHA1HexLen || !isHex(hash) {
+		return false, 0, fmt.Errorf("hibp: hash must be 40 hex characters, got %d", len(hash))
+	}
+	present, err := b.filter.Has(context.Background(), hash)
+	return present, 0, err
+}
@@ -0,0 +1,134 @@
+package hibp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+)
+
+func buildBloomFile(t *testing.T, passwords ...string) []byte {
+	t.Helper()
+	m, k := OptimalBloomParams(uint64(len(passwords)), 0.001)
+	f := NewEmptyBloomFilter(m, k)
+	for _, pw := range passwords {
+		f.Add(sha1Hash(pw))
+	}
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+	return buf.Bytes()
+}
+
+func TestBloomChecker(t *testing.T) {
+	breached := []string{"password", "123456", "letmein"}
+	b, err := NewBloomChecker(bytes.NewReader(buildBloomFile(t, breached...)))
+	if err != nil {
+		t.Fatalf("NewBloomChecker: %v", err)
+	}
+	for _, pw := range breached {
+		found, count, err := b.Check(pw)
+		if err != nil || !found || count != 0 {
+			t.Errorf("Check(%q) = (%v, %d, %v), want (true, 0, nil)", pw, found, count, err)
+		}
+	}
+	if found, _, _ := b.Check("Xk9$mP2!vR7@nL4&wQ"); found {
+		t.Error("unexpected match for a password not in the filter")
+	}
+	if found, _, err := b.Check(""); found || err != nil {
+		t.Errorf("Check(\"\") = (%v, %v), want (false, nil)", found, err)
+	}
+	if found, _, _ := b.CheckHash("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"); !found {
+		t.Error("CheckHash should accept uppercase hashes")
+	}
+	if _, _, err := b.CheckHash("short"); err == nil {
+		t.Error("expected error for short hash")
+	}
+}
+
+func TestBloomChecker_FalsePositiveRate(t *testing.T) {
+	var added []string
+	for i := range 1000 {
+		added = append(added, fmt.Sprintf("breached-%d", i))
+	}
+	b, err := NewBloomChecker(bytes.NewReader(buildBloomFile(t, added...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp := 0
+	for i := range 10000 {
+		if found, _, _ := b.Check(fmt.Sprintf("clean-%d", i)); found {
+			fp++
+		}
+	}
+	// Sized for 0.1%; allow generous slack for the small sample.
+	if fp > 50 {
+		t.Errorf("%d false positives in 10000 checks, want about 10", fp)
+	}
+}
+
+func TestNewBloomChecker_Invalid(t *testing.T) {
+	valid := buildBloomFile(t, "password")
+	zeroK := bytes.Clone(valid)
+	zeroK[bloomHeaderLen-1] = 0
+
+	tests := map[string][]byte{
+		"empty":        nil,
+		"bad magic":    append([]byte("XXXX"), valid[4:]...),
+		"zero k":       zeroK,
+		"short header": valid[:6],
+	}
+	for name, data := range tests {
+		if _, err := NewBloomChecker(bytes.NewReader(data)); !errors.Is(err, ErrInvalidBloomFilter) {
+			t.Errorf("%s: err = %v, want ErrInvalidBloomFilter", name, err)
+		}
+	}
+	if _, err := NewBloomChecker(bytes.NewReader(valid[:len(valid)-1])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated bitset: err = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestNewBloomChecker_HugeM(t *testing.T) {
+	header := func(m uint64) []byte {
+		b := append([]byte(bloomMagic), make([]byte, 12)...)
+		binary.BigEndian.PutUint64(b[len(bloomMagic):], m)
+		binary.BigEndian.PutUint32(b[len(bloomMagic)+8:], 7)
+		return append(b, make([]byte, 64)...)
+	}
+	for _, m := range []uint64{1 << 62, maxBloomBits + 1, math.MaxUint64} {
+		if _, err := NewBloomChecker(bytes.NewReader(header(m))); !errors.Is(err, ErrInvalidBloomFilter) {
+			t.Errorf("m=%d: err = %v, want ErrInvalidBloomFilter", m, err)
+		}
+	}
+
+	// Within the bound but far beyond the data: rejected up front when the
+	// length is known, and at EOF after reading what is there otherwise.
+	data := header(1 << 36)
+	if _, err := NewBloomChecker(bytes.NewReader(data)); !errors.Is(err, ErrInvalidBloomFilter) {
+		t.Errorf("known length: err = %v, want ErrInvalidBloomFilter", err)
+	}
+	if _, err := NewBloomChecker(io.MultiReader(bytes.NewReader(data))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("unknown length: err = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestOptimalBloomParams(t *testing.T) {
+	m, k := OptimalBloomParams(1_000_000_000, 0.001)
+	if gb := float64(m) / 8 / 1e9; gb < 1.7 || gb > 1.9 {
+		t.Errorf("m = %d bits (%.2f GB), want about 1.8 GB", m, gb)
+	}
+	if k != 10 {
+		t.Errorf("k = %d, want 10", k)
+	}
+	if m, k := OptimalBloomParams(0, 0.5); m == 0 || k == 0 {
+		t.Errorf("OptimalBloomParams(0, 0.5) = (%d, %d), want non-zero", m, k)
+	}
+}
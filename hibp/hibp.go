@@ -10,8 +10,10 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha1"
+	"crypto/subtle"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -19,6 +21,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -42,6 +45,11 @@ const (
 	maxRetryDelay = 30 * time.Second
 )
 
+// ErrTooManyRequests is returned when a lookup would exceed
+// Client.MaxConcurrent outstanding range requests. passcheck treats it like
+// any other checker error and skips the breach check.
+var ErrTooManyRequests = errors.New("hibp: too many concurrent requests")
+
 // Client calls the HIBP Pwned Passwords API. It is safe for concurrent use.
 type Client struct {
 	HTTPClient *http.Client
@@ -64,6 +72,30 @@ type Client struct {
 	// When provided, the Client will query this offline DB before making
 	// network requests to the HIBP API.
 	OfflineDB OfflineDB
+
+	// ConstantTime, when true, compares the hash suffix against every line
+	// of the range response in constant time, so response time does not
+	// leak whether or where the bucket contained a match. Results are
+	// unchanged. passcheck enables it per call via CheckConstantTime when
+	// Config.ConstantTimeMode is set.
+	ConstantTime bool
+
+	// Timeout bounds each range lookup, including retries and back-off,
+	// on top of any HTTPClient timeout; a lookup that runs out of time
+	// returns an error. Cache and OfflineDB hits are not affected. Zero
+	// means no limit.
+	Timeout time.Duration
+
+	// MaxConcurrent caps the number of range lookups in flight at once.
+	// A check that would exceed it fails immediately with
+	// ErrTooManyRequests instead of queueing, so a slow API cannot pile up
+	// requests; Prefetch waits for a free slot instead. Cache and
+	// OfflineDB hits are not counted. Set it before the first check; later
+	// changes are ignored. Zero means no limit.
+	MaxConcurrent int
+
+	semOnce sync.Once
+	sem     chan struct{}
 }
 
 // Cache allows optional caching of API responses (key = 5-char prefix, value = response body).
@@ -113,6 +145,47 @@ func (c *Client) CheckHash(hash string) (breached bool, count int, err error) {
 
 // CheckHashContext is like CheckHash but includes a context.Context.
 func (c *Client) CheckHashContext(ctx context.Context, hash string) (breached bool, count int, err error) {
+	return c.checkHash(ctx, hash, c.ConstantTime)
+}
+
+// CheckConstantTime is like Check but always compares the range response
+// in constant time, regardless of c.ConstantTime.
+func (c *Client) CheckConstantTime(password string) (breached bool, count int, err error) {
+	if password == "" {
+		return false, 0, nil
+	}
+	return c.CheckConstantTimeContext(context.Background(), password)
+}
+
+// CheckConstantTimeContext is like CheckConstantTime but includes a
+// context.Context.
+func (c *Client) CheckConstantTimeContext(ctx context.Context, password string) (breached bool, count int, err error) {
+	if password == "" {
+		return false, 0, nil
+	}
+	return c.checkHash(ctx, sha1Hash(password), true)
+}
+
+// HashPrefix returns the lowercase SHA-1 hex of password split into the
+// 5-character prefix sent to the range API and the 35-character suffix to
+// look up in its response. It makes no network calls, so callers that
+// fetch the range themselves (e.g. from JavaScript in a WASM build) can
+// pair it with [ParseRange].
+func HashPrefix(password string) (prefix, suffix string) {
+	hash := sha1Hash(password)
+	return hash[:PrefixLen], hash[PrefixLen:]
+}
+
+// ParseRange looks up suffix in a range API response body of
+// "SUFFIX:COUNT" lines and reports whether it was found and its count.
+// suffix is matched case-insensitively; malformed lines are skipped. The
+// result can be passed to passcheck as Config.HIBPResult.
+func ParseRange(suffix, body string) (breached bool, count int) {
+	return parseRange(body, strings.ToLower(strings.TrimSpace(suffix)), false)
+}
+
+// checkHash implements CheckHashContext with an explicit comparison mode.
+func (c *Client) checkHash(ctx context.Context, hash string, constantTime bool) (breached bool, count int, err error) {
 	hash = strings.ToLower(strings.TrimSpace(hash))
 	if len(hash) != SHA1HexLen || !isHex(hash) {
 		return false, 0, fmt.Errorf("hibp: hash must be 40 hex characters, got %d", len(hash))
@@ -131,11 +204,24 @@ func (c *Client) CheckHashContext(ctx context.Context, hash string) (breached bo
 	prefix := hash[:PrefixLen]
 	suffix := hash[PrefixLen:]
 
-	body, err := c.fetchRange(ctx, prefix)
+	body, err := c.fetchRange(ctx, prefix, false)
 	if err != nil {
 		return false, 0, err
 	}
+	breached, count = parseRange(body, suffix, constantTime)
+	return breached, count, nil
+}
 
+// parseRange looks up suffix (lowercase) in a range response body of
+// "SUFFIX:COUNT" lines and returns whether it was found and its count.
+// Lines that are malformed or have an unparsable count are skipped; the
+// first valid match wins.
+//
+// When constantTime is true, every line is parsed and compared with
+// subtle.ConstantTimeCompare and the result is selected without branching
+// on the match, so the scan does not exit early.
+func parseRange(body, suffix string, constantTime bool) (found bool, count int) {
+	var matched int
 	for _, line := range strings.Split(body, "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" {
@@ -146,23 +232,32 @@ func (c *Client) CheckHashContext(ctx context.Context, hash string) (breached bo
 			continue
 		}
 		lineSuffix := strings.TrimSpace(strings.ToLower(line[:idx]))
-		if lineSuffix != suffix {
+		if !constantTime && lineSuffix != suffix {
 			continue
 		}
-		countStr := strings.TrimSpace(line[idx+1:])
-		n, parseErr := strconv.Atoi(countStr)
-		if parseErr != nil {
-			continue
+		n, parseErr := strconv.Atoi(strings.TrimSpace(line[idx+1:]))
+		if !constantTime {
+			if parseErr != nil {
+				continue
+			}
+			return true, n
+		}
+		valid := 0
+		if parseErr == nil {
+			valid = 1
 		}
-		return true, n, nil
+		take := subtle.ConstantTimeCompare([]byte(lineSuffix), []byte(suffix)) & valid &^ matched
+		count = subtle.ConstantTimeSelect(take, n, count)
+		matched |= take
 	}
-	return false, 0, nil
+	return matched == 1, count
 }
 
 // fetchRange retrieves the HIBP range response for prefix, consulting the
 // cache first and retrying on transient errors and HTTP 429 responses with
-// exponential back-off and jitter.
-func (c *Client) fetchRange(ctx context.Context, prefix string) (string, error) {
+// exponential back-off and jitter. Network lookups take a MaxConcurrent
+// slot, waiting for one when wait is set and failing otherwise.
+func (c *Client) fetchRange(ctx context.Context, prefix string, wait bool) (string, error) {
 	prefix = strings.ToLower(strings.TrimSpace(prefix))
 	if len(prefix) != PrefixLen {
 		return "", fmt.Errorf("hibp: prefix must be %d hex characters", PrefixLen)
@@ -174,6 +269,17 @@ func (c *Client) fetchRange(ctx context.Context, prefix string) (string, error)
 		}
 	}
 
+	release, err := c.acquire(ctx, wait)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
 	maxAttempts := c.MaxRetries + 1
 	if maxAttempts < 1 {
 		maxAttempts = 1
@@ -221,6 +327,31 @@ func (c *Client) fetchRange(ctx context.Context, prefix string) (string, error)
 	return "", lastErr
 }
 
+// acquire takes one of the MaxConcurrent request slots and returns the
+// function that frees it. Without a free slot it waits until ctx is done
+// when wait is set, and otherwise fails with ErrTooManyRequests.
+func (c *Client) acquire(ctx context.Context, wait bool) (release func(), err error) {
+	if c.MaxConcurrent <= 0 {
+		return func() {}, nil
+	}
+	c.semOnce.Do(func() { c.sem = make(chan struct{}, c.MaxConcurrent) })
+	release = func() { <-c.sem }
+	if !wait {
+		select {
+		case c.sem <- struct{}{}:
+			return release, nil
+		default:
+			return nil, ErrTooManyRequests
+		}
+	}
+	select {
+	case c.sem <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // fetchRangeOnce performs a single HTTP GET for the given 5-char prefix.
 // On HTTP 429 it returns (body="", retryAfter, err); on other non-200
 // responses it returns the status error. retryAfter is zero unless the
@@ -191,6 +191,66 @@ func TestCheckHash_Timeout(t *testing.T) {
 	}
 }
 
+func TestCheckHash_ClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.Timeout = 20 * time.Millisecond
+	start := time.Now()
+	if _, _, err := c.CheckHash("abc12" + strings.Repeat("0", 35)); err == nil {
+		t.Error("expected error when Timeout elapses")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("lookup took %v, want about Timeout", elapsed)
+	}
+}
+
+func TestCheckHash_MaxConcurrent(t *testing.T) {
+	arrived, unblock := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		arrived <- struct{}{}
+		<-unblock
+		w.Write([]byte("x:1\n"))
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.MaxConcurrent = 1
+	hash := "abc12" + strings.Repeat("0", 35)
+
+	done := make(chan error)
+	go func() {
+		_, _, err := c.CheckHash(hash)
+		done <- err
+	}()
+	<-arrived
+
+	breached, _, err := c.CheckHash(hash)
+	if !errors.Is(err, ErrTooManyRequests) || breached {
+		t.Errorf("over the limit: breached=%v err=%v, want ErrTooManyRequests", breached, err)
+	}
+
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("first lookup: %v", err)
+	}
+	// The slot is released once a lookup finishes.
+	go func() { <-arrived }()
+	if _, _, err := c.CheckHash(hash); err != nil {
+		t.Errorf("after release: %v", err)
+	}
+}
+
 func TestCheckHash_ConnectionFails(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.Write([]byte("x:1\n")) }))
 	baseURL := server.URL
@@ -206,6 +266,81 @@ func TestCheckHash_ConnectionFails(t *testing.T) {
 	}
 }
 
+func TestParseRange_ConstantTimeMatchesPlain(t *testing.T) {
+	suffix := strings.Repeat("a", 35)
+	other := strings.Repeat("b", 35)
+	bodies := map[string]string{
+		"empty":           "",
+		"no match":        other + ":3\n",
+		"match":           other + ":3\r\n" + strings.ToUpper(suffix) + ":42\r\n",
+		"first wins":      suffix + ":7\n" + suffix + ":9\n",
+		"bad count skips": suffix + ":x\n" + suffix + ":5\n",
+		"bad count only":  suffix + ":x\n",
+		"malformed lines": ":1\nnocolon\n\n" + suffix + ": 12 \n",
+		"zero count":      suffix + ":0\n",
+	}
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			wantFound, wantCount := parseRange(body, suffix, false)
+			gotFound, gotCount := parseRange(body, suffix, true)
+			if gotFound != wantFound || gotCount != wantCount {
+				t.Errorf("constant time = (%v, %d), plain = (%v, %d)", gotFound, gotCount, wantFound, wantCount)
+			}
+		})
+	}
+}
+
+func TestHashPrefix(t *testing.T) {
+	prefix, suffix := HashPrefix("password")
+	// SHA-1("password") = 5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8
+	if prefix != "5baa6" || suffix != "1e4c9b93f3f0682250b6cf8331b7ee68fd8" {
+		t.Errorf("HashPrefix = (%q, %q)", prefix, suffix)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	_, suffix := HashPrefix("password")
+	body := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" + strings.ToUpper(suffix) + ":3861493\r\n"
+	if breached, count := ParseRange(suffix, body); !breached || count != 3861493 {
+		t.Errorf("ParseRange = (%v, %d), want (true, 3861493)", breached, count)
+	}
+	if breached, count := ParseRange(strings.ToUpper(suffix), body); !breached || count != 3861493 {
+		t.Errorf("ParseRange(upper) = (%v, %d), want (true, 3861493)", breached, count)
+	}
+	if breached, count := ParseRange(suffix, "garbage\n"); breached || count != 0 {
+		t.Errorf("ParseRange(no match) = (%v, %d), want (false, 0)", breached, count)
+	}
+}
+
+func TestCheckConstantTime(t *testing.T) {
+	hash := sha1Hash("password")
+	body := strings.Repeat("0", 35) + ":1\n" + strings.ToUpper(hash[PrefixLen:]) + ":3861493\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	wantBreached, wantCount, err := c.Check("password")
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	breached, count, err := c.CheckConstantTime("password")
+	if err != nil {
+		t.Fatalf("CheckConstantTime: %v", err)
+	}
+	if breached != wantBreached || count != wantCount || count != 3861493 {
+		t.Errorf("CheckConstantTime = (%v, %d), Check = (%v, %d)", breached, count, wantBreached, wantCount)
+	}
+
+	c.ConstantTime = true
+	if breached, count, _ := c.Check("password"); !breached || count != 3861493 {
+		t.Errorf("Check with ConstantTime = (%v, %d), want (true, 3861493)", breached, count)
+	}
+}
+
 func TestParseRetryAfter(t *testing.T) {
 	now := time.Now()
 	future := now.Add(2 * time.Second).UTC().Format(http.TimeFormat)
@@ -0,0 +1,82 @@
+package hibp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PrefetchWorkers is the maximum number of range requests Prefetch runs
+// concurrently.
+const PrefetchWorkers = 4
+
+// Prefetch fetches the range responses for prefixes into c.Cache, so that
+// later checks of passwords with those hash prefixes are cache hits. Use
+// [HashPrefix] to compute the prefix of a likely candidate. Prefixes
+// already cached are not fetched again, fetched ranges are cached for the
+// same TTL as in [Client.Check], and retries follow c.MaxRetries.
+//
+// Up to [PrefetchWorkers] requests run at a time, each waiting for a free
+// slot when c.MaxConcurrent is set. On the first error, or when ctx is
+// done, Prefetch stops starting requests, waits for those in flight, and
+// returns that error, or ctx.Err(). Every prefix must be 5 hex characters;
+// Prefetch fetches nothing and returns an error otherwise. It does nothing
+// when c.Cache is nil.
+func (c *Client) Prefetch(ctx context.Context, prefixes []string) error {
+	for _, p := range prefixes {
+		if p = strings.TrimSpace(p); len(p) != PrefixLen || !isHex(p) {
+			return fmt.Errorf("hibp: prefix must be %d hex characters", PrefixLen)
+		}
+	}
+	if c.Cache == nil || len(prefixes) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	jobs := make(chan string)
+	workers := min(PrefetchWorkers, len(prefixes))
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				if _, err := c.fetchRange(ctx, p, true); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, p := range prefixes {
+		select {
+		case jobs <- p:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Requests aborted by the caller's ctx report ctx.Err(), not the
+	// transport error they failed with.
+	if err := parent.Err(); err != nil {
+		return err
+	}
+	return firstErr
+}
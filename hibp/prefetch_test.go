@@ -0,0 +1,112 @@
+package hibp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPrefetch_WarmsCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if strings.HasSuffix(r.URL.Path, "/abc12") {
+			w.Write([]byte(strings.Repeat("F", 35) + ":42\n"))
+			return
+		}
+		w.Write([]byte(strings.Repeat("0", 35) + ":1\n"))
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.Cache = NewMemoryCache(16)
+
+	prefixes := []string{"abc12", "00000", "11111", "22222", "33333", "44444"}
+	if err := c.Prefetch(context.Background(), prefixes); err != nil {
+		t.Fatalf("Prefetch: %v", err)
+	}
+	if got := requests.Load(); got != int32(len(prefixes)) {
+		t.Fatalf("requests = %d, want %d", got, len(prefixes))
+	}
+
+	breached, count, err := c.CheckHash("abc12" + strings.Repeat("f", 35))
+	if err != nil || !breached || count != 42 {
+		t.Errorf("CheckHash = (%v, %d, %v), want (true, 42, nil)", breached, count, err)
+	}
+	if err := c.Prefetch(context.Background(), prefixes[:2]); err != nil {
+		t.Fatalf("second Prefetch: %v", err)
+	}
+	if got := requests.Load(); got != int32(len(prefixes)) {
+		t.Errorf("cached prefixes were fetched again: %d requests", got)
+	}
+}
+
+func TestPrefetch_ReturnsFirstError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.Cache = NewMemoryCache(16)
+
+	err := c.Prefetch(context.Background(), []string{"00000", "11111", "22222", "33333", "44444", "55555"})
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Prefetch error = %v, want the API error", err)
+	}
+}
+
+func TestPrefetch_Canceled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.Cache = NewMemoryCache(16)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Prefetch(ctx, []string{"00000", "11111"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Prefetch error = %v, want context.Canceled", err)
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("canceled Prefetch made %d requests", got)
+	}
+}
+
+func TestPrefetch_InvalidPrefixOrNoCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+
+	if err := c.Prefetch(context.Background(), []string{"00000"}); err != nil {
+		t.Errorf("Prefetch without a cache: %v", err)
+	}
+	c.Cache = NewMemoryCache(16)
+	for _, bad := range []string{"0000", "zzzzz", "000000"} {
+		if err := c.Prefetch(context.Background(), []string{"00000", bad}); err == nil {
+			t.Errorf("Prefetch(%q): expected an error", bad)
+		}
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("made %d requests, want none", got)
+	}
+}
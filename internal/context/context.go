@@ -12,17 +12,28 @@ package context
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
 	"github.com/rafaelsanzio/passcheck/internal/leet"
 )
 
+// DefaultMinWordLen is the default minimum length of a context word.
+const DefaultMinWordLen = 3
+
 // Options holds configuration for context-aware checking.
 type Options struct {
 	// ContextWords is a list of user-specific terms to detect in passwords.
 	// Examples: username, email, company name, personal information.
-	// Words shorter than 3 characters are ignored to avoid false positives.
+	// Words shorter than MinWordLen characters are ignored to avoid false
+	// positives.
 	ContextWords []string
+
+	// MinWordLen is the minimum length of a context word, or of a part
+	// extracted from one, for it to be checked. Lowering it to 2 catches
+	// short names such as "HP" but also matches many unrelated passwords
+	// (and email TLDs such as "io"). Zero means DefaultMinWordLen.
+	MinWordLen int
 }
 
 // DefaultOptions returns the recommended default options.
@@ -30,6 +41,7 @@ type Options struct {
 func DefaultOptions() Options {
 	return Options{
 		ContextWords: nil,
+		MinWordLen:   DefaultMinWordLen,
 	}
 }
 
@@ -50,15 +62,20 @@ func Check(password string) []issue.Issue {
 //  3. Leetspeak variants of context words
 //  4. Email component extraction and matching
 //
-// Words shorter than 3 characters are skipped to reduce false positives.
+// Words shorter than opts.MinWordLen characters are skipped to reduce
+// false positives.
 func CheckWith(password string, opts Options) []issue.Issue {
 	if len(opts.ContextWords) == 0 {
 		return nil
 	}
+	minLen := opts.MinWordLen
+	if minLen <= 0 {
+		minLen = DefaultMinWordLen
+	}
 
 	// Normalize password for comparison
 	pwLower := strings.ToLower(password)
-	pwNormalized := leet.Normalize(pwLower)
+	pwNormalized, offsets := leet.NormalizeMultiOffsets(pwLower)
 
 	var issues []issue.Issue
 	seen := make(map[string]bool) // Deduplicate issues
@@ -66,16 +83,17 @@ func CheckWith(password string, opts Options) []issue.Issue {
 	for _, word := range opts.ContextWords {
 		// Normalize and validate context word
 		normalized := normalizeContextWord(word)
-		if len(normalized) < 3 {
+		if len(normalized) < minLen {
 			continue // Skip short words to avoid false positives
 		}
 
-		// Extract email parts if the word looks like an email
-		words := extractWords(normalized)
+		// Extract email parts if the word looks like an email. Case is
+		// kept so that camelCase words can be split.
+		words := extractWords(strings.TrimSpace(word))
 
 		// Check each extracted word
 		for _, w := range words {
-			if len(w) < 3 {
+			if len(w) < minLen {
 				continue
 			}
 
@@ -85,13 +103,13 @@ func CheckWith(password string, opts Options) []issue.Issue {
 			}
 
 			// Check for matches
-			if containsContextWord(pwLower, pwNormalized, w) {
+			if start, end, ok := findContextWord(pwLower, pwNormalized, offsets, w); ok {
 				issues = append(issues, issue.New(
 					issue.CodeContextWord,
 					formatContextMessage(w),
 					issue.CategoryContext,
 					issue.SeverityHigh,
-				))
+				).At(start, end))
 				seen[w] = true
 			}
 		}
@@ -106,21 +124,30 @@ func normalizeContextWord(word string) string {
 	return strings.TrimSpace(strings.ToLower(word))
 }
 
-// extractWords extracts individual words from a context term.
+// extractWords extracts individual lowercase words from a context term.
 // For emails, it extracts the local part, domain parts, and TLD.
-// For other strings, it splits on common separators.
+// For other strings, it splits on common separators and camelCase
+// boundaries ("johnSmith" → "john", "smith"), and phone numbers such as
+// "555-123-4567" also yield their digits joined ("5551234567").
 func extractWords(word string) []string {
 	// Check if it's an email
 	if strings.Contains(word, "@") {
-		return extractEmailParts(word)
+		return extractEmailParts(strings.ToLower(word))
 	}
 
 	// Start with the original word
+	cased := word
+	word = strings.ToLower(word)
 	result := []string{word}
 
+	// Phone numbers are typed without their separators
+	if digits, ok := phoneDigits(word); ok {
+		result = append(result, digits)
+	}
+
 	// Split on common separators
 	separators := []string{".", "-", "_", " "}
-	parts := []string{word}
+	parts := []string{cased}
 
 	for _, sep := range separators {
 		var newParts []string
@@ -131,12 +158,22 @@ func extractWords(word string) []string {
 		parts = newParts
 	}
 
-	// Add split parts to result
+	// Add split parts to result, then their camelCase words
+	var camel []string
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
-		if part != "" && part != word {
-			result = append(result, part)
+		if part == "" {
+			continue
 		}
+		if lower := strings.ToLower(part); lower != word {
+			result = append(result, lower)
+		}
+		if words := splitCamelCase(part); len(words) > 1 {
+			camel = append(camel, words...)
+		}
+	}
+	for _, w := range camel {
+		result = append(result, strings.ToLower(w))
 	}
 
 	// Deduplicate
@@ -152,6 +189,49 @@ func extractWords(word string) []string {
 	return unique
 }
 
+// splitCamelCase splits s before each uppercase letter that follows a
+// lowercase letter or digit, or that starts a capitalized word after an
+// acronym: "johnSmith" → ["john", "Smith"], "JSmith" → ["J", "Smith"].
+func splitCamelCase(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prev := runes[i-1]
+		acronymEnd := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+// minPhoneDigits is the minimum number of digits for a term to be treated
+// as a phone number.
+const minPhoneDigits = 4
+
+// phoneDigits returns the digits of a phone-like term, one made only of
+// digits and the separators " -.()+", when it has separators and at least
+// minPhoneDigits digits: "555-123-4567" → "5551234567".
+func phoneDigits(s string) (string, bool) {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune(" -.()+", r):
+		default:
+			return "", false
+		}
+	}
+	digits := b.String()
+	return digits, len(digits) >= minPhoneDigits && digits != s
+}
+
 // extractEmailParts extracts meaningful parts from an email address.
 // For "john.doe@acme.com", it returns ["john", "doe", "acme", "com", "john.doe"].
 func extractEmailParts(email string) []string {
@@ -206,14 +286,26 @@ func extractEmailParts(email string) []string {
 // containsContextWord checks if the password contains the context word.
 // It checks both the original lowercased password and the leetspeak-normalized version.
 func containsContextWord(pwLower, pwNormalized, word string) bool {
+	_, _, ok := findContextWord(pwLower, pwNormalized, nil, word)
+	return ok
+}
+
+// findContextWord is like containsContextWord but also returns the rune
+// offsets [start, end) of the match in the password. offsets maps
+// pwNormalized back onto pwLower as returned by [leet.NormalizeMultiOffsets];
+// nil means pwNormalized has the same runes as pwLower.
+func findContextWord(pwLower, pwNormalized string, offsets []int, word string) (start, end int, ok bool) {
 	// Check exact substring match
-	if strings.Contains(pwLower, word) {
-		return true
+	if start, end, ok := issue.RuneSpan(pwLower, word); ok {
+		return start, end, true
 	}
 
 	// Check leetspeak-normalized version
-	wordNormalized := leet.Normalize(word)
-	return strings.Contains(pwNormalized, wordNormalized)
+	start, end, ok = issue.RuneSpan(pwNormalized, leet.NormalizeMulti(word))
+	if !ok || offsets == nil {
+		return start, end, ok
+	}
+	return offsets[start], offsets[end], true
 }
 
 // formatContextMessage creates a human-readable message for a context word match.
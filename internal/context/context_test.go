@@ -1,6 +1,7 @@
 package context
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
@@ -125,6 +126,12 @@ func TestCheckWith_LeetSpeakVariants(t *testing.T) {
 			context:  []string{"admin"},
 			wantHit:  true,
 		},
+		{
+			name:     "multi-character sequence",
+			password: "()rder99",
+			context:  []string{"order"},
+			wantHit:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -142,6 +149,16 @@ func TestCheckWith_LeetSpeakVariants(t *testing.T) {
 	}
 }
 
+func TestCheckWith_MultiCharLeetSpan(t *testing.T) {
+	issues := CheckWith("my|\\/|ike!", Options{ContextWords: []string{"mike"}})
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %v", issues)
+	}
+	if issues[0].Start != 2 || issues[0].End != 9 {
+		t.Errorf("span = [%d, %d), want [2, 9)", issues[0].Start, issues[0].End)
+	}
+}
+
 func TestCheckWith_EmailExtraction(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -250,6 +267,40 @@ func TestCheckWith_MultipleContextWords(t *testing.T) {
 	}
 }
 
+func TestCheckWith_MinWordLen(t *testing.T) {
+	opts := Options{ContextWords: []string{"GE", "x"}, MinWordLen: 2}
+
+	result := CheckWith("myGe2024!", opts)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 issue for 'ge', got %v", result)
+	}
+	if result[0].Start != 2 || result[0].End != 4 {
+		t.Errorf("span = [%d, %d), want [2, 4)", result[0].Start, result[0].End)
+	}
+
+	opts.MinWordLen = 0 // default
+	if result := CheckWith("myGe2024!", opts); len(result) != 0 {
+		t.Errorf("default MinWordLen should ignore 'ge', got %v", result)
+	}
+}
+
+func TestCheckWith_PhoneAndCamelCase(t *testing.T) {
+	tests := []struct {
+		password string
+		context  string
+		want     string
+	}{
+		{"call5551234567!", "555-123-4567", "5551234567"},
+		{"Smith#2024", "johnSmith", "smith"},
+	}
+	for _, tt := range tests {
+		issues := CheckWith(tt.password, Options{ContextWords: []string{tt.context}})
+		if len(issues) == 0 || !strings.Contains(issues[0].Message, `"`+tt.want+`"`) {
+			t.Errorf("CheckWith(%q, %q) = %v, want a match on %q", tt.password, tt.context, issues, tt.want)
+		}
+	}
+}
+
 func TestCheckWith_ShortWords(t *testing.T) {
 	// Words shorter than 3 characters should be ignored
 	opts := Options{
@@ -387,6 +438,30 @@ func TestExtractWords(t *testing.T) {
 			input: "john.doe@example.com",
 			want:  []string{"john.doe", "john", "doe", "example", "com"},
 		},
+		{
+			input: "johnSmith",
+			want:  []string{"johnsmith", "john", "smith"},
+		},
+		{
+			input: "JSmith_92",
+			want:  []string{"jsmith_92", "jsmith", "92", "j", "smith"},
+		},
+		{
+			input: "j.smith_92",
+			want:  []string{"j.smith_92", "j", "smith", "92"},
+		},
+		{
+			input: "555-123-4567",
+			want:  []string{"555-123-4567", "5551234567", "555", "123", "4567"},
+		},
+		{
+			input: "+1 (555) 123.4567",
+			want:  []string{"+1 (555) 123.4567", "15551234567", "+1", "(555)", "123", "4567"},
+		},
+		{
+			input: "5551234567",
+			want:  []string{"5551234567"},
+		},
 	}
 
 	for _, tt := range tests {
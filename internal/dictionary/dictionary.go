@@ -12,6 +12,8 @@ package dictionary
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
 )
@@ -30,8 +32,18 @@ func Check(password string) []issue.Issue {
 // computed. Both forms are checked by each detector.
 //
 // Detection order:
-//  1. Exact match against common passwords (plain + leet-normalized)
-//  2. Common English word containment (plain + leet-normalized)
+//  1. Exact match against the trending hot list
+//  2. Exact match against common passwords (plain + leet-normalized)
+//  3. Common password with only its first letter capitalized
+//  4. Common password or word followed or preceded by its own reverse
+//  5. Password as a part of a longer common password, when
+//     opts.DetectSubstrings is set
+//  6. Password one edit away from a common password, when opts.Fuzzy is
+//     set
+//  7. Common password altered by canonical mangling rules (capitalize,
+//     leetify, append digits or a symbol), when opts.Mangled is set
+//  8. Common English word containment (plain + leet-normalized)
+//  9. Reversed common words, when opts.DetectReversed is set
 func CheckWith(password string, opts Options) []issue.Issue {
 	lower := strings.ToLower(password)
 
@@ -41,59 +53,294 @@ func CheckWith(password string, opts Options) []issue.Issue {
 		normalized = normalizeLeet(lower)
 	}
 
+	// Whole-password matches span every rune.
+	n := utf8.RuneCountInString(password)
 	var issues []issue.Issue
-	issues = append(issues, checkExactPasswordWith(lower, normalized, opts)...)
+	for _, iss := range checkTrending(lower, opts) {
+		issues = append(issues, iss.At(0, n))
+	}
+	for _, iss := range checkExactPasswordWith(lower, normalized, opts) {
+		issues = append(issues, iss.At(0, n))
+	}
+	for _, iss := range checkCapitalizedCommon(password, lower, opts) {
+		issues = append(issues, iss.At(0, n))
+	}
+	for _, iss := range checkMirrored(lower, opts) {
+		issues = append(issues, iss.At(0, n))
+	}
+	if opts.DetectSubstrings {
+		for _, iss := range checkPasswordSubstring(lower, normalized, opts) {
+			issues = append(issues, iss.At(0, n))
+		}
+	}
+	if opts.Fuzzy {
+		for _, iss := range checkNearCommon(lower, normalized, opts) {
+			issues = append(issues, iss.At(0, n))
+		}
+	}
+	if opts.Mangled {
+		for _, iss := range checkMangledCommon(password, lower, normalized, opts) {
+			issues = append(issues, iss.At(0, n))
+		}
+	}
 	issues = append(issues, checkCommonWordsWith(lower, normalized, opts)...)
+	if opts.DetectReversed {
+		issues = append(issues, checkReversedWords(lower, normalized, opts)...)
+	}
 	return issues
 }
 
+// checkTrending reports whether the password exactly matches an entry in
+// the hot list of currently trending passwords. It runs first so that the
+// trending issue sorts ahead of other dictionary findings.
+func checkTrending(password string, opts Options) []issue.Issue {
+	if !isHotPassword(password, opts) {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(issue.CodeDictTrending, "This password is trending in recent breaches and attacks", issue.CategoryDictionary, issue.SeverityHigh),
+	}
+}
+
 // checkExactPasswordWith reports whether the password (or its leet-normalized
 // form) exactly matches a known common password — either the built-in set
 // or a user-supplied custom list.
 func checkExactPasswordWith(password, normalized string, opts Options) []issue.Issue {
 	var issues []issue.Issue
 
-	if isCommonPasswordIn(password, opts.CustomPasswords, opts.ConstantTime) {
-		issues = append(issues, issue.New(issue.CodeDictCommonPassword, "This password appears in common password lists", issue.CategoryDictionary, issue.SeverityHigh))
+	if isCommonPasswordWith(password, opts) {
+		iss := issue.New(issue.CodeDictCommonPassword, "This password appears in common password lists", issue.CategoryDictionary, issue.SeverityHigh)
+		iss.Frequency = opts.PasswordSet.Frequency(password)
+		issues = append(issues, iss)
 		return issues // exact match is the strongest signal; no need to also flag leet
 	}
 
-	if normalized != password && isCommonPasswordIn(normalized, opts.CustomPasswords, opts.ConstantTime) {
-		issues = append(issues, issue.New(issue.CodeDictLeetVariant, "This is a leetspeak variant of a common password", issue.CategoryDictionary, issue.SeverityHigh))
+	if normalized != password && isCommonPasswordWith(normalized, opts) {
+		iss := issue.New(issue.CodeDictLeetVariant, "This is a leetspeak variant of a common password", issue.CategoryDictionary, issue.SeverityHigh)
+		iss.Frequency = opts.PasswordSet.Frequency(normalized)
+		issues = append(issues, iss)
 	}
 
 	return issues
 }
 
+// checkCapitalizedCommon reports passwords that are a common password with
+// only the first letter uppercased (e.g. "Password", "Dragon"). This is the
+// most common way users satisfy an uppercase requirement, so it earns its own
+// high-severity signal on top of the case-insensitive exact match.
+func checkCapitalizedCommon(password, lower string, opts Options) []issue.Issue {
+	if !isFirstLetterCapitalized(password) || !isCommonPasswordWith(lower, opts) {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(issue.CodeDictCapitalizedCommon, "Capitalizing the first letter of a common password does not make it stronger", issue.CategoryDictionary, issue.SeverityHigh),
+	}
+}
+
+// isFirstLetterCapitalized reports whether password starts with an
+// uppercase letter and contains no other uppercase letters.
+func isFirstLetterCapitalized(password string) bool {
+	for i, r := range password {
+		if i == 0 {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			continue
+		}
+		if unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return utf8.RuneCountInString(password) > 1
+}
+
+// Half-length bounds for mirrored detection. Halves outside this range
+// cannot plausibly be a common password or dictionary word.
+const (
+	minMirrorHalf = DefaultMinWordLen
+	maxMirrorHalf = 32
+)
+
+// checkMirrored reports passwords made of a common password or word
+// concatenated with its own reverse, in either order (e.g.
+// "passworddrowssap", "drowssappassword"). Such passwords are even-length
+// palindromes whose halves reveal the base entry.
+func checkMirrored(password string, opts Options) []issue.Issue {
+	runes := []rune(password)
+	n := len(runes)
+	if n%2 != 0 || n/2 < minMirrorHalf || n/2 > maxMirrorHalf {
+		return nil
+	}
+	half := n / 2
+	for i := 0; i < half; i++ {
+		if runes[i] != runes[n-1-i] {
+			return nil
+		}
+	}
+	first, second := string(runes[:half]), string(runes[half:])
+	for _, candidate := range []string{first, second} {
+		if isCommonPasswordWith(candidate, opts) || isCommonWordWith(candidate, opts) {
+			return []issue.Issue{
+				issue.New(issue.CodeDictMirrored, "Password is a common word followed by its reverse", issue.CategoryDictionary, issue.SeverityHigh),
+			}
+		}
+	}
+	return nil
+}
+
+// checkPasswordSubstring reports passwords that are a strict substring of
+// a longer common password, such as "assword12" in "password123" or
+// "2345678" in "12345678" — trimmed entries chosen to evade an exact match.
+// This is the inverse of word containment. Passwords shorter than
+// opts.MinSubstringLen (and never below DefaultMinWordLen) runes, and
+// exact matches, which are reported as such, are skipped.
+//
+// The built-in list and opts.CustomPasswords are searched; opts.PasswordSet
+// is not, since it may hold millions of entries.
+func checkPasswordSubstring(password, normalized string, opts Options) []issue.Issue {
+	if utf8.RuneCountInString(password) < max(opts.MinSubstringLen, DefaultMinWordLen) ||
+		isCommonPasswordWith(password, opts) || isCommonPasswordWith(normalized, opts) {
+		return nil
+	}
+	candidates := []string{password}
+	if normalized != password {
+		candidates = append(candidates, normalized)
+	}
+	for _, c := range candidates {
+		if isPasswordSubstring(c, opts.CustomPasswords, opts.ConstantTime) {
+			return []issue.Issue{
+				issue.New(issue.CodeDictPasswordSubstring, "Password is part of a common password", issue.CategoryDictionary, issue.SeverityHigh),
+			}
+		}
+	}
+	return nil
+}
+
+// IsCommon reports whether word (must be lowercase) exactly matches a
+// common password or common word in the built-in or custom lists of opts.
+func IsCommon(word string, opts Options) bool {
+	return isCommonPasswordWith(word, opts) || isCommonWordWith(word, opts)
+}
+
+// isCommonWordWith reports whether word exactly matches an entry in the
+// built-in or custom word lists.
+func isCommonWordWith(word string, opts Options) bool {
+	var found []string
+	if len(opts.CustomWords) > 0 {
+		found = findCommonWordsWithCustom(word, opts.CustomWords, opts.ConstantTime)
+	} else {
+		found = findCommonWords(word, opts.ConstantTime)
+	}
+	for _, w := range found {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
 // checkCommonWordsWith reports common English words found inside the password
 // (or its leet-normalized form), using both the built-in and custom word lists.
 func checkCommonWordsWith(password, normalized string, opts Options) []issue.Issue {
 	seen := make(map[string]bool)
 	var issues []issue.Issue
 
-	// Select word-finding function based on whether custom words are present.
-	findWords := func(pw string) []string {
-		if len(opts.CustomWords) > 0 {
-			return findCommonWordsWithCustom(pw, opts.CustomWords, opts.ConstantTime)
-		}
-		return findCommonWords(pw, opts.ConstantTime)
-	}
+	findWords := wordFinder(opts)
 
 	// Plain-text word matches.
 	for _, word := range findWords(password) {
 		seen[word] = true
-		issues = append(issues, issue.New(issue.CodeDictCommonWord, fmt.Sprintf("Contains common word: '%s'", word), issue.CategoryDictionary, issue.SeverityHigh))
+		issues = append(issues, issue.New(issue.CodeDictCommonWord, fmt.Sprintf("Contains common word: '%s'", word), issue.CategoryDictionary, issue.SeverityHigh).AtSubstring(password, word))
 	}
 
-	// Leet-normalized word matches (only report new words).
+	// Leet-normalized word matches (only report new words), with spans
+	// mapped back onto the password since multi-character sequences such
+	// as "()" shorten it.
 	if normalized != password {
+		offsets := leetOffsets(password)
 		for _, word := range findWords(normalized) {
 			if !seen[word] {
 				seen[word] = true
-				issues = append(issues, issue.New(issue.CodeDictCommonWordSub, fmt.Sprintf("Contains common word (via substitution): '%s'", word), issue.CategoryDictionary, issue.SeverityHigh))
+				iss := issue.New(issue.CodeDictCommonWordSub, fmt.Sprintf("Contains common word (via substitution): '%s'", word), issue.CategoryDictionary, issue.SeverityHigh)
+				if start, end, ok := issue.RuneSpan(normalized, word); ok {
+					iss = iss.At(sourceSpan(offsets, start, end))
+				}
+				issues = append(issues, iss)
 			}
 		}
 	}
 
 	return issues
 }
+
+// wordFinder returns the word-finding function for opts, using the custom
+// word list when one is present.
+func wordFinder(opts Options) func(string) []string {
+	return func(pw string) []string {
+		if len(opts.CustomWords) > 0 {
+			return findCommonWordsWithCustom(pw, opts.CustomWords, opts.ConstantTime)
+		}
+		return findCommonWords(pw, opts.ConstantTime)
+	}
+}
+
+// maxReversedLeet is the maximum number of leet substitutions allowed inside
+// a reversed match. Reversing symbol-heavy passwords after normalization
+// otherwise produces accidental words (e.g. "l3x!p" → "lexip" → "pixel").
+const maxReversedLeet = 1
+
+// checkReversedWords reports common words that appear spelled backwards in
+// the leet-normalized password (e.g. "nogard" → "dragon", "drowssap" →
+// "password"). Words the forward pass already found, such as palindromes,
+// are not reported again, nor are matches relying on more than
+// maxReversedLeet substitutions.
+func checkReversedWords(password, normalized string, opts Options) []issue.Issue {
+	findWords := wordFinder(opts)
+	seen := make(map[string]bool)
+	for _, w := range findWords(password) {
+		seen[w] = true
+	}
+	for _, w := range findWords(normalized) {
+		seen[w] = true
+	}
+
+	plain, leet := []rune(password), []rune(normalized)
+	offsets := leetOffsets(password)
+	if normalized == password {
+		offsets = nil
+	}
+	reversed := reverseRunes(normalized)
+	n := len(leet)
+	var issues []issue.Issue
+	for _, word := range findWords(reversed) {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		// Map the span in the reversed string back onto the normalized
+		// password, then onto the password itself.
+		rs, re, _ := issue.RuneSpan(reversed, word)
+		start, end := n-re, n-rs
+		subs := 0
+		for i := start; i < end; i++ {
+			src, srcEnd := sourceSpan(offsets, i, i+1)
+			if srcEnd-src != 1 || plain[src] != leet[i] {
+				subs++
+			}
+		}
+		if subs > maxReversedLeet {
+			continue
+		}
+		issues = append(issues, issue.New(issue.CodeDictReversedWord, fmt.Sprintf("Contains reversed common word: '%s'", word), issue.CategoryDictionary, issue.SeverityHigh).At(sourceSpan(offsets, start, end)))
+	}
+	return issues
+}
+
+// reverseRunes returns s with its runes in reverse order.
+func reverseRunes(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -1,6 +1,11 @@
 package dictionary
 
 import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"math"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -128,6 +133,358 @@ func TestCheckExactPassword_ExactMatchSkipsLeet(t *testing.T) {
 	assertContainsIssue(t, issues, "common password lists")
 }
 
+// ---------------------------------------------------------------------------
+// Capitalized Common Password
+// ---------------------------------------------------------------------------
+
+func TestCheckCapitalizedCommon(t *testing.T) {
+	tests := []struct {
+		password  string
+		wantIssue bool
+	}{
+		{"Password", true},
+		{"Dragon", true},
+		{"Qwerty", true},
+		{"password", false}, // no capital
+		{"PASSWORD", false}, // fully uppercased
+		{"PassWord", false}, // inner capital
+		{"pAssword", false}, // capital not first
+		{"Xkcdrandom", false},
+		{"P", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			issues := CheckWith(tt.password, DefaultOptions())
+			got := false
+			for _, iss := range issues {
+				if iss.Code == issue.CodeDictCapitalizedCommon {
+					got = true
+					if iss.Severity != issue.SeverityHigh {
+						t.Errorf("severity = %d, want %d", iss.Severity, issue.SeverityHigh)
+					}
+				}
+			}
+			if got != tt.wantIssue {
+				t.Errorf("CheckWith(%q): DICT_CAPITALIZED_COMMON = %v, want %v (issues: %v)", tt.password, got, tt.wantIssue, issues)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Mirrored
+// ---------------------------------------------------------------------------
+
+func TestCheckMirrored(t *testing.T) {
+	tests := []struct {
+		password  string
+		wantIssue bool
+	}{
+		{"passworddrowssap", true},
+		{"drowssappassword", true},
+		{"dragonnogard", true},
+		{"sunshineenihsnus", true},  // common word
+		{"passwordpassword", false}, // repeat, not mirror
+		{"xkqzvbbvzqkx", false},     // mirror of non-dictionary text
+		{"passworddrowssa", false},  // odd length
+		{"abba", false},             // halves too short
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			issues := checkMirrored(tt.password, DefaultOptions())
+			if got := len(issues) > 0; got != tt.wantIssue {
+				t.Errorf("checkMirrored(%q) = %v, want issue=%v", tt.password, issues, tt.wantIssue)
+			}
+			for _, iss := range issues {
+				if iss.Code != issue.CodeDictMirrored {
+					t.Errorf("code = %q, want %q", iss.Code, issue.CodeDictMirrored)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckMirrored_CustomWord(t *testing.T) {
+	opts := DefaultOptions()
+	opts.CustomWords = []string{"acmecorp"}
+	if len(checkMirrored("acmecorpprocemca", opts)) == 0 {
+		t.Error("expected mirrored custom word to be flagged")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Reversed Words
+// ---------------------------------------------------------------------------
+
+func TestCheckReversedWords(t *testing.T) {
+	opts := DefaultOptions()
+	opts.DetectReversed = true
+
+	tests := []struct {
+		password string
+		word     string
+		start    int
+		end      int
+	}{
+		{"nogard", "dragon", 0, 6},
+		{"xxnogard99", "dragon", 2, 8},
+		{"dr0wssap", "password", 0, 8},
+		{"n()gard", "dragon", 0, 7},
+	}
+	for _, tt := range tests {
+		issues := CheckWith(tt.password, opts)
+		var found *issue.Issue
+		for i := range issues {
+			if issues[i].Code == issue.CodeDictReversedWord {
+				found = &issues[i]
+			}
+		}
+		if found == nil {
+			t.Errorf("%q: expected %s, got %v", tt.password, issue.CodeDictReversedWord, issues)
+			continue
+		}
+		if !strings.Contains(found.Message, "'"+tt.word+"'") || found.Start != tt.start || found.End != tt.end {
+			t.Errorf("%q: got %q [%d, %d), want %q [%d, %d)", tt.password, found.Message, found.Start, found.End, tt.word, tt.start, tt.end)
+		}
+	}
+
+	if hasReversed(CheckWith("nogard", DefaultOptions())) {
+		t.Error("reversed detection should be off unless DetectReversed is set")
+	}
+}
+
+func TestCheckReversedWords_SkipsForwardMatches(t *testing.T) {
+	opts := DefaultOptions()
+	opts.DetectReversed = true
+	opts.CustomWords = []string{"racecar"}
+
+	if hasReversed(CheckWith("myracecar1", opts)) {
+		t.Error("palindromic word caught by the forward pass should not be reported reversed")
+	}
+	if hasReversed(CheckWith("passworddrowssap", opts)) {
+		t.Error("word found forward should not also be reported reversed")
+	}
+}
+
+func TestCheckReversedWords_LimitsLeet(t *testing.T) {
+	opts := DefaultOptions()
+	opts.DetectReversed = true
+	// "l3x!p" normalizes to "lexip", which reversed is "pixel".
+	if hasReversed(CheckWith("MyC0mpl3x!P@ss2024", opts)) {
+		t.Error("reversed match relying on several leet substitutions should be ignored")
+	}
+}
+
+func hasReversed(issues []issue.Issue) bool {
+	for _, iss := range issues {
+		if iss.Code == issue.CodeDictReversedWord {
+			return true
+		}
+	}
+	return false
+}
+
+// ---------------------------------------------------------------------------
+// Password Substrings
+// ---------------------------------------------------------------------------
+
+func TestCheckPasswordSubstring(t *testing.T) {
+	opts := DefaultOptions()
+	opts.DetectSubstrings = true
+	opts.MinSubstringLen = 7
+
+	for _, pw := range []string{"assword12", "2345678", "Qwertyuio", "@ssword"} {
+		if !hasPasswordSubstring(CheckWith(pw, opts)) {
+			t.Errorf("%q: expected %s", pw, issue.CodeDictPasswordSubstring)
+		}
+	}
+
+	for _, pw := range []string{
+		"asswor",      // below MinSubstringLen
+		"password123", // exact match, reported as DICT_COMMON_PASSWORD
+		"xassword12",  // not a substring
+	} {
+		if hasPasswordSubstring(CheckWith(pw, opts)) {
+			t.Errorf("%q: unexpected %s", pw, issue.CodeDictPasswordSubstring)
+		}
+	}
+
+	if hasPasswordSubstring(CheckWith("assword12", DefaultOptions())) {
+		t.Error("substring detection should be off unless DetectSubstrings is set")
+	}
+
+	opts.MinSubstringLen = 0
+	if !hasPasswordSubstring(CheckWith("asswor", opts)) {
+		t.Error("expected a 6-rune substring once MinSubstringLen allows it")
+	}
+	if hasPasswordSubstring(CheckWith("ass", opts)) {
+		t.Error("substrings shorter than DefaultMinWordLen should never be reported")
+	}
+
+	opts.CustomPasswords = []string{"acmecorp2024"}
+	opts.ConstantTime = true
+	if !hasPasswordSubstring(CheckWith("cmecorp20", opts)) {
+		t.Error("expected a substring of a custom password in constant-time mode")
+	}
+}
+
+func hasPasswordSubstring(issues []issue.Issue) bool {
+	for _, iss := range issues {
+		if iss.Code == issue.CodeDictPasswordSubstring {
+			return true
+		}
+	}
+	return false
+}
+
+// ---------------------------------------------------------------------------
+// Fuzzy Matching
+// ---------------------------------------------------------------------------
+
+func TestCheckNearCommon(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Fuzzy = true
+
+	for _, pw := range []string{"passw0rd1", "qwerrty", "sunshinee", "monkeys1", "iloveyu"} {
+		if !hasNearCommon(CheckWith(pw, opts)) {
+			t.Errorf("%q: expected %s", pw, issue.CodeDictNearCommon)
+		}
+	}
+	for _, pw := range []string{
+		"password1",    // exact match
+		"p@ssword",     // leet variant
+		"qwerty",       // exact match, shorter than the fuzzy minimum anyway
+		"dragn",        // below minFuzzyLen
+		"Xk9$mP2!vR7@", // unrelated
+	} {
+		if hasNearCommon(CheckWith(pw, opts)) {
+			t.Errorf("%q: unexpected %s", pw, issue.CodeDictNearCommon)
+		}
+	}
+
+	if hasNearCommon(CheckWith("qwerrty", DefaultOptions())) {
+		t.Error("fuzzy matching should be off unless Fuzzy is set")
+	}
+
+	opts.CustomPasswords = []string{"acmecorp2024"}
+	if !hasNearCommon(CheckWith("acmecorp2025", opts)) {
+		t.Error("expected a near-miss of a custom password")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Mangled Common Passwords
+// ---------------------------------------------------------------------------
+
+func TestCheckMangledCommon(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Mangled = true
+
+	tests := []struct {
+		pw, base string
+	}{
+		{"Dragon2024!", "dragon"},
+		{"P@ssw0rd1!", "p@ssw0rd"},
+		{"monkey12#", "monkey1"},
+		{"Sh4dow99", "shadow"},
+		{"!Qwerty", ""},     // prepended symbol
+		{"dRagon1", ""},     // not a capitalize-first rule
+		{"dragon", ""},      // exact match
+		{"p@ssword", ""},    // leet variant
+		{"dragon98765", ""}, // too many digits
+		{"xqzvbn1!", ""},
+	}
+	for _, tt := range tests {
+		var got string
+		for _, iss := range CheckWith(tt.pw, opts) {
+			if iss.Code == issue.CodeDictMangledCommon {
+				got = tt.base
+				if !strings.Contains(iss.Message, "'"+tt.base+"'") {
+					t.Errorf("%q: message %q does not quote %q", tt.pw, iss.Message, tt.base)
+				}
+			}
+		}
+		if (got != "") != (tt.base != "") {
+			t.Errorf("%q: flagged=%v, want %v", tt.pw, got != "", tt.base != "")
+		}
+	}
+
+	for _, iss := range CheckWith("Dragon2024!", DefaultOptions()) {
+		if iss.Code == issue.CodeDictMangledCommon {
+			t.Error("mangling detection should be off unless Mangled is set")
+		}
+	}
+}
+
+func TestUnmangle_ConstantTimeSameResult(t *testing.T) {
+	for _, pw := range []string{"Dragon2024!", "P@ssw0rd1!", "monkey12#", "password1!", "xqzvbn1!"} {
+		opts := DefaultOptions()
+		base, ok := unmangle(pw, opts)
+		opts.ConstantTime = true
+		ctBase, ctOK := unmangle(pw, opts)
+		if base != ctBase || ok != ctOK {
+			t.Errorf("unmangle(%q) = %q, %v; constant time = %q, %v", pw, base, ok, ctBase, ctOK)
+		}
+	}
+}
+
+func TestWithinOneEdit(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"password", "password", true},
+		{"password", "passw0rd", true},
+		{"password", "passwords", true},
+		{"password", "pssword", true},
+		{"password", "apassword", true},
+		{"password", "passwrod", false},
+		{"password", "pass", false},
+		{"qwerty", "qwertyui", false},
+		{"añoñ12", "año12", true},
+	}
+	for _, tt := range tests {
+		if got := withinOneEdit(tt.a, tt.b); got != tt.want {
+			t.Errorf("withinOneEdit(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func hasNearCommon(issues []issue.Issue) bool {
+	for _, iss := range issues {
+		if iss.Code == issue.CodeDictNearCommon {
+			return true
+		}
+	}
+	return false
+}
+
+// ---------------------------------------------------------------------------
+// Trending Hot List
+// ---------------------------------------------------------------------------
+
+func TestCheckTrending(t *testing.T) {
+	for _, constantTime := range []bool{false, true} {
+		opts := DefaultOptions()
+		opts.HotList = []string{"summer2026!", "tr3nd1ng"}
+		opts.ConstantTime = constantTime
+
+		issues := CheckWith("Summer2026!", opts)
+		if len(issues) == 0 || issues[0].Code != issue.CodeDictTrending {
+			t.Errorf("constantTime=%v: expected %s first, got %+v", constantTime, issue.CodeDictTrending, issues)
+		}
+		if len(checkTrending("summer2026", opts)) != 0 {
+			t.Errorf("constantTime=%v: hot list should match exactly", constantTime)
+		}
+		if len(checkTrending("xsummer2026!", opts)) != 0 {
+			t.Errorf("constantTime=%v: hot list should not match substrings", constantTime)
+		}
+	}
+	if len(checkTrending("summer2026!", DefaultOptions())) != 0 {
+		t.Error("empty hot list should not match")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Common Word Containment
 // ---------------------------------------------------------------------------
@@ -173,6 +530,21 @@ func TestCheckCommonWords_LeetNormalized(t *testing.T) {
 	assertContainsIssue(t, issues, "dragon")
 }
 
+func TestCheckCommonWords_MultiCharLeet(t *testing.T) {
+	// "vv" stands for "w", so the normalized string is shorter than the
+	// password; the span must still cover the password's runes.
+	issues := CheckWith("xvvelcome1", DefaultOptions())
+	for _, iss := range issues {
+		if iss.Code == issue.CodeDictCommonWordSub && strings.Contains(iss.Message, "'welcome'") {
+			if iss.Start != 1 || iss.End != 9 {
+				t.Errorf("span = [%d, %d), want [1, 9)", iss.Start, iss.End)
+			}
+			return
+		}
+	}
+	t.Errorf("expected %s for 'welcome', got %v", issue.CodeDictCommonWordSub, issues)
+}
+
 func TestCheckCommonWords_NoDuplicates(t *testing.T) {
 	// "dragon" appears in both plain and normalized forms — report once.
 	password := "xdragonx"
@@ -263,6 +635,37 @@ func TestIsCommonPassword(t *testing.T) {
 	}
 }
 
+func TestIsPasswordToken(t *testing.T) {
+	for _, w := range []string{"qwerty", "letmein", "123456"} {
+		if !IsPasswordToken(w) {
+			t.Errorf("IsPasswordToken(%q) = false, want true", w)
+		}
+	}
+	// Common passwords that are also English words, and non-passwords.
+	for _, w := range []string{"horse", "dragon", "battery", "xkqzvw"} {
+		if IsPasswordToken(w) {
+			t.Errorf("IsPasswordToken(%q) = true, want false", w)
+		}
+	}
+}
+
+func TestIsCommon(t *testing.T) {
+	for _, w := range []string{"dragon", "qwerty", "horse", "password"} {
+		if !IsCommon(w, Options{}) {
+			t.Errorf("IsCommon(%q) = false, want true", w)
+		}
+	}
+	if IsCommon("xkqzvw", Options{}) {
+		t.Error("IsCommon(\"xkqzvw\") = true, want false")
+	}
+	opts := Options{CustomWords: []string{"acmecorp"}, CustomPasswords: []string{"hunter42"}}
+	for _, w := range []string{"acmecorp", "hunter42"} {
+		if !IsCommon(w, opts) {
+			t.Errorf("IsCommon(%q) with custom lists = false, want true", w)
+		}
+	}
+}
+
 func TestBuildPasswordSet(t *testing.T) {
 	set := buildPasswordSet([]string{"alpha", "beta", "gamma"})
 	if len(set) != 3 {
@@ -734,6 +1137,22 @@ func BenchmarkCheckWith_CustomWords(b *testing.B) {
 	}
 }
 
+func BenchmarkCheckWith_Fuzzy(b *testing.B) {
+	opts := Options{Fuzzy: true}
+	for i := 0; i < b.N; i++ {
+		CheckWith("Xk9$mP2!vR7@nL4&wQ", opts)
+		CheckWith("passw0rd1", opts)
+	}
+}
+
+func BenchmarkCheckWith_Mangled(b *testing.B) {
+	opts := Options{Mangled: true}
+	for i := 0; i < b.N; i++ {
+		CheckWith("Xk9$mP2!vR7@nL4&wQ", opts)
+		CheckWith("Dragon2024!", opts)
+	}
+}
+
 func BenchmarkCheckWith_LargeCustomList(b *testing.B) {
 	// Simulate a realistic blocklist (500 entries).
 	customPw := make([]string, 500)
@@ -746,6 +1165,204 @@ func BenchmarkCheckWith_LargeCustomList(b *testing.B) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// PasswordSet
+// ---------------------------------------------------------------------------
+
+func TestLoadPasswordList(t *testing.T) {
+	input := "# corporate blocklist\n" +
+		"Acme2024  \n" +
+		"\n" +
+		"   \n" +
+		"winter-is-coming\r\n" +
+		" leadingspace\n"
+	set, err := LoadPasswordList(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadPasswordList: %v", err)
+	}
+	for _, pw := range []string{"acme2024", "winter-is-coming", " leadingspace"} {
+		if !set.Contains(pw) {
+			t.Errorf("expected set to contain %q", pw)
+		}
+	}
+	for _, pw := range []string{"Acme2024", "", "# corporate blocklist", "acme2024  "} {
+		if set.Contains(pw) {
+			t.Errorf("expected set not to contain %q", pw)
+		}
+	}
+	if set.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", set.Len())
+	}
+}
+
+func TestLoadPasswordList_Frequency(t *testing.T) {
+	input := "123456\t1000000\n" +
+		"Dragon\t1000\n" +
+		"dragon\t10\n" +
+		"plain\n" +
+		"tab\tseparated\n" +
+		"zero\t0\n"
+	set, err := LoadPasswordList(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadPasswordList: %v", err)
+	}
+	for _, pw := range []string{"123456", "dragon", "plain", "tab\tseparated", "zero"} {
+		if !set.Contains(pw) {
+			t.Errorf("expected set to contain %q", pw)
+		}
+	}
+	if set.Contains("123456\t1000000") {
+		t.Error("frequency should not be part of the password")
+	}
+	if set.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", set.Len())
+	}
+
+	if got := set.Frequency("123456"); got != 1 {
+		t.Errorf("Frequency(123456) = %v, want 1", got)
+	}
+	// Duplicates keep the highest frequency: log(1001)/log(1000001) ≈ 0.5.
+	if got := set.Frequency("dragon"); math.Abs(got-0.5) > 0.01 {
+		t.Errorf("Frequency(dragon) = %v, want ≈ 0.5", got)
+	}
+	for _, pw := range []string{"plain", "tab\tseparated", "zero", "missing"} {
+		if got := set.Frequency(pw); got != 0 {
+			t.Errorf("Frequency(%q) = %v, want 0", pw, got)
+		}
+	}
+	var nilSet *PasswordSet
+	if got := nilSet.Frequency("123456"); got != 0 {
+		t.Errorf("nil set Frequency = %v, want 0", got)
+	}
+}
+
+func TestCheckWith_PasswordSetFrequency(t *testing.T) {
+	set, err := LoadPasswordList(strings.NewReader("hunter42\t500\nletmeinnow\t50\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions()
+	opts.PasswordSet = set
+
+	for _, tc := range []struct {
+		password, code string
+		want           float64
+	}{
+		{"hunter42", issue.CodeDictCommonPassword, 1},
+		{"l3tm3innow", issue.CodeDictLeetVariant, set.Frequency("letmeinnow")},
+		{"password", issue.CodeDictCommonPassword, 0},
+	} {
+		var found bool
+		for _, iss := range CheckWith(tc.password, opts) {
+			if iss.Code == tc.code {
+				found = true
+				if iss.Frequency != tc.want {
+					t.Errorf("%s: Frequency = %v, want %v", tc.password, iss.Frequency, tc.want)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected %s", tc.password, tc.code)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+func TestLoadPasswordList_ReadError(t *testing.T) {
+	if _, err := LoadPasswordList(errReader{}); err == nil {
+		t.Error("expected error from failing reader")
+	}
+}
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoadPasswordListGzip(t *testing.T) {
+	data := gzipBytes(t, "# header\r\nAcme2024\r\nwinter-is-coming\r\n")
+	set, err := LoadPasswordListGzip(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadPasswordListGzip: %v", err)
+	}
+	if set.Len() != 2 || !set.Contains("acme2024") || !set.Contains("winter-is-coming") {
+		t.Errorf("unexpected set contents (len %d)", set.Len())
+	}
+}
+
+func TestLoadWordListGzip(t *testing.T) {
+	data := gzipBytes(t, "Acme\r\n\r\nRocket\n")
+	words, err := LoadWordListGzip(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadWordListGzip: %v", err)
+	}
+	if want := []string{"acme", "rocket"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("words = %q, want %q", words, want)
+	}
+}
+
+func TestLoadGzip_InvalidStream(t *testing.T) {
+	plain := strings.NewReader("password\n")
+	if _, err := LoadPasswordListGzip(plain); err == nil || !strings.Contains(err.Error(), "invalid gzip") {
+		t.Errorf("LoadPasswordListGzip(plain) error = %v, want invalid gzip error", err)
+	}
+	plain = strings.NewReader("password\n")
+	if _, err := LoadWordListGzip(plain); err == nil || !strings.Contains(err.Error(), "invalid gzip") {
+		t.Errorf("LoadWordListGzip(plain) error = %v, want invalid gzip error", err)
+	}
+}
+
+func TestPasswordSet_Nil(t *testing.T) {
+	var set *PasswordSet
+	if set.Contains("password") || set.Len() != 0 || set.Digest() != "" {
+		t.Error("nil PasswordSet should be empty")
+	}
+}
+
+func TestPasswordSet_Digest(t *testing.T) {
+	load := func(list string) *PasswordSet {
+		t.Helper()
+		set, err := LoadPasswordList(strings.NewReader(list))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return set
+	}
+	a := load("acmecorp\nhunter42\n")
+	if got := load("# comment\nAcmeCorp\nhunter42  \n").Digest(); got != a.Digest() {
+		t.Errorf("equal lists: digests %q and %q differ", got, a.Digest())
+	}
+	if b := load("acmecorp\nletmein1\n"); b.Len() != a.Len() || b.Digest() == a.Digest() {
+		t.Errorf("different lists of size %d share digest %q", a.Len(), a.Digest())
+	}
+	if b := load("acmecorp\t10\nhunter42\n"); b.Digest() == a.Digest() {
+		t.Error("a breach frequency should change the digest")
+	}
+}
+
+func TestCheckWith_PasswordSet(t *testing.T) {
+	set, _ := LoadPasswordList(strings.NewReader("acmecorp\n"))
+	opts := DefaultOptions()
+	opts.PasswordSet = set
+
+	assertContainsIssue(t, CheckWith("ACMECORP", opts), "common password lists")
+	assertContainsIssue(t, CheckWith("@cmec0rp", opts), "leetspeak variant")
+	if issues := CheckWith("acmecorp", DefaultOptions()); containsIssue(issues, "common password lists") {
+		t.Errorf("without PasswordSet, 'acmecorp' should not match: %v", issues)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
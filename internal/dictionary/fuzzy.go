@@ -0,0 +1,94 @@
+package dictionary
+
+import (
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// minFuzzyLen is the shortest password compared by fuzzy matching. Below
+// it, one edit away from some common password describes most strings.
+const minFuzzyLen = 6
+
+// commonPasswordsByLen buckets the built-in common passwords by rune
+// length, so a fuzzy lookup only compares entries within one rune of the
+// password's length.
+var commonPasswordsByLen = bucketByLen(commonPasswordsList)
+
+// bucketByLen groups passwords by their length in runes.
+func bucketByLen(passwords []string) map[int][]string {
+	buckets := make(map[int][]string)
+	for _, p := range passwords {
+		n := utf8.RuneCountInString(p)
+		buckets[n] = append(buckets[n], p)
+	}
+	return buckets
+}
+
+// checkNearCommon reports passwords one edit (an inserted, deleted, or
+// substituted character) away from a common password, such as
+// "passw0rd1" or "qwerrty". Exact and leetspeak matches, which are
+// reported as such, and passwords shorter than minFuzzyLen runes are
+// skipped.
+//
+// The built-in list and opts.CustomPasswords are compared; opts.PasswordSet
+// is not. Comparisons are not constant-time.
+func checkNearCommon(password, normalized string, opts Options) []issue.Issue {
+	n := utf8.RuneCountInString(password)
+	if n < minFuzzyLen || isCommonPasswordWith(password, opts) || isCommonPasswordWith(normalized, opts) {
+		return nil
+	}
+	candidates := []string{password}
+	if normalized != password {
+		candidates = append(candidates, normalized)
+	}
+	for _, c := range candidates {
+		if isNearCommon(c, opts.CustomPasswords) {
+			return []issue.Issue{
+				issue.New(issue.CodeDictNearCommon, "Password is one character away from a common password", issue.CategoryDictionary, issue.SeverityMed),
+			}
+		}
+	}
+	return nil
+}
+
+// isNearCommon reports whether password is within one edit of an entry
+// in the built-in set or the extra custom list.
+func isNearCommon(password string, custom []string) bool {
+	n := utf8.RuneCountInString(password)
+	for _, size := range []int{n - 1, n, n + 1} {
+		for _, p := range commonPasswordsByLen[size] {
+			if withinOneEdit(password, p) {
+				return true
+			}
+		}
+	}
+	for _, p := range custom {
+		if withinOneEdit(password, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinOneEdit reports whether a and b differ by at most one inserted,
+// deleted, or substituted rune, in time linear in their length.
+func withinOneEdit(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) > len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(rb)-len(ra) > 1 {
+		return false
+	}
+	// Skip the common prefix; the rest must match after one edit.
+	i := 0
+	for i < len(ra) && ra[i] == rb[i] {
+		i++
+	}
+	if len(ra) == len(rb) {
+		i++ // substitution
+		return i >= len(ra) || string(ra[i:]) == string(rb[i:])
+	}
+	return string(ra[i:]) == string(rb[i+1:]) // insertion into ra
+}
@@ -2,8 +2,25 @@ package dictionary
 
 import "github.com/rafaelsanzio/passcheck/internal/leet"
 
-// normalizeLeet delegates to the shared leet package.
-func normalizeLeet(s string) string { return leet.Normalize(s) }
+// normalizeLeet delegates to the shared leet package, including its
+// multi-character sequences.
+func normalizeLeet(s string) string { return leet.NormalizeMulti(s) }
+
+// leetOffsets returns the offsets mapping normalizeLeet(s) back onto s, or
+// nil when normalization leaves s unchanged. See [leet.NormalizeMultiOffsets].
+func leetOffsets(s string) []int {
+	_, offsets := leet.NormalizeMultiOffsets(s)
+	return offsets
+}
+
+// sourceSpan maps the rune span [start, end) of a leet-normalized string
+// onto the string it was normalized from, given its offsets.
+func sourceSpan(offsets []int, start, end int) (int, int) {
+	if offsets == nil {
+		return start, end
+	}
+	return offsets[start], offsets[end]
+}
 
 // containsLeet delegates to the shared leet package.
 func containsLeet(s string) bool { return leet.Contains(s) }
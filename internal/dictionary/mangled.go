@@ -0,0 +1,112 @@
+package dictionary
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// mangleSymbols are the symbols the canonical mangling rules append.
+const mangleSymbols = "!@#$%&*?."
+
+// maxMangleDigits is the most digits the canonical rules append ("1" to
+// "2024").
+const maxMangleDigits = 4
+
+// checkMangledCommon reports passwords built from a common password by
+// the canonical cracking rules (as in John the Ripper or Hashcat rule
+// sets): capitalizing the first letter, leetifying, appending one to
+// maxMangleDigits digits, and appending a common symbol, e.g. "Dragon2024!"
+// or "P@ssw0rd1". The issue quotes the base password.
+//
+// The rules are undone rather than applied, so each check makes a fixed,
+// small number of lookups: at most one symbol, up to maxMangleDigits
+// digits, and one more symbol are stripped from the end. Passwords that
+// are common as a whole, or as a leetspeak variant, are reported by the
+// exact-match checks instead.
+func checkMangledCommon(password, lower, normalized string, opts Options) []issue.Issue {
+	if isCommonPasswordWith(lower, opts) || isCommonPasswordWith(normalized, opts) {
+		return nil
+	}
+	base, ok := unmangle(password, opts)
+	if !ok {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(issue.CodeDictMangledCommon, fmt.Sprintf("Password is a common password with predictable changes: '%s'", base), issue.CategoryDictionary, issue.SeverityHigh),
+	}
+}
+
+// unmangle returns the common password that password was derived from by
+// the canonical rules, trying the shortest suffix first so the longest
+// base wins ("password1" in "password1!"). With opts.ConstantTime every
+// core is looked up, so the time taken does not reveal which one matched.
+func unmangle(password string, opts Options) (string, bool) {
+	base, found := "", false
+	for _, core := range mangleCores(password) {
+		if b, ok := commonBase(core, opts); ok && !found {
+			base, found = b, true
+			if !opts.ConstantTime {
+				break
+			}
+		}
+	}
+	return base, found
+}
+
+// mangleCores returns the prefixes of password left after stripping an
+// optional symbol, up to maxMangleDigits digits, and another optional
+// symbol from its end, shorter suffixes first. At most 20 are returned.
+func mangleCores(password string) []string {
+	var cores []string
+	for _, s := range []string{password, trimSymbol(password)} {
+		for range maxMangleDigits + 1 {
+			cores = append(cores, s, trimSymbol(s))
+			if s == "" || s[len(s)-1] < '0' || s[len(s)-1] > '9' {
+				break
+			}
+			s = s[:len(s)-1]
+		}
+	}
+	return cores
+}
+
+// commonBase reports the common password core is, once lowercased from a
+// capitalized first letter and optionally leet-normalized. A core in any
+// other mixed case is not the result of a canonical rule.
+func commonBase(core string, opts Options) (string, bool) {
+	if utf8.RuneCountInString(core) < DefaultMinWordLen {
+		return "", false
+	}
+	lower := strings.ToLower(core)
+	if lower != core && !isFirstLetterCapitalized(core) {
+		return "", false
+	}
+	common := isCommonPasswordWith(lower, opts)
+	if common && !opts.ConstantTime {
+		return lower, true
+	}
+	leet := false
+	normalized := lower
+	if !opts.DisableLeet {
+		normalized = normalizeLeet(lower)
+		leet = normalized != lower && isCommonPasswordWith(normalized, opts)
+	}
+	switch {
+	case common:
+		return lower, true
+	case leet:
+		return normalized, true
+	}
+	return "", false
+}
+
+// trimSymbol returns s without a trailing mangleSymbols character.
+func trimSymbol(s string) string {
+	if s != "" && strings.IndexByte(mangleSymbols, s[len(s)-1]) >= 0 {
+		return s[:len(s)-1]
+	}
+	return s
+}
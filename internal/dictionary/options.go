@@ -10,6 +10,17 @@ type Options struct {
 	// lowercase. Nil or empty means use only the built-in list.
 	CustomPasswords []string
 
+	// HotList is a list of currently trending (recently breached)
+	// passwords, kept separate from CustomPasswords so that matches are
+	// reported as DICT_TRENDING. Entries should be lowercase. Nil or empty
+	// disables the check.
+	HotList []string
+
+	// PasswordSet is an optional prebuilt set of passwords to check
+	// against, in addition to CustomPasswords. Lookups are O(1), making it
+	// the right choice for large blocklists. Nil means no extra set.
+	PasswordSet *PasswordSet
+
 	// CustomWords is an additional list of words to check for substring
 	// matches, merged with the built-in common word list. Entries should
 	// be lowercase. Nil or empty means use only the built-in list.
@@ -22,6 +33,25 @@ type Options struct {
 	// Default: false (leet normalization enabled).
 	DisableLeet bool
 
+	// DetectReversed enables detection of common words spelled backwards
+	// in the password (e.g. "nogard" for "dragon"). Default: false.
+	DetectReversed bool
+
+	// DetectSubstrings enables detection of passwords that are a strict
+	// substring of a longer common password (e.g. "assword12" from
+	// "password123"), for passwords of at least MinSubstringLen runes.
+	// Default: false.
+	DetectSubstrings bool
+	MinSubstringLen  int
+
+	// Fuzzy enables detection of passwords one edit away from a common
+	// password (e.g. "qwerrty"). Default: false.
+	Fuzzy bool
+
+	// Mangled enables detection of common passwords altered by the
+	// canonical cracking rules (e.g. "Dragon2024!"). Default: false.
+	Mangled bool
+
 	// ConstantTime, when true, uses constant-time string comparison and
 	// substring checks so that execution time does not leak whether the
 	// password matched a blocklist entry or where it matched. Slower than
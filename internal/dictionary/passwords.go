@@ -1,6 +1,10 @@
 package dictionary
 
-import "github.com/rafaelsanzio/passcheck/internal/safemem"
+import (
+	"strings"
+
+	"github.com/rafaelsanzio/passcheck/internal/safemem"
+)
 
 // commonPasswordsList is the canonical list of well-known weak passwords
 // compiled from public breach data (RockYou, LinkedIn, Adobe, etc.) and
@@ -271,6 +275,14 @@ func isCommonPassword(password string) bool {
 	return commonPasswords[password]
 }
 
+// IsPasswordToken reports whether word (must be lowercase) is a common
+// password that is not also a common English word, such as "qwerty",
+// "letmein", or "123456". Genuine passphrase words like "horse" appear on
+// both lists and are not password tokens.
+func IsPasswordToken(word string) bool {
+	return commonPasswords[word] && !isCommonWordWith(word, Options{})
+}
+
 // isCommonPasswordIn reports whether password appears in the built-in set
 // OR in the extra custom list. When constantTime is true, all comparisons
 // use constant-time equality so timing does not leak match position.
@@ -289,6 +301,49 @@ func isCommonPasswordIn(password string, custom []string, constantTime bool) boo
 	return false
 }
 
+// isCommonPasswordWith reports whether password appears in the built-in
+// set, opts.CustomPasswords, or opts.PasswordSet. The PasswordSet lookup
+// is a hash-map probe and is not constant-time even when opts.ConstantTime
+// is set.
+func isCommonPasswordWith(password string, opts Options) bool {
+	return isCommonPasswordIn(password, opts.CustomPasswords, opts.ConstantTime) ||
+		opts.PasswordSet.Contains(password)
+}
+
+// isHotPassword reports whether password appears in opts.HotList. When
+// opts.ConstantTime is set, every entry is compared in constant time.
+func isHotPassword(password string, opts Options) bool {
+	var found int
+	for _, p := range opts.HotList {
+		if opts.ConstantTime {
+			found |= safemem.ConstantTimeEqual(password, p)
+		} else if p == password {
+			return true
+		}
+	}
+	return found == 1
+}
+
+// isPasswordSubstring reports whether password is a strict substring of an
+// entry in the built-in set or the extra custom list. When constantTime is
+// true, every entry is searched in constant time.
+func isPasswordSubstring(password string, custom []string, constantTime bool) bool {
+	found := false
+	for _, list := range [][]string{commonPasswordsList, custom} {
+		for _, p := range list {
+			if len(p) <= len(password) {
+				continue
+			}
+			if constantTime {
+				found = safemem.ConstantTimeContains(p, password) || found
+			} else if strings.Contains(p, password) {
+				return true
+			}
+		}
+	}
+	return found
+}
+
 // isCommonPasswordInConstantTime does a linear scan with constant-time compare.
 func isCommonPasswordInConstantTime(password string, custom []string) bool {
 	var found int
@@ -0,0 +1,179 @@
+package dictionary
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PasswordSet is an immutable set of lowercase passwords with O(1)
+// membership tests. Build it once with [LoadPasswordList] and share it
+// across checks; it is safe for concurrent use.
+type PasswordSet struct {
+	set map[string]bool
+
+	// freq holds the breach frequency of entries loaded with one; maxFreq
+	// is the largest value in freq.
+	freq    map[string]uint64
+	maxFreq uint64
+
+	// digest is the hex SHA-256 of the entries in load order.
+	digest string
+}
+
+// LoadPasswordList reads newline-delimited passwords from r and returns
+// them as a [PasswordSet]. Each line is stripped of trailing whitespace
+// (including "\r") and lowercased. Blank lines and lines starting with
+// "#" are skipped.
+//
+// A line may carry a breach frequency after a tab ("123456\t23547453");
+// the text after the last tab must be a non-negative integer, otherwise
+// the whole line is the password. A password listed more than once keeps
+// its highest frequency. See [PasswordSet.Frequency].
+func LoadPasswordList(r io.Reader) (*PasswordSet, error) {
+	s := &PasswordSet{set: make(map[string]bool)}
+	h := sha256.New()
+	err := scanList(r, func(entry string) {
+		h.Write([]byte(entry))
+		h.Write([]byte{'\n'})
+		pw, freq := splitFrequency(entry)
+		s.set[pw] = true
+		if freq > s.freq[pw] {
+			if s.freq == nil {
+				s.freq = make(map[string]uint64)
+			}
+			s.freq[pw] = freq
+			s.maxFreq = max(s.maxFreq, freq)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.digest = hex.EncodeToString(h.Sum(nil))
+	return s, nil
+}
+
+// splitFrequency splits a "password\tfrequency" entry. It returns the
+// entry unchanged and a zero frequency when there is no valid frequency.
+func splitFrequency(entry string) (string, uint64) {
+	i := strings.LastIndexByte(entry, '\t')
+	if i <= 0 {
+		return entry, 0
+	}
+	freq, err := strconv.ParseUint(entry[i+1:], 10, 64)
+	if err != nil {
+		return entry, 0
+	}
+	return entry[:i], freq
+}
+
+// LoadPasswordListGzip is like [LoadPasswordList] but reads a
+// gzip-compressed list. It returns an error if r is not a valid gzip stream.
+func LoadPasswordListGzip(r io.Reader) (*PasswordSet, error) {
+	zr, err := newGzipReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return LoadPasswordList(zr)
+}
+
+// LoadWordList reads newline-delimited words from r, applying the same
+// line rules as [LoadPasswordList], and returns them lowercased in file
+// order. The result is suitable for Options.CustomWords.
+func LoadWordList(r io.Reader) ([]string, error) {
+	var words []string
+	err := scanList(r, func(entry string) {
+		words = append(words, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// LoadWordListGzip is like [LoadWordList] but reads a gzip-compressed
+// list. It returns an error if r is not a valid gzip stream.
+func LoadWordListGzip(r io.Reader) ([]string, error) {
+	zr, err := newGzipReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return LoadWordList(zr)
+}
+
+// newGzipReader wraps r in a gzip reader, annotating header errors.
+func newGzipReader(r io.Reader) (*gzip.Reader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary: invalid gzip stream: %w", err)
+	}
+	return zr, nil
+}
+
+// scanList calls add for every entry in a newline-delimited list. Lines
+// are stripped of trailing whitespace and lowercased; blank lines and
+// lines starting with "#" are skipped.
+func scanList(r io.Reader, add func(entry string)) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRightFunc(sc.Text(), unicode.IsSpace)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		add(strings.ToLower(line))
+	}
+	return sc.Err()
+}
+
+// Contains reports whether password (must be lowercase) is in the set.
+// A nil set contains nothing.
+func (s *PasswordSet) Contains(password string) bool {
+	if s == nil {
+		return false
+	}
+	return s.set[password]
+}
+
+// Frequency returns the breach frequency of password (must be lowercase)
+// normalized to (0, 1] on a logarithmic scale, where 1 is the most
+// frequent entry in the set. It returns 0 when password is not in the
+// set, was loaded without a frequency, or s is nil.
+func (s *PasswordSet) Frequency(password string) float64 {
+	if s == nil {
+		return 0
+	}
+	f := s.freq[password]
+	if f == 0 {
+		return 0
+	}
+	return math.Log1p(float64(f)) / math.Log1p(float64(s.maxFreq))
+}
+
+// Len returns the number of distinct passwords in the set.
+func (s *PasswordSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.set)
+}
+
+// Digest returns a hex SHA-256 digest of the entries the set was loaded
+// from, after line normalization, so sets loaded from the same list share
+// a digest and sets loaded from different lists almost surely do not.
+// Lists with the same entries in a different order get different digests.
+// It returns "" for a nil set.
+func (s *PasswordSet) Digest() string {
+	if s == nil {
+		return ""
+	}
+	return s.digest
+}
@@ -25,9 +25,15 @@ import (
 //
 //   - sequenceSpace: 36 possible starting characters (26 alpha + 10 digit)
 //     × 2 directions (ascending / descending) × 2 step sizes (±1, ±2) = 144.
+//
+//   - walkStartKeys, walkBranching: a zig-zag walk (PATTERN_ADJACENT_WALK)
+//     has no fixed direction, so after one of ~36 starting keys each step
+//     chooses among the ~6 neighbors of the previous key.
 const (
 	keyboardWalkSpace = 150.0
 	sequenceSpace     = 144.0
+	walkStartKeys     = 36.0
+	walkBranching     = 6.0
 )
 
 // CalculateAdvanced calculates entropy using a segment-based model.
@@ -39,7 +45,8 @@ const (
 //     (see intrinsicPatternEntropy).
 //
 //  2. Free characters (not covered by any detected pattern): contribute the
-//     standard character-pool entropy (bits = count × log2(poolSize)).
+//     standard character-pool entropy (bits = count × log2(poolSize)), with
+//     each emoji grapheme credited log2(PoolEmoji) bits instead.
 //
 // Repeated-block patterns are counted once regardless of how many times the
 // block repeats in the password; all repetitions are marked as covered but add
@@ -48,6 +55,11 @@ const (
 // Issues whose Pattern field is empty are silently ignored (e.g. issues from
 // rule or dictionary checkers that are unrelated to structural patterns).
 func CalculateAdvanced(password string, patternIssues []issue.Issue) float64 {
+	return calculateAdvanced(password, patternIssues, Options{})
+}
+
+// calculateAdvanced implements CalculateAdvanced under opts.
+func calculateAdvanced(password string, patternIssues []issue.Issue, opts Options) float64 {
 	runes := []rune(password)
 	n := len(runes)
 	if n == 0 {
@@ -55,8 +67,12 @@ func CalculateAdvanced(password string, patternIssues []issue.Issue) float64 {
 	}
 
 	info, _ := AnalyzeCharsets(password)
-	pool := info.PoolSize()
-	if pool == 0 {
+	pool := info.poolSizeWith(opts)
+	var spans [][2]int
+	if info.HasEmoji {
+		spans = emojiSpans(password)
+	}
+	if pool == 0 && len(spans) == 0 {
 		return 0
 	}
 
@@ -125,7 +141,20 @@ func CalculateAdvanced(password string, patternIssues []issue.Issue) float64 {
 		}
 	}
 
-	// Count characters not covered by any pattern.
+	// Count emoji graphemes and other characters not covered by any
+	// pattern. Each free emoji is credited once, however many code points
+	// it spans.
+	freeEmoji := 0
+	for _, s := range spans {
+		free := true
+		for i := s[0]; i < s[1]; i++ {
+			free = free && !covered[i]
+			covered[i] = true
+		}
+		if free {
+			freeEmoji++
+		}
+	}
 	freeCount := 0
 	for _, c := range covered {
 		if !c {
@@ -133,7 +162,10 @@ func CalculateAdvanced(password string, patternIssues []issue.Issue) float64 {
 		}
 	}
 
-	freeEntropy := float64(freeCount) * math.Log2(float64(pool))
+	freeEntropy := emojiBits(freeEmoji, opts)
+	if pool > 0 {
+		freeEntropy += float64(freeCount) * math.Log2(float64(pool))
+	}
 	total := freeEntropy + patternEntropy
 	if total < 0 {
 		return 0
@@ -163,6 +195,10 @@ func intrinsicPatternEntropy(code, pattern string) float64 {
 	case issue.CodePatternSequence:
 		return math.Log2(sequenceSpace)
 
+	case issue.CodePatternAdjacentWalk:
+		steps := len([]rune(pattern)) - 1
+		return math.Log2(walkStartKeys) + float64(steps)*math.Log2(walkBranching)
+
 	case issue.CodePatternBlock:
 		// Only one copy of the block is secret; the repetitions are free.
 		blockInfo, blockLen := AnalyzeCharsets(pattern)
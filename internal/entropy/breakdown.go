@@ -0,0 +1,61 @@
+// Package entropy implements password entropy calculation.
+//
+// This file provides an itemized view of how each calculation stage
+// contributes to the final entropy estimate.
+package entropy
+
+import (
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// Breakdown itemizes an entropy estimate by calculation stage. All values
+// are in bits and satisfy
+//
+//	Final = BaseCharset − PatternReduction + MarkovAdjustment
+type Breakdown struct {
+	// BaseCharset is the character-pool entropy (length × log2(pool)).
+	BaseCharset float64
+
+	// PatternReduction is the entropy removed by the segment-based pattern
+	// model. Zero in simple mode.
+	PatternReduction float64
+
+	// MarkovAdjustment is the entropy added (positive) or removed
+	// (negative) by Markov transition analysis. Non-zero only in
+	// pattern-aware mode.
+	MarkovAdjustment float64
+
+	// Final is the resulting entropy estimate, equal to the value returned
+	// by [CalculateWithMode] for the same inputs.
+	Final float64
+}
+
+// CalculateBreakdown computes entropy using the specified mode and returns
+// the contribution of each stage. If mode is empty or invalid, falls back
+// to simple mode.
+func CalculateBreakdown(password, mode string, patternIssues []issue.Issue) Breakdown {
+	return CalculateBreakdownWith(password, mode, patternIssues, Options{})
+}
+
+// CalculateBreakdownWith is like [CalculateBreakdown] but applies opts to
+// every stage.
+func CalculateBreakdownWith(password, mode string, patternIssues []issue.Issue, opts Options) Breakdown {
+	base := calculate(password, opts)
+	b := Breakdown{BaseCharset: base, Final: base}
+
+	switch Mode(mode) {
+	case ModeAdvanced, ModePatternAware:
+	default:
+		return b
+	}
+
+	advanced := calculateAdvanced(password, patternIssues, opts)
+	b.PatternReduction = base - advanced
+	b.Final = advanced
+	if Mode(mode) == ModePatternAware {
+		final := calculatePatternAware(password, patternIssues, opts)
+		b.MarkovAdjustment = final - advanced
+		b.Final = final
+	}
+	return b
+}
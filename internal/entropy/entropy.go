@@ -6,15 +6,18 @@
 //
 // Entropy is calculated as:
 //
-//	bits = runeCount × log2(poolSize)
+//	bits = runeCount × log2(poolSize) + emojiCount × log2(PoolEmoji)
 //
 // where poolSize is the total number of possible characters based on
-// which character sets (lowercase, uppercase, digits, symbols) are present.
+// which character sets (lowercase, uppercase, digits, symbols, and CJK
+// characters) are present, runeCount excludes the code points of emoji,
+// and emojiCount is the number of emoji graphemes.
 package entropy
 
 import (
 	"math"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Character pool sizes for each set.
@@ -23,14 +26,65 @@ const (
 	PoolUpper  = 26
 	PoolDigit  = 10
 	PoolSymbol = 32
+
+	// PoolWhitespace is the pool contribution of whitespace, counted only
+	// when Options.CountWhitespace is set. Passphrase separators are
+	// almost always a single space.
+	PoolWhitespace = 1
+
+	// PoolEmoji is the default number of emoji an attacker chooses from,
+	// roughly the number of single code point emoji. Each emoji grapheme
+	// adds log2(PoolEmoji) bits; emoji do not enlarge the pool of the
+	// other characters.
+	PoolEmoji = 1400
+
+	// PoolCJK is the pool contribution of CJK ideographs, kana, and
+	// Hangul, roughly the number of characters in common use.
+	PoolCJK = 3000
 )
 
+// Options configures optional entropy calculation behavior. The zero
+// value matches the package-level functions.
+type Options struct {
+	// CountWhitespace adds PoolWhitespace to the character pool when the
+	// password contains whitespace, for policies that allow spaces.
+	CountWhitespace bool
+
+	// Markov, when non-nil, replaces the built-in transition heuristics
+	// in pattern-aware mode with probabilities learned from a corpus.
+	Markov *MarkovModel
+
+	// EmojiPool, when positive, replaces PoolEmoji as the number of emoji
+	// credited per emoji grapheme.
+	EmojiPool int
+}
+
+// emojiPool returns the number of emoji credited per emoji grapheme.
+func (o Options) emojiPool() int {
+	if o.EmojiPool > 0 {
+		return o.EmojiPool
+	}
+	return PoolEmoji
+}
+
 // CharsetInfo holds the results of a single-pass character set analysis.
 type CharsetInfo struct {
 	HasLower  bool // at least one lowercase letter
 	HasUpper  bool // at least one uppercase letter
 	HasDigit  bool // at least one digit
 	HasSymbol bool // at least one symbol / punctuation
+
+	// HasEmoji and HasCJK record at least one emoji and at least one CJK
+	// ideograph, kana, or Hangul character. Neither has case, so both
+	// count toward the symbol type in SetCount and [CharsetInfo.AnySymbol].
+	// CJK adds its own, much larger pool to PoolSize; emoji are credited
+	// per grapheme instead (see PoolEmoji).
+	HasEmoji bool
+	HasCJK   bool
+
+	// HasWhitespace records at least one whitespace character. It is not a
+	// character set type and is left out of SetCount and PoolSize.
+	HasWhitespace bool
 }
 
 // SetCount returns how many of the four character set types are present.
@@ -45,14 +99,20 @@ func (c CharsetInfo) SetCount() int {
 	if c.HasDigit {
 		n++
 	}
-	if c.HasSymbol {
+	if c.AnySymbol() {
 		n++
 	}
 	return n
 }
 
+// AnySymbol reports whether the symbol type is present: a symbol, an
+// emoji, or a CJK character.
+func (c CharsetInfo) AnySymbol() bool {
+	return c.HasSymbol || c.HasEmoji || c.HasCJK
+}
+
 // PoolSize returns the total number of possible characters based on
-// which sets are present.
+// which sets are present. Emoji are not part of the pool.
 func (c CharsetInfo) PoolSize() int {
 	size := 0
 	if c.HasLower {
@@ -67,32 +127,72 @@ func (c CharsetInfo) PoolSize() int {
 	if c.HasSymbol {
 		size += PoolSymbol
 	}
+	if c.HasCJK {
+		size += PoolCJK
+	}
+	return size
+}
+
+// poolSizeWith returns PoolSize adjusted for opts: PoolWhitespace is added
+// when opts counts whitespace and the password contains some.
+func (c CharsetInfo) poolSizeWith(opts Options) int {
+	size := c.PoolSize()
+	if opts.CountWhitespace && c.HasWhitespace {
+		size += PoolWhitespace
+	}
 	return size
 }
 
 // Calculate estimates the entropy of a password in bits.
 //
 // Length is measured in Unicode code points (runes), not bytes, so
-// multi-byte characters are counted correctly.
+// multi-byte characters are counted correctly. Each emoji grapheme, such
+// as "👍🏽" or a family joined with U+200D, counts once with its own pool.
 func Calculate(password string) float64 {
+	return calculate(password, Options{})
+}
+
+// calculate implements Calculate under opts.
+func calculate(password string, opts Options) float64 {
 	info, count := AnalyzeCharsets(password)
 	if count == 0 {
 		return 0
 	}
 
-	poolSize := info.PoolSize()
-	if poolSize == 0 {
-		return 0
+	var spans [][2]int
+	if info.HasEmoji {
+		spans = emojiSpans(password)
+	}
+	bits := emojiBits(len(spans), opts)
+	for _, s := range spans {
+		count -= s[1] - s[0]
+	}
+	if poolSize := info.poolSizeWith(opts); poolSize > 0 {
+		bits += float64(count) * math.Log2(float64(poolSize))
 	}
+	return bits
+}
 
-	return float64(count) * math.Log2(float64(poolSize))
+// emojiBits returns the entropy of n emoji graphemes under opts.
+func emojiBits(n int, opts Options) float64 {
+	return float64(n) * math.Log2(float64(opts.emojiPool()))
 }
 
 // AnalyzeCharsets performs a single pass over the password to determine
 // which character set types are present and counts the number of runes.
 // Uses the unicode package for correct handling of non-ASCII letters and digits.
+// The code points of an emoji grapheme, including skin tones, joiners,
+// and selectors, count toward HasEmoji only.
 func AnalyzeCharsets(password string) (info CharsetInfo, runeCount int) {
-	for _, r := range password {
+	for i := 0; i < len(password); {
+		if n := emojiPrefix(password[i:]); n > 0 {
+			info.HasEmoji = true
+			runeCount += utf8.RuneCountInString(password[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(password[i:])
+		i += size
 		runeCount++
 		switch {
 		case unicode.IsLower(r):
@@ -101,10 +201,152 @@ func AnalyzeCharsets(password string) (info CharsetInfo, runeCount int) {
 			info.HasUpper = true
 		case unicode.IsDigit(r):
 			info.HasDigit = true
-		case !unicode.IsSpace(r) && !unicode.IsControl(r):
+		case unicode.IsSpace(r):
+			info.HasWhitespace = true
+		case isCJK(r):
+			info.HasCJK = true
+		case !unicode.IsControl(r):
 			info.HasSymbol = true
 		}
 	}
 	return info, runeCount
 }
 
+// isCJK reports whether r is a CJK ideograph, kana, or Hangul character,
+// including the prolonged sound mark "ー" that kana words share.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) || r == 'ー'
+}
+
+// Code points that join or modify emoji into a single grapheme.
+const (
+	zeroWidthJoiner = '\u200D'
+	emojiPresenter  = '\uFE0F' // VARIATION SELECTOR-16
+	combiningKeycap = '\u20E3'
+	regionalIndicLo = 0x1F1E6
+	regionalIndicHi = 0x1F1FF
+	skinToneLo      = 0x1F3FB
+	skinToneHi      = 0x1F3FF
+	tagLo, tagHi    = 0xE0020, 0xE007F
+)
+
+// emojiPrefix returns the length in bytes of the emoji grapheme at the
+// start of s, or 0 if s does not start with one. A grapheme is an emoji,
+// or a symbol such as "❤" that renders as text by default followed by
+// U+FE0F, extended by skin tones, selectors, keycaps, tags, and further
+// emoji joined with U+200D. Two regional indicators form one flag. The
+// segmentation is a simplification of UAX #29 that is exact for the
+// sequences people type.
+func emojiPrefix(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	switch {
+	case isRegionalIndicator(r):
+		if next, size := utf8.DecodeRuneInString(s[n:]); isRegionalIndicator(next) {
+			n += size
+		}
+		return n
+	case unicode.Is(emojiTable, r):
+	default:
+		if next, _ := utf8.DecodeRuneInString(s[n:]); next != emojiPresenter || r == utf8.RuneError || unicode.IsLetter(r) || unicode.IsSpace(r) {
+			return 0
+		}
+	}
+	for n < len(s) {
+		next, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case next == emojiPresenter, next == combiningKeycap,
+			next >= skinToneLo && next <= skinToneHi, next >= tagLo && next <= tagHi:
+			n += size
+		case next == zeroWidthJoiner && joinsEmoji(s[n+size:]):
+			_, joined := utf8.DecodeRuneInString(s[n+size:])
+			n += size + joined
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// joinsEmoji reports whether s starts with a code point that U+200D can
+// join to an emoji: anything but a letter, digit, or space.
+func joinsEmoji(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return s != "" && r != utf8.RuneError && !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+}
+
+// isRegionalIndicator reports whether r is one of the letters that pair
+// into flag emoji.
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicLo && r <= regionalIndicHi
+}
+
+// emojiSpans returns the rune index range [start, end) of each emoji
+// grapheme in password.
+func emojiSpans(password string) [][2]int {
+	var spans [][2]int
+	runeIdx := 0
+	for i := 0; i < len(password); {
+		if n := emojiPrefix(password[i:]); n > 0 {
+			runes := utf8.RuneCountInString(password[i : i+n])
+			spans = append(spans, [2]int{runeIdx, runeIdx + runes})
+			runeIdx += runes
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(password[i:])
+		i += size
+		runeIdx++
+	}
+	return spans
+}
+
+// emojiTable lists the code points with default emoji presentation.
+// Joiners, selectors, and skin tones only extend a grapheme (see
+// emojiPrefix).
+var emojiTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23EC, Stride: 1},
+		{Lo: 0x23F0, Hi: 0x23F3, Stride: 3},
+		{Lo: 0x25FD, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267F, Hi: 0x267F, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26A1, Hi: 0x26A1, Stride: 1},
+		{Lo: 0x26AA, Hi: 0x26AB, Stride: 1},
+		{Lo: 0x26BD, Hi: 0x26BE, Stride: 1},
+		{Lo: 0x26C4, Hi: 0x26C5, Stride: 1},
+		{Lo: 0x26CE, Hi: 0x26CE, Stride: 1},
+		{Lo: 0x26D4, Hi: 0x26D4, Stride: 1},
+		{Lo: 0x26EA, Hi: 0x26EA, Stride: 1},
+		{Lo: 0x26F2, Hi: 0x26F3, Stride: 1},
+		{Lo: 0x26F5, Hi: 0x26F5, Stride: 1},
+		{Lo: 0x26FA, Hi: 0x26FA, Stride: 1},
+		{Lo: 0x26FD, Hi: 0x26FD, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270A, Hi: 0x270B, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274E, Stride: 2},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B55, Stride: 5},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F004, Hi: 0x1F004, Stride: 1},
+		{Lo: 0x1F0CF, Hi: 0x1F0CF, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F201, Hi: 0x1F201, Stride: 1},
+		{Lo: 0x1F21A, Hi: 0x1F21A, Stride: 1},
+		{Lo: 0x1F22F, Hi: 0x1F22F, Stride: 1},
+		{Lo: 0x1F232, Hi: 0x1F236, Stride: 1},
+		{Lo: 0x1F238, Hi: 0x1F23A, Stride: 1},
+		{Lo: 0x1F250, Hi: 0x1F251, Stride: 1},
+		{Lo: 0x1F300, Hi: 0x1FAFF, Stride: 1}, // pictographs, emoticons, transport
+	},
+}
@@ -72,6 +72,23 @@ func TestCalculate_OnlySpaces(t *testing.T) {
 	}
 }
 
+func TestCalculateBreakdownWith_CountWhitespace(t *testing.T) {
+	opts := Options{CountWhitespace: true}
+	got := CalculateBreakdownWith("correct horse", string(ModeSimple), nil, opts)
+	assertClose(t, 13*math.Log2(PoolLower+PoolWhitespace), got.Final, 0.01)
+
+	// Without whitespace the option changes nothing.
+	assertClose(t, Calculate("correcthorse"), CalculateBreakdownWith("correcthorse", string(ModeSimple), nil, opts).Final, 0.001)
+
+	for _, mode := range []Mode{ModeSimple, ModeAdvanced, ModePatternAware} {
+		plain := CalculateBreakdown("correct horse", string(mode), nil)
+		counted := CalculateBreakdownWith("correct horse", string(mode), nil, opts)
+		if counted.Final <= plain.Final {
+			t.Errorf("%s: CountWhitespace entropy %.2f should exceed %.2f", mode, counted.Final, plain.Final)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // AnalyzeCharsets
 // ---------------------------------------------------------------------------
@@ -93,6 +110,12 @@ func TestAnalyzeCharsets(t *testing.T) {
 		{"unicode lower", "ñéü", 26},
 		{"unicode upper", "ÑÉÜ", 26},
 		{"unicode digit", "٣٤٥", 10}, // Arabic-Indic digits
+		{"emoji", "🔒🔑✨🎉", 0},         // credited per grapheme, not pooled
+		{"emoji sequence", "👍🏽❤️", 0},
+		{"cjk", "密码パスワード", PoolCJK},
+		{"emoji + cjk", "🔒🔑✨🎉密码", PoolCJK},
+		{"text symbol", "★", PoolSymbol}, // renders as text without U+FE0F
+		{"lone joiner", "a\u200Db", PoolLower + PoolSymbol},
 	}
 
 	for _, tt := range tests {
@@ -120,6 +143,8 @@ func TestCharsetInfo_SetCount(t *testing.T) {
 		{CharsetInfo{HasLower: true, HasUpper: true}, 2},
 		{CharsetInfo{HasLower: true, HasUpper: true, HasDigit: true}, 3},
 		{CharsetInfo{HasLower: true, HasUpper: true, HasDigit: true, HasSymbol: true}, 4},
+		{CharsetInfo{HasLower: true, HasEmoji: true}, 2},
+		{CharsetInfo{HasLower: true, HasSymbol: true, HasEmoji: true, HasCJK: true}, 2},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +173,47 @@ func TestCharsetInfo_PoolSize(t *testing.T) {
 	}
 }
 
+func TestCalculate_EmojiGraphemes(t *testing.T) {
+	emoji := math.Log2(PoolEmoji)
+	tests := []struct {
+		name     string
+		password string
+		expected float64
+	}{
+		{"single emoji", "👍", emoji},
+		{"skin tone", "👍🏽", emoji},
+		{"family", "👨\u200D👩\u200D👧\u200D👦", emoji},
+		{"selector", "❤️", emoji},
+		{"flag", "🇧🇷", emoji},
+		{"keycap", "1️⃣", emoji},
+		{"appended", "Password1!👍", Calculate("Password1!") + emoji},
+		{"family appended", "Password1!👨\u200D👩\u200D👧\u200D👦", Calculate("Password1!") + emoji},
+		{"four emoji", "🔒🔑✨🎉", 4 * emoji},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertClose(t, tt.expected, Calculate(tt.password), 0.001)
+		})
+	}
+}
+
+func TestCalculateAdvanced_EmojiGraphemes(t *testing.T) {
+	// Pattern-free emoji are credited the same as in simple mode.
+	for _, pw := range []string{"👨\u200D👩\u200D👧\u200D👦", "Xk9👍🏽mP2"} {
+		assertClose(t, Calculate(pw), CalculateAdvanced(pw, nil), 0.001)
+	}
+}
+
+func TestCalculateBreakdownWith_EmojiPool(t *testing.T) {
+	pw := "🔒🔑✨🎉密码"
+	assertClose(t, 4*math.Log2(PoolEmoji)+2*math.Log2(PoolCJK), Calculate(pw), 0.001)
+	got := CalculateBreakdownWith(pw, string(ModeSimple), nil, Options{EmojiPool: 100})
+	assertClose(t, 4*math.Log2(100)+2*math.Log2(PoolCJK), got.Final, 0.001)
+
+	// Without emoji the option changes nothing.
+	assertClose(t, Calculate("密码"), CalculateBreakdownWith("密码", string(ModeSimple), nil, Options{EmojiPool: 100}).Final, 0.001)
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
@@ -14,14 +14,19 @@ import (
 // CalculatePatternAware calculates entropy using pattern-aware adjustments
 // plus Markov-chain analysis for character transition probabilities.
 func CalculatePatternAware(password string, patternIssues []issue.Issue) float64 {
+	return calculatePatternAware(password, patternIssues, Options{})
+}
+
+// calculatePatternAware implements CalculatePatternAware under opts.
+func calculatePatternAware(password string, patternIssues []issue.Issue, opts Options) float64 {
 	// Start with advanced pattern-aware entropy
-	patternEntropy := CalculateAdvanced(password, patternIssues)
+	patternEntropy := calculateAdvanced(password, patternIssues, opts)
 	if patternEntropy == 0 {
 		return 0
 	}
 
 	// Apply Markov-chain adjustment
-	markovAdjustment := calculateMarkovAdjustment(password)
+	markovAdjustment := calculateMarkovAdjustmentWith(password, opts.Markov)
 
 	// Combine: pattern entropy adjusted by Markov analysis
 	// Markov adjustment is multiplicative (0.5 to 1.5 range)
@@ -40,16 +45,24 @@ func CalculatePatternAware(password string, patternIssues []issue.Issue) float64
 // based on character transition probabilities. Returns a value between
 // 0.5 (very predictable transitions) and 1.5 (very unpredictable transitions).
 func calculateMarkovAdjustment(password string) float64 {
+	return calculateMarkovAdjustmentWith(password, nil)
+}
+
+// calculateMarkovAdjustmentWith is like calculateMarkovAdjustment but
+// scores transitions with model when it is non-nil.
+func calculateMarkovAdjustmentWith(password string, model *MarkovModel) float64 {
 	runes := []rune(password)
 	if len(runes) < 2 {
 		return 1.0 // No transitions to analyze
 	}
 
-	// Analyze character transitions
-	transitions := analyzeTransitions(runes)
-
 	// Calculate predictability score (0.0 = very predictable, 1.0 = very unpredictable)
-	predictability := calculatePredictability(transitions)
+	var predictability float64
+	if model != nil {
+		predictability = model.predictability(runes)
+	} else {
+		predictability = calculatePredictability(analyzeTransitions(runes))
+	}
 
 	// Convert predictability to adjustment factor
 	// Low predictability (predictable) → lower adjustment (0.5-1.0)
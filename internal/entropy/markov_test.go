@@ -1,6 +1,8 @@
 package entropy
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
@@ -118,3 +120,65 @@ func TestCalculatePredictability(t *testing.T) {
 		t.Errorf("predictability out of range: %.2f", predMixed)
 	}
 }
+
+func TestLoadMarkovModel(t *testing.T) {
+	corpus := "# keyboard walks\nqwerty\nqwertyuiop\r\nQWERTY123\n\nasdfgh\nqwert\n"
+	model, err := LoadMarkovModel(strings.NewReader(corpus))
+	if err != nil {
+		t.Fatalf("LoadMarkovModel: %v", err)
+	}
+	// qwerty(5) + qwertyuiop(9) + qwerty123(8) + asdfgh(5) + qwert(4)
+	if model.Len() != 31 {
+		t.Errorf("Len() = %d, want 31", model.Len())
+	}
+
+	similar := model.predictability([]rune("qwertyu"))
+	unlike := model.predictability([]rune("zmxk7vbn"))
+	if similar >= unlike {
+		t.Errorf("predictability(similar) = %.2f should be below predictability(unlike) = %.2f", similar, unlike)
+	}
+	if unlike > 1 || similar < 0 {
+		t.Errorf("predictability out of range: %.2f, %.2f", similar, unlike)
+	}
+}
+
+func TestLoadMarkovModel_Empty(t *testing.T) {
+	for _, corpus := range []string{"", "\n\n", "# only a comment\na\nb\n"} {
+		if _, err := LoadMarkovModel(strings.NewReader(corpus)); !errors.Is(err, ErrEmptyCorpus) {
+			t.Errorf("LoadMarkovModel(%q) err = %v, want ErrEmptyCorpus", corpus, err)
+		}
+	}
+	var nilModel *MarkovModel
+	if nilModel.Len() != 0 || nilModel.Digest() != "" {
+		t.Error("nil model should be empty")
+	}
+}
+
+func TestMarkovModel_Digest(t *testing.T) {
+	a, _ := LoadMarkovModel(strings.NewReader("qwerty\n"))
+	b, _ := LoadMarkovModel(strings.NewReader("# walks\nQWERTY  \n"))
+	c, _ := LoadMarkovModel(strings.NewReader("zxcvbn\n"))
+	if a.Digest() != b.Digest() {
+		t.Errorf("equal corpora: digests %q and %q differ", a.Digest(), b.Digest())
+	}
+	if a.Len() != c.Len() || a.Digest() == c.Digest() {
+		t.Errorf("different corpora of %d transitions share digest %q", a.Len(), a.Digest())
+	}
+}
+
+func TestCalculateBreakdownWith_MarkovModel(t *testing.T) {
+	model, err := LoadMarkovModel(strings.NewReader("qwerty\nqwertyuiop\nqwerty123\nqwer1234\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	builtin := CalculateBreakdown("wertyuio", string(ModePatternAware), nil)
+	trained := CalculateBreakdownWith("wertyuio", string(ModePatternAware), nil, Options{Markov: model})
+	if trained.Final >= builtin.Final {
+		t.Errorf("trained model entropy %.2f should be below built-in %.2f", trained.Final, builtin.Final)
+	}
+	// Other modes ignore the model.
+	simple := CalculateBreakdownWith("wertyuio", string(ModeSimple), nil, Options{Markov: model})
+	if simple.Final != Calculate("wertyuio") {
+		t.Errorf("simple mode should ignore the model: got %.2f", simple.Final)
+	}
+}
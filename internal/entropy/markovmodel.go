@@ -0,0 +1,122 @@
+package entropy
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// markovMinAlphabet is the smallest alphabet assumed when smoothing
+// transition probabilities: the 95 printable ASCII characters. Without a
+// floor, a corpus over a few characters would make unseen transitions
+// look cheap.
+const markovMinAlphabet = 95
+
+// markovSmoothing is the pseudo-count added to every transition. A value
+// well below one keeps a small corpus from being swamped by the unseen
+// transitions of a large alphabet.
+const markovSmoothing = 0.1
+
+// ErrEmptyCorpus is returned by [LoadMarkovModel] when the corpus holds no
+// character transitions.
+var ErrEmptyCorpus = errors.New("entropy: Markov corpus has no character transitions")
+
+// MarkovModel holds character-bigram transition counts learned from a
+// password corpus. Build it once with [LoadMarkovModel] and share it
+// across checks; it is immutable and safe for concurrent use.
+type MarkovModel struct {
+	counts   map[rune]map[rune]int
+	totals   map[rune]int
+	alphabet float64 // smoothing alphabet size
+	n        int     // transitions counted
+	digest   string  // hex SHA-256 of the corpus lines in load order
+}
+
+// LoadMarkovModel reads a newline-delimited password corpus from r and
+// counts the transitions between consecutive characters of each line.
+// Lines are lowercased and stripped of trailing whitespace (including
+// "\r"); blank lines and lines starting with "#" are skipped. It returns
+// [ErrEmptyCorpus] if no line has two or more characters.
+func LoadMarkovModel(r io.Reader) (*MarkovModel, error) {
+	m := &MarkovModel{
+		counts: make(map[rune]map[rune]int),
+		totals: make(map[rune]int),
+	}
+	seen := make(map[rune]bool)
+	h := sha256.New()
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.ToLower(strings.TrimRightFunc(sc.Text(), unicode.IsSpace))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		h.Write([]byte(line))
+		h.Write([]byte{'\n'})
+		var prev rune
+		for i, c := range line {
+			seen[c] = true
+			if i > 0 {
+				if m.counts[prev] == nil {
+					m.counts[prev] = make(map[rune]int)
+				}
+				m.counts[prev][c]++
+				m.totals[prev]++
+				m.n++
+			}
+			prev = c
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("entropy: reading Markov corpus: %w", err)
+	}
+	if m.n == 0 {
+		return nil, ErrEmptyCorpus
+	}
+	m.alphabet = float64(max(len(seen), markovMinAlphabet))
+	m.digest = hex.EncodeToString(h.Sum(nil))
+	return m, nil
+}
+
+// Len returns the number of transitions the model was trained on, or 0
+// for a nil model.
+func (m *MarkovModel) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.n
+}
+
+// Digest returns a hex SHA-256 digest of the corpus lines the model was
+// trained on, after line normalization, so models trained on different
+// corpora almost surely differ. It returns "" for a nil model.
+func (m *MarkovModel) Digest() string {
+	if m == nil {
+		return ""
+	}
+	return m.digest
+}
+
+// predictability returns the mean surprisal of the password's transitions
+// under the model, normalized to [0.0, 1.0] by the surprisal of a uniform
+// choice over the alphabet: 0.0 means every transition is certain, 1.0
+// means the transitions are as unlikely as random characters. Transition
+// probabilities use additive smoothing with markovSmoothing.
+func (m *MarkovModel) predictability(runes []rune) float64 {
+	if len(runes) < 2 {
+		return 0.5 // Neutral for single character
+	}
+	var bits float64
+	for i := 1; i < len(runes); i++ {
+		prev, curr := unicode.ToLower(runes[i-1]), unicode.ToLower(runes[i])
+		p := (float64(m.counts[prev][curr]) + markovSmoothing) / (float64(m.totals[prev]) + markovSmoothing*m.alphabet)
+		bits -= math.Log2(p)
+	}
+	mean := bits / float64(len(runes)-1)
+	return min(mean/math.Log2(m.alphabet), 1.0)
+}
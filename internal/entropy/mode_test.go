@@ -1,6 +1,8 @@
 package entropy
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
@@ -79,3 +81,62 @@ func TestCalculateWithMode_Comparison(t *testing.T) {
 			patternedEntropy, randomEntropy)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// CalculateBreakdown
+// ---------------------------------------------------------------------------
+
+func TestCalculateBreakdown_MatchesMode(t *testing.T) {
+	passwords := []string{"", "a", "password", "qwerty123", "abcabcabc", "Xk9$mP2!vR7@nL4&wQ"}
+	modes := []string{"", string(ModeSimple), string(ModeAdvanced), string(ModePatternAware), "bogus"}
+	for _, pw := range passwords {
+		issues := patternIssuesFor(pw)
+		for _, mode := range modes {
+			b := CalculateBreakdown(pw, mode, issues)
+			want := CalculateWithMode(pw, mode, issues)
+			if math.Abs(b.Final-want) > 1e-9 {
+				t.Errorf("CalculateBreakdown(%q, %q).Final = %v, want %v", pw, mode, b.Final, want)
+			}
+			sum := b.BaseCharset - b.PatternReduction + b.MarkovAdjustment
+			if math.Abs(sum-b.Final) > 1e-9 {
+				t.Errorf("CalculateBreakdown(%q, %q): components sum to %v, Final = %v", pw, mode, sum, b.Final)
+			}
+		}
+	}
+}
+
+func TestCalculateBreakdown_Simple(t *testing.T) {
+	b := CalculateBreakdown("qwerty123", string(ModeSimple), patternIssuesFor("qwerty123"))
+	if b.PatternReduction != 0 || b.MarkovAdjustment != 0 {
+		t.Errorf("simple mode breakdown = %+v, want zero reductions", b)
+	}
+	if b.BaseCharset != b.Final {
+		t.Errorf("simple mode: BaseCharset %v != Final %v", b.BaseCharset, b.Final)
+	}
+}
+
+func TestCalculateBreakdown_AdvancedReducesForPatterns(t *testing.T) {
+	b := CalculateBreakdown("qwerty123", string(ModeAdvanced), patternIssuesFor("qwerty123"))
+	if b.PatternReduction <= 0 {
+		t.Errorf("expected positive PatternReduction for keyboard walk, got %+v", b)
+	}
+	if b.MarkovAdjustment != 0 {
+		t.Errorf("advanced mode MarkovAdjustment = %v, want 0", b.MarkovAdjustment)
+	}
+}
+
+// patternIssuesFor returns hand-built pattern issues for the fixtures used
+// by the breakdown tests.
+func patternIssuesFor(password string) []issue.Issue {
+	var issues []issue.Issue
+	if strings.Contains(password, "qwerty") {
+		issues = append(issues, issue.NewPattern(issue.CodePatternKeyboard, "Contains keyboard pattern: 'qwerty'", "qwerty", issue.CategoryPattern, issue.SeverityMed))
+	}
+	if strings.Contains(password, "123") {
+		issues = append(issues, issue.NewPattern(issue.CodePatternSequence, "Contains sequence: '123'", "123", issue.CategoryPattern, issue.SeverityMed))
+	}
+	if strings.Contains(password, "abcabc") {
+		issues = append(issues, issue.NewPattern(issue.CodePatternBlock, "Contains repeated block: 'abc'", "abc", issue.CategoryPattern, issue.SeverityMed))
+	}
+	return issues
+}
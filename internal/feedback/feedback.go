@@ -7,6 +7,7 @@
 package feedback
 
 import (
+	"slices"
 	"sort"
 	"strings"
 
@@ -31,9 +32,30 @@ type rankedIssue struct {
 // Deduplication uses quoted tokens in the message; when the same token
 // appears in multiple issues, the highest-severity one is kept.
 func Refine(issues scoring.IssueSet, maxIssues int) []issue.Issue {
+	return RefineLocale(issues, maxIssues, "")
+}
+
+// RefineLocale is like [Refine] but translates each message into locale
+// using the tables registered with [RegisterMessages], keyed by issue code.
+// Deduplication and ordering use the English messages, so the result holds
+// the same issues as Refine. Issues without a translation keep their
+// English message; codes are never changed.
+func RefineLocale(issues scoring.IssueSet, maxIssues int, locale string) []issue.Issue {
+	return RefineOrdered(issues, maxIssues, locale, nil)
+}
+
+// RefineOrdered is like [RefineLocale] but groups the issues by category
+// in the given order, e.g. []string{"breach", "dictionary", "rule"}, before
+// maxIssues is applied. Within a category, and among categories missing
+// from order (which follow the listed ones), issues keep their severity
+// order. A nil order gives the same result as RefineLocale.
+func RefineOrdered(issues scoring.IssueSet, maxIssues int, locale string, order []string) []issue.Issue {
 	ranked := buildRanked(issues)
 	ranked = dedup(ranked)
 	sortBySeverity(ranked)
+	if order != nil {
+		sortByCategory(ranked, order)
+	}
 
 	if maxIssues > 0 && len(ranked) > maxIssues {
 		ranked = ranked[:maxIssues]
@@ -42,12 +64,16 @@ func Refine(issues scoring.IssueSet, maxIssues int) []issue.Issue {
 	out := make([]issue.Issue, len(ranked))
 	for i, r := range ranked {
 		out[i] = r.issue
+		if msg, ok := Localize(locale, r.issue.Code); ok {
+			out[i].Message = msg
+		}
 	}
 	return out
 }
 
 // buildRanked converts an IssueSet into a flat slice of rankedIssues,
-// preserving category order (HIBP, dictionary, context, patterns, rules).
+// preserving category order (HIBP, history, dictionary, context, patterns,
+// rules).
 func buildRanked(issues scoring.IssueSet) []rankedIssue {
 	var ranked []rankedIssue
 	idx := 0
@@ -56,6 +82,10 @@ func buildRanked(issues scoring.IssueSet) []rankedIssue {
 		ranked = append(ranked, rankedIssue{iss, idx})
 		idx++
 	}
+	for _, iss := range issues.History {
+		ranked = append(ranked, rankedIssue{iss, idx})
+		idx++
+	}
 	for _, iss := range issues.Dictionary {
 		ranked = append(ranked, rankedIssue{iss, idx})
 		idx++
@@ -121,6 +151,20 @@ func sortBySeverity(ranked []rankedIssue) {
 	})
 }
 
+// sortByCategory stably sorts ranked issues by the position of their
+// category in order; categories not in order sort after all listed ones.
+func sortByCategory(ranked []rankedIssue, order []string) {
+	pos := func(category string) int {
+		if i := slices.Index(order, category); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return pos(ranked[i].issue.Category) < pos(ranked[j].issue.Category)
+	})
+}
+
 // extractQuoted returns the text between the first pair of single
 // quotes in s, or "" if no quoted text is found.
 func extractQuoted(s string) string {
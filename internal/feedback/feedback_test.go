@@ -1,6 +1,7 @@
 package feedback
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
@@ -151,6 +152,39 @@ func TestRefine_DedupThenLimit(t *testing.T) {
 	}
 }
 
+func TestRefineOrdered(t *testing.T) {
+	issues := scoring.IssueSet{
+		Rules: []issue.Issue{
+			issue.New(issue.CodeRuleTooShort, "Too short", issue.CategoryRule, issue.SeverityLow),
+			issue.New(issue.CodeRuleNoSymbol, "Add a symbol", issue.CategoryRule, issue.SeverityMed),
+		},
+		Patterns:   []issue.Issue{issue.New(issue.CodePatternSequence, "Contains sequence: 'abcd'", issue.CategoryPattern, issue.SeverityMed)},
+		Dictionary: []issue.Issue{issue.New(issue.CodeDictCommonPassword, "Common password", issue.CategoryDictionary, issue.SeverityHigh)},
+	}
+
+	got := RefineOrdered(issues, 0, "", []string{issue.CategoryRule})
+	var codes []string
+	for _, iss := range got {
+		codes = append(codes, iss.Code)
+	}
+	// Rules first, by severity; the unlisted categories follow by severity.
+	want := []string{issue.CodeRuleNoSymbol, issue.CodeRuleTooShort, issue.CodeDictCommonPassword, issue.CodePatternSequence}
+	if !slices.Equal(codes, want) {
+		t.Errorf("codes = %v, want %v", codes, want)
+	}
+
+	// The limit applies after reordering.
+	got = RefineOrdered(issues, 1, "", []string{issue.CategoryPattern, issue.CategoryRule})
+	if len(got) != 1 || got[0].Code != issue.CodePatternSequence {
+		t.Errorf("limited to 1 = %v, want the pattern issue", got)
+	}
+
+	// A nil order is the severity order of Refine.
+	if !slices.Equal(RefineOrdered(issues, 0, "", nil), Refine(issues, 0)) {
+		t.Error("nil order should match Refine")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // dedup
 // ---------------------------------------------------------------------------
@@ -254,6 +288,22 @@ func TestGeneratePositive_StrongPassword(t *testing.T) {
 	assertContainsMsg(t, msgs, "Good entropy")
 }
 
+func TestGeneratePositive_DiversityIncludingEmojiAndCJK(t *testing.T) {
+	tests := []struct {
+		password, want string
+	}{
+		{"Xk9🔒mP2🎉vR7", "Good character diversity including emoji (4 of 4 character types)"},
+		{"Xk9密mP2码vR7", "Good character diversity including CJK characters (4 of 4 character types)"},
+		{"Xk9$mP2!vR7", "Good character diversity (4 of 4 character types)"},
+	}
+	for _, tt := range tests {
+		msgs := GeneratePositive(tt.password, scoring.IssueSet{}, 0)
+		if !slices.Contains(msgs, tt.want) {
+			t.Errorf("GeneratePositive(%q) = %v, want %q", tt.password, msgs, tt.want)
+		}
+	}
+}
+
 func TestGeneratePositive_Empty(t *testing.T) {
 	msgs := GeneratePositive("", scoring.IssueSet{}, 0)
 	if len(msgs) != 0 {
@@ -298,6 +348,18 @@ func TestGeneratePositive_NoDictPraise_WhenDictIssuesExist(t *testing.T) {
 	}
 }
 
+func TestGeneratePositive_UnrelatedIssuesKeepPraise(t *testing.T) {
+	issues := scoring.IssueSet{
+		Rules:      []issue.Issue{issue.New(issue.CodeRuleNoSymbol, "no symbol", issue.CategoryRule, issue.SeverityLow)},
+		Dictionary: []issue.Issue{issue.New(issue.CodeDictCommonWord, "common word: 'dragon'", issue.CategoryDictionary, issue.SeverityHigh)},
+	}
+	msgs := GeneratePositive("Dragon7Fx9KqzW2mBv", issues, 80)
+	assertContainsMsg(t, msgs, "Good length")
+	assertContainsMsg(t, msgs, "character diversity")
+	assertContainsMsg(t, msgs, "No common patterns")
+	assertContainsMsg(t, msgs, "Good entropy")
+}
+
 func TestGeneratePositive_OnlyDeservedPraise(t *testing.T) {
 	issues := scoring.IssueSet{
 		Patterns: []issue.Issue{issue.New(issue.CodePatternSequence, "sequence found", issue.CategoryPattern, issue.SeverityMed)},
@@ -339,3 +401,206 @@ func assertContainsMsg(t *testing.T, msgs []string, substr string) {
 	}
 	t.Errorf("expected a message containing %q in %v", substr, msgs)
 }
+
+// ---------------------------------------------------------------------------
+// FriendlyMessage
+// ---------------------------------------------------------------------------
+
+func TestFriendlyMessage(t *testing.T) {
+	codes := []string{
+		issue.CodeRuleTooShort, issue.CodePatternSequence, issue.CodePatternKeyboard,
+		issue.CodeDictCommonPassword, issue.CodeContextWord, issue.CodeHIBPBreached,
+	}
+	for _, code := range codes {
+		msg, ok := FriendlyMessage(code)
+		if !ok || msg == "" {
+			t.Errorf("FriendlyMessage(%q) missing", code)
+		}
+		if strings.Contains(msg, "'") {
+			t.Errorf("FriendlyMessage(%q) should not quote password content: %q", code, msg)
+		}
+	}
+	if _, ok := FriendlyMessage("UNKNOWN_CODE"); ok {
+		t.Error("FriendlyMessage should report false for unknown codes")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Hint
+// ---------------------------------------------------------------------------
+
+func TestHint(t *testing.T) {
+	if got := Hint(issue.CodeRuleTooShort); got != "increase_length" {
+		t.Errorf("Hint(RULE_TOO_SHORT) = %q, want increase_length", got)
+	}
+	if got := Hint(issue.CodeDictCommonPassword); got != "avoid_dictionary" {
+		t.Errorf("Hint(DICT_COMMON_PASSWORD) = %q, want avoid_dictionary", got)
+	}
+	if got := Hint("UNKNOWN_CODE"); got != "" {
+		t.Errorf("Hint(UNKNOWN_CODE) = %q, want empty", got)
+	}
+	// Every code with a friendly message has a snake_case hint.
+	for code := range friendlyMessages {
+		h := Hint(code)
+		if h == "" {
+			t.Errorf("Hint(%q) missing", code)
+		}
+		for _, r := range h {
+			if (r < 'a' || r > 'z') && r != '_' {
+				t.Errorf("Hint(%q) = %q is not snake_case", code, h)
+				break
+			}
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Locale
+// ---------------------------------------------------------------------------
+
+func TestRefineLocale(t *testing.T) {
+	RegisterMessages("xx-Refine", map[string]string{
+		issue.CodeRuleTooShort: "xx: too short",
+	})
+	issues := scoring.IssueSet{
+		Rules: []issue.Issue{
+			issue.New(issue.CodeRuleTooShort, "Password is too short", issue.CategoryRule, issue.SeverityHigh),
+			issue.New(issue.CodeRuleNoDigit, "Add at least one digit", issue.CategoryRule, issue.SeverityMed),
+		},
+	}
+
+	got := RefineLocale(issues, 0, "XX_refine")
+	if got[0].Message != "xx: too short" || got[0].Code != issue.CodeRuleTooShort {
+		t.Errorf("translated issue = %+v", got[0])
+	}
+	if got[1].Message != "Add at least one digit" {
+		t.Errorf("untranslated code should stay English, got %q", got[1].Message)
+	}
+
+	english := Refine(issues, 0)
+	if english[0].Message != "Password is too short" {
+		t.Errorf("Refine should not translate, got %q", english[0].Message)
+	}
+	if unknown := RefineLocale(issues, 0, "zz"); unknown[0].Message != "Password is too short" {
+		t.Errorf("unknown locale should fall back to English, got %q", unknown[0].Message)
+	}
+}
+
+func TestLocalize_ParentFallback(t *testing.T) {
+	RegisterMessages("xy", map[string]string{"K": "base"})
+	RegisterMessages("xy-AB", map[string]string{"L": "region"})
+
+	if msg, ok := Localize("xy-ab", "L"); !ok || msg != "region" {
+		t.Errorf("Localize(xy-ab, L) = %q, %v", msg, ok)
+	}
+	if msg, ok := Localize("xy-AB", "K"); !ok || msg != "base" {
+		t.Errorf("region should fall back to its language: %q, %v", msg, ok)
+	}
+	if _, ok := Localize("xy-AB", "M"); ok {
+		t.Error("missing key should report false")
+	}
+	if _, ok := Localize("", "K"); ok {
+		t.Error("empty locale should report false")
+	}
+}
+
+func TestGeneratePositiveLocale(t *testing.T) {
+	RegisterMessages("xx-Positive", map[string]string{
+		PositiveGoodLength: "xx: {n} chars",
+		PositiveNoPatterns: "xx: no patterns",
+	})
+	msgs := GeneratePositiveLocale("Xk9$mP2!vR7@nL4&wQzB", scoring.IssueSet{}, 120, "xx-positive")
+	assertContainsMsg(t, msgs, "xx: 20 chars")
+	assertContainsMsg(t, msgs, "xx: no patterns")
+	assertContainsMsg(t, msgs, "Not found in common password")
+}
+
+// ---------------------------------------------------------------------------
+// StrengthReasons
+// ---------------------------------------------------------------------------
+
+func TestStrengthReasons(t *testing.T) {
+	msgs := StrengthReasons(20, 4, scoring.IssueSet{}, 118.4, "")
+	want := []string{"20 characters", "4 character types", "No dictionary words", "No common patterns", "~118 bits of entropy"}
+	if strings.Join(msgs, "|") != strings.Join(want, "|") {
+		t.Errorf("StrengthReasons = %q, want %q", msgs, want)
+	}
+}
+
+func TestStrengthReasons_AlwaysReportsNumbers(t *testing.T) {
+	set := scoring.IssueSet{
+		Patterns:   []issue.Issue{issue.New(issue.CodePatternKeyboard, "kb", issue.CategoryPattern, issue.SeverityMed)},
+		Dictionary: []issue.Issue{issue.New(issue.CodeDictCommonWord, "w", issue.CategoryDictionary, issue.SeverityMed)},
+	}
+	msgs := StrengthReasons(1, 1, set, 4, "")
+	want := []string{"1 character", "1 character type", "~4 bits of entropy"}
+	if strings.Join(msgs, "|") != strings.Join(want, "|") {
+		t.Errorf("StrengthReasons = %q, want %q", msgs, want)
+	}
+	if msgs := StrengthReasons(0, 0, scoring.IssueSet{}, 0, ""); msgs != nil {
+		t.Errorf("empty password: got %q, want nil", msgs)
+	}
+}
+
+func TestStrengthReasons_Locale(t *testing.T) {
+	RegisterMessages("xx-reasons", map[string]string{ReasonLength: "xx: {n} chars"})
+	msgs := StrengthReasons(20, 4, scoring.IssueSet{}, 118, "xx-reasons")
+	assertContainsMsg(t, msgs, "xx: 20 chars")
+	assertContainsMsg(t, msgs, "4 character types")
+}
+
+// ---------------------------------------------------------------------------
+// Improve
+// ---------------------------------------------------------------------------
+
+func TestImprove(t *testing.T) {
+	short := issue.New(issue.CodeRuleTooShort, "short", issue.CategoryRule, issue.SeverityHigh)
+	seq := issue.NewPattern(issue.CodePatternSequence, "seq", "abcd", issue.CategoryPattern, issue.SeverityMed).At(6, 10)
+	seq.Match = "abcd"
+	word := issue.New(issue.CodeDictCommonWord, "word", issue.CategoryDictionary, issue.SeverityMed).At(0, 6)
+	word.Match = "Dragon"
+	set := scoring.IssueSet{
+		Rules:      []issue.Issue{short, issue.New(issue.CodeRuleNoSymbol, "sym", issue.CategoryRule, issue.SeverityMed)},
+		Patterns:   []issue.Issue{seq},
+		Dictionary: []issue.Issue{word, word},
+	}
+
+	got := Improve(10, set, ImproveOptions{MinLength: 14})
+	want := []string{
+		"Add 4 more characters to reach 14",
+		"Add a symbol",
+		"Remove the sequence 'abcd'",
+		"Replace the dictionary word 'Dragon'",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Improve = %q, want %q", got, want)
+	}
+
+	redacted := Improve(10, set, ImproveOptions{MinLength: 14, Redact: true})
+	for _, step := range redacted {
+		if strings.Contains(step, "'") {
+			t.Errorf("redacted step quotes a token: %q", step)
+		}
+	}
+}
+
+func TestImprove_NeverQuotesWholePassword(t *testing.T) {
+	word := issue.New(issue.CodeDictCommonWord, "word", issue.CategoryDictionary, issue.SeverityMed).At(0, 6)
+	word.Match = "dragon"
+	got := Improve(6, scoring.IssueSet{Dictionary: []issue.Issue{word}}, ImproveOptions{})
+	if len(got) != 1 || strings.Contains(got[0], "dragon") {
+		t.Errorf("Improve = %q, want a generic step without the password", got)
+	}
+	if got := Improve(20, scoring.IssueSet{}, ImproveOptions{MinLength: 12}); len(got) != 0 {
+		t.Errorf("no issues: got %q, want none", got)
+	}
+}
+
+func TestImprove_OneStepPerSpan(t *testing.T) {
+	kb := issue.NewPattern(issue.CodePatternKeyboard, "kb", "1234", issue.CategoryPattern, issue.SeverityMed).At(6, 10)
+	seq := issue.NewPattern(issue.CodePatternSequence, "seq", "1234", issue.CategoryPattern, issue.SeverityMed).At(6, 10)
+	got := Improve(10, scoring.IssueSet{Patterns: []issue.Issue{kb, seq}}, ImproveOptions{})
+	if len(got) != 1 || got[0] != "Remove the keyboard pattern '1234'" {
+		t.Errorf("Improve = %q, want a single step for the shared span", got)
+	}
+}
@@ -0,0 +1,58 @@
+package feedback
+
+import "github.com/rafaelsanzio/passcheck/internal/issue"
+
+// friendlyMessages maps issue codes to longer, plain-language explanations
+// aimed at non-technical users. They change tone and length, not language,
+// and never quote parts of the password.
+var friendlyMessages = map[string]string{
+	issue.CodeRuleTooShort:            "Longer passwords are much harder to guess — try adding a few more words or characters.",
+	issue.CodeRuleTooLong:             "This password is longer than this service can store — please choose a shorter one.",
+	issue.CodeRuleNoUpper:             "Mixing in a capital letter somewhere other than the start makes your password harder to guess.",
+	issue.CodeRuleNoLower:             "Adding some lowercase letters widens the range of characters an attacker has to try.",
+	issue.CodeRuleNoDigit:             "Adding a number somewhere in the middle (not just at the end) makes your password harder to guess.",
+	issue.CodeRuleNoSymbol:            "Adding a symbol such as ! or # somewhere in the middle makes your password harder to guess.",
+	issue.CodeRuleInsufficientClasses: "Mixing more kinds of characters — capital letters, lowercase letters, numbers, and symbols — makes your password harder to guess.",
+	issue.CodeRuleWhitespace:          "Spaces and tabs can cause login trouble on some systems — consider using another separator.",
+	issue.CodeRuleControlChar:         "Your password contains invisible characters that may not be typed the same way everywhere — please remove them.",
+	issue.CodeRuleRepeatedChars:       "Repeating the same character several times adds little strength — try varying the characters instead.",
+	issue.CodeRuleLowDiversity:        "Using only a handful of different characters makes a password easy to guess, even when it is long — mix in more variety.",
+	issue.CodeRuleNumericOnly:         "A password made only of numbers is guessed like a PIN, even when it is long — add letters or use several words.",
+	issue.CodeRuleNoAlphanumeric:      "A password made only of symbols or spaces is easy to guess — include some letters and numbers.",
+	issue.CodeRuleLowEntropy:          "This password follows a pattern that is easy to predict. Mixing unrelated words, numbers, and symbols makes it much harder to guess.",
+	issue.CodePatternKeyboard:         "Avoid keys that sit next to each other on the keyboard — attackers try those first. Try unrelated words instead.",
+	issue.CodePatternAdjacentWalk:     "Zig-zagging between neighboring keys is still a keyboard pattern that attackers try. Try unrelated words instead.",
+	issue.CodePatternSequence:         "Avoid number or letter runs like 1234 or abcd — try unrelated words instead.",
+	issue.CodePatternBlock:            "Repeating the same chunk twice doesn't make a password much stronger — use different parts instead.",
+	issue.CodePatternSubstitution:     "Swapping letters for look-alike symbols (like @ for a) is a trick attackers know well — it adds little strength.",
+	issue.CodePatternAffixed:          "Adding numbers or symbols to the start or end of a common word is the first thing attackers try — use unrelated words instead.",
+	issue.CodePatternRepeatedWord:     "Repeating the same word adds no strength — every word in a passphrase should be different.",
+	issue.CodePatternTemplateRepeat:   "Repeating the same mix of letters, digits, and symbols (like Abc123!Xyz456!) makes the password easier to guess — vary the structure.",
+	issue.CodePatternCaseTokenized:    "Capitalizing just one letter (like Password1) is the first thing attackers try — mix case throughout or use a longer passphrase.",
+	issue.CodePatternDate:             "Dates such as birthdays or years are easy to guess — avoid using them in your password.",
+	issue.CodePatternForbidden:        "This password contains something our policy does not allow, such as a company or product name. Please choose a different one.",
+	issue.CodeDictCommonPassword:      "This is one of the most commonly used passwords, so attackers try it first. Choose something unique to you.",
+	issue.CodeDictLeetVariant:         "This is a well-known password with a few letters swapped for symbols — attackers try these variants too.",
+	issue.CodeDictCommonWord:          "Single dictionary words are easy to guess — combine several unrelated words instead.",
+	issue.CodeDictCommonWordSub:       "This contains a dictionary word disguised with symbols — attackers check those disguises too.",
+	issue.CodeDictCapitalizedCommon:   "Capitalizing the first letter of a common password is the first thing attackers try — pick something unique instead.",
+	issue.CodePassphraseWeakWords:     "Some of your words are just repeated letters or well-known passwords, so they barely count — pick real, unrelated words.",
+	issue.CodeDictReversedWord:        "Spelling a common word backwards is a trick attackers try early — use unrelated words instead.",
+	issue.CodeDictTrending:            "Attackers are actively trying this exact password right now after recent breaches — choose something completely different.",
+	issue.CodeDictPasswordSubstring:   "This is a common password with a few characters trimmed off — attackers try those fragments too.",
+	issue.CodeDictNearCommon:          "This is a common password with one character changed, added, or removed — attackers try those near-misses too.",
+	issue.CodeDictMangledCommon:       "Capitalizing, swapping letters for look-alikes, or adding digits and a symbol to a common password are the first changes attackers try.",
+	issue.CodeDictMirrored:            "A word followed by its reverse is a known trick and is easy to guess — try unrelated words instead.",
+	issue.CodeContextWord:             "Your password includes personal details like your name or email, which others may know — leave them out.",
+	issue.CodeHIBPBreached:            "This password has appeared in a data breach, so attackers already have it. Please choose a different one.",
+	issue.CodeHistorySharedSubstring:  "This password is too close to one you used before. Please choose something new rather than changing a few characters.",
+	issue.CodeHistoryReuse:            "This is your current password. Please choose a new one.",
+	issue.CodeRuleTooSimilar:          "This is only a small edit of a password you used before, which attackers try first. Please choose something new.",
+}
+
+// FriendlyMessage returns the plain-language explanation for code, or
+// false when no explanation is available.
+func FriendlyMessage(code string) (string, bool) {
+	msg, ok := friendlyMessages[code]
+	return msg, ok
+}
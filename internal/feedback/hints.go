@@ -0,0 +1,56 @@
+package feedback
+
+import "github.com/rafaelsanzio/passcheck/internal/issue"
+
+// hints maps issue codes to short snake_case remediation tokens that
+// clients can use as i18n keys. Codes sharing a fix share a token, and
+// tokens never change once published.
+var hints = map[string]string{
+	issue.CodeRuleTooShort:            "increase_length",
+	issue.CodeRuleTooLong:             "reduce_length",
+	issue.CodeRuleNoUpper:             "add_uppercase",
+	issue.CodeRuleNoLower:             "add_lowercase",
+	issue.CodeRuleNoDigit:             "add_digit",
+	issue.CodeRuleNoSymbol:            "add_symbol",
+	issue.CodeRuleInsufficientClasses: "add_character_types",
+	issue.CodeRuleWhitespace:          "remove_whitespace",
+	issue.CodeRuleControlChar:         "remove_control_chars",
+	issue.CodeRuleRepeatedChars:       "reduce_repeats",
+	issue.CodeRuleNoAlphanumeric:      "add_letters_and_digits",
+	issue.CodeRuleNumericOnly:         "add_letters",
+	issue.CodeRuleLowDiversity:        "increase_diversity",
+	issue.CodeRuleLowEntropy:          "increase_randomness",
+	issue.CodePatternKeyboard:         "avoid_keyboard_patterns",
+	issue.CodePatternAdjacentWalk:     "avoid_keyboard_patterns",
+	issue.CodePatternSequence:         "avoid_sequences",
+	issue.CodePatternBlock:            "avoid_repeated_blocks",
+	issue.CodePatternSubstitution:     "avoid_substitutions",
+	issue.CodePatternAffixed:          "avoid_affixed_words",
+	issue.CodePatternRepeatedWord:     "avoid_repeated_words",
+	issue.CodePatternTemplateRepeat:   "vary_character_layout",
+	issue.CodePatternCaseTokenized:    "vary_capitalization",
+	issue.CodePatternDate:             "avoid_dates",
+	issue.CodePatternForbidden:        "remove_forbidden_content",
+	issue.CodePassphraseWeakWords:     "use_stronger_words",
+	issue.CodeDictCommonPassword:      "avoid_dictionary",
+	issue.CodeDictLeetVariant:         "avoid_dictionary",
+	issue.CodeDictCapitalizedCommon:   "avoid_dictionary",
+	issue.CodeDictMirrored:            "avoid_dictionary",
+	issue.CodeDictTrending:            "avoid_dictionary",
+	issue.CodeDictPasswordSubstring:   "avoid_dictionary",
+	issue.CodeDictNearCommon:          "avoid_dictionary",
+	issue.CodeDictMangledCommon:       "avoid_dictionary",
+	issue.CodeDictCommonWord:          "avoid_dictionary_words",
+	issue.CodeDictCommonWordSub:       "avoid_dictionary_words",
+	issue.CodeDictReversedWord:        "avoid_dictionary_words",
+	issue.CodeContextWord:             "avoid_personal_info",
+	issue.CodeHIBPBreached:            "avoid_breached",
+	issue.CodeHistorySharedSubstring:  "avoid_reuse",
+	issue.CodeHistoryReuse:            "avoid_reuse",
+	issue.CodeRuleTooSimilar:          "avoid_reuse",
+}
+
+// Hint returns the remediation token for code, or "" for unknown codes.
+func Hint(code string) string {
+	return hints[code]
+}
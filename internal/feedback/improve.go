@@ -0,0 +1,127 @@
+package feedback
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/scoring"
+)
+
+// ImproveOptions configures [Improve].
+type ImproveOptions struct {
+	// MinLength is the length the step for RULE_TOO_SHORT aims for, and
+	// MaxLength the one for RULE_TOO_LONG.
+	MinLength int
+	MaxLength int
+
+	// Redact omits the offending tokens from every step.
+	Redact bool
+}
+
+// improvement is the next step suggested for an issue code: token names
+// the offending part of the password ("%s" is replaced with it), generic is
+// used when the token is unknown, redacted, or the whole password.
+type improvement struct {
+	token   string
+	generic string
+}
+
+var improvements = map[string]improvement{
+	issue.CodeRuleTooShort:            {generic: "Make it longer"},
+	issue.CodeRuleTooLong:             {generic: "Make it shorter"},
+	issue.CodeRuleNoUpper:             {generic: "Add an uppercase letter"},
+	issue.CodeRuleNoLower:             {generic: "Add a lowercase letter"},
+	issue.CodeRuleNoDigit:             {generic: "Add a number"},
+	issue.CodeRuleNoSymbol:            {generic: "Add a symbol"},
+	issue.CodeRuleInsufficientClasses: {generic: "Mix in more character types"},
+	issue.CodeRuleWhitespace:          {generic: "Remove spaces and tabs"},
+	issue.CodeRuleControlChar:         {generic: "Remove invisible control characters"},
+	issue.CodeRuleRepeatedChars:       {generic: "Avoid repeating the same character"},
+	issue.CodeRuleNoAlphanumeric:      {generic: "Add letters and numbers"},
+	issue.CodeRuleNumericOnly:         {generic: "Add letters or symbols, not just numbers"},
+	issue.CodeRuleLowDiversity:        {generic: "Use more different characters"},
+	issue.CodeRuleLowEntropy:          {generic: "Add unrelated words or random characters"},
+	issue.CodePatternKeyboard:         {"Remove the keyboard pattern '%s'", "Avoid keyboard patterns"},
+	issue.CodePatternAdjacentWalk:     {"Remove the keyboard walk '%s'", "Avoid keyboard patterns"},
+	issue.CodePatternSequence:         {"Remove the sequence '%s'", "Avoid sequences like 1234 or abcd"},
+	issue.CodePatternBlock:            {"Break up the repeated block '%s'", "Avoid repeating the same block"},
+	issue.CodePatternSubstitution:     {"Replace the disguised word '%s'", "Avoid look-alike symbol substitutions"},
+	issue.CodePatternAffixed:          {"Replace the common word '%s'", "Avoid common words with digits or symbols added"},
+	issue.CodePatternRepeatedWord:     {"Replace the repeated word '%s'", "Avoid repeating the same word"},
+	issue.CodePatternTemplateRepeat:   {generic: "Avoid repeating the same layout of letters, digits, and symbols"},
+	issue.CodePatternCaseTokenized:    {generic: "Capitalize more than one letter, not just the first or last"},
+	issue.CodePatternDate:             {"Remove the date '%s'", "Avoid dates and years"},
+	issue.CodePatternForbidden:        {generic: "Remove the part this policy does not allow"},
+	issue.CodePassphraseWeakWords:     {generic: "Replace repeated or common words with unrelated ones"},
+	issue.CodeDictCommonPassword:      {generic: "Choose a password that is not on common password lists"},
+	issue.CodeDictLeetVariant:         {generic: "Choose a password that is not on common password lists"},
+	issue.CodeDictCapitalizedCommon:   {generic: "Choose a password that is not on common password lists"},
+	issue.CodeDictTrending:            {generic: "Choose a password that is not on common password lists"},
+	issue.CodeDictPasswordSubstring:   {generic: "Choose a password that is not part of a common password"},
+	issue.CodeDictNearCommon:          {generic: "Choose a password that is not a small change to a common password"},
+	issue.CodeDictMangledCommon:       {"Replace the common password '%s'", "Choose a password that is not a common password with digits or symbols added"},
+	issue.CodeDictMirrored:            {"Replace the mirrored word '%s'", "Avoid a word followed by its reverse"},
+	issue.CodeDictCommonWord:          {"Replace the dictionary word '%s'", "Replace dictionary words with unrelated ones"},
+	issue.CodeDictCommonWordSub:       {"Replace the dictionary word '%s'", "Replace dictionary words with unrelated ones"},
+	issue.CodeDictReversedWord:        {"Replace the reversed word '%s'", "Avoid words spelled backwards"},
+	issue.CodeContextWord:             {"Remove the personal detail '%s'", "Remove personal details such as your name or email"},
+	issue.CodeHIBPBreached:            {generic: "Choose a password that has not appeared in a data breach"},
+	issue.CodeHistorySharedSubstring:  {generic: "Change more than a few characters of your previous password"},
+	issue.CodeHistoryReuse:            {generic: "Choose a password different from your current one"},
+	issue.CodeRuleTooSimilar:          {generic: "Change more than a few characters of your previous password"},
+}
+
+// Improve returns actionable next steps for a password of the given length
+// (in runes) with the given issues, e.g. "Add 4 more characters to reach
+// 12", "Remove the sequence 'abcd'", "Replace the dictionary word
+// 'dragon'". Steps follow the order of the issues, phase by phase, and
+// are deduplicated; a substring flagged by several detectors (e.g. "1234"
+// as both keyboard walk and sequence) gets only the first step.
+//
+// Steps quote only the offending substring an issue points at, taken from
+// its Match (or Pattern); a token spanning the whole password is never
+// quoted, so no step reveals the full password. With opts.Redact no token
+// is quoted at all.
+func Improve(length int, issues scoring.IssueSet, opts ImproveOptions) []string {
+	var steps []string
+	seen := make(map[string]bool)
+	quoted := make(map[[2]int]bool) // spans already named by a step
+	add := func(step string) {
+		if !seen[step] {
+			seen[step] = true
+			steps = append(steps, step)
+		}
+	}
+	for _, group := range [][]issue.Issue{issues.Rules, issues.Patterns, issues.Dictionary, issues.Context, issues.HIBP, issues.History} {
+		for _, iss := range group {
+			imp, ok := improvements[iss.Code]
+			if !ok {
+				continue
+			}
+			if n := opts.MinLength - length; iss.Code == issue.CodeRuleTooShort && n > 0 {
+				add(fmt.Sprintf("Add %s to reach %d", plural(n, "more character"), opts.MinLength))
+				continue
+			}
+			if n := length - opts.MaxLength; iss.Code == issue.CodeRuleTooLong && opts.MaxLength > 0 && n > 0 {
+				add(fmt.Sprintf("Remove %s to stay within %d", plural(n, "character"), opts.MaxLength))
+				continue
+			}
+			tok := iss.Match
+			if tok == "" {
+				tok = iss.Pattern
+			}
+			if imp.token == "" || tok == "" || opts.Redact || utf8.RuneCountInString(tok) >= length {
+				add(imp.generic)
+				continue
+			}
+			span := [2]int{iss.Start, iss.End}
+			if iss.End > iss.Start && quoted[span] {
+				continue
+			}
+			quoted[span] = true
+			add(fmt.Sprintf(imp.token, tok))
+		}
+	}
+	return steps
+}
@@ -0,0 +1,102 @@
+package feedback
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message keys for positive feedback. Issue messages are keyed by their
+// issue code; these keys name the strengths reported by GeneratePositive
+// so that they can be translated the same way.
+const (
+	PositiveGoodLength     = "POSITIVE_GOOD_LENGTH"
+	PositiveDiversity      = "POSITIVE_CHARSET_DIVERSITY"
+	PositiveDiversityEmoji = "POSITIVE_CHARSET_DIVERSITY_EMOJI"
+	PositiveDiversityCJK   = "POSITIVE_CHARSET_DIVERSITY_CJK"
+	PositiveNoPatterns     = "POSITIVE_NO_PATTERNS"
+	PositiveNotCommon      = "POSITIVE_NOT_COMMON"
+	PositiveHighEntropy    = "POSITIVE_HIGH_ENTROPY"
+)
+
+// numberPlaceholder is replaced with the number a positive message carries.
+const numberPlaceholder = "{n}"
+
+var (
+	localeMu     sync.RWMutex
+	localeTables = map[string]map[string]string{}
+)
+
+// RegisterMessages registers translated messages for a BCP-47 locale tag
+// (e.g. "pt-BR"), keyed by issue code or Positive* key. The table is
+// copied; registering the same locale again merges into and overrides
+// earlier entries. Tags are matched case-insensitively, and "_" is
+// accepted in place of "-".
+//
+// Positive messages that carry a number (length, character types,
+// entropy bits) may include the placeholder "{n}", which is replaced with
+// that number.
+func RegisterMessages(locale string, table map[string]string) {
+	tag := normalizeLocale(locale)
+	if tag == "" {
+		return
+	}
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	dst := localeTables[tag]
+	if dst == nil {
+		dst = make(map[string]string, len(table))
+		localeTables[tag] = dst
+	}
+	for k, v := range table {
+		dst[k] = v
+	}
+}
+
+// Localize returns the message registered for key under locale. When the
+// exact tag has no entry, less specific tags are tried in turn ("pt-BR"
+// then "pt"). It reports false when no translation exists, in which case
+// callers keep the English message.
+func Localize(locale, key string) (string, bool) {
+	tag := normalizeLocale(locale)
+	if tag == "" {
+		return "", false
+	}
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	for {
+		if msg, ok := localeTables[tag][key]; ok {
+			return msg, true
+		}
+		i := strings.LastIndex(tag, "-")
+		if i < 0 {
+			return "", false
+		}
+		tag = tag[:i]
+	}
+}
+
+// localize returns the translation of key for locale, or english when no
+// translation exists.
+func localize(locale, key, english string) string {
+	if msg, ok := Localize(locale, key); ok {
+		return msg
+	}
+	return english
+}
+
+// localizeNumber is like localize for messages carrying a number: the
+// "{n}" placeholder in the translation is replaced with n.
+func localizeNumber(locale, key, english string, n int) string {
+	msg, ok := Localize(locale, key)
+	if !ok {
+		return english
+	}
+	return strings.ReplaceAll(msg, numberPlaceholder, strconv.Itoa(n))
+}
+
+// normalizeLocale lowercases a BCP-47 tag and converts "_" separators
+// to "-".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -2,6 +2,7 @@ package feedback
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/rafaelsanzio/passcheck/internal/entropy"
 	"github.com/rafaelsanzio/passcheck/internal/scoring"
@@ -18,8 +19,17 @@ const (
 //
 // Only aspects that are genuinely strong are praised — a short password
 // does not get "Good length", and a password full of patterns does not
-// get "No common patterns detected".
+// get "No common patterns detected". Issues withhold only the praise they
+// contradict: a 20-character common password still gets "Good length".
 func GeneratePositive(password string, issues scoring.IssueSet, entropyBits float64) []string {
+	return GeneratePositiveLocale(password, issues, entropyBits, "")
+}
+
+// GeneratePositiveLocale is like [GeneratePositive] but translates each
+// message into locale using the tables registered with [RegisterMessages],
+// keyed by the Positive* constants. Messages without a translation stay
+// in English.
+func GeneratePositiveLocale(password string, issues scoring.IssueSet, entropyBits float64, locale string) []string {
 	var msgs []string
 
 	// Character-set diversity praise.
@@ -27,29 +37,42 @@ func GeneratePositive(password string, issues scoring.IssueSet, entropyBits floa
 
 	// Length praise.
 	if runeLen >= goodLengthThreshold {
-		msgs = append(msgs, fmt.Sprintf("Good length (%d characters)", runeLen))
+		msgs = append(msgs, localizeNumber(locale, PositiveGoodLength,
+			fmt.Sprintf("Good length (%d characters)", runeLen), runeLen))
 	}
 
 	if count := info.SetCount(); count >= 3 {
-		msgs = append(msgs, fmt.Sprintf(
-			"Good character diversity (%d of 4 character types)", count,
-		))
+		switch {
+		case info.HasEmoji:
+			msgs = append(msgs, localizeNumber(locale, PositiveDiversityEmoji, fmt.Sprintf(
+				"Good character diversity including emoji (%d of 4 character types)", count,
+			), count))
+		case info.HasCJK:
+			msgs = append(msgs, localizeNumber(locale, PositiveDiversityCJK, fmt.Sprintf(
+				"Good character diversity including CJK characters (%d of 4 character types)", count,
+			), count))
+		default:
+			msgs = append(msgs, localizeNumber(locale, PositiveDiversity, fmt.Sprintf(
+				"Good character diversity (%d of 4 character types)", count,
+			), count))
+		}
 	}
 
 
 	// No pattern issues → praise.
 	if len(issues.Patterns) == 0 && runeLen > 0 {
-		msgs = append(msgs, "No common patterns detected")
+		msgs = append(msgs, localize(locale, PositiveNoPatterns, "No common patterns detected"))
 	}
 
 	// No dictionary issues → praise.
 	if len(issues.Dictionary) == 0 && runeLen > 0 {
-		msgs = append(msgs, "Not found in common password lists")
+		msgs = append(msgs, localize(locale, PositiveNotCommon, "Not found in common password lists"))
 	}
 
 	// High entropy → praise.
 	if entropyBits >= highEntropyThreshold {
-		msgs = append(msgs, fmt.Sprintf("Good entropy (%.0f bits)", entropyBits))
+		msgs = append(msgs, localizeNumber(locale, PositiveHighEntropy,
+			fmt.Sprintf("Good entropy (%.0f bits)", entropyBits), int(math.Round(entropyBits))))
 	}
 
 	return msgs
@@ -0,0 +1,53 @@
+package feedback
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rafaelsanzio/passcheck/internal/scoring"
+)
+
+// Message keys for the strength reasons reported by StrengthReasons.
+const (
+	ReasonLength       = "REASON_LENGTH"
+	ReasonCharTypes    = "REASON_CHARACTER_TYPES"
+	ReasonNoDictionary = "REASON_NO_DICTIONARY"
+	ReasonNoPatterns   = "REASON_NO_PATTERNS"
+	ReasonEntropy      = "REASON_ENTROPY"
+)
+
+// StrengthReasons explains, with numbers, what a password's strength rests
+// on: its length, its number of character types, the absence of dictionary
+// words and patterns, and its entropy.
+//
+// Unlike GeneratePositive, which praises only aspects above a threshold,
+// the length, character-type, and entropy reasons are always reported for
+// a non-empty password, so the list is never empty; callers decide when
+// to show it. Messages are translated like GeneratePositiveLocale, keyed
+// by the Reason* constants.
+func StrengthReasons(length, charTypes int, issues scoring.IssueSet, entropyBits float64, locale string) []string {
+	if length == 0 {
+		return nil
+	}
+	msgs := []string{
+		localizeNumber(locale, ReasonLength, plural(length, "character"), length),
+		localizeNumber(locale, ReasonCharTypes, plural(charTypes, "character type"), charTypes),
+	}
+	if len(issues.Dictionary) == 0 {
+		msgs = append(msgs, localize(locale, ReasonNoDictionary, "No dictionary words"))
+	}
+	if len(issues.Patterns) == 0 {
+		msgs = append(msgs, localize(locale, ReasonNoPatterns, "No common patterns"))
+	}
+	bits := int(math.Round(entropyBits))
+	msgs = append(msgs, localizeNumber(locale, ReasonEntropy, fmt.Sprintf("~%d bits of entropy", bits), bits))
+	return msgs
+}
+
+// plural formats n with noun, adding "s" unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -3,6 +3,8 @@
 package hibpcheck
 
 import (
+	"context"
+
 	"github.com/rafaelsanzio/passcheck/internal/issue"
 )
 
@@ -13,9 +15,34 @@ type Options struct {
 		Check(password string) (breached bool, count int, err error)
 	}
 	// MinOccurrences is the minimum breach count required to report an issue.
+	// Breached results with a count of 0 (unknown) are always reported.
 	MinOccurrences int
 	// Result is an optional pre-computed HIBP check result.
 	Result *Result
+
+	// ConstantTime asks the checker to compare breach data in constant
+	// time. It takes effect only when Checker implements
+	// constantTimeChecker, as *hibp.Client does.
+	ConstantTime bool
+
+	// Context, when non-nil, is passed to checkers that implement
+	// contextChecker, as *hibp.Client does, so a cancelled request aborts
+	// the network round-trip. The check is then skipped like any other
+	// checker error.
+	Context context.Context
+}
+
+// constantTimeChecker is implemented by checkers that offer a
+// constant-time variant of Check.
+type constantTimeChecker interface {
+	CheckConstantTime(password string) (breached bool, count int, err error)
+}
+
+// contextChecker is implemented by checkers that accept a context.Context,
+// with constant-time and ordinary variants.
+type contextChecker interface {
+	CheckContext(ctx context.Context, password string) (breached bool, count int, err error)
+	CheckConstantTimeContext(ctx context.Context, password string) (breached bool, count int, err error)
 }
 
 // Result is a pre-computed HIBP check result.
@@ -34,7 +61,7 @@ func CheckWith(password string, opts Options) []issue.Issue {
 		count = opts.Result.Count
 	} else if opts.Checker != nil {
 		var err error
-		breached, count, err = opts.Checker.Check(password)
+		breached, count, err = checkFunc(opts)(password)
 		if err != nil {
 			// Graceful degradation: errors from the HIBP checker are intentionally
 			// ignored so that the core analysis can continue even if the network
@@ -48,7 +75,9 @@ func CheckWith(password string, opts Options) []issue.Issue {
 		minOcc = 1
 	}
 
-	if breached && count >= minOcc {
+	// A count of 0 means the checker knows presence but not frequency
+	// (e.g. a Bloom filter), so MinOccurrences cannot apply.
+	if breached && (count == 0 || count >= minOcc) {
 		return []issue.Issue{
 			issue.New(
 				issue.CodeHIBPBreached,
@@ -61,3 +90,20 @@ func CheckWith(password string, opts Options) []issue.Issue {
 
 	return nil
 }
+
+// checkFunc returns the checker method to call under opts: the
+// context-aware variant when opts.Context is set, and the constant-time
+// variant when opts.ConstantTime is set, as far as the checker offers them.
+func checkFunc(opts Options) func(password string) (bool, int, error) {
+	if cc, ok := opts.Checker.(contextChecker); ok && opts.Context != nil {
+		check := cc.CheckContext
+		if opts.ConstantTime {
+			check = cc.CheckConstantTimeContext
+		}
+		return func(password string) (bool, int, error) { return check(opts.Context, password) }
+	}
+	if ct, ok := opts.Checker.(constantTimeChecker); ok && opts.ConstantTime {
+		return ct.CheckConstantTime
+	}
+	return opts.Checker.Check
+}
@@ -1,6 +1,7 @@
 package hibpcheck
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -78,6 +79,16 @@ func TestCheckWith(t *testing.T) {
 			},
 			wantIssues: 0,
 		},
+		{
+			name:     "breached with unknown count ignores MinOccurrences",
+			password: "password123",
+			opts: Options{
+				Result:         &Result{Breached: true, Count: 0},
+				MinOccurrences: 5,
+			},
+			wantIssues: 1,
+			wantCode:   issue.CodeHIBPBreached,
+		},
 		{
 			name:     "breached and count at MinOccurrences",
 			password: "password123",
@@ -102,3 +113,77 @@ func TestCheckWith(t *testing.T) {
 		})
 	}
 }
+
+// ctChecker records which check method was called.
+type ctChecker struct {
+	plain, constantTime int
+}
+
+func (c *ctChecker) Check(string) (bool, int, error) {
+	c.plain++
+	return true, 1, nil
+}
+
+func (c *ctChecker) CheckConstantTime(string) (bool, int, error) {
+	c.constantTime++
+	return true, 1, nil
+}
+
+func TestCheckWith_ConstantTime(t *testing.T) {
+	c := &ctChecker{}
+	CheckWith("password", Options{Checker: c})
+	if c.plain != 1 || c.constantTime != 0 {
+		t.Errorf("default: plain=%d constantTime=%d, want 1 and 0", c.plain, c.constantTime)
+	}
+	got := CheckWith("password", Options{Checker: c, ConstantTime: true})
+	if c.plain != 1 || c.constantTime != 1 {
+		t.Errorf("ConstantTime: plain=%d constantTime=%d, want 1 and 1", c.plain, c.constantTime)
+	}
+	if len(got) != 1 {
+		t.Errorf("ConstantTime: %d issues, want 1", len(got))
+	}
+
+	// Checkers without a constant-time variant fall back to Check.
+	m := &mockChecker{checkFunc: func(string) (bool, int, error) { return true, 1, nil }}
+	if len(CheckWith("password", Options{Checker: m, ConstantTime: true})) != 1 {
+		t.Error("expected fallback to Check")
+	}
+}
+
+type ctxChecker struct {
+	ctChecker
+	ctx       context.Context
+	ctVariant bool
+}
+
+func (c *ctxChecker) CheckContext(ctx context.Context, _ string) (bool, int, error) {
+	c.ctx = ctx
+	return false, 0, ctx.Err()
+}
+
+func (c *ctxChecker) CheckConstantTimeContext(ctx context.Context, pw string) (bool, int, error) {
+	c.ctVariant = true
+	return c.CheckContext(ctx, pw)
+}
+
+func TestCheckWith_Context(t *testing.T) {
+	c := &ctxChecker{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := CheckWith("password", Options{Checker: c, Context: ctx}); len(got) != 0 {
+		t.Errorf("cancelled lookup: %d issues, want none", len(got))
+	}
+	if c.ctx != ctx || c.ctVariant || c.plain != 0 {
+		t.Errorf("expected CheckContext with the options' context")
+	}
+
+	CheckWith("password", Options{Checker: c, Context: ctx, ConstantTime: true})
+	if !c.ctVariant {
+		t.Error("ConstantTime: expected CheckConstantTimeContext")
+	}
+
+	// Without a context the plain methods are used.
+	if got := CheckWith("password", Options{Checker: c}); len(got) != 1 || c.plain != 1 {
+		t.Errorf("no context: %d issues and %d Check calls, want 1 and 1", len(got), c.plain)
+	}
+}
@@ -0,0 +1,164 @@
+// Package history implements password-history checks.
+//
+// It compares a new password against the user's previous passwords,
+// supplied transiently in plaintext during a password change, to reject
+// rotations that keep most of an old password (e.g. "MyDogMax1" →
+// "MyDogMax2024") or are a trivial edit of one (e.g. "Summer2024" →
+// "Summer2025").
+//
+// Comparisons are case-insensitive and run over runes. The lowercased
+// working copies are zeroed after use, and no issue message ever contains
+// any part of a previous password.
+//
+// It can also detect reuse of the current password from its stored hash
+// (Argon2 or bcrypt), without the plaintext.
+package history
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/pwhash"
+	"github.com/rafaelsanzio/passcheck/internal/safemem"
+)
+
+// Options holds configuration for history checks.
+type Options struct {
+	// Previous is the list of prior plaintext passwords to compare against.
+	// Nil or empty disables all history checks.
+	Previous []string
+
+	// MaxSharedSubstring is the longest common substring, in runes, that
+	// the password may share with any previous password. Longer shared
+	// substrings are reported as HISTORY_SHARED_SUBSTRING. Zero disables
+	// the check.
+	MaxSharedSubstring int
+
+	// MinEditDistance is the minimum Levenshtein distance, in runes, that
+	// the password must keep from every previous password. Closer
+	// passwords are reported as RULE_TOO_SIMILAR. Zero disables the check.
+	MinEditDistance int
+
+	// CurrentHash is the stored hash of the current password (see package
+	// pwhash). A password that verifies against it is reported as
+	// HISTORY_REUSE. Empty disables the check.
+	CurrentHash string
+}
+
+// CheckWith runs the history checks enabled in opts against password.
+func CheckWith(password string, opts Options) []issue.Issue {
+	if opts.CurrentHash != "" && reusesCurrent(password, opts.CurrentHash) {
+		// Exact reuse supersedes any shared-substring finding.
+		n := utf8.RuneCountInString(password)
+		return []issue.Issue{
+			issue.New(issue.CodeHistoryReuse, "Is the same as the current password", issue.CategoryHistory, issue.SeverityHigh).At(0, n),
+		}
+	}
+	if len(opts.Previous) == 0 || (opts.MaxSharedSubstring <= 0 && opts.MinEditDistance <= 0) {
+		return nil
+	}
+
+	pw := lowerRunes(password)
+	defer zeroRunes(pw)
+
+	var shared, similar *issue.Issue
+	for _, prev := range opts.Previous {
+		old := lowerRunes(prev)
+		if shared == nil && opts.MaxSharedSubstring > 0 {
+			if start, n := longestCommonSubstring(pw, old); n > opts.MaxSharedSubstring {
+				iss := issue.New(issue.CodeHistorySharedSubstring, "Shares too long a part with a previous password", issue.CategoryHistory, issue.SeverityHigh).At(start, start+n)
+				shared = &iss
+			}
+		}
+		if similar == nil && opts.MinEditDistance > 0 && levenshtein(pw, old) < opts.MinEditDistance {
+			iss := issue.New(issue.CodeRuleTooSimilar, "Is too similar to a previous password", issue.CategoryHistory, issue.SeverityHigh).At(0, len(pw))
+			similar = &iss
+		}
+		zeroRunes(old)
+	}
+
+	var issues []issue.Issue
+	for _, iss := range []*issue.Issue{shared, similar} {
+		if iss != nil {
+			issues = append(issues, *iss)
+		}
+	}
+	return issues
+}
+
+// reusesCurrent reports whether password verifies against the current
+// password's hash. Unparseable hashes never match; Config.Validate rejects
+// them before a check runs.
+func reusesCurrent(password, hash string) bool {
+	buf := []byte(password)
+	defer safemem.Zero(buf)
+	ok, err := pwhash.Verify(buf, hash)
+	return err == nil && ok
+}
+
+// longestCommonSubstring returns the start offset in a and the length of
+// the longest run of runes common to a and b. It uses the classic dynamic
+// programming recurrence with a single rolling row, in O(len(a)×len(b))
+// time and O(len(b)) space.
+func longestCommonSubstring(a, b []rune) (start, length int) {
+	row := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		prevDiag := 0
+		for j := 1; j <= len(b); j++ {
+			above := row[j]
+			if a[i-1] == b[j-1] {
+				row[j] = prevDiag + 1
+				if row[j] > length {
+					length = row[j]
+					start = i - length
+				}
+			} else {
+				row[j] = 0
+			}
+			prevDiag = above
+		}
+	}
+	return start, length
+}
+
+// levenshtein returns the edit distance between a and b: the minimum
+// number of rune insertions, deletions, and substitutions turning one into
+// the other. It uses a single rolling row, in O(len(a)×len(b)) time and
+// O(len(b)) space.
+func levenshtein(a, b []rune) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prevDiag := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			above := row[j]
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = min(above+1, row[j-1]+1, prevDiag+cost)
+			prevDiag = above
+		}
+	}
+	return row[len(b)]
+}
+
+// lowerRunes returns the lowercased runes of s in a fresh slice.
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// zeroRunes overwrites every rune in r with zero.
+func zeroRunes(r []rune) {
+	for i := range r {
+		r[i] = 0
+	}
+}
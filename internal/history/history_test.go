@@ -0,0 +1,195 @@
+package history
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+func TestCheckWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		opts       Options
+		wantIssues int
+		wantStart  int
+		wantEnd    int
+	}{
+		{
+			name:       "same base new suffix",
+			password:   "MyDogMax2024",
+			opts:       Options{Previous: []string{"MyDogMax1"}, MaxSharedSubstring: 5},
+			wantIssues: 1,
+			wantStart:  0,
+			wantEnd:    8,
+		},
+		{
+			name:       "shared base with new prefix",
+			password:   "2025!Summer-Lake",
+			opts:       Options{Previous: []string{"Summer-Lake!2024"}, MaxSharedSubstring: 6},
+			wantIssues: 1,
+			wantStart:  5,
+			wantEnd:    16,
+		},
+		{
+			name:       "case-insensitive",
+			password:   "mydogmax2024",
+			opts:       Options{Previous: []string{"MYDOGMAX1"}, MaxSharedSubstring: 5},
+			wantIssues: 1,
+			wantStart:  0,
+			wantEnd:    8,
+		},
+		{
+			name:       "second previous password matches",
+			password:   "MyDogMax2024",
+			opts:       Options{Previous: []string{"unrelated", "MyDogMax1"}, MaxSharedSubstring: 5},
+			wantIssues: 1,
+			wantStart:  0,
+			wantEnd:    8,
+		},
+		{
+			name:     "shared substring at the limit",
+			password: "MyDogRex",
+			opts:     Options{Previous: []string{"MyDogMax1"}, MaxSharedSubstring: 5},
+		},
+		{
+			name:     "unrelated passwords",
+			password: "Xk9$mP2!vR7@",
+			opts:     Options{Previous: []string{"MyDogMax1"}, MaxSharedSubstring: 3},
+		},
+		{
+			name:     "disabled by zero max",
+			password: "MyDogMax2024",
+			opts:     Options{Previous: []string{"MyDogMax1"}},
+		},
+		{
+			name:     "no previous passwords",
+			password: "MyDogMax2024",
+			opts:     Options{MaxSharedSubstring: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := CheckWith(tt.password, tt.opts)
+			if len(issues) != tt.wantIssues {
+				t.Fatalf("got %d issues, want %d: %v", len(issues), tt.wantIssues, issues)
+			}
+			if tt.wantIssues == 0 {
+				return
+			}
+			iss := issues[0]
+			if iss.Code != issue.CodeHistorySharedSubstring {
+				t.Errorf("Code = %q, want %q", iss.Code, issue.CodeHistorySharedSubstring)
+			}
+			if iss.Start != tt.wantStart || iss.End != tt.wantEnd {
+				t.Errorf("span = [%d,%d), want [%d,%d)", iss.Start, iss.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCheckWith_MessageOmitsPrevious(t *testing.T) {
+	prev := "MyDogMax1"
+	issues := CheckWith("MyDogMax2024", Options{Previous: []string{prev}, MaxSharedSubstring: 5})
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1", len(issues))
+	}
+	msg := strings.ToLower(issues[0].Message)
+	if strings.Contains(msg, "mydog") || strings.Contains(msg, strings.ToLower(prev)) {
+		t.Errorf("message leaks the previous password: %q", issues[0].Message)
+	}
+}
+
+func TestCheckWith_CurrentHash(t *testing.T) {
+	// bcrypt (cost 10) of "Tr0ub4dor&3".
+	const hash = "$2a$10$h9UyaS0LerXKJe2rZNVl7OYm6PvsNdNl5YxEIpoObWh4jZGd7X8Vu"
+
+	issues := CheckWith("Tr0ub4dor&3", Options{CurrentHash: hash, Previous: []string{"Tr0ub4dor&3"}, MaxSharedSubstring: 4})
+	if len(issues) != 1 || issues[0].Code != issue.CodeHistoryReuse {
+		t.Fatalf("got %v, want a single HISTORY_REUSE", issues)
+	}
+	if issues[0].Start != 0 || issues[0].End != 11 {
+		t.Errorf("span = [%d,%d), want [0,11)", issues[0].Start, issues[0].End)
+	}
+
+	if issues := CheckWith("tr0ub4dor&3", Options{CurrentHash: hash}); len(issues) != 0 {
+		t.Errorf("hash comparison must be exact, got %v", issues)
+	}
+	if issues := CheckWith("Tr0ub4dor&3", Options{CurrentHash: "not a hash"}); len(issues) != 0 {
+		t.Errorf("unparseable hash should not match, got %v", issues)
+	}
+}
+
+func TestCheckWith_MinEditDistance(t *testing.T) {
+	opts := Options{Previous: []string{"unrelated!", "Summer2024"}, MinEditDistance: 4}
+
+	for _, pw := range []string{"Summer2025", "SUMMER2024", "Summer2024!", "summer24"} {
+		issues := CheckWith(pw, opts)
+		if len(issues) != 1 || issues[0].Code != issue.CodeRuleTooSimilar {
+			t.Errorf("%q: got %v, want a single %s", pw, issues, issue.CodeRuleTooSimilar)
+			continue
+		}
+		if issues[0].Category != issue.CategoryHistory || issues[0].Start != 0 || issues[0].End != len([]rune(pw)) {
+			t.Errorf("%q: got %+v, want history issue spanning the password", pw, issues[0])
+		}
+		if strings.Contains(strings.ToLower(issues[0].Message), "summer") {
+			t.Errorf("message leaks the previous password: %q", issues[0].Message)
+		}
+	}
+
+	for _, pw := range []string{"Winter2025!", "Xk9$mP2!vR7@"} {
+		if issues := CheckWith(pw, opts); len(issues) != 0 {
+			t.Errorf("%q: got %v, want no issues", pw, issues)
+		}
+	}
+}
+
+func TestCheckWith_SharedSubstringAndEditDistance(t *testing.T) {
+	issues := CheckWith("MyDogMax2", Options{Previous: []string{"MyDogMax1"}, MaxSharedSubstring: 5, MinEditDistance: 4})
+	if len(issues) != 2 || issues[0].Code != issue.CodeHistorySharedSubstring || issues[1].Code != issue.CodeRuleTooSimilar {
+		t.Errorf("got %v, want HISTORY_SHARED_SUBSTRING and RULE_TOO_SIMILAR", issues)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"abc", "abc", 0},
+		{"kitten", "sitting", 3},
+		{"summer2024", "summer2025", 1},
+		{"héllo", "hello", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		wantStart  int
+		wantLength int
+	}{
+		{"", "abc", 0, 0},
+		{"abc", "", 0, 0},
+		{"abc", "xyz", 0, 0},
+		{"xxabcdyy", "abcd", 2, 4},
+		{"héllo", "jéllo", 1, 4},
+	}
+	for _, tt := range tests {
+		start, n := longestCommonSubstring([]rune(tt.a), []rune(tt.b))
+		if start != tt.wantStart || n != tt.wantLength {
+			t.Errorf("longestCommonSubstring(%q, %q) = (%d, %d), want (%d, %d)",
+				tt.a, tt.b, start, n, tt.wantStart, tt.wantLength)
+		}
+	}
+}
@@ -2,6 +2,11 @@
 // findings used across rules, patterns, dictionary, and feedback packages.
 package issue
 
+import (
+	"strings"
+	"unicode/utf8"
+)
+
 // Severity levels — higher is more critical.
 const (
 	SeverityLow  = 1 // rule violations (length, charset, etc.)
@@ -16,38 +21,66 @@ const (
 	CategoryDictionary = "dictionary"
 	CategoryContext    = "context"
 	CategoryBreach     = "breach"
+	CategoryHistory    = "history"
 )
 
 // Issue codes — stable identifiers for programmatic handling.
 const (
 	// Rules
-	CodeRuleTooShort      = "RULE_TOO_SHORT"
-	CodeRuleNoUpper       = "RULE_NO_UPPER"
-	CodeRuleNoLower       = "RULE_NO_LOWER"
-	CodeRuleNoDigit       = "RULE_NO_DIGIT"
-	CodeRuleNoSymbol      = "RULE_NO_SYMBOL"
-	CodeRuleWhitespace    = "RULE_WHITESPACE"
-	CodeRuleControlChar   = "RULE_CONTROL_CHAR"
-	CodeRuleRepeatedChars = "RULE_REPEATED_CHARS"
+	CodeRuleTooShort            = "RULE_TOO_SHORT"
+	CodeRuleTooLong             = "RULE_TOO_LONG"
+	CodeRuleNoUpper             = "RULE_NO_UPPER"
+	CodeRuleNoLower             = "RULE_NO_LOWER"
+	CodeRuleNoDigit             = "RULE_NO_DIGIT"
+	CodeRuleNoSymbol            = "RULE_NO_SYMBOL"
+	CodeRuleInsufficientClasses = "RULE_INSUFFICIENT_CLASSES"
+	CodeRuleWhitespace          = "RULE_WHITESPACE"
+	CodeRuleControlChar         = "RULE_CONTROL_CHAR"
+	CodeRuleRepeatedChars       = "RULE_REPEATED_CHARS"
+	CodeRuleNoAlphanumeric      = "RULE_NO_ALPHANUMERIC"
+	CodeRuleLowEntropy          = "RULE_LOW_ENTROPY"
+	CodeRuleLowDiversity        = "RULE_LOW_DIVERSITY"
+	CodeRuleNumericOnly         = "RULE_NUMERIC_ONLY"
 
 	// Patterns
-	CodePatternKeyboard     = "PATTERN_KEYBOARD"
-	CodePatternSequence     = "PATTERN_SEQUENCE"
-	CodePatternBlock        = "PATTERN_BLOCK"
-	CodePatternSubstitution = "PATTERN_SUBSTITUTION"
-	CodePatternDate         = "PATTERN_DATE"
+	CodePatternKeyboard       = "PATTERN_KEYBOARD"
+	CodePatternAdjacentWalk   = "PATTERN_ADJACENT_WALK"
+	CodePatternSequence       = "PATTERN_SEQUENCE"
+	CodePatternBlock          = "PATTERN_BLOCK"
+	CodePatternSubstitution   = "PATTERN_SUBSTITUTION"
+	CodePatternDate           = "PATTERN_DATE"
+	CodePatternForbidden      = "PATTERN_FORBIDDEN"
+	CodePatternAffixed        = "PATTERN_AFFIXED"
+	CodePatternRepeatedWord   = "PATTERN_REPEATED_WORD"
+	CodePatternTemplateRepeat = "PATTERN_TEMPLATE_REPEAT"
+	CodePatternCaseTokenized  = "PATTERN_CASE_TOKENIZED"
+
+	// Passphrases
+	CodePassphraseWeakWords = "PASSPHRASE_WEAK_WORDS"
 
 	// Dictionary
-	CodeDictCommonPassword = "DICT_COMMON_PASSWORD"
-	CodeDictLeetVariant    = "DICT_LEET_VARIANT"
-	CodeDictCommonWord     = "DICT_COMMON_WORD"
-	CodeDictCommonWordSub  = "DICT_COMMON_WORD_SUB"
+	CodeDictCommonPassword    = "DICT_COMMON_PASSWORD"
+	CodeDictLeetVariant       = "DICT_LEET_VARIANT"
+	CodeDictCommonWord        = "DICT_COMMON_WORD"
+	CodeDictCommonWordSub     = "DICT_COMMON_WORD_SUB"
+	CodeDictCapitalizedCommon = "DICT_CAPITALIZED_COMMON"
+	CodeDictMirrored          = "DICT_MIRRORED"
+	CodeDictTrending          = "DICT_TRENDING"
+	CodeDictReversedWord      = "DICT_REVERSED_WORD"
+	CodeDictPasswordSubstring = "DICT_PASSWORD_SUBSTRING"
+	CodeDictNearCommon        = "DICT_NEAR_COMMON"
+	CodeDictMangledCommon     = "DICT_MANGLED_COMMON"
 
 	// Context
 	CodeContextWord = "CONTEXT_WORD"
 
 	// HIBP (Have I Been Pwned)
 	CodeHIBPBreached = "HIBP_BREACHED"
+
+	// History
+	CodeHistorySharedSubstring = "HISTORY_SHARED_SUBSTRING"
+	CodeHistoryReuse           = "HISTORY_REUSE"
+	CodeRuleTooSimilar         = "RULE_TOO_SIMILAR" // edit distance to a previous password
 )
 
 // Issue represents a single finding from a password check.
@@ -60,6 +93,44 @@ type Issue struct {
 	// Empty for all non-pattern issues. Used by the entropy package to
 	// compute intrinsic pattern entropy without parsing Message text.
 	Pattern string
+	// Start and End are the rune offsets [Start, End) of the offending
+	// substring in the analyzed password. Both are zero for issues that do
+	// not refer to a substring (e.g. rule violations).
+	Start, End int
+	// Match is the offending substring as it appears in the password. It is
+	// filled in from Start and End by the caller, since detectors only see
+	// a lowercased copy of the password.
+	Match string
+	// Frequency is the normalized breach frequency (0, 1] of the list
+	// entry a dictionary issue matched, used to scale its penalty. Zero
+	// means unknown.
+	Frequency float64
+}
+
+// At returns a copy of i spanning the rune offsets [start, end).
+func (i Issue) At(start, end int) Issue {
+	i.Start, i.End = start, end
+	return i
+}
+
+// RuneSpan returns the rune offsets [start, end) of the first occurrence
+// of substr in s, and false when s does not contain substr.
+func RuneSpan(s, substr string) (start, end int, ok bool) {
+	b := strings.Index(s, substr)
+	if b < 0 || substr == "" {
+		return 0, 0, false
+	}
+	start = utf8.RuneCountInString(s[:b])
+	return start, start + utf8.RuneCountInString(substr), true
+}
+
+// AtSubstring returns a copy of i spanning the first occurrence of substr
+// in s, or i unchanged when s does not contain substr.
+func (i Issue) AtSubstring(s, substr string) Issue {
+	if start, end, ok := RuneSpan(s, substr); ok {
+		return i.At(start, end)
+	}
+	return i
 }
 
 // New creates an Issue with the given fields.
@@ -57,3 +57,34 @@ func TestNewPattern(t *testing.T) {
 	}
 }
 
+
+func TestRuneSpan(t *testing.T) {
+	tests := []struct {
+		s, substr  string
+		start, end int
+		ok         bool
+	}{
+		{"xxqwerty", "qwerty", 2, 8, true},
+		{"ééqwerty", "qwerty", 2, 8, true},
+		{"aéb", "éb", 1, 3, true},
+		{"abc", "xyz", 0, 0, false},
+		{"abc", "", 0, 0, false},
+	}
+	for _, tt := range tests {
+		start, end, ok := RuneSpan(tt.s, tt.substr)
+		if start != tt.start || end != tt.end || ok != tt.ok {
+			t.Errorf("RuneSpan(%q, %q) = %d, %d, %v; want %d, %d, %v",
+				tt.s, tt.substr, start, end, ok, tt.start, tt.end, tt.ok)
+		}
+	}
+}
+
+func TestAtSubstring(t *testing.T) {
+	iss := New(CodeDictCommonWord, "msg", CategoryDictionary, SeverityHigh)
+	if got := iss.AtSubstring("mydragon", "dragon"); got.Start != 2 || got.End != 8 {
+		t.Errorf("AtSubstring span = [%d, %d), want [2, 8)", got.Start, got.End)
+	}
+	if got := iss.AtSubstring("mydragon", "tiger"); got.Start != 0 || got.End != 0 {
+		t.Errorf("AtSubstring without match should leave span zero, got [%d, %d)", got.Start, got.End)
+	}
+}
@@ -1,5 +1,5 @@
 // Package leet provides leetspeak normalization utilities shared by
-// the pattern-detection and dictionary-lookup packages.
+// the pattern-detection, dictionary-lookup, and context packages.
 package leet
 
 import "strings"
@@ -53,3 +53,98 @@ func Contains(s string) bool {
 	}
 	return false
 }
+
+// Multi lists multi-character leetspeak sequences and the letter each
+// stands for, e.g. "()" → 'o' and "vv" → 'w'. [NormalizeMulti] tries them
+// in order at each position before falling back to [Map], so longer
+// sequences come first.
+var Multi = []struct {
+	Seq    string
+	Letter rune
+}{
+	{`|\/|`, 'm'},
+	{`|\|`, 'n'},
+	{`|-|`, 'h'},
+	{`()`, 'o'},
+	{`[]`, 'o'},
+	{`|<`, 'k'},
+	{`/\`, 'a'},
+	{`\/`, 'v'},
+	{`vv`, 'w'},
+}
+
+// NormalizeMulti is like [Normalize] but also replaces the multi-character
+// sequences in [Multi], so "()rder" → "order" and "vvelcome" → "welcome".
+// If no substitutions apply the original string is returned, avoiding
+// allocation.
+func NormalizeMulti(s string) string {
+	normalized, _ := NormalizeMultiOffsets(s)
+	return normalized
+}
+
+// NormalizeMultiOffsets returns [NormalizeMulti] of s together with the
+// rune offsets mapping it back onto s: rune i of the normalized string
+// comes from runes [offsets[i], offsets[i+1]) of s. offsets has one more
+// element than the normalized string has runes, so a span [start, end) of
+// the normalized string covers [offsets[start], offsets[end]) of s.
+//
+// When no substitutions apply, s is returned with nil offsets, without
+// allocating.
+func NormalizeMultiOffsets(s string) (normalized string, offsets []int) {
+	if !Contains(s) && !containsMulti(s) {
+		return s, nil
+	}
+	runes := []rune(s)
+	out := make([]rune, 0, len(runes))
+	offsets = make([]int, 0, len(runes)+1)
+	for i := 0; i < len(runes); {
+		offsets = append(offsets, i)
+		if letter, n := matchMulti(runes[i:]); n > 0 {
+			out = append(out, letter)
+			i += n
+			continue
+		}
+		r := runes[i]
+		if repl, ok := Map[r]; ok {
+			r = repl
+		}
+		out = append(out, r)
+		i++
+	}
+	offsets = append(offsets, len(runes))
+	return string(out), offsets
+}
+
+// matchMulti returns the letter and rune length of the first [Multi]
+// sequence that runes starts with, or 0 when none matches.
+func matchMulti(runes []rune) (letter rune, n int) {
+	for _, m := range Multi {
+		if hasRunePrefix(runes, m.Seq) {
+			return m.Letter, len(m.Seq)
+		}
+	}
+	return 0, 0
+}
+
+// hasRunePrefix reports whether runes starts with the ASCII string prefix.
+func hasRunePrefix(runes []rune, prefix string) bool {
+	if len(runes) < len(prefix) {
+		return false
+	}
+	for i := range len(prefix) {
+		if runes[i] != rune(prefix[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsMulti reports whether s contains any [Multi] sequence.
+func containsMulti(s string) bool {
+	for _, m := range Multi {
+		if strings.Contains(s, m.Seq) {
+			return true
+		}
+	}
+	return false
+}
@@ -72,3 +72,57 @@ func BenchmarkNormalize_Leet(b *testing.B) {
 		Normalize("p@$$w0rd!23")
 	}
 }
+
+func TestNormalizeMulti(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"hello", "hello"},
+		{"", ""},
+		{"()rder", "order"},
+		{"vvelcome", "welcome"},
+		{"[]pen", "open"},
+		{"|-|ello", "hello"},
+		{"|\\|ame", "name"},
+		{"|\\/|onkey", "monkey"},
+		{"/\\dmin", "admin"},
+		{"|<ey", "key"},
+		{"p@$$vv()rd", "password"},
+		{"|eet", "leet"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeMulti(tt.input); got != tt.want {
+			t.Errorf("NormalizeMulti(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeMultiOffsets(t *testing.T) {
+	got, offsets := NormalizeMultiOffsets("x()rd")
+	if got != "xord" {
+		t.Fatalf("normalized = %q, want %q", got, "xord")
+	}
+	want := []int{0, 1, 3, 4, 5}
+	if len(offsets) != len(want) {
+		t.Fatalf("offsets = %v, want %v", offsets, want)
+	}
+	for i := range want {
+		if offsets[i] != want[i] {
+			t.Fatalf("offsets = %v, want %v", offsets, want)
+		}
+	}
+
+	if got, offsets := NormalizeMultiOffsets("hello"); got != "hello" || offsets != nil {
+		t.Errorf("NormalizeMultiOffsets(%q) = %q, %v, want unchanged and nil", "hello", got, offsets)
+	}
+}
+
+func TestNormalizeMulti_NoAllocWithoutSubstitutions(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = NormalizeMulti("correcthorsebatterystaple")
+	})
+	if allocs != 0 {
+		t.Errorf("NormalizeMulti allocated %v times, want 0", allocs)
+	}
+}
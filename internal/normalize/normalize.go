@@ -0,0 +1,202 @@
+// Package normalize folds visually equivalent Unicode spellings of a
+// password onto one form before analysis, so that "é" typed as "e" plus a
+// combining accent, a fullwidth "ｐａｓｓ", or "pаss" with a Cyrillic "а"
+// are all checked like their plain Latin counterparts.
+//
+// It implements the parts of NFKC normalization that matter for password
+// checks — canonical composition of Latin letters with combining marks and
+// the compatibility mappings for fullwidth forms, ligatures, super- and
+// subscript digits, circled and mathematical alphanumerics, and
+// non-breaking spaces — plus a fold of confusable Cyrillic and Greek
+// homoglyphs onto Latin letters, without depending on golang.org/x/text.
+package normalize
+
+import "unicode/utf8"
+
+// marks lists the combining marks that [Fold] composes with a preceding
+// base letter. For each mark, rune i of base composes to rune i of
+// composed.
+var marks = []struct {
+	mark           rune
+	base, composed string
+}{
+	{'\u0300', "AEIOUaeiouNnWwYy", "ÀÈÌÒÙàèìòùǸǹẀẁỲỳ"},                         // grave
+	{'\u0301', "AEIOUYaeiouyCcGgLlNnRrSsZzWw", "ÁÉÍÓÚÝáéíóúýĆćǴǵĹĺŃńŔŕŚśŹźẂẃ"}, // acute
+	{'\u0302', "AEIOUaeiouCcGgHhJjSsWwYy", "ÂÊÎÔÛâêîôûĈĉĜĝĤĥĴĵŜŝŴŵŶŷ"},         // circumflex
+	{'\u0303', "ANOanoIiUuEeYy", "ÃÑÕãñõĨĩŨũẼẽỸỹ"},                             // tilde
+	{'\u0304', "AEIOUaeiou", "ĀĒĪŌŪāēīōū"},                                     // macron
+	{'\u0306', "AEGIOUaegiou", "ĂĔĞĬŎŬăĕğĭŏŭ"},                                 // breve
+	{'\u0307', "CEGIZcegz", "ĊĖĠİŻċėġż"},                                       // dot above
+	{'\u0308', "AEIOUaeiouyYWw", "ÄËÏÖÜäëïöüÿŸẄẅ"},                             // diaeresis
+	{'\u030A', "AaUu", "ÅåŮů"},                                                 // ring above
+	{'\u030B', "OoUu", "ŐőŰű"},                                                 // double acute
+	{'\u030C', "CcDdEeNnRrSsTtZz", "ČčĎďĚěŇňŘřŠšŤťŽž"},                         // caron
+	{'\u0327', "CcGKkLlNnRrSsTt", "ÇçĢĶķĻļŅņŖŗŞşŢţ"},                           // cedilla
+	{'\u0328', "AaEeIiUu", "ĄąĘęĮįŲų"},                                         // ogonek
+}
+
+// compose maps a base letter and a combining mark to the precomposed
+// letter, built from marks.
+var compose = buildCompose()
+
+func buildCompose() map[[2]rune]rune {
+	m := make(map[[2]rune]rune)
+	for _, mk := range marks {
+		composed := []rune(mk.composed)
+		for i, b := range []rune(mk.base) {
+			m[[2]rune{b, mk.mark}] = composed[i]
+		}
+	}
+	return m
+}
+
+// compat holds the NFKC compatibility mappings that are not covered by
+// the ranges in [compatRange].
+var compat = map[rune]string{
+	'\u00A0': " ", // no-break space
+	'\u2007': " ", // figure space
+	'\u202F': " ", // narrow no-break space
+	'\u3000': " ", // ideographic space
+	'¹':      "1",
+	'²':      "2",
+	'³':      "3",
+	'⁰':      "0",
+	'ⁱ':      "i",
+	'ⁿ':      "n",
+	'ﬀ':      "ff",
+	'ﬁ':      "fi",
+	'ﬂ':      "fl",
+	'ﬃ':      "ffi",
+	'ﬄ':      "ffl",
+	'ﬅ':      "st",
+	'ﬆ':      "st",
+	'Ĳ':      "IJ",
+	'ĳ':      "ij",
+	'ǆ':      "dž",
+	'ǉ':      "lj",
+	'ǌ':      "nj",
+	'ſ':      "s", // long s
+}
+
+// homoglyphs folds Cyrillic and Greek letters that are visually
+// indistinguishable from a Latin letter onto that letter.
+var homoglyphs = map[rune]rune{
+	// Cyrillic lowercase
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x',
+	'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'һ': 'h', 'ԛ': 'q', 'ԝ': 'w',
+	'ӏ': 'l',
+	// Cyrillic uppercase
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'У': 'Y', 'І': 'I', 'Ј': 'J',
+	'Ѕ': 'S', 'Ԛ': 'Q', 'Ԝ': 'W',
+	// Greek
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'ο': 'o', 'ν': 'v', 'ι': 'i', 'κ': 'k', 'ρ': 'p',
+}
+
+// Mathematical alphanumeric symbols: 13 styles of A–Z a–z starting at
+// mathLetters, then 5 styles of 0–9 starting at mathDigits. Reserved code
+// points inside the letter block never occur in valid text, so mapping
+// the whole block arithmetically is safe.
+const (
+	mathLetters    = 0x1D400
+	mathLettersEnd = 0x1D6A3
+	mathDigits     = 0x1D7CE
+	mathDigitsEnd  = 0x1D7FF
+)
+
+// compatRange returns the NFKC mapping of r for the contiguous blocks of
+// compatibility characters, or false when r is in none of them.
+func compatRange(r rune) (rune, bool) {
+	switch {
+	case r >= '！' && r <= '～': // fullwidth ASCII
+		return r - 0xFF01 + '!', true
+	case r >= '⁴' && r <= '⁹': // superscript 4–9
+		return r - 0x2074 + '4', true
+	case r >= '₀' && r <= '₉': // subscript 0–9
+		return r - 0x2080 + '0', true
+	case r >= '①' && r <= '⑨': // circled 1–9
+		return r - 0x2460 + '1', true
+	case r >= 'Ⓐ' && r <= 'Ⓩ': // circled A–Z
+		return r - 0x24B6 + 'A', true
+	case r >= 'ⓐ' && r <= 'ⓩ': // circled a–z
+		return r - 0x24D0 + 'a', true
+	case r >= mathLetters && r <= mathLettersEnd:
+		i := (r - mathLetters) % 52
+		if i < 26 {
+			return 'A' + i, true
+		}
+		return 'a' + i - 26, true
+	case r >= mathDigits && r <= mathDigitsEnd:
+		return '0' + (r-mathDigits)%10, true
+	}
+	return 0, false
+}
+
+// Fold returns s with combining marks composed onto their base letters,
+// compatibility characters replaced by their NFKC mapping, and homoglyphs
+// folded onto Latin letters, together with the rune offsets mapping the
+// result back onto s: rune i of the result comes from runes
+// [offsets[i], offsets[i+1]) of s, and offsets has one more element than
+// the result has runes. Runes expanded from one source rune (such as the
+// ligature "ﬁ") all map to that rune.
+//
+// When s is unchanged, s is returned with nil offsets, without allocating.
+func Fold(s string) (folded string, offsets []int) {
+	if !needsFold(s) {
+		return s, nil
+	}
+	out := make([]rune, 0, len(s))
+	offsets = make([]int, 0, len(s)+1)
+	i := 0
+	for _, r := range s {
+		if n := len(out); n > 0 {
+			if c, ok := compose[[2]rune{out[n-1], r}]; ok {
+				// The mark joins the previous rune's source span.
+				out[n-1] = c
+				i++
+				continue
+			}
+		}
+		switch m, ok := compatRange(r); {
+		case ok:
+			out = append(out, m)
+			offsets = append(offsets, i)
+		case compat[r] != "":
+			for _, c := range compat[r] {
+				out = append(out, c)
+				offsets = append(offsets, i)
+			}
+		case homoglyphs[r] != 0:
+			out = append(out, homoglyphs[r])
+			offsets = append(offsets, i)
+		default:
+			out = append(out, r)
+			offsets = append(offsets, i)
+		}
+		i++
+	}
+	offsets = append(offsets, i)
+	return string(out), offsets
+}
+
+// needsFold reports whether [Fold] would change s. ASCII strings never
+// change, which keeps the common case cheap.
+func needsFold(s string) bool {
+	var prev rune
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			prev = r
+			continue
+		}
+		if _, ok := compose[[2]rune{prev, r}]; ok {
+			return true
+		}
+		if _, ok := compatRange(r); ok || compat[r] != "" || homoglyphs[r] != 0 {
+			return true
+		}
+		prev = r
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMarksAligned(t *testing.T) {
+	for _, mk := range marks {
+		if b, c := utf8.RuneCountInString(mk.base), utf8.RuneCountInString(mk.composed); b != c {
+			t.Errorf("mark %U: %d base letters, %d composed letters", mk.mark, b, c)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"ascii", "Password123!", "Password123!"},
+		{"precomposed", "café", "café"},
+		{"combining acute", "cafe\u0301", "café"},
+		{"combining cedilla", "garc\u0327on", "garçon"},
+		{"mark without base", "\u0301abc", "\u0301abc"},
+		{"fullwidth", "ｐａｓｓｗｏｒｄ１２３", "password123"},
+		{"ideographic space", "a　b", "a b"},
+		{"no-break space", "a\u00A0b", "a b"},
+		{"ligature", "ﬂower", "flower"},
+		{"superscript", "x²y⁵", "x2y5"},
+		{"subscript", "h₂o", "h2o"},
+		{"circled", "ⓟⓐⓢⓢ①②", "pass12"},
+		{"math bold", "𝐩𝐚𝐬𝐬𝟏", "pass1"},
+		{"math double-struck digit", "𝟙𝟚", "12"},
+		{"cyrillic a", "p\u0430ssword", "password"},
+		{"cyrillic upper", "\u0420\u0410SSWORD", "PASSWORD"},
+		{"greek omicron", "passw\u03BFrd", "password"},
+		{"homoglyph then mark", "\u0430\u0301", "á"},
+		{"other scripts", "密码\u043F\u0430\u0440\u043E\u043B\u044C", "密码\u043Fapo\u043B\u044C"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, offsets := Fold(tt.in)
+			if got != tt.want {
+				t.Errorf("Fold(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if got == tt.in {
+				return
+			}
+			n := utf8.RuneCountInString(got)
+			if len(offsets) != n+1 {
+				t.Fatalf("len(offsets) = %d, want %d", len(offsets), n+1)
+			}
+			if last := offsets[n]; last != utf8.RuneCountInString(tt.in) {
+				t.Errorf("offsets[%d] = %d, want %d", n, last, utf8.RuneCountInString(tt.in))
+			}
+		})
+	}
+}
+
+func TestFold_Unchanged_NoOffsets(t *testing.T) {
+	for _, s := range []string{"", "hunter2", "café", "密码"} {
+		got, offsets := Fold(s)
+		if got != s || offsets != nil {
+			t.Errorf("Fold(%q) = %q, %v; want unchanged with nil offsets", s, got, offsets)
+		}
+	}
+}
+
+func TestFold_Offsets(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []int
+	}{
+		// "e" + U+0301 folds into one rune spanning both source runes.
+		{"xe\u0301y", []int{0, 1, 3, 4}},
+		// "ﬁ" expands to two runes that both come from source rune 1.
+		{"aﬁb", []int{0, 1, 1, 2, 3}},
+		{"p\u0430ss", []int{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		if _, got := Fold(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Fold(%q) offsets = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,10 @@ package passphrase
 import (
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/leet"
 )
 
 // Info holds passphrase detection results.
@@ -15,29 +19,136 @@ type Info struct {
 	IsPassphrase bool   // true if detected as a passphrase (meets word count threshold)
 	WordCount    int    // number of distinct words found
 	Words        []string // individual words (lowercased, deduplicated)
+	WeakWords    []string // words excluded as low-diversity or common passwords
+}
+
+// Options configures passphrase detection.
+type Options struct {
+	// MinWords is the minimum number of words required to consider the
+	// password a passphrase. Values below 1 are treated as 1.
+	MinWords int
+
+	// IsCommon, when non-nil, excludes words for which it returns true
+	// (e.g. "password", "qwerty"), so degenerate word choices cannot
+	// inflate the word count. It receives lowercased words.
+	IsCommon func(word string) bool
+
+	// NormalizeLeet, when true, undoes leetspeak within each word before
+	// splitting, so "C0rr3ct-H0rs3" counts the words "correct" and
+	// "horse" instead of being broken up at its digits and symbols.
+	NormalizeLeet bool
 }
 
 // Detect analyzes a password and returns passphrase information.
 // It detects word boundaries using spaces, hyphens, camelCase, and snake_case.
 //
 // minWords is the minimum number of words required to consider it a passphrase.
+// Low-diversity words such as "aaaa" or "abab" do not count toward minWords.
 func Detect(password string, minWords int) Info {
-	if minWords < 1 {
-		minWords = 1
-	}
+	return DetectWith(password, Options{MinWords: minWords})
+}
 
-	words := extractWords(password)
-	uniqueWords := deduplicate(words)
+// DetectWith is like [Detect] with the additional word filtering and leet
+// normalization described by opts.
+func DetectWith(password string, opts Options) Info {
+	minWords := max(opts.MinWords, 1)
+	if opts.NormalizeLeet {
+		password = normalizeLeetWords(password)
+	}
 
-	info := Info{
-		WordCount: len(uniqueWords),
-		Words:     uniqueWords,
+	var info Info
+	for _, w := range deduplicate(extractWords(password)) {
+		if isWeakWord(w, opts.IsCommon) {
+			info.WeakWords = append(info.WeakWords, w)
+			continue
+		}
+		info.Words = append(info.Words, w)
 	}
+	info.WordCount = len(info.Words)
 	info.IsPassphrase = info.WordCount >= minWords
 
 	return info
 }
 
+// CheckWeakWords reports a PASSPHRASE_WEAK_WORDS issue when the password
+// was written as a passphrase — at least minWords words in total — but
+// some of its words failed the quality bar and were not counted.
+func CheckWeakWords(info Info, minWords int) []issue.Issue {
+	if len(info.WeakWords) == 0 || info.WordCount+len(info.WeakWords) < minWords {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(issue.CodePassphraseWeakWords, "Some passphrase words are repeated letters or common passwords and add little strength", issue.CategoryPattern, issue.SeverityMed),
+	}
+}
+
+// minDistinctRunes is the minimum number of distinct characters a word of
+// three or more characters needs to count as a passphrase word.
+const minDistinctRunes = 3
+
+// isWeakWord reports whether w is too low-diversity to count as a word
+// (e.g. "aaaa", "abab", "1212") or is a common password per isCommon.
+func isWeakWord(w string, isCommon func(string) bool) bool {
+	distinct := make(map[rune]struct{}, len(w))
+	n := 0
+	for _, r := range w {
+		distinct[r] = struct{}{}
+		n++
+	}
+	if len(distinct) == 1 || (n >= minDistinctRunes && len(distinct) < minDistinctRunes) {
+		return true
+	}
+	return isCommon != nil && isCommon(w)
+}
+
+// normalizeLeetWords undoes leetspeak in each space-, hyphen-, or
+// underscore-separated segment that becomes all letters once normalized,
+// e.g. "H0rs3" → "Horse" and "B@tt3ry" → "Battery". Segments that stay
+// partly non-letters, such as "2024" or "dragon99", are left unchanged so
+// appended numbers are not mistaken for words.
+func normalizeLeetWords(password string) string {
+	if !leet.Contains(password) {
+		return password
+	}
+	var b strings.Builder
+	b.Grow(len(password))
+	start := 0
+	flush := func(end int) {
+		seg := password[start:end]
+		if norm := leet.Normalize(seg); norm != seg && isLetters(norm) {
+			seg = norm
+		}
+		b.WriteString(seg)
+	}
+	for i, r := range password {
+		if isSeparator(r) {
+			flush(i)
+			b.WriteRune(r)
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	flush(len(password))
+	return b.String()
+}
+
+// isLetters reports whether s is non-empty and consists only of letters.
+func isLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSeparator reports whether r is an explicit word separator.
+func isSeparator(r rune) bool {
+	return r == ' ' || r == '-' || r == '_'
+}
+
 // extractWords splits the password into words using multiple strategies:
 // 1. Spaces and hyphens as explicit separators
 // 2. camelCase boundaries (lowercase followed by uppercase)
@@ -54,7 +165,7 @@ func extractWords(password string) []string {
 
 	for i, r := range runes {
 		// Explicit separators: space, hyphen, underscore
-		if r == ' ' || r == '-' || r == '_' {
+		if isSeparator(r) {
 			if current.Len() > 0 {
 				words = append(words, current.String())
 				current.Reset()
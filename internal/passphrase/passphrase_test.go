@@ -1,6 +1,11 @@
 package passphrase
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
 
 func TestDetect_Spaces(t *testing.T) {
 	info := Detect("correct horse battery staple", 4)
@@ -174,3 +179,72 @@ func TestDeduplicate_FiltersShort(t *testing.T) {
 		t.Errorf("expected 3 words (filtered short), got %d", len(output))
 	}
 }
+
+func TestDetect_DegenerateWords(t *testing.T) {
+	for _, pw := range []string{"aaaa bbbb cccc dddd", "abab cdcd efef ghgh", "1111-2222-3333-4444"} {
+		info := Detect(pw, 4)
+		if info.IsPassphrase {
+			t.Errorf("%q: degenerate words should not form a passphrase", pw)
+		}
+		if info.WordCount != 0 || len(info.WeakWords) != 4 {
+			t.Errorf("%q: WordCount = %d, WeakWords = %v", pw, info.WordCount, info.WeakWords)
+		}
+		if len(CheckWeakWords(info, 4)) != 1 {
+			t.Errorf("%q: expected a weak-words issue", pw)
+		}
+	}
+}
+
+func TestDetectWith_CommonWords(t *testing.T) {
+	isCommon := func(w string) bool { return w == "password" || w == "qwerty" }
+	info := DetectWith("password qwerty battery staple", Options{MinWords: 4, IsCommon: isCommon})
+	if info.IsPassphrase || info.WordCount != 2 {
+		t.Errorf("common passwords should not count as words: %+v", info)
+	}
+	issues := CheckWeakWords(info, 4)
+	if len(issues) != 1 || issues[0].Code != issue.CodePassphraseWeakWords {
+		t.Errorf("expected %s, got %v", issue.CodePassphraseWeakWords, issues)
+	}
+}
+
+func TestDetect_GenuinePassphraseNoWeakWords(t *testing.T) {
+	info := DetectWith("correct horse battery staple", Options{MinWords: 4, IsCommon: func(string) bool { return false }})
+	if !info.IsPassphrase || info.WordCount != 4 || len(info.WeakWords) != 0 {
+		t.Errorf("genuine passphrase: %+v", info)
+	}
+	if issues := CheckWeakWords(info, 4); len(issues) != 0 {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+	// Too few words overall: not an attempted passphrase, no issue.
+	if issues := CheckWeakWords(Detect("aaaa horse", 4), 4); len(issues) != 0 {
+		t.Errorf("unexpected issues for short input: %v", issues)
+	}
+}
+
+func TestDetectWith_NormalizeLeet(t *testing.T) {
+	pw := "C0rr3ct-H0rs3-B@tt3ry-St@pl3"
+	info := DetectWith(pw, Options{MinWords: 4, NormalizeLeet: true})
+	want := []string{"correct", "horse", "battery", "staple"}
+	if !info.IsPassphrase || !reflect.DeepEqual(info.Words, want) {
+		t.Errorf("leet passphrase: %+v, want words %v", info, want)
+	}
+	if info := DetectWith(pw, Options{MinWords: 4}); reflect.DeepEqual(info.Words, want) {
+		t.Errorf("without NormalizeLeet the words should not be recovered: %+v", info)
+	}
+}
+
+func TestNormalizeLeetWords(t *testing.T) {
+	tests := map[string]string{
+		"C0rr3ct-H0rs3":      "Correct-Horse",
+		"C0rr3ctH0rs3":       "CorrectHorse",
+		"correct horse 2024": "correct horse 2024",
+		"dragon99_b@ttery":   "dragon99_battery",
+		"plain words":        "plain words",
+		"":                   "",
+	}
+	for in, want := range tests {
+		if got := normalizeLeetWords(in); got != want {
+			t.Errorf("normalizeLeetWords(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
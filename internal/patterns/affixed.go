@@ -0,0 +1,45 @@
+package patterns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// minAffixedCore is the minimum length of the core word left after
+// stripping affixes. Shorter cores match too many unrelated passwords.
+const minAffixedCore = 4
+
+// checkAffixedWord strips leading and trailing runs of digits and symbols
+// from the password and reports the remaining core when it is a common
+// word or password (e.g. "dragon123", "123dragon", "dragon!").
+//
+// isCommon reports whether a lowercase word is common; when nil, the
+// embedded commonWeakWords list is used. Passwords with nothing to strip
+// are left to the dictionary checks.
+func checkAffixedWord(password string, isCommon func(string) bool) []issue.Issue {
+	core := strings.TrimFunc(password, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if core == password || utf8.RuneCountInString(core) < minAffixedCore {
+		return nil
+	}
+	if isCommon == nil {
+		isCommon = func(w string) bool { return slices.Contains(commonWeakWords, w) }
+	}
+	if !isCommon(core) {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(
+			issue.CodePatternAffixed,
+			fmt.Sprintf("Common word with appended digits/symbols: '%s'", core),
+			issue.CategoryPattern,
+			issue.SeverityHigh,
+		).AtSubstring(password, core),
+	}
+}
@@ -62,7 +62,7 @@ func checkRepeatedBlocks(password string) []issue.Issue {
 					block,
 					issue.CategoryPattern,
 					issue.SeverityMed,
-				))
+				).At(start, start+blockLen*2))
 				if len(issues) >= maxBlockIssues {
 					return issues
 				}
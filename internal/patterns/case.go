@@ -0,0 +1,69 @@
+package patterns
+
+import (
+	"unicode"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// minCaseTokenLetters is the fewest letters the word holding the
+// exception needs: three of one case and the exception.
+const minCaseTokenLetters = 4
+
+// checkCaseTokenized detects passwords whose cased letters all share one
+// case except exactly one, when that letter sits in a word — a run of at
+// least minCaseTokenLetters letters — such as "Password" in "Password1!",
+// "passworD1", or "PASSWORd1a": the capitalization that satisfies a
+// mixed-case rule with the least effort, and one attackers' mangling rules
+// try first. The issue spans that word. An exception in a short run, as in
+// random strings like "g7#Tq2%zw8", is not a capitalized word and is
+// ignored.
+//
+// It must run on the original-case password. Letters without case, such
+// as CJK characters, end a word like digits and symbols do.
+func checkCaseTokenized(password string) []issue.Issue {
+	runes := []rune(password)
+	var upper, lower int
+	for _, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if min(upper, lower) != 1 {
+		return nil
+	}
+	minority := unicode.IsUpper
+	if lower == 1 {
+		minority = unicode.IsLower
+	}
+	i := 0
+	for i < len(runes) && !minority(runes[i]) {
+		i++
+	}
+	start, end := i, i+1
+	for start > 0 && isCased(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && isCased(runes[end]) {
+		end++
+	}
+	if end-start < minCaseTokenLetters {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(
+			issue.CodePatternCaseTokenized,
+			"Only one letter differs in case from the rest, a predictable capitalization",
+			issue.CategoryPattern,
+			issue.SeverityLow,
+		).At(start, end),
+	}
+}
+
+// isCased reports whether r is an uppercase or lowercase letter.
+func isCased(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsLower(r)
+}
@@ -2,6 +2,9 @@ package patterns
 
 import (
 	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
 )
@@ -11,24 +14,169 @@ import (
 // Matches 19xx, 20xx up to 2099, and common 6-8 digit date sequences starting with 01-31.
 var dateRegex = regexp.MustCompile(`(?:19\d{2}|20\d{2}|(?:0[1-9]|[12]\d|3[01])(?:0[1-9]|1[0-2])(?:\d{2}|\d{4}))`)
 
+// Year bounds and minimum run length for consecutive-year detection.
+const (
+	minYear        = 1900
+	maxYear        = 2099
+	minYearRunSize = 3 // at least three years, e.g. "199019911992"
+)
+
 // CheckDates identifies substring sequences that look like dates (e.g., years, MMDDYY, DDMMYY).
+// Runs of consecutive years such as "19901991199219931994" are reported once
+// as a whole rather than as individual years.
 func CheckDates(password string, minPatternLen int) []issue.Issue {
-	matches := dateRegex.FindAllString(password, -1)
-	if len(matches) == 0 {
-		return nil
-	}
-	
+	runs := findYearRuns(password)
+
 	var issues []issue.Issue
-	for _, m := range matches {
-		if len(m) >= minPatternLen {
-			issues = append(issues, issue.Issue{
-				Category: issue.CategoryPattern,
-				Severity: issue.SeverityMed,
-				Code:     issue.CodePatternDate,
-				Message:  "Contains a common date pattern ('" + m + "')",
-				Pattern:  m,
-			})
+	for _, r := range runs {
+		m := password[r[0]:r[1]]
+		start := utf8.RuneCountInString(password[:r[0]])
+		issues = append(issues, issue.Issue{
+			Category: issue.CategoryPattern,
+			Severity: issue.SeverityMed,
+			Code:     issue.CodePatternDate,
+			Message:  "Contains a run of consecutive years ('" + m + "')",
+			Pattern:  m,
+			Start:    start,
+			End:      start + len(m),
+		})
+	}
+
+	for _, loc := range dateRegex.FindAllStringIndex(password, -1) {
+		m := password[loc[0]:loc[1]]
+		if len(m) < minPatternLen || withinRuns(loc[0], loc[1], runs) {
+			continue
+		}
+		start := utf8.RuneCountInString(password[:loc[0]])
+		issues = append(issues, issue.Issue{
+			Category: issue.CategoryPattern,
+			Severity: issue.SeverityMed,
+			Code:     issue.CodePatternDate,
+			Message:  "Contains a common date pattern ('" + m + "')",
+			Pattern:  m,
+			Start:    start,
+			End:      start + len(m),
+		})
+	}
+	return issues
+}
+
+// findYearRuns returns the [start, end) byte ranges of runs of at least
+// minYearRunSize four-digit years that increase or decrease by one
+// (e.g. "199019911992", "202420232022").
+func findYearRuns(password string) [][2]int {
+	var runs [][2]int
+	for i := 0; i+4*minYearRunSize <= len(password); {
+		first, ok := yearAt(password, i)
+		if !ok {
+			i++
+			continue
+		}
+		end := i + 4
+		for _, step := range []int{1, -1} {
+			n, prev := 1, first
+			for j := i + 4; ; j += 4 {
+				y, ok := yearAt(password, j)
+				if !ok || y != prev+step {
+					break
+				}
+				n, prev = n+1, y
+			}
+			if n >= minYearRunSize {
+				end = i + 4*n
+				break
+			}
+		}
+		if end > i+4 {
+			runs = append(runs, [2]int{i, end})
+			i = end
+			continue
+		}
+		i++
+	}
+	return runs
+}
+
+// yearAt parses the four ASCII digits at password[i:i+4] as a year and
+// reports whether they form a year between minYear and maxYear.
+func yearAt(password string, i int) (int, bool) {
+	if i+4 > len(password) {
+		return 0, false
+	}
+	y := 0
+	for _, c := range []byte(password[i : i+4]) {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		y = y*10 + int(c-'0')
+	}
+	return y, y >= minYear && y <= maxYear
+}
+
+// withinRuns reports whether [start, end) lies entirely inside one of runs.
+func withinRuns(start, end int, runs [][2]int) bool {
+	for _, r := range runs {
+		if start >= r[0] && end <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDates runs CheckDates and, when opts.CurrentYear is set, singles out
+// the current and prior year: date issues matching exactly one of them get
+// a recency message, and occurrences not already covered by a date issue
+// (e.g. when the minimum pattern length exceeds four) are added.
+func checkDates(password string, opts Options) []issue.Issue {
+	issues := CheckDates(password, opts.SequenceMinLen)
+	if opts.CurrentYear <= 0 {
+		return issues
+	}
+
+	recent := []struct {
+		year, label string
+	}{
+		{strconv.Itoa(opts.CurrentYear), "the current year"},
+		{strconv.Itoa(opts.CurrentYear - 1), "last year"},
+	}
+	for _, y := range recent {
+		msg := "Contains " + y.label + " ('" + y.year + "'), a likely recency pattern"
+		for i := range issues {
+			if issues[i].Pattern == y.year {
+				issues[i].Message = msg
+			}
+		}
+		for off := 0; ; {
+			k := strings.Index(password[off:], y.year)
+			if k < 0 {
+				break
+			}
+			start := utf8.RuneCountInString(password[:off+k])
+			end := start + len(y.year)
+			if !coveredBy(start, end, issues) {
+				issues = append(issues, issue.Issue{
+					Category: issue.CategoryPattern,
+					Severity: issue.SeverityMed,
+					Code:     issue.CodePatternDate,
+					Message:  msg,
+					Pattern:  y.year,
+					Start:    start,
+					End:      end,
+				})
+			}
+			off += k + len(y.year)
 		}
 	}
 	return issues
 }
+
+// coveredBy reports whether the rune span [start, end) lies inside the
+// span of one of issues.
+func coveredBy(start, end int, issues []issue.Issue) bool {
+	for _, iss := range issues {
+		if start >= iss.Start && end <= iss.End {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package patterns
+
+import (
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// CheckForbidden reports the first match of each forbidden regular
+// expression in password as a PATTERN_FORBIDDEN issue spanning the match.
+// The expression itself is not included in the message, so policies do
+// not leak through feedback.
+func CheckForbidden(password string, forbidden []*regexp.Regexp) []issue.Issue {
+	var issues []issue.Issue
+	for _, re := range forbidden {
+		if re == nil {
+			continue
+		}
+		loc := re.FindStringIndex(password)
+		if loc == nil {
+			continue
+		}
+		start := utf8.RuneCountInString(password[:loc[0]])
+		end := start + utf8.RuneCountInString(password[loc[0]:loc[1]])
+		issues = append(issues, issue.New(
+			issue.CodePatternForbidden,
+			"Matches a pattern forbidden by policy",
+			issue.CategoryPattern,
+			issue.SeverityHigh,
+		).At(start, end))
+	}
+	return issues
+}
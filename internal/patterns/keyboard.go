@@ -2,6 +2,7 @@ package patterns
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
 )
@@ -10,91 +11,170 @@ import (
 // characters that trigger a detection.
 const DefaultKeyboardMinLen = 4
 
-// keyboardLayouts holds all keyboard layout paths (forward and reversed),
-// precomputed at package initialisation for efficiency.
-var keyboardLayouts []string
+// Keyboard layout names accepted in [Options.Layouts].
+const (
+	LayoutQWERTY = "qwerty"
+	LayoutAZERTY = "azerty"
+	LayoutQWERTZ = "qwertz"
+	LayoutDvorak = "dvorak"
+)
 
-// layoutIndex maps a starting byte to the list of (layout, offset) pairs
-// where that byte appears, allowing O(1) lookup instead of scanning all
-// layouts for every password position.
+// layoutPos records where a byte appears within a keyboard path
+// (a row, column, or diagonal, forward or reversed).
 type layoutPos struct {
 	layout string
 	offset int
 }
 
-var layoutIndex map[byte][]layoutPos
-
-func init() {
-	rows := []string{
-		// QWERTY horizontal rows
-		"qwertyuiop",
-		"asdfghjkl",
-		"zxcvbnm",
-
-		// Number row
-		"1234567890",
+// layoutIndexes maps each layout name to an index from a starting byte to
+// the (path, offset) pairs where that byte appears, allowing O(1) lookup
+// instead of scanning all paths for every password position.
+var layoutIndexes map[string]map[byte][]layoutPos
 
-		// QWERTY vertical columns (top → bottom)
-		"qaz", "wsx", "edc", "rfv", "tgb", "yhn", "ujm",
-
-		// QWERTY diagonals (top-left → bottom-right)
-		"qwsz", "wedf", "erfc", "rtgv", "tyhb", "yujn", "uikm",
+// sharedRows are the number-row and numeric-keypad paths, identical on
+// every supported layout.
+//
+// Keypads come in two orientations: the computer numpad has 7-8-9 on top,
+// the phone keypad 1-2-3. Both have 0 on the bottom row, which people
+// treat as the end of any column walk ("1470", "9630"). Phone keypad rows
+// read in order are the number row itself, so they need no entry of their
+// own; the longest-run scan reports a walk shared with the number row once.
+var sharedRows = []string{
+	// Number row
+	"1234567890",
+
+	// Numpad rows, read top to bottom as one block ("7894", "4561")
+	"7894561230",
+
+	// Numpad columns (top → bottom)
+	"7410", "8520", "9630",
+
+	// Phone keypad columns (top → bottom)
+	"1470", "2580", "3690",
+
+	// Keypad diagonals
+	"159", "357",
+}
 
-		// Numeric keypad rows
-		"123", "456", "789",
+// letterRows are the top, home, and bottom letter rows of each layout,
+// each row starting at the key below "1" on the number row.
+var letterRows = map[string][3]string{
+	LayoutQWERTY: {"qwertyuiop", "asdfghjkl", "zxcvbnm"},
+	LayoutAZERTY: {"azertyuiop", "qsdfghjklm", "wxcvbn"},
+	LayoutQWERTZ: {"qwertzuiop", "asdfghjkl", "yxcvbnm"},
+	LayoutDvorak: {"',.pyfgcrl", "aoeuidhtns", ";qjkxbmwvz"},
+}
 
-		// Numeric keypad columns
-		"147", "258", "369",
+func init() {
+	layoutIndexes = make(map[string]map[byte][]layoutPos, len(letterRows))
+	adjacency = make(map[string]keyGraph, len(letterRows))
+	for name, rows := range letterRows {
+		layoutIndexes[name] = buildLayoutIndex(append(letterPaths(rows), sharedRows...))
+		adjacency[name] = buildAdjacency(rows)
+	}
+}
 
-		// Numeric keypad diagonals
-		"159", "357",
+// letterPaths derives the walkable paths of a three-row letter block: the
+// rows themselves, the vertical columns (top → bottom), and two kinds of
+// zig-zag diagonal: two adjacent keys on one row followed by the two keys
+// below-right of them (e.g. "wedf" on QWERTY), and two adjacent keys
+// followed by the slant down-left from the second (e.g. "rtgv").
+func letterPaths(block [3]string) []string {
+	top, home, bottom := block[0], block[1], block[2]
+	rows := block[:]
+	paths := append([]string(nil), rows...)
+	for i := 0; i < len(top); i++ {
+		col := []byte{top[i]}
+		if i < len(home) {
+			col = append(col, home[i])
+		}
+		if i < len(bottom) {
+			col = append(col, bottom[i])
+		}
+		if len(col) == 3 {
+			paths = append(paths, string(col))
+		}
 	}
+	for i := 0; i+2 < len(top) && i+2 < len(home); i++ {
+		paths = append(paths, string([]byte{top[i], top[i+1], home[i+1], home[i+2]}))
+	}
+	for i := 0; i+1 < len(top) && i+1 < len(home) && i < len(bottom); i++ {
+		paths = append(paths, string([]byte{top[i], top[i+1], home[i+1], bottom[i]}))
+	}
+	return paths
+}
 
-	for _, row := range rows {
-		keyboardLayouts = append(keyboardLayouts, row)
+// buildLayoutIndex indexes every path and its reverse by starting byte.
+func buildLayoutIndex(paths []string) map[byte][]layoutPos {
+	index := make(map[byte][]layoutPos)
+	for _, row := range paths {
+		variants := []string{row}
 		if rev := reverseStr(row); rev != row {
-			keyboardLayouts = append(keyboardLayouts, rev)
+			variants = append(variants, rev)
+		}
+		for _, layout := range variants {
+			for j := 0; j < len(layout); j++ {
+				b := layout[j]
+				index[b] = append(index[b], layoutPos{layout, j})
+			}
 		}
 	}
+	return index
+}
+
+// IsKnownLayout reports whether name is a supported keyboard layout.
+func IsKnownLayout(name string) bool {
+	_, ok := layoutIndexes[name]
+	return ok
+}
 
-	// Build the reverse index for fast lookup.
-	layoutIndex = make(map[byte][]layoutPos)
-	for _, layout := range keyboardLayouts {
-		for j := 0; j < len(layout); j++ {
-			b := layout[j]
-			layoutIndex[b] = append(layoutIndex[b], layoutPos{layout, j})
+// selectedIndexes returns the indexes for the layouts in opts, defaulting
+// to QWERTY when none are configured. Unknown names are ignored.
+func selectedIndexes(opts Options) []map[byte][]layoutPos {
+	if len(opts.Layouts) == 0 {
+		return []map[byte][]layoutPos{layoutIndexes[LayoutQWERTY]}
+	}
+	indexes := make([]map[byte][]layoutPos, 0, len(opts.Layouts))
+	for _, name := range opts.Layouts {
+		if idx, ok := layoutIndexes[name]; ok {
+			indexes = append(indexes, idx)
 		}
 	}
+	return indexes
 }
 
 // checkKeyboard detects keyboard walk patterns in the password.
 //
 // For each starting position, it finds the longest consecutive run that
-// appears in any known keyboard layout (forward or reversed). Runs of at
+// appears in any configured keyboard layout (forward or reversed). Runs of at
 // least opts.KeyboardMinLen characters are reported. After a match the
 // scanner skips past it so that overlapping sub-patterns (e.g. "werty"
-// inside "qwerty") are not reported separately.
+// inside "qwerty") are not reported separately. Taking the longest run
+// across all layouts at each position also means a run shared by several
+// layouts (e.g. "qwert" on QWERTY and QWERTZ) is reported once.
 func checkKeyboard(password string, opts Options) []issue.Issue {
 	if len(password) < opts.KeyboardMinLen {
 		return nil
 	}
+	indexes := selectedIndexes(opts)
 
 	seen := make(map[string]bool)
 	var issues []issue.Issue
 
 	i := 0
 	for i <= len(password)-opts.KeyboardMinLen {
-		match := longestKeyboardRunAt(password, i)
+		match := longestKeyboardRunAt(password, i, indexes)
 		if len(match) >= opts.KeyboardMinLen {
 			if !seen[match] {
 				seen[match] = true
+				start := utf8.RuneCountInString(password[:i])
 				issues = append(issues, issue.NewPattern(
 					issue.CodePatternKeyboard,
 					fmt.Sprintf("Contains keyboard pattern: '%s'", match),
 					match,
 					issue.CategoryPattern,
 					issue.SeverityMed,
-				))
+				).At(start, start+len(match)))
 			}
 			i += len(match) // Skip past the matched region.
 		} else {
@@ -105,29 +185,27 @@ func checkKeyboard(password string, opts Options) []issue.Issue {
 }
 
 // longestKeyboardRunAt returns the longest consecutive keyboard-layout
-// substring of password starting at the given byte offset.
+// substring of password starting at the given byte offset, across the
+// given layout indexes.
 //
 // All keyboard layouts are ASCII-only, so byte-level indexing is safe
 // even when the password contains multi-byte UTF-8 characters (UTF-8
 // continuation bytes are always > 0x7F and will never match).
-func longestKeyboardRunAt(password string, start int) string {
+func longestKeyboardRunAt(password string, start int, indexes []map[byte][]layoutPos) string {
 	var best string
 
 	ch := password[start]
-	positions, ok := layoutIndex[ch]
-	if !ok {
-		return ""
-	}
-
-	for _, pos := range positions {
-		layout, j := pos.layout, pos.offset
-		// Extend the match forward.
-		k := 1
-		for start+k < len(password) && j+k < len(layout) && password[start+k] == layout[j+k] {
-			k++
-		}
-		if k > len(best) {
-			best = password[start : start+k]
+	for _, index := range indexes {
+		for _, pos := range index[ch] {
+			layout, j := pos.layout, pos.offset
+			// Extend the match forward.
+			k := 1
+			for start+k < len(password) && j+k < len(layout) && password[start+k] == layout[j+k] {
+				k++
+			}
+			if k > len(best) {
+				best = password[start : start+k]
+			}
 		}
 	}
 
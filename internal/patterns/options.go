@@ -1,5 +1,7 @@
 package patterns
 
+import "regexp"
+
 // Options configures the behavior of pattern detection checks.
 //
 // Use [DefaultOptions] to obtain the recommended defaults, then
@@ -12,6 +14,28 @@ type Options struct {
 	// SequenceMinLen is the minimum number of characters in an arithmetic
 	// progression that trigger a sequence detection.
 	SequenceMinLen int
+
+	// Layouts lists the keyboard layouts walked by keyboard-pattern
+	// detection: any of LayoutQWERTY, LayoutAZERTY, LayoutQWERTZ, and
+	// LayoutDvorak. Unknown names are ignored; nil or empty means QWERTY
+	// only. The number row and numeric keypad are checked for every layout.
+	Layouts []string
+
+	// Forbidden lists compiled regular expressions that the password must
+	// not match. They run against the password as given, not lowercased;
+	// use the (?i) flag for case-insensitive patterns. Nil or empty
+	// disables the check.
+	Forbidden []*regexp.Regexp
+
+	// CurrentYear, when positive, singles out occurrences of this year and
+	// the year before as recency patterns (PATTERN_DATE), the most common
+	// kind of date in passwords. Zero disables the check.
+	CurrentYear int
+
+	// IsCommonWord reports whether a lowercase word is a common word or
+	// password, for detecting such words with digits or symbols affixed
+	// (PATTERN_AFFIXED). Nil falls back to a small embedded list.
+	IsCommonWord func(word string) bool
 }
 
 // DefaultOptions returns the recommended pattern options.
@@ -20,9 +44,11 @@ type Options struct {
 //
 //	KeyboardMinLen: 4
 //	SequenceMinLen: 4
+//	Layouts:        ["qwerty"]
 func DefaultOptions() Options {
 	return Options{
 		KeyboardMinLen: DefaultKeyboardMinLen,
 		SequenceMinLen: DefaultSequenceMinLen,
+		Layouts:        []string{LayoutQWERTY},
 	}
 }
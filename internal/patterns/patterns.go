@@ -1,8 +1,10 @@
 // Package patterns implements password pattern detection.
 //
 // It detects common weak patterns such as keyboard walks (qwerty, asdf),
-// sequential runs (abcd, 1234), repeated blocks (abcabc), and simple
-// leetspeak substitutions (p@ssw0rd, adm1n).
+// zig-zag finger walks (qawsedrf), sequential runs (abcd, 1234), repeated
+// blocks (abcabc), repeated words (dragon dragon), repeated character-class
+// templates (Abc123!Xyz456!), and simple leetspeak substitutions (p@ssw0rd,
+// adm1n).
 //
 // Each detector is a standalone checker function. The main Check function
 // orchestrates all detectors in order, operating on a lowercased copy of
@@ -10,6 +12,7 @@
 package patterns
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
@@ -35,23 +38,80 @@ func Check(password string) []issue.Issue {
 //
 // Detection order:
 //  1. Keyboard patterns (QWERTY rows, vertical walks, numpad)
-//  2. Sequential runs (alphabetic, numeric, forward and reverse)
-//  3. Repeated blocks (abcabc, 121212)
-//  4. Leetspeak substitutions (p@ssw0rd → password)
+//  2. Adjacent-key walks (qawsedrf, qazwsxedc), unless a keyboard
+//     pattern already covers them
+//  3. Sequential runs (alphabetic, numeric, forward and reverse)
+//  4. Repeated blocks (abcabc, 121212)
+//  5. Repeated delimited words (dragon dragon, ab-ab-ab)
+//  6. Leetspeak substitutions (p@ssw0rd → password)
+//  7. Common words with digit or symbol affixes (dragon123, 123dragon)
+//  8. Repeated character-class templates (Abc123!Xyz456!), matched
+//     against the original (not lowercased) password
+//  9. A single letter differing in case from the rest (Password1,
+//     PASSWORD1a), matched against the original password
+//  10. Forbidden patterns from Options.Forbidden, matched against the
+//     original (not lowercased) password
 func CheckWith(password string, opts Options) []issue.Issue {
 	lower := strings.ToLower(password)
 
 	checkers := []checker{
 		func(pw string) []issue.Issue { return checkKeyboard(pw, opts) },
+		func(pw string) []issue.Issue { return checkAdjacentWalk(pw, opts) },
 		func(pw string) []issue.Issue { return checkSequence(pw, opts) },
-		func(pw string) []issue.Issue { return CheckDates(pw, opts.SequenceMinLen) },
+		func(pw string) []issue.Issue { return checkDates(pw, opts) },
 		checkRepeatedBlocks,
+		checkRepeatedWords,
 		checkSubstitution,
+		func(pw string) []issue.Issue { return checkAffixedWord(pw, opts.IsCommonWord) },
 	}
 
 	var issues []issue.Issue
 	for _, check := range checkers {
 		issues = append(issues, check(lower)...)
 	}
-	return issues
+	issues = dropBlocksInRepeatedWords(issues)
+	issues = dropWalksInKeyboard(issues)
+	issues = append(issues, checkTemplateRepeat(password)...)
+	issues = append(issues, checkCaseTokenized(password)...)
+	return append(issues, CheckForbidden(password, opts.Forbidden)...)
+}
+
+// dropBlocksInRepeatedWords removes repeated-block issues that lie within a
+// repeated-word span, such as "ragon d" in "dragon dragon dragon"; the
+// repeated-word issue already describes them.
+func dropBlocksInRepeatedWords(issues []issue.Issue) []issue.Issue {
+	var words []issue.Issue
+	for _, iss := range issues {
+		if iss.Code == issue.CodePatternRepeatedWord {
+			words = append(words, iss)
+		}
+	}
+	if len(words) == 0 {
+		return issues
+	}
+	return slices.DeleteFunc(issues, func(b issue.Issue) bool {
+		return b.Code == issue.CodePatternBlock && slices.ContainsFunc(words, func(w issue.Issue) bool {
+			return w.Start <= b.Start && b.End <= w.End
+		})
+	})
+}
+
+// dropWalksInKeyboard removes adjacent-walk issues that lie within a
+// keyboard-pattern span, such as the straight row "qwerty"; the keyboard
+// issue already describes them.
+func dropWalksInKeyboard(issues []issue.Issue) []issue.Issue {
+	var rows []issue.Issue
+	for _, iss := range issues {
+		if iss.Code == issue.CodePatternKeyboard {
+			rows = append(rows, iss)
+		}
+	}
+	if len(rows) == 0 {
+		return issues
+	}
+	return slices.DeleteFunc(issues, func(w issue.Issue) bool {
+		return w.Code == issue.CodePatternAdjacentWalk && slices.ContainsFunc(rows, func(k issue.Issue) bool {
+			return k.Start <= w.Start && w.End <= k.End
+		})
+	})
 }
@@ -1,6 +1,9 @@
 package patterns
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
@@ -64,6 +67,15 @@ func TestCheckKeyboard(t *testing.T) {
 		// "qaz" is only 3 chars, below the threshold of 4
 		{"vertical qaz (too short)", "qaz", false, ""},
 
+		// Numeric keypad walks
+		{"numpad row wrap", "7894", true, "7894"},
+		{"numpad rows", "4561", true, "4561"},
+		{"phone column to 0", "1470", true, "1470"},
+		{"numpad column to 0", "9630", true, "9630"},
+		{"keypad column upward", "0258", true, "0258"},
+		{"keypad column embedded", "pin2580x", true, "2580"},
+		{"keypad diagonal (too short)", "159", false, ""},
+
 		// Diagonals
 		{"diagonal qwsz", "qwsz", true, "qwsz"},
 		{"diagonal rtgv", "rtgv", true, "rtgv"},
@@ -108,6 +120,137 @@ func TestCheckKeyboard_NoDuplicates(t *testing.T) {
 	}
 }
 
+func TestCheck_KeypadWalks(t *testing.T) {
+	// "7531" is caught as a step -2 sequence; the others as keypad walks.
+	for _, pw := range []string{"1470", "7531", "7894", "9630"} {
+		if len(Check(pw)) == 0 {
+			t.Errorf("Check(%q) found no pattern", pw)
+		}
+	}
+
+	// A number-row walk is reported once, not again as a keypad walk.
+	issues := checkKeyboard("1230", DefaultOptions())
+	if len(issues) != 1 || issues[0].Pattern != "1230" {
+		t.Errorf("checkKeyboard(1230) = %v, want one match", issues)
+	}
+	issues = checkKeyboard("123456", DefaultOptions())
+	if len(issues) != 1 || issues[0].Pattern != "123456" {
+		t.Errorf("checkKeyboard(123456) = %v, want the number-row match only", issues)
+	}
+}
+
+func TestCheckKeyboard_Layouts(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		layouts   []string
+		wantMatch string
+	}{
+		{"azerty row on AZERTY", "azertyuiop", []string{LayoutAZERTY}, "azertyuiop"},
+		{"azerty row on QWERTY only", "azertyuiop", []string{LayoutQWERTY}, "ertyuiop"},
+		{"qwertz row on QWERTZ", "qwertz", []string{LayoutQWERTZ}, "qwertz"},
+		{"dvorak home row", "aoeuidhtns", []string{LayoutDvorak}, "aoeuidhtns"},
+		{"default layouts", "asdfgh", nil, "asdfgh"},
+		{"unknown layout ignored", "asdfgh", []string{"colemak"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Layouts = tt.layouts
+			issues := checkKeyboard(tt.password, opts)
+			got := ""
+			if len(issues) > 0 {
+				got = issues[0].Pattern
+			}
+			if got != tt.wantMatch {
+				t.Errorf("checkKeyboard(%q, %v) match = %q, want %q (issues: %v)", tt.password, tt.layouts, got, tt.wantMatch, issues)
+			}
+		})
+	}
+}
+
+func TestCheckKeyboard_LayoutsDedup(t *testing.T) {
+	// "qwert" is a run on both QWERTY and QWERTZ; it must be reported once.
+	opts := DefaultOptions()
+	opts.Layouts = []string{LayoutQWERTY, LayoutQWERTZ, LayoutAZERTY}
+	issues := checkKeyboard("qwert", opts)
+	if len(issues) != 1 {
+		t.Errorf("expected exactly 1 keyboard issue across layouts, got %d: %v", len(issues), issues)
+	}
+}
+
+func TestLetterPaths_QWERTY(t *testing.T) {
+	// The QWERTY paths come from letterRows like every other layout's and
+	// still cover the rows, columns, and diagonals it always detected.
+	paths := letterPaths(letterRows[LayoutQWERTY])
+	for _, want := range []string{
+		"qwertyuiop", "asdfghjkl", "zxcvbnm",
+		"qaz", "wsx", "edc", "rfv", "tgb", "yhn", "ujm",
+		"qwsz", "wedf", "erfc", "rtgv", "tyhb", "yujn", "uikm",
+	} {
+		if !slices.Contains(paths, want) {
+			t.Errorf("letterPaths(QWERTY) is missing %q", want)
+		}
+	}
+}
+
+func TestCheckAdjacentWalk(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		layouts   []string
+		wantMatch string
+	}{
+		{"zig-zag", "qawsedrf", nil, "qawsedrf"},
+		{"column by column", "qazwsxedc", nil, "qazwsxedc"},
+		{"number row and columns", "1qaz2wsx", nil, "1qaz2wsx"},
+		{"embedded", "Hello!qawsed", nil, "qawsed"},
+		{"straight diagonal", "1qaz", nil, ""},
+		{"word sweater", "Sweater", nil, ""},
+		{"word freshwater", "Freshwater", nil, ""},
+		{"word dresden", "Dresden", nil, ""},
+		{"back and forth", "were", nil, ""},
+		{"repeated key", "passw", nil, ""},
+		{"too short", "xdr", nil, ""},
+		{"non-adjacent", "qpzm", nil, ""},
+		{"AZERTY", "aqwzsx", []string{LayoutAZERTY}, "aqwzsx"},
+		{"AZERTY keys on QWERTY", "aqwzsx", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Layouts = tt.layouts
+			issues := checkAdjacentWalk(strings.ToLower(tt.password), opts)
+			got := ""
+			if len(issues) > 0 {
+				got = issues[0].Pattern
+				if issues[0].Code != issue.CodePatternAdjacentWalk {
+					t.Errorf("code = %s, want %s", issues[0].Code, issue.CodePatternAdjacentWalk)
+				}
+			}
+			if got != tt.wantMatch {
+				t.Errorf("checkAdjacentWalk(%q) match = %q, want %q (issues: %v)", tt.password, got, tt.wantMatch, issues)
+			}
+		})
+	}
+}
+
+func TestCheckWith_AdjacentWalkNotStraightRow(t *testing.T) {
+	// A straight row is reported as PATTERN_KEYBOARD only.
+	for _, iss := range CheckWith("qwerty", DefaultOptions()) {
+		if iss.Code == issue.CodePatternAdjacentWalk {
+			t.Errorf("unexpected %s for a straight row: %v", iss.Code, iss)
+		}
+	}
+	found := false
+	for _, iss := range CheckWith("qawsedrf", DefaultOptions()) {
+		found = found || iss.Code == issue.CodePatternAdjacentWalk
+	}
+	if !found {
+		t.Errorf("expected %s for a zig-zag walk", issue.CodePatternAdjacentWalk)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Sequence Detection
 // ---------------------------------------------------------------------------
@@ -469,6 +612,357 @@ func TestCheckWith_EquivalentToCheck(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Dates
+// ---------------------------------------------------------------------------
+
+func TestCheckDates_ConsecutiveYears(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     string
+	}{
+		{"ascending", "19901991199219931994", "19901991199219931994"},
+		{"descending", "202420232022", "202420232022"},
+		{"embedded", "x200020012002y", "200020012002"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := CheckDates(tt.password, 4)
+			if len(issues) != 1 {
+				t.Fatalf("expected one issue, got %v", issues)
+			}
+			if issues[0].Code != issue.CodePatternDate || issues[0].Pattern != tt.want {
+				t.Errorf("got %s %q, want %s %q", issues[0].Code, issues[0].Pattern, issue.CodePatternDate, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDates_RandomDigitsNotYearRun(t *testing.T) {
+	// Same length as "19901991199219931994" but no years or dates.
+	if issues := CheckDates("57895768957869587965", 4); len(issues) != 0 {
+		t.Errorf("expected no date issues, got %v", issues)
+	}
+	// Two years or non-consecutive years are not a run.
+	for _, pw := range []string{"19901991", "199019921994"} {
+		for _, iss := range CheckDates(pw, 4) {
+			if strings.Contains(iss.Message, "consecutive") {
+				t.Errorf("%q: unexpected year run %v", pw, iss)
+			}
+		}
+	}
+}
+
+func TestCheckWith_CurrentYear(t *testing.T) {
+	opts := DefaultOptions()
+	opts.CurrentYear = 2026
+
+	tests := []struct {
+		password string
+		want     string // expected message fragment, "" for no recency issue
+	}{
+		{"summer2026!", "the current year ('2026')"},
+		{"winter2025!", "last year ('2025')"},
+		{"autumn2019!", ""},
+		{"nodigitshere", ""},
+	}
+	for _, tt := range tests {
+		issues := CheckWith(tt.password, opts)
+		got := ""
+		for _, iss := range issues {
+			if iss.Code == issue.CodePatternDate && strings.Contains(iss.Message, "recency") {
+				got = iss.Message
+			}
+		}
+		if tt.want == "" {
+			if got != "" {
+				t.Errorf("%q: unexpected recency issue %q", tt.password, got)
+			}
+			continue
+		}
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%q: recency message = %q, want it to contain %q", tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestCheckWith_CurrentYearBeyondMinLength(t *testing.T) {
+	// A minimum pattern length above four hides plain years from general
+	// date detection; the current-year check still reports them, once.
+	opts := DefaultOptions()
+	opts.SequenceMinLen = 6
+	opts.CurrentYear = 2026
+
+	var dates []issue.Issue
+	for _, iss := range CheckWith("x2026y", opts) {
+		if iss.Code == issue.CodePatternDate {
+			dates = append(dates, iss)
+		}
+	}
+	if len(dates) != 1 {
+		t.Fatalf("got %d date issues, want 1: %v", len(dates), dates)
+	}
+	if dates[0].Start != 1 || dates[0].End != 5 {
+		t.Errorf("span = [%d,%d), want [1,5)", dates[0].Start, dates[0].End)
+	}
+
+	opts.CurrentYear = 0
+	for _, iss := range CheckWith("x2026y", opts) {
+		if iss.Code == issue.CodePatternDate {
+			t.Errorf("disabled check reported %v", iss)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Forbidden patterns
+// ---------------------------------------------------------------------------
+
+func TestCheckForbidden(t *testing.T) {
+	forbidden := []*regexp.Regexp{
+		regexp.MustCompile(`(?i)acme`),
+		regexp.MustCompile(`TCK-\d{4}`),
+	}
+
+	issues := CheckForbidden("über-ACME-vault!", forbidden)
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1: %v", len(issues), issues)
+	}
+	iss := issues[0]
+	if iss.Code != issue.CodePatternForbidden {
+		t.Errorf("Code = %q, want %q", iss.Code, issue.CodePatternForbidden)
+	}
+	if iss.Start != 5 || iss.End != 9 {
+		t.Errorf("span = [%d,%d), want [5,9) in runes", iss.Start, iss.End)
+	}
+	if strings.Contains(iss.Message, "acme") {
+		t.Errorf("message leaks the pattern: %q", iss.Message)
+	}
+
+	if got := CheckForbidden("acme TCK-1234", forbidden); len(got) != 2 {
+		t.Errorf("got %d issues, want one per matching pattern", len(got))
+	}
+	if got := CheckForbidden("tck-1234", forbidden); len(got) != 0 {
+		t.Errorf("case-sensitive pattern matched lowercase input: %v", got)
+	}
+	if got := CheckForbidden("acme", nil); got != nil {
+		t.Errorf("nil patterns should report nothing, got %v", got)
+	}
+}
+
+func TestCheckWith_ForbiddenUsesOriginalCase(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Forbidden = []*regexp.Regexp{regexp.MustCompile(`TCK-\d{4}`)}
+	if !hasForbidden(CheckWith("xTCK-9931x", opts)) {
+		t.Error("expected PATTERN_FORBIDDEN for an uppercase match")
+	}
+	if hasForbidden(CheckWith("xtck-9931x", opts)) {
+		t.Error("forbidden patterns must not run against the lowercased password")
+	}
+}
+
+func TestCheckAffixedWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     string // expected core, or "" for no issue
+	}{
+		{"appended digits", "dragon123", "dragon"},
+		{"prepended digits", "123dragon", "dragon"},
+		{"appended symbol", "dragon!", "dragon"},
+		{"both sides", "!!monkey99", "monkey"},
+		{"nothing to strip", "dragon", ""},
+		{"uncommon core", "xkqzvw123", ""},
+		{"short core", "abc123", ""},
+		{"digits only", "123456", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkAffixedWord(tt.password, nil)
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Errorf("checkAffixedWord(%q) = %v, want none", tt.password, issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Code != issue.CodePatternAffixed {
+				t.Fatalf("checkAffixedWord(%q) = %v, want one PATTERN_AFFIXED", tt.password, issues)
+			}
+			assertContainsIssue(t, issues, "'"+tt.want+"'")
+			start := strings.Index(tt.password, tt.want)
+			if issues[0].Start != start || issues[0].End != start+len(tt.want) {
+				t.Errorf("span = [%d, %d), want [%d, %d)", issues[0].Start, issues[0].End, start, start+len(tt.want))
+			}
+		})
+	}
+}
+
+func TestCheckRepeatedWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     string // expected word, or "" for no issue
+		count    int
+		start    int
+		end      int
+	}{
+		{"spaces", "dragon dragon dragon", "dragon", 3, 0, 20},
+		{"hyphens", "ab-ab-ab", "ab", 3, 0, 8},
+		{"underscores", "x_sun_moon_sun", "sun", 2, 2, 14},
+		{"not adjacent", "red blue red", "red", 2, 0, 12},
+		{"distinct words", "correct horse battery", "", 0, 0, 0},
+		{"single chars", "a-a-a", "", 0, 0, 0},
+		{"no delimiters", "dragondragon", "", 0, 0, 0},
+		{"empty", "", "", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkRepeatedWords(tt.password)
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Errorf("checkRepeatedWords(%q) = %v, want none", tt.password, issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Code != issue.CodePatternRepeatedWord {
+				t.Fatalf("checkRepeatedWords(%q) = %v, want one PATTERN_REPEATED_WORD", tt.password, issues)
+			}
+			assertContainsIssue(t, issues, fmt.Sprintf("repeated %d times: '%s'", tt.count, tt.password[tt.start:tt.end]))
+			if issues[0].Pattern != tt.want {
+				t.Errorf("Pattern = %q, want %q", issues[0].Pattern, tt.want)
+			}
+			if issues[0].Start != tt.start || issues[0].End != tt.end {
+				t.Errorf("span = [%d, %d), want [%d, %d)", issues[0].Start, issues[0].End, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestCheckWith_RepeatedWordSupersedesBlocks(t *testing.T) {
+	issues := CheckWith("dragon dragon dragon", DefaultOptions())
+	if !slices.ContainsFunc(issues, func(iss issue.Issue) bool { return iss.Code == issue.CodePatternRepeatedWord }) {
+		t.Fatalf("expected PATTERN_REPEATED_WORD, got %v", issues)
+	}
+	if slices.ContainsFunc(issues, func(iss issue.Issue) bool { return iss.Code == issue.CodePatternBlock }) {
+		t.Errorf("blocks inside the repeated words should be dropped, got %v", issues)
+	}
+}
+
+func TestCheckWith_IsCommonWord(t *testing.T) {
+	opts := DefaultOptions()
+	opts.IsCommonWord = func(w string) bool { return w == "acme" }
+	issues := CheckWith("Acme2024!", opts)
+	assertContainsIssue(t, issues, "'acme'")
+	for _, iss := range CheckWith("dragon123", opts) {
+		if iss.Code == issue.CodePatternAffixed {
+			t.Errorf("IsCommonWord should replace the built-in list, got %v", iss)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Template repeats
+// ---------------------------------------------------------------------------
+
+func TestCheckTemplateRepeat(t *testing.T) {
+	tests := []struct {
+		password   string
+		start, end int
+	}{
+		{"Abc123!Xyz456!", 0, 14},
+		{"xAbc123!Xyz456!9", 1, 15},
+		{"Summer2024!Winter2025!", 0, 22},
+		{"ab12cd34ef56", 0, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			issues := checkTemplateRepeat(tt.password)
+			if len(issues) != 1 || issues[0].Code != issue.CodePatternTemplateRepeat {
+				t.Fatalf("checkTemplateRepeat(%q) = %v, want one PATTERN_TEMPLATE_REPEAT", tt.password, issues)
+			}
+			if issues[0].Start != tt.start || issues[0].End != tt.end {
+				t.Errorf("span = [%d, %d), want [%d, %d)", issues[0].Start, issues[0].End, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestCheckTemplateRepeat_NotFlagged(t *testing.T) {
+	for _, pw := range []string{
+		"",
+		"Abc123!",
+		"Blue-Fish-Jump-Tall", // no digits in the template
+		"Pass123!Pass123!",    // literal repeat, reported as PATTERN_BLOCK
+		"PASS123!pass123!",    // literal repeat ignoring case
+		"a1b2c3d4e5f6",        // period 2
+		"Xk9$mP2!vR7@nL4&",    // class changes at every rune
+		"correcthorse42battery",
+	} {
+		if issues := checkTemplateRepeat(pw); len(issues) != 0 {
+			t.Errorf("checkTemplateRepeat(%q) = %v, want none", pw, issues)
+		}
+	}
+}
+
+func TestCheckWith_TemplateRepeatUsesOriginalCase(t *testing.T) {
+	// Lowercased, every chunk reads "LLLDD"; with case the chunks differ.
+	for _, iss := range CheckWith("ABc12aBC34abC56", DefaultOptions()) {
+		if iss.Code == issue.CodePatternTemplateRepeat {
+			t.Errorf("template should be built from the original case, got %v", iss)
+		}
+	}
+	if issues := CheckWith("Abc12Xyz34Qrs56", DefaultOptions()); !slices.ContainsFunc(issues, func(iss issue.Issue) bool { return iss.Code == issue.CodePatternTemplateRepeat }) {
+		t.Errorf("expected PATTERN_TEMPLATE_REPEAT, got %v", issues)
+	}
+}
+
+func TestCheckCaseTokenized(t *testing.T) {
+	tests := []struct {
+		password   string
+		start, end int
+	}{
+		{"passworD1", 0, 8},
+		{"PASSWORd1!", 0, 8},
+		{"Password1!", 0, 8},
+		{"12tRoubadour", 2, 12},
+		{"émilE99", 0, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			issues := checkCaseTokenized(tt.password)
+			if len(issues) != 1 || issues[0].Code != issue.CodePatternCaseTokenized {
+				t.Fatalf("checkCaseTokenized(%q) = %v, want one PATTERN_CASE_TOKENIZED", tt.password, issues)
+			}
+			if issues[0].Start != tt.start || issues[0].End != tt.end {
+				t.Errorf("span = [%d, %d), want [%d, %d)", issues[0].Start, issues[0].End, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestCheckCaseTokenized_NotFlagged(t *testing.T) {
+	for _, pw := range []string{
+		"",
+		"password1",        // one case only
+		"Abc1",             // too few letters
+		"PassWord1",        // two exceptions
+		"Xk9$mP2!vR7@nL4&", // mixed throughout
+		"1234567!",
+		"password1A",      // no word with an exception
+		"g7#Tq2%zw8&kp4!", // random: letter runs too short
+		"xk9$mp2!vR7@qzt", // random: letter runs too short
+		"g7Tq2zw8kp4xQm",  // random without symbols
+	} {
+		if issues := checkCaseTokenized(pw); len(issues) != 0 {
+			t.Errorf("checkCaseTokenized(%q) = %v, want none", pw, issues)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
@@ -489,3 +983,12 @@ func assertContainsIssue(t *testing.T, issues []issue.Issue, substr string) {
 		t.Errorf("expected an issue containing %q, got: %v", substr, issues)
 	}
 }
+
+func hasForbidden(issues []issue.Issue) bool {
+	for _, iss := range issues {
+		if iss.Code == issue.CodePatternForbidden {
+			return true
+		}
+	}
+	return false
+}
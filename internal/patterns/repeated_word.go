@@ -0,0 +1,73 @@
+package patterns
+
+import (
+	"fmt"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// minRepeatedWordLen is the minimum length of a word token that is
+// reported when repeated. Single characters between delimiters are noise.
+const minRepeatedWordLen = 2
+
+// checkRepeatedWords splits the password on spaces, hyphens, and
+// underscores and reports each token that occurs more than once, e.g.
+// "dragon dragon dragon" or "ab-ab-ab". checkRepeatedBlocks misses these
+// when the delimiter breaks the raw repetition.
+//
+// Each issue spans from the first to the last occurrence of the word, and
+// its message quotes that span rather than the word alone so feedback does
+// not merge it with a dictionary match on the same word.
+func checkRepeatedWords(password string) []issue.Issue {
+	type occurrences struct {
+		count, start, end int
+	}
+	var order []string
+	seen := make(map[string]*occurrences)
+
+	runes := []rune(password)
+	for start := 0; start < len(runes); {
+		if isWordDelimiter(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && !isWordDelimiter(runes[end]) {
+			end++
+		}
+		if word := string(runes[start:end]); end-start >= minRepeatedWordLen {
+			if o, ok := seen[word]; ok {
+				o.count++
+				o.end = end
+			} else {
+				seen[word] = &occurrences{count: 1, start: start, end: end}
+				order = append(order, word)
+			}
+		}
+		start = end
+	}
+
+	var issues []issue.Issue
+	for _, word := range order {
+		o := seen[word]
+		if o.count < 2 {
+			continue
+		}
+		issues = append(issues, issue.NewPattern(
+			issue.CodePatternRepeatedWord,
+			fmt.Sprintf("Contains a word repeated %d times: '%s'", o.count, string(runes[o.start:o.end])),
+			word,
+			issue.CategoryPattern,
+			issue.SeverityMed,
+		).At(o.start, o.end))
+		if len(issues) >= maxBlockIssues {
+			break
+		}
+	}
+	return issues
+}
+
+// isWordDelimiter reports whether r separates word tokens.
+func isWordDelimiter(r rune) bool {
+	return r == ' ' || r == '-' || r == '_'
+}
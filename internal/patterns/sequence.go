@@ -31,7 +31,8 @@ func checkSequence(password string, opts Options) []issue.Issue {
 	var issues []issue.Issue
 
 	for _, step := range sequenceSteps {
-		for _, run := range findArithmeticRuns(runes, step, opts.SequenceMinLen) {
+		for _, span := range arithmeticRunSpans(runes, step, opts.SequenceMinLen) {
+			run := string(runes[span[0]:span[1]])
 			if !seen[run] {
 				seen[run] = true
 				issues = append(issues, issue.NewPattern(
@@ -40,7 +41,7 @@ func checkSequence(password string, opts Options) []issue.Issue {
 					run,
 					issue.CategoryPattern,
 					issue.SeverityMed,
-				))
+				).At(span[0], span[1]))
 			}
 		}
 	}
@@ -53,12 +54,22 @@ func checkSequence(password string, opts Options) []issue.Issue {
 // minLen are returned.
 func findArithmeticRuns(runes []rune, step, minLen int) []string {
 	var results []string
+	for _, span := range arithmeticRunSpans(runes, step, minLen) {
+		results = append(results, string(runes[span[0]:span[1]]))
+	}
+	return results
+}
+
+// arithmeticRunSpans is like findArithmeticRuns but returns the [start, end)
+// rune offsets of each run.
+func arithmeticRunSpans(runes []rune, step, minLen int) [][2]int {
+	var spans [][2]int
 
 	runStart := 0
 	for i := 1; i < len(runes); i++ {
 		if int(runes[i])-int(runes[i-1]) != step {
 			if i-runStart >= minLen {
-				results = append(results, string(runes[runStart:i]))
+				spans = append(spans, [2]int{runStart, i})
 			}
 			runStart = i
 		}
@@ -66,8 +77,8 @@ func findArithmeticRuns(runes []rune, step, minLen int) []string {
 
 	// Flush the final run.
 	if len(runes)-runStart >= minLen {
-		results = append(results, string(runes[runStart:]))
+		spans = append(spans, [2]int{runStart, len(runes)})
 	}
 
-	return results
+	return spans
 }
@@ -60,7 +60,7 @@ func checkSubstitution(password string) []issue.Issue {
 				fmt.Sprintf("Contains common word with substitution: '%s'", word),
 				issue.CategoryPattern,
 				issue.SeverityMed,
-			))
+			).AtSubstring(normalized, word))
 		}
 	}
 
@@ -0,0 +1,124 @@
+package patterns
+
+import (
+	"unicode"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// minTemplatePeriod is the shortest repeating template reported. Two-class
+// alternations such as "a1b2" are too common in random passwords to flag.
+const minTemplatePeriod = 3
+
+// maxTemplatePeriod caps the length of the repeating character-class
+// template. Longer periods describe ordinary mixed passwords rather than a
+// recognisable template.
+const maxTemplatePeriod = 16
+
+// minTemplateLen is the minimum number of runes a repeating template must
+// span before it is reported.
+const minTemplateLen = 8
+
+// Character classes of a template.
+const (
+	classUpper  = 'U'
+	classLower  = 'L'
+	classDigit  = 'D'
+	classSymbol = 'S'
+)
+
+// checkTemplateRepeat detects passwords built by repeating the same layout
+// of character classes, such as "Abc123!Xyz456!" (template "ULLDDDS"
+// twice). Each rune is abstracted to uppercase (U), lowercase or other
+// letter (L), digit (D), or symbol (S), and the longest span whose
+// template repeats with a short period is reported.
+//
+// It must run on the original-case password. A period must mix at least
+// two classes, contain a digit, and group some class into a run, so
+// capitalised passphrases such as "Blue-Fish-Jump" and random passwords
+// such as "Xk9$mP2!vR7@" are not reported. Spans that repeat literally (ignoring
+// case) are left to the repeated-block detector.
+func checkTemplateRepeat(password string) []issue.Issue {
+	runes := []rune(password)
+	n := len(runes)
+	if n < minTemplateLen {
+		return nil
+	}
+
+	template := make([]byte, n)
+	for i, r := range runes {
+		template[i] = runeClass(r)
+	}
+
+	limit := min(n/2, maxTemplatePeriod)
+	bestStart, bestEnd := 0, 0
+	for period := minTemplatePeriod; period <= limit; period++ {
+		for start := 0; start+period < n; {
+			end := start
+			for end+period < n && template[end] == template[end+period] {
+				end++
+			}
+			if end == start {
+				start++
+				continue
+			}
+			// template[start : end+period] repeats with this period.
+			span := end + period - start
+			if span >= 2*period && span >= minTemplateLen && span > bestEnd-bestStart &&
+				templateQualifies(template[start:start+period]) &&
+				!literalRepeat(runes[start:end+period], period) {
+				bestStart, bestEnd = start, end+period
+			}
+			start = end
+		}
+	}
+
+	if bestEnd == 0 {
+		return nil
+	}
+	return []issue.Issue{issue.New(
+		issue.CodePatternTemplateRepeat,
+		"Repeats the same layout of letters, digits, and symbols",
+		issue.CategoryPattern,
+		issue.SeverityLow,
+	).At(bestStart, bestEnd)}
+}
+
+// runeClass returns the template class of r.
+func runeClass(r rune) byte {
+	switch {
+	case unicode.IsUpper(r):
+		return classUpper
+	case unicode.IsLetter(r):
+		return classLower
+	case unicode.IsDigit(r):
+		return classDigit
+	default:
+		return classSymbol
+	}
+}
+
+// templateQualifies reports whether one period of a template mixes at
+// least two classes, includes a digit, and groups some class into a run
+// of two or more, as in "ULLDDDS". Periods that change class at every
+// rune, such as "LUDS", are typical of random passwords.
+func templateQualifies(period []byte) bool {
+	digit, mixed, grouped := false, false, false
+	for i, c := range period {
+		digit = digit || c == classDigit
+		mixed = mixed || c != period[0]
+		grouped = grouped || i > 0 && c == period[i-1]
+	}
+	return digit && mixed && grouped
+}
+
+// literalRepeat reports whether runes repeat with the given period when
+// case is ignored.
+func literalRepeat(runes []rune, period int) bool {
+	for i := period; i < len(runes); i++ {
+		if unicode.ToLower(runes[i]) != unicode.ToLower(runes[i-period]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// keyGraph records, for each key of a layout's number row and letter
+// block, the keys physically adjacent to it, the subset on its own row,
+// and the index of that row (0 for the number row).
+type keyGraph struct {
+	neighbors map[byte]string
+	sameRow   map[byte]string
+	row       map[byte]int
+}
+
+// adjacency maps each layout name to its key graph, built in init from
+// letterRows.
+var adjacency map[string]keyGraph
+
+// buildAdjacency returns the key graph of the number row stacked over the
+// given letter rows. Rows are staggered as on a standard keyboard: the key
+// at index i of a row touches keys i-1 and i+1 of its own row, keys i-1
+// and i of the row below, and keys i and i+1 of the row above (e.g. "w"
+// touches "q", "e", "2", "3", "a", and "s" on QWERTY).
+func buildAdjacency(block [3]string) keyGraph {
+	rows := []string{"1234567890", block[0], block[1], block[2]}
+	g := keyGraph{
+		neighbors: make(map[byte]string),
+		sameRow:   make(map[byte]string),
+		row:       make(map[byte]int),
+	}
+	add := func(k byte, row string, i int) {
+		if i >= 0 && i < len(row) {
+			g.neighbors[k] += string(row[i])
+		}
+	}
+	for r, row := range rows {
+		for i := 0; i < len(row); i++ {
+			k := row[i]
+			g.row[k] = r
+			add(k, row, i-1)
+			add(k, row, i+1)
+			g.sameRow[k] = g.neighbors[k]
+			if r > 0 {
+				add(k, rows[r-1], i)
+				add(k, rows[r-1], i+1)
+			}
+			if r+1 < len(rows) {
+				add(k, rows[r+1], i-1)
+				add(k, rows[r+1], i)
+			}
+		}
+	}
+	return g
+}
+
+// selectedAdjacency returns the key graphs for the layouts in opts,
+// defaulting to QWERTY like [selectedIndexes].
+func selectedAdjacency(opts Options) []keyGraph {
+	if len(opts.Layouts) == 0 {
+		return []keyGraph{adjacency[LayoutQWERTY]}
+	}
+	graphs := make([]keyGraph, 0, len(opts.Layouts))
+	for _, name := range opts.Layouts {
+		if g, ok := adjacency[name]; ok {
+			graphs = append(graphs, g)
+		}
+	}
+	return graphs
+}
+
+// checkAdjacentWalk detects finger walks that zig-zag across the keyboard
+// rather than follow a straight row, column, or diagonal, such as
+// "qawsedrf" or "qazwsxedc".
+//
+// A walk is a run in which every key sits on a row above or below the key
+// before it (diagonals included), or starts a new stroke beside the first
+// key of the previous one on the same row, as when typing column by
+// column ("qaz" then "wsx"). A step along a row, repeating a key, or
+// returning to the one two places back as in "were" ends a walk, and a
+// walk must change direction at least once, by turning back up or down
+// or by starting a new stroke. This keeps ordinary words whose letters
+// happen to touch, such as "sweater" or "dresden", from being reported.
+// Runs of at least opts.KeyboardMinLen keys are reported, and the scanner
+// skips past each match as checkKeyboard does. Straight walks also found
+// by checkKeyboard are dropped by [CheckWith].
+func checkAdjacentWalk(password string, opts Options) []issue.Issue {
+	if len(password) < opts.KeyboardMinLen {
+		return nil
+	}
+	graphs := selectedAdjacency(opts)
+
+	seen := make(map[string]bool)
+	var issues []issue.Issue
+
+	i := 0
+	for i <= len(password)-opts.KeyboardMinLen {
+		n := 0
+		for _, g := range graphs {
+			n = max(n, walkLenAt(password, i, g))
+		}
+		if n < opts.KeyboardMinLen {
+			i++
+			continue
+		}
+		match := password[i : i+n]
+		if !seen[match] {
+			seen[match] = true
+			start := utf8.RuneCountInString(password[:i])
+			issues = append(issues, issue.NewPattern(
+				issue.CodePatternAdjacentWalk,
+				fmt.Sprintf("Contains keyboard walk: '%s'", match),
+				match,
+				issue.CategoryPattern,
+				issue.SeverityMed,
+			).At(start, start+n))
+		}
+		i += n
+	}
+	return issues
+}
+
+// walkLenAt returns the length in bytes of the longest walk over g that
+// starts at byte offset start of password, or 0 if that walk never
+// changes direction. Keys are ASCII, so multi-byte characters end a walk.
+func walkLenAt(password string, start int, g keyGraph) int {
+	stroke := start
+	dir := 0 // -1 moving up a row, +1 moving down
+	turned := false
+	j := start + 1
+	for ; j < len(password); j++ {
+		prev, cur := password[j-1], password[j]
+		if cur == prev || j-2 >= start && password[j-2] == cur {
+			break
+		}
+		if j-1 > stroke && strings.IndexByte(g.sameRow[password[stroke]], cur) >= 0 {
+			stroke, dir, turned = j, 0, true
+			continue
+		}
+		if strings.IndexByte(g.neighbors[prev], cur) < 0 || g.row[cur] == g.row[prev] {
+			break
+		}
+		d := 1
+		if g.row[cur] < g.row[prev] {
+			d = -1
+		}
+		turned = turned || dir != 0 && d != dir
+		dir = d
+	}
+	if !turned {
+		return 0
+	}
+	return j - start
+}
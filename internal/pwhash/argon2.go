@@ -0,0 +1,228 @@
+package pwhash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Argon2 (RFC 9106). Lanes are processed sequentially; the output does not
+// depend on how segments are scheduled.
+
+// Argon2 variants, as encoded in the type field of H0.
+const (
+	argon2d  = 0
+	argon2i  = 1
+	argon2id = 2
+)
+
+// Argon2 versions.
+const (
+	argon2Version10 = 0x10
+	argon2Version13 = 0x13
+)
+
+const (
+	argon2BlockWords = 128 // 1 KiB blocks of 64-bit words
+	argon2SyncPoints = 4   // slices per pass
+)
+
+type argon2Block [argon2BlockWords]uint64
+
+// argon2Params are the cost parameters of an Argon2 hash.
+type argon2Params struct {
+	mode    int
+	version uint32
+	memory  uint32 // KiB
+	time    uint32 // passes
+	threads uint32 // lanes
+}
+
+// argon2Key derives a keyLen-byte tag from password and salt.
+func argon2Key(password, salt []byte, p argon2Params, keyLen uint32) []byte {
+	var params [24]byte
+	binary.LittleEndian.PutUint32(params[0:], p.threads)
+	binary.LittleEndian.PutUint32(params[4:], keyLen)
+	binary.LittleEndian.PutUint32(params[8:], p.memory)
+	binary.LittleEndian.PutUint32(params[12:], p.time)
+	binary.LittleEndian.PutUint32(params[16:], p.version)
+	binary.LittleEndian.PutUint32(params[20:], uint32(p.mode))
+	var pwLen, saltLen, empty [4]byte
+	binary.LittleEndian.PutUint32(pwLen[:], uint32(len(password)))
+	binary.LittleEndian.PutUint32(saltLen[:], uint32(len(salt)))
+
+	var h0 [72]byte
+	blake2bSum(h0[:64], params[:], pwLen[:], password, saltLen[:], salt, empty[:], empty[:])
+
+	memory := p.memory / (argon2SyncPoints * p.threads) * (argon2SyncPoints * p.threads)
+	if memory < 2*argon2SyncPoints*p.threads {
+		memory = 2 * argon2SyncPoints * p.threads
+	}
+	laneLen := memory / p.threads
+	segLen := laneLen / argon2SyncPoints
+
+	B := make([]argon2Block, memory)
+	var buf [1024]byte
+	for lane := uint32(0); lane < p.threads; lane++ {
+		binary.LittleEndian.PutUint32(h0[68:], lane)
+		for i := uint32(0); i < 2; i++ {
+			binary.LittleEndian.PutUint32(h0[64:], i)
+			blake2bLong(buf[:], h0[:])
+			for w := range B[lane*laneLen+i] {
+				B[lane*laneLen+i][w] = binary.LittleEndian.Uint64(buf[w*8:])
+			}
+		}
+	}
+
+	for n := uint32(0); n < p.time; n++ {
+		for slice := uint32(0); slice < argon2SyncPoints; slice++ {
+			for lane := uint32(0); lane < p.threads; lane++ {
+				s := argon2Segment{B: B, p: p, memory: memory, laneLen: laneLen, segLen: segLen}
+				s.fill(n, slice, lane)
+			}
+		}
+	}
+
+	last := B[memory-1]
+	for lane := uint32(0); lane < p.threads-1; lane++ {
+		for w, v := range B[lane*laneLen+laneLen-1] {
+			last[w] ^= v
+		}
+	}
+	for w, v := range last {
+		binary.LittleEndian.PutUint64(buf[w*8:], v)
+	}
+	key := make([]byte, keyLen)
+	blake2bLong(key, buf[:])
+	return key
+}
+
+type argon2Segment struct {
+	B       []argon2Block
+	p       argon2Params
+	memory  uint32
+	laneLen uint32
+	segLen  uint32
+}
+
+// fill computes the blocks of one segment.
+func (s argon2Segment) fill(n, slice, lane uint32) {
+	// Argon2i, and Argon2id in the first half of the first pass, use
+	// data-independent addressing.
+	independent := s.p.mode == argon2i || (s.p.mode == argon2id && n == 0 && slice < argon2SyncPoints/2)
+
+	var addresses, in, zero argon2Block
+	if independent {
+		in[0], in[1], in[2] = uint64(n), uint64(lane), uint64(slice)
+		in[3], in[4], in[5] = uint64(s.memory), uint64(s.p.time), uint64(s.p.mode)
+	}
+
+	index := uint32(0)
+	if n == 0 && slice == 0 {
+		index = 2 // the first two blocks of each lane are already set
+		if independent {
+			in[6]++
+			argon2Compress(&addresses, &in, &zero, false)
+			argon2Compress(&addresses, &addresses, &zero, false)
+		}
+	}
+
+	xor := s.p.version == argon2Version13 && n > 0
+	offset := lane*s.laneLen + slice*s.segLen + index
+	for ; index < s.segLen; index, offset = index+1, offset+1 {
+		prev := offset - 1
+		if index == 0 && slice == 0 {
+			prev += s.laneLen // wrap to the last block of the lane
+		}
+		var random uint64
+		if independent {
+			if index%argon2BlockWords == 0 {
+				in[6]++
+				argon2Compress(&addresses, &in, &zero, false)
+				argon2Compress(&addresses, &addresses, &zero, false)
+			}
+			random = addresses[index%argon2BlockWords]
+		} else {
+			random = s.B[prev][0]
+		}
+		ref := s.refIndex(random, n, slice, lane, index)
+		argon2Compress(&s.B[offset], &s.B[prev], &s.B[ref], xor)
+	}
+}
+
+// refIndex maps a pseudo-random value to the reference block (RFC 9106,
+// 3.4.1.2).
+func (s argon2Segment) refIndex(random uint64, n, slice, lane, index uint32) uint32 {
+	refLane := uint32(random>>32) % s.p.threads
+	if n == 0 && slice == 0 {
+		refLane = lane
+	}
+	area, start := 3*s.segLen, ((slice+1)%argon2SyncPoints)*s.segLen
+	if lane == refLane {
+		area += index
+	}
+	if n == 0 {
+		area, start = slice*s.segLen, 0
+		if slice == 0 || lane == refLane {
+			area += index
+		}
+	}
+	if index == 0 || lane == refLane {
+		area--
+	}
+	x := random & 0xFFFFFFFF
+	x = (x * x) >> 32
+	x = (x * uint64(area)) >> 32
+	return refLane*s.laneLen + uint32((uint64(start)+uint64(area)-(x+1))%uint64(s.laneLen))
+}
+
+// argon2Compress is the compression function G: out = P(in1 ^ in2) ^ in1 ^
+// in2, XORed into out instead when xor is set.
+func argon2Compress(out, in1, in2 *argon2Block, xor bool) {
+	var r argon2Block
+	for i := range r {
+		r[i] = in1[i] ^ in2[i]
+	}
+	t := r
+	// Rows: eight 16-word groups.
+	for i := 0; i < argon2BlockWords; i += 16 {
+		blamkaRound(&t,
+			i, i+1, i+2, i+3, i+4, i+5, i+6, i+7,
+			i+8, i+9, i+10, i+11, i+12, i+13, i+14, i+15)
+	}
+	// Columns: pairs of words from each row.
+	for i := 0; i < 16; i += 2 {
+		blamkaRound(&t,
+			i, i+1, 16+i, 16+i+1, 32+i, 32+i+1, 48+i, 48+i+1,
+			64+i, 64+i+1, 80+i, 80+i+1, 96+i, 96+i+1, 112+i, 112+i+1)
+	}
+	for i := range t {
+		if xor {
+			out[i] ^= r[i] ^ t[i]
+		} else {
+			out[i] = r[i] ^ t[i]
+		}
+	}
+}
+
+// blamkaRound applies the BLAKE2b round with the BlaMka multiplication to
+// the sixteen words of t at the given indices.
+func blamkaRound(t *argon2Block, i0, i1, i2, i3, i4, i5, i6, i7, i8, i9, i10, i11, i12, i13, i14, i15 int) {
+	g := func(a, b, c, d int) {
+		t[a] += t[b] + 2*uint64(uint32(t[a]))*uint64(uint32(t[b]))
+		t[d] = bits.RotateLeft64(t[d]^t[a], -32)
+		t[c] += t[d] + 2*uint64(uint32(t[c]))*uint64(uint32(t[d]))
+		t[b] = bits.RotateLeft64(t[b]^t[c], -24)
+		t[a] += t[b] + 2*uint64(uint32(t[a]))*uint64(uint32(t[b]))
+		t[d] = bits.RotateLeft64(t[d]^t[a], -16)
+		t[c] += t[d] + 2*uint64(uint32(t[c]))*uint64(uint32(t[d]))
+		t[b] = bits.RotateLeft64(t[b]^t[c], -63)
+	}
+	g(i0, i4, i8, i12)
+	g(i1, i5, i9, i13)
+	g(i2, i6, i10, i14)
+	g(i3, i7, i11, i15)
+	g(i0, i5, i10, i15)
+	g(i1, i6, i11, i12)
+	g(i2, i7, i8, i13)
+	g(i3, i4, i9, i14)
+}
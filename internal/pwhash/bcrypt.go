@@ -0,0 +1,107 @@
+package pwhash
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+)
+
+// bcrypt (Provos and Mazières, 1999), as implemented by OpenBSD.
+
+// bcryptEncoding is bcrypt's unpadded base64 alphabet.
+var bcryptEncoding = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// bcryptMagic is the plaintext encrypted 64 times to produce the hash.
+var bcryptMagic = []byte("OrpheanBeholderScryDoubt")
+
+const (
+	bcryptMinCost = 4
+	bcryptMaxCost = 31
+)
+
+type blowfish struct {
+	p [18]uint32
+	s [4][256]uint32
+}
+
+// bcryptHash returns the 23 raw hash bytes of password for the given cost
+// and 16-byte salt. Only the first 72 bytes of password are used.
+func bcryptHash(password []byte, cost int, salt []byte) []byte {
+	// Like the C implementations, the key includes the trailing NUL.
+	key := make([]byte, len(password)+1)
+	copy(key, password)
+	defer clear(key)
+
+	c := &blowfish{p: blowfishP, s: blowfishS}
+	c.expand(key, salt)
+	for i := uint64(0); i < 1<<cost; i++ {
+		c.expand(key, nil)
+		c.expand(salt, nil)
+	}
+
+	data := make([]byte, len(bcryptMagic))
+	copy(data, bcryptMagic)
+	for i := 0; i < len(data); i += 8 {
+		l, r := binary.BigEndian.Uint32(data[i:]), binary.BigEndian.Uint32(data[i+4:])
+		for j := 0; j < 64; j++ {
+			l, r = c.encrypt(l, r)
+		}
+		binary.BigEndian.PutUint32(data[i:], l)
+		binary.BigEndian.PutUint32(data[i+4:], r)
+	}
+	// C implementations only encode 23 of the 24 bytes.
+	return data[:23]
+}
+
+// expand runs the (salted) Blowfish key schedule. A nil salt is the plain
+// schedule.
+func (c *blowfish) expand(key, salt []byte) {
+	j := 0
+	for i := range c.p {
+		c.p[i] ^= nextWord(key, &j)
+	}
+	j = 0
+	var l, r uint32
+	next := func() {
+		if salt != nil {
+			l ^= nextWord(salt, &j)
+			r ^= nextWord(salt, &j)
+		}
+		l, r = c.encrypt(l, r)
+	}
+	for i := 0; i < len(c.p); i += 2 {
+		next()
+		c.p[i], c.p[i+1] = l, r
+	}
+	for s := range c.s {
+		for i := 0; i < 256; i += 2 {
+			next()
+			c.s[s][i], c.s[s][i+1] = l, r
+		}
+	}
+}
+
+func (c *blowfish) f(x uint32) uint32 {
+	return ((c.s[0][x>>24] + c.s[1][byte(x>>16)]) ^ c.s[2][byte(x>>8)]) + c.s[3][byte(x)]
+}
+
+func (c *blowfish) encrypt(l, r uint32) (uint32, uint32) {
+	for i := 0; i < 16; i += 2 {
+		l ^= c.p[i]
+		r ^= c.f(l)
+		r ^= c.p[i+1]
+		l ^= c.f(r)
+	}
+	l ^= c.p[16]
+	r ^= c.p[17]
+	return r, l
+}
+
+// nextWord reads the next big-endian word of b, cycling through b.
+func nextWord(b []byte, pos *int) uint32 {
+	var w uint32
+	for range 4 {
+		w = w<<8 | uint32(b[*pos])
+		*pos = (*pos + 1) % len(b)
+	}
+	return w
+}
@@ -0,0 +1,215 @@
+// Package pwhash verifies a password against a stored password hash using
+// only the standard library, so the root module keeps its zero-dependency
+// promise.
+//
+// Supported encodings are Argon2 in the PHC string format
+// ("$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>", also argon2i and
+// argon2d, with or without the version field) and bcrypt in the modular
+// crypt format ("$2a$", "$2b$", "$2y$"). Verification only: hashes
+// are never generated here, so the package needs no random source.
+//
+// Results are compared in constant time. Cost parameters are bounded so a
+// corrupted or hostile hash cannot exhaust memory or CPU, and must reach
+// the minimums OWASP recommends for new hashes: bcrypt cost 10, and
+// Argon2 memory of at least 7 MiB with memory × passes of at least 35 MiB
+// (e.g. m=19456,t=2 or m=7168,t=5). A cheaper hash protects the stored
+// password too little to rely on, and usually means a test fixture or a
+// misconfigured hasher, so it is rejected with [ErrTooWeak].
+package pwhash
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by [Verify] and [Check].
+var (
+	ErrMalformed   = errors.New("pwhash: malformed password hash")
+	ErrUnsupported = errors.New("pwhash: unsupported password hash algorithm")
+	ErrTooWeak     = errors.New("pwhash: password hash cost below the accepted minimum")
+)
+
+// Limits on the cost parameters accepted from a hash.
+const (
+	maxArgon2Memory  = 4 << 20 // KiB (4 GiB)
+	maxArgon2Time    = 1 << 10
+	maxArgon2Threads = 255
+	maxArgon2KeyLen  = 1 << 10
+
+	// Minimum costs; see the package documentation.
+	minBcryptCost   = 10
+	minArgon2Memory = 7 << 10  // KiB (7 MiB)
+	minArgon2Work   = 35 << 10 // memory × passes, KiB
+)
+
+// Verify reports whether password matches the encoded hash. It returns
+// [ErrUnsupported] for unknown algorithms and an error wrapping
+// [ErrMalformed] for hashes it cannot parse. Surrounding whitespace in
+// encoded is ignored.
+func Verify(password []byte, encoded string) (bool, error) {
+	h, err := parse(encoded)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(h.derive(password), h.want) == 1, nil
+}
+
+// Check parses encoded without verifying anything, returning the error
+// [Verify] would return for it.
+func Check(encoded string) error {
+	_, err := parse(encoded)
+	return err
+}
+
+// parsedHash is a decoded hash: derive recomputes it for a password.
+type parsedHash struct {
+	derive func(password []byte) []byte
+	want   []byte
+}
+
+func parse(encoded string) (parsedHash, error) {
+	encoded = strings.TrimSpace(encoded)
+	switch {
+	case strings.HasPrefix(encoded, "$argon2"):
+		return parseArgon2(encoded)
+	case strings.HasPrefix(encoded, "$2"):
+		return parseBcrypt(encoded)
+	}
+	return parsedHash{}, ErrUnsupported
+}
+
+// parseArgon2 parses a PHC-format Argon2 hash.
+func parseArgon2(encoded string) (parsedHash, error) {
+	fields := strings.Split(encoded, "$")
+	// "", variant, [v=N], params, salt, hash
+	if len(fields) != 5 && len(fields) != 6 {
+		return parsedHash{}, malformed("expected $variant$[v=N$]params$salt$hash")
+	}
+	var p argon2Params
+	switch fields[1] {
+	case "argon2id":
+		p.mode = argon2id
+	case "argon2i":
+		p.mode = argon2i
+	case "argon2d":
+		p.mode = argon2d
+	default:
+		return parsedHash{}, ErrUnsupported
+	}
+	rest := fields[2:]
+
+	// Hashes without a version field predate version 1.3.
+	p.version = argon2Version10
+	if v, ok := strings.CutPrefix(rest[0], "v="); ok {
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || (n != argon2Version10 && n != argon2Version13) {
+			return parsedHash{}, malformed("unknown version %q", v)
+		}
+		p.version = uint32(n)
+		rest = rest[1:]
+	}
+	if len(rest) != 3 {
+		return parsedHash{}, malformed("expected $variant$[v=N$]params$salt$hash")
+	}
+
+	var haveM, haveT, haveP bool
+	for _, kv := range strings.Split(rest[0], ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return parsedHash{}, malformed("invalid parameter %q", kv)
+		}
+		if k == "keyid" || k == "data" {
+			return parsedHash{}, ErrUnsupported
+		}
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return parsedHash{}, malformed("invalid parameter %q", kv)
+		}
+		switch k {
+		case "m":
+			p.memory, haveM = uint32(n), true
+		case "t":
+			p.time, haveT = uint32(n), true
+		case "p":
+			p.threads, haveP = uint32(n), true
+		default:
+			return parsedHash{}, malformed("unknown parameter %q", k)
+		}
+	}
+	switch {
+	case !haveM || !haveT || !haveP:
+		return parsedHash{}, malformed("missing m, t, or p parameter")
+	case p.threads < 1 || p.threads > maxArgon2Threads:
+		return parsedHash{}, malformed("parallelism %d out of range", p.threads)
+	case p.time < 1 || p.time > maxArgon2Time:
+		return parsedHash{}, malformed("time cost %d out of range", p.time)
+	case p.memory < 8*p.threads || p.memory > maxArgon2Memory:
+		return parsedHash{}, malformed("memory cost %d out of range", p.memory)
+	}
+
+	salt, err := decodePHC(rest[1])
+	if err != nil || len(salt) == 0 {
+		return parsedHash{}, malformed("invalid salt")
+	}
+	want, err := decodePHC(rest[2])
+	if err != nil || len(want) < 4 || len(want) > maxArgon2KeyLen {
+		return parsedHash{}, malformed("invalid hash")
+	}
+	if p.memory < minArgon2Memory || uint64(p.memory)*uint64(p.time) < minArgon2Work {
+		return parsedHash{}, fmt.Errorf("%w: Argon2 m=%d,t=%d, want m >= %d and m*t >= %d",
+			ErrTooWeak, p.memory, p.time, minArgon2Memory, minArgon2Work)
+	}
+	return parsedHash{
+		derive: func(password []byte) []byte { return argon2Key(password, salt, p, uint32(len(want))) },
+		want:   want,
+	}, nil
+}
+
+// decodePHC decodes PHC base64: standard alphabet, padding optional.
+func decodePHC(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// parseBcrypt parses a modular-crypt bcrypt hash: $2?$cc$ followed by 22
+// characters of salt and 31 of hash.
+func parseBcrypt(encoded string) (parsedHash, error) {
+	fields := strings.Split(encoded, "$")
+	if len(fields) != 4 {
+		return parsedHash{}, malformed("expected $2?$cost$salthash")
+	}
+	switch fields[1] {
+	case "2a", "2b", "2y":
+	default:
+		return parsedHash{}, ErrUnsupported
+	}
+	cost, err := strconv.Atoi(fields[2])
+	if err != nil || len(fields[2]) != 2 || cost < bcryptMinCost || cost > bcryptMaxCost {
+		return parsedHash{}, malformed("invalid cost %q", fields[2])
+	}
+	if len(fields[3]) != 53 {
+		return parsedHash{}, malformed("salt and hash must be 53 characters")
+	}
+	salt, err := bcryptEncoding.DecodeString(fields[3][:22])
+	if err != nil {
+		return parsedHash{}, malformed("invalid salt")
+	}
+	want, err := bcryptEncoding.DecodeString(fields[3][22:])
+	if err != nil {
+		return parsedHash{}, malformed("invalid hash")
+	}
+	if cost < minBcryptCost {
+		return parsedHash{}, fmt.Errorf("%w: bcrypt cost %d, want >= %d", ErrTooWeak, cost, minBcryptCost)
+	}
+	return parsedHash{
+		derive: func(password []byte) []byte { return bcryptHash(password, cost, salt) },
+		want:   want,
+	}, nil
+}
+
+func malformed(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrMalformed, fmt.Sprintf(format, args...))
+}
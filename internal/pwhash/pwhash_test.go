@@ -0,0 +1,140 @@
+package pwhash
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// Hashes generated with golang.org/x/crypto/argon2 and /bcrypt.
+const (
+	argon2idHash    = "$argon2id$v=19$m=9216,t=4,p=1$c29tZXNhbHQtMTZieXRlcw$tN7bTT9CFQDsNQ/0P0SiRP+HVIhn2VMPn4Bx18yvwn4"
+	argon2iHash     = "$argon2i$v=19$m=12288,t=3,p=2$c29tZXNhbHQtMTZieXRlcw$4pO5mLyQ5UY9rWiKL1KIuQ"
+	argon2idLongTag = "$argon2id$v=19$m=9216,t=4,p=4$c29tZXNhbHQtMTZieXRlcw$GDx3klZThv8oPyuHXIk2uyQYzMWW8bPFjaR/e67DqJaLvoyrqK66s4w1D7dFSLVW8D9g7gJt1DIB/VXOHtJywg"
+	bcryptHash10    = "$2a$10$h9UyaS0LerXKJe2rZNVl7OYm6PvsNdNl5YxEIpoObWh4jZGd7X8Vu"
+	bcryptHash10b   = "$2a$10$bXYvmTB0riEzgQm4fm71O.dIi99skYI4sJJBjDc7tDh307moHOYw."
+
+	// Below the minimum costs.
+	argon2idWeak = "$argon2id$v=19$m=64,t=2,p=2$c29tZXNhbHQtMTZieXRlcw$J8/dQ+Pq0h6fukIPWHGCi+TH6F7ecCEliuB9FZA2Sq8"
+	bcryptWeak   = "$2a$04$0tmfPkuNuGcqoe2qY5Tf0u2Ku0GtaZnvtRLDSYc695uJJGvgVVKHO"
+)
+
+func TestVerify_KnownHashes(t *testing.T) {
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+	}{
+		{"argon2id", argon2idHash, "Tr0ub4dor&3"},
+		{"argon2i", argon2iHash, "Tr0ub4dor&3"},
+		{"argon2id 64-byte tag", argon2idLongTag, "correct horse battery staple"},
+		{"bcrypt 2a", bcryptHash10, "Tr0ub4dor&3"},
+		{"bcrypt 2b", "$2b$" + bcryptHash10[4:], "Tr0ub4dor&3"},
+		{"bcrypt 2y", "$2y$" + bcryptHash10[4:], "Tr0ub4dor&3"},
+		{"bcrypt long password", bcryptHash10b, "correct horse battery staple"},
+		{"surrounding whitespace", " " + argon2idHash + "\n", "Tr0ub4dor&3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := Verify([]byte(tt.password), tt.hash)
+			if err != nil || !ok {
+				t.Errorf("Verify(match) = %v, %v; want true", ok, err)
+			}
+			ok, err = Verify([]byte(tt.password+"!"), tt.hash)
+			if err != nil || ok {
+				t.Errorf("Verify(mismatch) = %v, %v; want false", ok, err)
+			}
+		})
+	}
+}
+
+func TestVerify_PaddedPHCBase64(t *testing.T) {
+	// PHC strings omit padding, but some encoders emit it.
+	padded := "$argon2i$v=19$m=12288,t=3,p=2$c29tZXNhbHQtMTZieXRlcw==$4pO5mLyQ5UY9rWiKL1KIuQ=="
+	if ok, err := Verify([]byte("Tr0ub4dor&3"), padded); err != nil || !ok {
+		t.Errorf("Verify = %v, %v; want true", ok, err)
+	}
+}
+
+func TestVerify_Unsupported(t *testing.T) {
+	for _, h := range []string{
+		"",
+		"plaintext",
+		"$1$saltsalt$hash",
+		"$scrypt$ln=15,r=8,p=1$c2FsdA$aGFzaA",
+		"$argon2x$v=19$m=64,t=2,p=2$c2FsdA$aGFzaGhhc2g",
+		"$2x$04$0tmfPkuNuGcqoe2qY5Tf0u2Ku0GtaZnvtRLDSYc695uJJGvgVVKHO",
+	} {
+		if _, err := Verify([]byte("pw"), h); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Verify(%q) err = %v, want ErrUnsupported", h, err)
+		}
+	}
+}
+
+func TestVerify_Malformed(t *testing.T) {
+	for _, h := range []string{
+		"$argon2id$v=19$m=64,t=2$c29tZXNhbHQ$J8/dQ+Pq0h6fukIPWHGCi+TH6F7ecCEliuB9FZA2Sq8",
+		"$argon2id$v=20$m=64,t=2,p=2$c29tZXNhbHQ$J8/dQ+Pq0h6fukIPWHGCi+TH6F7ecCEliuB9FZA2Sq8",
+		"$argon2id$v=19$m=64,t=0,p=2$c29tZXNhbHQ$J8/dQ+Pq0h6fukIPWHGCi+TH6F7ecCEliuB9FZA2Sq8",
+		"$argon2id$v=19$m=4294967295,t=2,p=2$c29tZXNhbHQ$J8/dQ+Pq0h6fukIPWHGCi+TH6F7ecCEliuB9FZA2Sq8",
+		"$argon2id$v=19$m=64,t=2,p=2$!!!$J8/dQ+Pq0h6fukIPWHGCi+TH6F7ecCEliuB9FZA2Sq8",
+		"$argon2id$v=19$m=64,t=2,p=2,x=1$c29tZXNhbHQ$J8/dQ+Pq0h6fukIPWHGCi+TH6F7ecCEliuB9FZA2Sq8",
+		"$argon2id$v=19$m=64,t=2,p=2",
+		"$2a$4$0tmfPkuNuGcqoe2qY5Tf0u2Ku0GtaZnvtRLDSYc695uJJGvgVVKHO",
+		"$2a$32$0tmfPkuNuGcqoe2qY5Tf0u2Ku0GtaZnvtRLDSYc695uJJGvgVVKHO",
+		"$2a$04$tooshort",
+		"$2a$04$0tmfPkuNuGcqoe2qY5Tf0u2Ku0GtaZnvtRLDSYc695uJJGvgVVK!O",
+	} {
+		if _, err := Verify([]byte("pw"), h); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Verify(%q) err = %v, want ErrMalformed", h, err)
+		}
+	}
+}
+
+func TestVerify_TooWeak(t *testing.T) {
+	for _, h := range []string{
+		argon2idWeak,
+		bcryptWeak,
+		"$2a$09$" + bcryptHash10[7:],
+		// 7 MiB of memory, but too few passes for it.
+		"$argon2id$v=19$m=7168,t=4,p=1$c29tZXNhbHQtMTZieXRlcw$tN7bTT9CFQDsNQ/0P0SiRP+HVIhn2VMPn4Bx18yvwn4",
+		// Enough work overall, but too little memory.
+		"$argon2id$v=19$m=4096,t=9,p=1$c29tZXNhbHQtMTZieXRlcw$tN7bTT9CFQDsNQ/0P0SiRP+HVIhn2VMPn4Bx18yvwn4",
+	} {
+		if _, err := Verify([]byte("Tr0ub4dor&3"), h); !errors.Is(err, ErrTooWeak) {
+			t.Errorf("Verify(%.30s…) err = %v, want ErrTooWeak", h, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(argon2idHash); err != nil {
+		t.Errorf("Check(argon2id) = %v", err)
+	}
+	if err := Check(bcryptHash10); err != nil {
+		t.Errorf("Check(bcrypt) = %v", err)
+	}
+	if err := Check("$2a$04$tooshort"); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Check(short bcrypt) = %v, want ErrMalformed", err)
+	}
+}
+
+func TestVerify_Argon2WithoutVersion(t *testing.T) {
+	// Version 1.0 hashes omit the v= field; they must parse and verify
+	// without error, though this password does not match.
+	h := "$argon2i$m=12288,t=3,p=2$c29tZXNhbHQtMTZieXRlcw$4pO5mLyQ5UY9rWiKL1KIuQ"
+	if ok, err := Verify([]byte("Tr0ub4dor&3"), h); err != nil || ok {
+		t.Errorf("Verify = %v, %v; want false, nil (version 1.0 differs from 1.3)", ok, err)
+	}
+}
+
+func TestBlake2b_KnownAnswer(t *testing.T) {
+	// RFC 7693 Appendix A: BLAKE2b-512("abc").
+	want := "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d1" +
+		"7d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"
+	var out [64]byte
+	blake2bSum(out[:], []byte("abc"))
+	if got := hex.EncodeToString(out[:]); got != want {
+		t.Errorf("BLAKE2b-512(abc) = %s, want %s", got, want)
+	}
+}
@@ -1,6 +1,9 @@
 package rules
 
 import (
+	"fmt"
+	"unicode"
+
 	"github.com/rafaelsanzio/passcheck/internal/entropy"
 	"github.com/rafaelsanzio/passcheck/internal/issue"
 )
@@ -16,7 +19,16 @@ func checkCharsets(password string, opts Options) []issue.Issue {
 	}
 
 	cs, _ := entropy.AnalyzeCharsets(password)
+	return charsetIssues(cs, opts)
+}
 
+// charsetIssues reports each character set required by opts that is
+// missing from cs, or a single RULE_INSUFFICIENT_CLASSES issue when
+// opts.MinCharClasses is set.
+func charsetIssues(cs entropy.CharsetInfo, opts Options) []issue.Issue {
+	if opts.MinCharClasses > 0 {
+		return classCountIssues(cs, opts)
+	}
 	var issues []issue.Issue
 	if opts.RequireUpper && !cs.HasUpper {
 		issues = append(issues, issue.New(issue.CodeRuleNoUpper, "Add at least one uppercase letter", issue.CategoryRule, issue.SeverityLow))
@@ -27,9 +39,44 @@ func checkCharsets(password string, opts Options) []issue.Issue {
 	if opts.RequireDigit && !cs.HasDigit {
 		issues = append(issues, issue.New(issue.CodeRuleNoDigit, "Add at least one digit", issue.CategoryRule, issue.SeverityLow))
 	}
-	if opts.RequireSymbol && !cs.HasSymbol {
+	if opts.RequireSymbol && !cs.AnySymbol() {
 		issues = append(issues, issue.New(issue.CodeRuleNoSymbol, "Add at least one symbol (!@#$%^&*...)", issue.CategoryRule, issue.SeverityLow))
 	}
 	return issues
 }
 
+// classCountIssues reports a RULE_INSUFFICIENT_CLASSES issue when cs has
+// fewer than opts.MinCharClasses of the four character classes.
+func classCountIssues(cs entropy.CharsetInfo, opts Options) []issue.Issue {
+	n := cs.SetCount()
+	if n >= opts.MinCharClasses {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(
+			issue.CodeRuleInsufficientClasses,
+			fmt.Sprintf("Use at least %d of: uppercase, lowercase, digits, symbols (found %d)", opts.MinCharClasses, n),
+			issue.CategoryRule,
+			issue.SeverityLow,
+		),
+	}
+}
+
+
+// checkAlphanumeric flags passwords that contain no letters or digits at
+// all (e.g. all spaces or all punctuation). Such inputs are trivially weak
+// and often the result of an input error, so the issue is reported with
+// high severity regardless of which character sets are required.
+func checkAlphanumeric(password string) []issue.Issue {
+	if password == "" {
+		return nil
+	}
+	for _, r := range password {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return nil
+		}
+	}
+	return []issue.Issue{
+		issue.New(issue.CodeRuleNoAlphanumeric, "Password contains no letters or digits", issue.CategoryRule, issue.SeverityHigh),
+	}
+}
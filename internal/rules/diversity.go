@@ -0,0 +1,38 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// checkDiversity reports passwords with fewer distinct runes than
+// opts.MinUniqueChars, such as "Aaaaaaaaaaaa1!", which satisfy the
+// composition rules while being trivially weak.
+func checkDiversity(password string, opts Options) []issue.Issue {
+	return diversityIssues(distinctRunes(password), opts)
+}
+
+// distinctRunes returns the number of different runes in password.
+func distinctRunes(password string) int {
+	seen := make(map[rune]struct{}, len(password))
+	for _, r := range password {
+		seen[r] = struct{}{}
+	}
+	return len(seen)
+}
+
+// diversityIssues reports a RULE_LOW_DIVERSITY issue when a non-empty
+// password has fewer than opts.MinUniqueChars distinct runes. Zero
+// disables the check.
+func diversityIssues(distinct int, opts Options) []issue.Issue {
+	if distinct == 0 || distinct >= opts.MinUniqueChars {
+		return nil
+	}
+	return []issue.Issue{issue.New(
+		issue.CodeRuleLowDiversity,
+		fmt.Sprintf("Use at least %d different characters (found %d)", opts.MinUniqueChars, distinct),
+		issue.CategoryRule,
+		issue.SeverityMed,
+	)}
+}
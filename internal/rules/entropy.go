@@ -0,0 +1,27 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// CheckEntropy reports a low-entropy issue when bits, the password's final
+// entropy estimate, is below opts.MinEntropy. A non-positive MinEntropy
+// disables the check.
+//
+// Entropy is computed after pattern analysis, so unlike the other rules
+// this one is applied separately from [CheckProfile].
+func CheckEntropy(bits float64, opts Options) []issue.Issue {
+	if opts.MinEntropy <= 0 || bits >= opts.MinEntropy {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(
+			issue.CodeRuleLowEntropy,
+			fmt.Sprintf("Password is too predictable (%.0f bits of entropy, minimum %.0f)", bits, opts.MinEntropy),
+			issue.CategoryRule,
+			issue.SeverityMed,
+		),
+	}
+}
@@ -12,7 +12,11 @@ const DefaultMinLength = 12
 // checkMinLength verifies the password meets the minimum length requirement.
 // It counts Unicode code points (runes), not bytes.
 func checkMinLength(password string, opts Options) []issue.Issue {
-	length := len([]rune(password))
+	return minLengthIssues(len([]rune(password)), opts)
+}
+
+// minLengthIssues reports a too-short issue for a password of length runes.
+func minLengthIssues(length int, opts Options) []issue.Issue {
 	if length < opts.MinLength {
 		return []issue.Issue{
 			issue.New(
@@ -25,3 +29,19 @@ func checkMinLength(password string, opts Options) []issue.Issue {
 	}
 	return nil
 }
+
+// CheckMaxLength reports a too-long issue for a password of length runes
+// when opts.MaxLength is set and exceeded.
+func CheckMaxLength(length int, opts Options) []issue.Issue {
+	if opts.MaxLength > 0 && length > opts.MaxLength {
+		return []issue.Issue{
+			issue.New(
+				issue.CodeRuleTooLong,
+				fmt.Sprintf("Password is too long (%d chars, maximum %d)", length, opts.MaxLength),
+				issue.CategoryRule,
+				issue.SeverityHigh,
+			),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"unicode"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// checkNumericOnly reports passwords made entirely of digits, such as
+// "482915736201". They satisfy a length rule yet draw from a pool of only
+// ten symbols and are the first target of PIN-aware attacks.
+func checkNumericOnly(password string, opts Options) []issue.Issue {
+	return numericOnlyIssues(isNumericOnly(password), opts)
+}
+
+// isNumericOnly reports whether password is non-empty and every rune is a
+// digit.
+func isNumericOnly(password string) bool {
+	if password == "" {
+		return false
+	}
+	for _, r := range password {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// numericOnlyIssues reports a RULE_NUMERIC_ONLY issue for an all-digit
+// password when opts.FlagNumericOnly is set.
+func numericOnlyIssues(numericOnly bool, opts Options) []issue.Issue {
+	if !numericOnly || !opts.FlagNumericOnly {
+		return nil
+	}
+	return []issue.Issue{
+		issue.New(issue.CodeRuleNumericOnly, "Password contains only digits", issue.CategoryRule, issue.SeverityHigh),
+	}
+}
@@ -8,6 +8,10 @@ type Options struct {
 	// MinLength is the minimum number of runes required.
 	MinLength int
 
+	// MaxLength is the maximum number of runes allowed, checked by
+	// [CheckMaxLength]. Zero disables the check.
+	MaxLength int
+
 	// RequireUpper requires at least one uppercase letter.
 	RequireUpper bool
 
@@ -20,9 +24,29 @@ type Options struct {
 	// RequireSymbol requires at least one symbol character.
 	RequireSymbol bool
 
+	// MinCharClasses, when positive, requires at least this many of the
+	// four character classes (upper, lower, digit, symbol) in place of the
+	// individual Require* flags, which are then ignored.
+	MinCharClasses int
+
 	// MaxRepeats is the maximum number of consecutive identical
 	// characters allowed before an issue is reported.
 	MaxRepeats int
+
+	// MinUniqueChars is the minimum number of distinct runes required.
+	// Zero disables the check.
+	MinUniqueChars int
+
+	// FlagNumericOnly reports passwords made entirely of digits.
+	FlagNumericOnly bool
+
+	// MinEntropy is the minimum final entropy, in bits, checked by
+	// [CheckEntropy]. Zero disables the check.
+	MinEntropy float64
+
+	// AllowWhitespace suppresses the RULE_WHITESPACE issue. Control
+	// characters are still reported.
+	AllowWhitespace bool
 }
 
 // DefaultOptions returns the recommended rule options.
@@ -35,13 +59,15 @@ type Options struct {
 //	RequireDigit: true
 //	RequireSymbol:true
 //	MaxRepeats:   3
+//	FlagNumericOnly: true
 func DefaultOptions() Options {
 	return Options{
-		MinLength:     DefaultMinLength,
-		RequireUpper:  true,
-		RequireLower:  true,
-		RequireDigit:  true,
-		RequireSymbol: true,
-		MaxRepeats:    DefaultMaxRepeats,
+		MinLength:       DefaultMinLength,
+		RequireUpper:    true,
+		RequireLower:    true,
+		RequireDigit:    true,
+		RequireSymbol:   true,
+		MaxRepeats:      DefaultMaxRepeats,
+		FlagNumericOnly: true,
 	}
 }
@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"github.com/rafaelsanzio/passcheck/internal/entropy"
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// Profile summarizes the properties of a password that the rule checks
+// depend on: its length, character sets, and runs of repeated characters.
+//
+// A Profile lets [CheckProfile] re-evaluate the rules under different
+// [Options] without access to the password itself.
+type Profile struct {
+	// Length is the password length in runes.
+	Length int
+
+	// Charsets records which character set types are present.
+	Charsets entropy.CharsetInfo
+
+	// fixed holds the issues that depend on Options only through
+	// AllowWhitespace (no letters or digits, whitespace and control
+	// characters).
+	fixed []issue.Issue
+
+	// runs lists the runs of two or more identical consecutive runes.
+	runs []run
+
+	// distinct is the number of different runes.
+	distinct int
+
+	// numericOnly records a non-empty password made only of digits.
+	numericOnly bool
+}
+
+// NewProfile builds the rule profile of password.
+func NewProfile(password string) Profile {
+	cs, length := entropy.AnalyzeCharsets(password)
+	var fixed []issue.Issue
+	fixed = append(fixed, checkAlphanumeric(password)...)
+	fixed = append(fixed, checkWhitespace(password)...)
+	return Profile{
+		Length:   length,
+		Charsets: cs,
+		fixed:    fixed,
+		runs:     repeatRuns(password),
+		distinct: distinctRunes(password),
+
+		numericOnly: isNumericOnly(password),
+	}
+}
+
+// CheckProfile runs all basic rule checks against a password profile and
+// returns the same issues, in the same order, as [CheckWith] would return
+// for the password the profile was built from.
+func CheckProfile(p Profile, opts Options) []issue.Issue {
+	var issues []issue.Issue
+	issues = append(issues, minLengthIssues(p.Length, opts)...)
+	if p.Length > 0 {
+		issues = append(issues, charsetIssues(p.Charsets, opts)...)
+	}
+	issues = append(issues, numericOnlyIssues(p.numericOnly, opts)...)
+	issues = append(issues, allowWhitespace(p.fixed, opts)...)
+	issues = append(issues, repeatIssues(p.runs, opts)...)
+	issues = append(issues, diversityIssues(p.distinct, opts)...)
+	return issues
+}
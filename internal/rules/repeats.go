@@ -18,34 +18,54 @@ const DefaultMaxRepeats = 3
 //   - "aa"   → allowed (only 2)
 //   - "aaab" → flagged (3 consecutive 'a')
 func checkRepeatedChars(password string, opts Options) []issue.Issue {
-	runes := []rune(password)
-	if len(runes) < opts.MaxRepeats {
-		return nil
-	}
+	return repeatIssues(repeatRuns(password), opts)
+}
 
-	seen := make(map[rune]bool)
-	var issues []issue.Issue
+// run is a maximal sequence of n consecutive copies of rune r.
+type run struct {
+	r rune
+	n int
+}
 
-	count := 1
-	for i := 1; i < len(runes); i++ {
-		if runes[i] == runes[i-1] {
+// repeatRuns returns the maximal runs of two or more identical consecutive
+// runes in password, in order of appearance.
+func repeatRuns(password string) []run {
+	var runs []run
+	var prev rune
+	count := 0
+	for _, r := range password {
+		if count > 0 && r == prev {
 			count++
-		} else {
-			count = 1
+			continue
 		}
-
-		if count >= opts.MaxRepeats && !seen[runes[i]] {
-			seen[runes[i]] = true
-			repeated := string(repeatRune(runes[i], count))
-			issues = append(issues, issue.New(
-				issue.CodeRuleRepeatedChars,
-				fmt.Sprintf("Avoid repeating character '%s'", repeated),
-				issue.CategoryRule,
-				issue.SeverityLow,
-			))
+		if count >= 2 {
+			runs = append(runs, run{r: prev, n: count})
 		}
+		prev, count = r, 1
 	}
+	if count >= 2 {
+		runs = append(runs, run{r: prev, n: count})
+	}
+	return runs
+}
 
+// repeatIssues reports each rune whose run length meets opts.MaxRepeats.
+// A rune is reported at most once, however many qualifying runs it has.
+func repeatIssues(runs []run, opts Options) []issue.Issue {
+	seen := make(map[rune]bool)
+	var issues []issue.Issue
+	for _, rn := range runs {
+		if rn.n < opts.MaxRepeats || seen[rn.r] {
+			continue
+		}
+		seen[rn.r] = true
+		issues = append(issues, issue.New(
+			issue.CodeRuleRepeatedChars,
+			fmt.Sprintf("Avoid repeating character '%s'", string(repeatRune(rn.r, opts.MaxRepeats))),
+			issue.CategoryRule,
+			issue.SeverityLow,
+		))
+	}
 	return issues
 }
 
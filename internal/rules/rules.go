@@ -28,14 +28,20 @@ func Check(password string) []issue.Issue {
 // Rules are evaluated in a fixed order:
 //  1. Minimum length
 //  2. Character set requirements (uppercase, lowercase, digits, symbols)
-//  3. Whitespace and control characters
-//  4. Repeated consecutive characters
+//  3. Digits only (FlagNumericOnly)
+//  4. No letters or digits at all
+//  5. Whitespace and control characters
+//  6. Repeated consecutive characters
+//  7. Distinct characters (MinUniqueChars)
 func CheckWith(password string, opts Options) []issue.Issue {
 	checkers := []checker{
 		func(pw string) []issue.Issue { return checkMinLength(pw, opts) },
 		func(pw string) []issue.Issue { return checkCharsets(pw, opts) },
-		checkWhitespace,
+		func(pw string) []issue.Issue { return checkNumericOnly(pw, opts) },
+		checkAlphanumeric,
+		func(pw string) []issue.Issue { return allowWhitespace(checkWhitespace(pw), opts) },
 		func(pw string) []issue.Issue { return checkRepeatedChars(pw, opts) },
+		func(pw string) []issue.Issue { return checkDiversity(pw, opts) },
 	}
 
 	var issues []issue.Issue
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -73,6 +74,23 @@ func TestCheckMinLength(t *testing.T) {
 	}
 }
 
+func TestCheckMaxLength(t *testing.T) {
+	opts := DefaultOptions()
+	if issues := CheckMaxLength(5000, opts); issues != nil {
+		t.Errorf("MaxLength=0: got %v, want no issue", issues)
+	}
+
+	opts.MaxLength = 16
+	if issues := CheckMaxLength(16, opts); issues != nil {
+		t.Errorf("at the limit: got %v, want no issue", issues)
+	}
+	issues := CheckMaxLength(17, opts)
+	if len(issues) != 1 || issues[0].Code != issue.CodeRuleTooLong {
+		t.Fatalf("over the limit: got %v, want %s", issues, issue.CodeRuleTooLong)
+	}
+	assertContainsIssue(t, issues, "too long")
+}
+
 // ---------------------------------------------------------------------------
 // Character Set Analysis
 // ---------------------------------------------------------------------------
@@ -175,6 +193,72 @@ func TestCheckCharsets(t *testing.T) {
 	}
 }
 
+func TestCheckCharsets_MinCharClasses(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MinCharClasses = 3
+
+	tests := []struct {
+		password  string
+		wantIssue bool
+	}{
+		{"abcdef", true},  // 1 class
+		{"abcDEF", true},  // 2 classes
+		{"abcDE1", false}, // 3 classes, no symbol
+		{"abc12!", false}, // 3 classes, no upper
+		{"aB1!", false},   // 4 classes
+		{"", false},
+	}
+	for _, tt := range tests {
+		issues := checkCharsets(tt.password, opts)
+		if tt.wantIssue {
+			if len(issues) != 1 || issues[0].Code != issue.CodeRuleInsufficientClasses {
+				t.Errorf("%q: got %v, want a single %s", tt.password, issues, issue.CodeRuleInsufficientClasses)
+			}
+			continue
+		}
+		if len(issues) != 0 {
+			t.Errorf("%q: got %v, want no issues (Require* flags are ignored)", tt.password, issues)
+		}
+	}
+
+	issues := checkCharsets("abcDEF", opts)
+	assertContainsIssue(t, issues, "at least 3")
+	assertContainsIssue(t, issues, "found 2")
+}
+
+func TestCheckAlphanumeric(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		wantIssue bool
+	}{
+		{"all spaces", "            ", true},
+		{"all punctuation", "!@#$%^&*()_+", true},
+		{"punctuation and spaces", "!! ?? ..", true},
+		{"one letter", "!@#$a%^&", false},
+		{"one digit", "!@#$1%^&", false},
+		{"unicode letter", "!!ñ!!", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkAlphanumeric(tt.password)
+			if hasIssue := len(issues) > 0; hasIssue != tt.wantIssue {
+				t.Fatalf("checkAlphanumeric(%q): got issue=%v, want issue=%v", tt.password, hasIssue, tt.wantIssue)
+			}
+			if tt.wantIssue {
+				if issues[0].Code != issue.CodeRuleNoAlphanumeric {
+					t.Errorf("code = %q, want %q", issues[0].Code, issue.CodeRuleNoAlphanumeric)
+				}
+				if issues[0].Severity != issue.SeverityHigh {
+					t.Errorf("severity = %d, want %d", issues[0].Severity, issue.SeverityHigh)
+				}
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Whitespace / Control Characters
 // ---------------------------------------------------------------------------
@@ -216,6 +300,21 @@ func TestCheckWhitespace(t *testing.T) {
 	}
 }
 
+func TestCheckWith_AllowWhitespace(t *testing.T) {
+	opts := DefaultOptions()
+	opts.AllowWhitespace = true
+	issues := CheckWith("abc def\x07", opts)
+	if containsIssue(issues, "whitespace") {
+		t.Errorf("AllowWhitespace should suppress the whitespace issue, got %v", issues)
+	}
+	if !containsIssue(issues, "control") {
+		t.Errorf("control characters should still be reported, got %v", issues)
+	}
+	if got := CheckProfile(NewProfile("abc def\x07"), opts); len(got) != len(issues) {
+		t.Errorf("CheckProfile = %v, want %v", got, issues)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Repeated Characters
 // ---------------------------------------------------------------------------
@@ -388,6 +487,111 @@ func TestCheckWith_EquivalentToCheck(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Diversity
+// ---------------------------------------------------------------------------
+
+func TestCheckDiversity(t *testing.T) {
+	opts := Options{MinUniqueChars: 5}
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"Aaaaaaaaaaaa1!", true}, // 4 distinct
+		{"Aaaaaaaaaab1!", false}, // 5 distinct
+		{"ééééèèèè", true},       // 2 distinct runes, 16 bytes
+		{"", false},
+	}
+	for _, tt := range tests {
+		issues := checkDiversity(tt.password, opts)
+		if got := len(issues) == 1 && issues[0].Code == issue.CodeRuleLowDiversity; got != tt.want {
+			t.Errorf("checkDiversity(%q) = %v, want issue: %t", tt.password, issues, tt.want)
+		}
+	}
+	assertContainsIssue(t, checkDiversity("Aaaaaaaaaaaa1!", opts), "at least 5 different characters (found 4)")
+
+	if issues := checkDiversity("aaaa", Options{}); issues != nil {
+		t.Errorf("zero MinUniqueChars should disable the check, got %v", issues)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Numeric only
+// ---------------------------------------------------------------------------
+
+func TestCheckNumericOnly(t *testing.T) {
+	opts := Options{FlagNumericOnly: true}
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"482915736201", true},
+		{"1234", true},
+		{"٣٤٥٦", true}, // Arabic-Indic digits
+		{"482915736201a", false},
+		{"4829 1573", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		issues := checkNumericOnly(tt.password, opts)
+		if got := len(issues) == 1 && issues[0].Code == issue.CodeRuleNumericOnly; got != tt.want {
+			t.Errorf("checkNumericOnly(%q) = %v, want issue: %t", tt.password, issues, tt.want)
+		}
+	}
+
+	if issues := checkNumericOnly("482915736201", Options{}); issues != nil {
+		t.Errorf("FlagNumericOnly=false should disable the check, got %v", issues)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CheckProfile
+// ---------------------------------------------------------------------------
+
+func TestCheckProfile_MatchesCheckWith(t *testing.T) {
+	passwords := []string{
+		"", "a", "short", "aaaBBB111!!!", "aaab aaa\x01", "Xk9$mP2!vR7@nL4&",
+		"!!!", "ééééé", "aabbaaab", "pass word", "482915736201",
+	}
+	optsList := []Options{
+		DefaultOptions(),
+		{MinLength: 4, RequireDigit: true, MaxRepeats: 2},
+		{MinLength: 20, RequireUpper: true, RequireSymbol: true, MaxRepeats: 4},
+		{MinLength: 1, MaxRepeats: 3, MinUniqueChars: 5},
+	}
+	for _, pw := range passwords {
+		p := NewProfile(pw)
+		for _, opts := range optsList {
+			got := CheckProfile(p, opts)
+			want := CheckWith(pw, opts)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("CheckProfile(%q, %+v) = %v, want %v", pw, opts, got, want)
+			}
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CheckEntropy
+// ---------------------------------------------------------------------------
+
+func TestCheckEntropy(t *testing.T) {
+	opts := Options{MinEntropy: 50}
+	issues := CheckEntropy(32.4, opts)
+	if len(issues) != 1 || issues[0].Code != issue.CodeRuleLowEntropy {
+		t.Fatalf("CheckEntropy(32.4) = %v, want one %s issue", issues, issue.CodeRuleLowEntropy)
+	}
+	assertContainsIssue(t, issues, "32 bits")
+	assertContainsIssue(t, issues, "minimum 50")
+
+	if got := CheckEntropy(50, opts); got != nil {
+		t.Errorf("entropy at the threshold should pass, got %v", got)
+	}
+	if got := CheckEntropy(0, Options{}); got != nil {
+		t.Errorf("zero MinEntropy should disable the check, got %v", got)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
@@ -36,3 +36,18 @@ func checkWhitespace(password string) []issue.Issue {
 	}
 	return issues
 }
+
+// allowWhitespace drops RULE_WHITESPACE from issues when opts allows
+// whitespace.
+func allowWhitespace(issues []issue.Issue, opts Options) []issue.Issue {
+	if !opts.AllowWhitespace {
+		return issues
+	}
+	var out []issue.Issue
+	for _, iss := range issues {
+		if iss.Code != issue.CodeRuleWhitespace {
+			out = append(out, iss)
+		}
+	}
+	return out
+}
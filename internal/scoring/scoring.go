@@ -26,6 +26,17 @@ const (
 	PenaltyPerDictMatch = 15 // common password, common word, leet variant
 	PenaltyPerContext   = 20 // personal information (username, email, company)
 	PenaltyPerHIBP      = 25 // password found in breach database (HIBP)
+	PenaltyPerHistory   = 20 // too close to a previous password
+)
+
+// Frequency scaling of dictionary penalties. A dictionary issue whose list
+// entry carries a breach frequency (see issue.Issue.Frequency) costs
+// between minFrequencyScale and maxFrequencyScale times the usual penalty,
+// growing with the normalized frequency; issues without one cost exactly
+// the usual penalty.
+const (
+	minFrequencyScale = 0.5
+	maxFrequencyScale = 1.5
 )
 
 // Bonus parameters.
@@ -41,6 +52,25 @@ const (
 	BonusPassphrase   = 25 // bonus for detected passphrases (4+ words)
 )
 
+// CharsetModel selects how the charset-diversity bonus grows with the
+// number of character set types present.
+type CharsetModel string
+
+const (
+	// CharsetModelLinear awards BonusPerCharset for every type beyond the
+	// first, up to MaxCharsetBonus (0, 3, 6, 9).
+	CharsetModelLinear CharsetModel = "linear"
+
+	// CharsetModelDiminishing awards a shrinking marginal credit for each
+	// additional type (0, 3, 5, 6), so a single symbol added to tick the
+	// fourth box is worth less than genuine diversity across the first three.
+	CharsetModelDiminishing CharsetModel = "diminishing"
+)
+
+// diminishingCharsetBonus is the cumulative bonus indexed by the number of
+// character set types present under [CharsetModelDiminishing].
+var diminishingCharsetBonus = [...]int{0, 0, 3, 5, 6}
+
 // Entropy-to-score mapping constants.
 const (
 	maxScoreBase = 100.0 // maximum base score (perfect entropy)
@@ -64,16 +94,18 @@ type IssueSet struct {
 	Dictionary []issue.Issue // Phase 3: dictionary matches
 	Context    []issue.Issue // Phase 4: context-aware detections
 	HIBP       []issue.Issue // Phase 5: breach database (HIBP)
+	History    []issue.Issue // Phase 6: previous-password comparisons
 }
 
 // AllIssues returns a single flat slice of all issues in evaluation order.
 func (s IssueSet) AllIssues() []issue.Issue {
-	out := make([]issue.Issue, 0, len(s.Rules)+len(s.Patterns)+len(s.Dictionary)+len(s.Context)+len(s.HIBP))
+	out := make([]issue.Issue, 0, len(s.Rules)+len(s.Patterns)+len(s.Dictionary)+len(s.Context)+len(s.HIBP)+len(s.History))
 	out = append(out, s.Rules...)
 	out = append(out, s.Patterns...)
 	out = append(out, s.Dictionary...)
 	out = append(out, s.Context...)
 	out = append(out, s.HIBP...)
+	out = append(out, s.History...)
 	return out
 }
 
@@ -101,60 +133,95 @@ func CalculateWith(entropyBits float64, password string, issues IssueSet, minLen
 	// --- Penalties ---
 	penalty := len(issues.Rules)*PenaltyPerRule +
 		len(issues.Patterns)*PenaltyPerPattern +
-		len(issues.Dictionary)*PenaltyPerDictMatch +
+		int(dictionaryPenalty(issues.Dictionary, PenaltyPerDictMatch)) +
 		len(issues.Context)*PenaltyPerContext +
-		len(issues.HIBP)*PenaltyPerHIBP
+		len(issues.HIBP)*PenaltyPerHIBP +
+		len(issues.History)*PenaltyPerHistory
 
 	score := int(base) + bonus - penalty
 
 	return clamp(score, 0, 100)
 }
 
+// Options holds the scoring inputs beyond entropy, password, and issues.
+// The zero value scores with a minimum length of 0, no passphrase
+// handling, default weights, and the linear charset model.
+type Options struct {
+	// MinLength is the baseline for the length bonus.
+	MinLength int
+
+	// Passphrase is the passphrase detection result, or nil when passphrase
+	// mode is disabled or the password is not a passphrase.
+	Passphrase *passphrase.Info
+
+	// Weights holds custom penalty multipliers, or nil for defaults.
+	Weights *Weights
+
+	// CharsetModel selects the charset-diversity bonus curve. Empty or
+	// unrecognized values use [CharsetModelLinear].
+	CharsetModel CharsetModel
+}
+
 // CalculateWithPassphrase computes a password strength score from 0 to 100,
 // similar to [CalculateWith], but reduces dictionary penalties when the password
 // is detected as a passphrase (has multiple words). This enables passphrase-friendly
 // scoring that rewards multi-word combinations.
 //
+// Like every Calculate variant, it scales the penalty of a dictionary issue
+// that carries a breach frequency, so a match on the most common password
+// of a ranked blocklist costs more than a match on a rare entry.
+//
 // passphraseInfo can be nil if passphrase detection is disabled or the password
 // is not a passphrase. When non-nil and IsPassphrase is true, dictionary penalties
 // are eliminated (dictionary words are expected and desired in passphrases).
 //
 // weights can be nil to use default weights (all multipliers = 1.0).
 func CalculateWithPassphrase(entropyBits float64, password string, issues IssueSet, minLength int, passphraseInfo *passphrase.Info, weights *Weights) int {
-	// --- Base score from entropy ---
-	baseEntropy := entropyBits * maxScoreBase / entropyFull
+	return CalculateWithOptions(entropyBits, password, issues, Options{
+		MinLength:  minLength,
+		Passphrase: passphraseInfo,
+		Weights:    weights,
+	})
+}
 
-	// --- Bonuses ---
-	bonus := lengthBonusWith(password, minLength) + charsetBonus(password)
-	// Add passphrase bonus for multi-word passphrases
-	if passphraseInfo != nil && passphraseInfo.IsPassphrase {
-		bonus += BonusPassphrase
-	}
+// CalculateWithOptions computes a password strength score from 0 to 100
+// using the scoring inputs in opts. It is the most general form of the
+// Calculate family; the other variants delegate to it or mirror its formula.
+func CalculateWithOptions(entropyBits float64, password string, issues IssueSet, opts Options) int {
+	cs, length := entropy.AnalyzeCharsets(password)
+	return calculate(entropyBits, length, cs, issues, opts)
+}
 
-	// --- Penalties ---
-	// Eliminate dictionary penalties for passphrases (dictionary words are expected and desired)
-	dictPenalty := PenaltyPerDictMatch
-	if passphraseInfo != nil && passphraseInfo.IsPassphrase {
-		dictPenalty = 0 // No dictionary penalties for passphrases
-	}
+// CalculateProfile computes a password strength score from 0 to 100 from
+// a rule profile instead of the password. It returns the same score as
+// [CalculateWithOptions] for the password the profile was built from.
+func CalculateProfile(entropyBits float64, p rules.Profile, issues IssueSet, opts Options) int {
+	return calculate(entropyBits, p.Length, p.Charsets, issues, opts)
+}
 
-	// Apply weights if provided
-	var base float64
-	var penalty int
-	if weights != nil {
-		base, penalty = weights.applyWeights(baseEntropy, issues, dictPenalty)
-	} else {
-		base = baseEntropy
-		penalty = len(issues.Rules)*PenaltyPerRule +
-			len(issues.Patterns)*PenaltyPerPattern +
-			len(issues.Dictionary)*dictPenalty +
-			len(issues.Context)*PenaltyPerContext +
-			len(issues.HIBP)*PenaltyPerHIBP
-	}
+// calculate implements the scoring formula given the password length in
+// runes and its character sets.
+func calculate(entropyBits float64, length int, cs entropy.CharsetInfo, issues IssueSet, opts Options) int {
+	return trace(entropyBits, length, cs, issues, opts).Score
+}
 
-	score := int(base) + bonus - penalty
+// dictionaryPenalty returns the total penalty for the dictionary issues at
+// perIssue points each, scaled by each issue's breach frequency when known.
+func dictionaryPenalty(issues []issue.Issue, perIssue int) float64 {
+	var total float64
+	for _, iss := range issues {
+		total += float64(perIssue) * frequencyScale(iss.Frequency)
+	}
+	return total
+}
 
-	return clamp(score, 0, 100)
+// frequencyScale returns the penalty multiplier for a normalized breach
+// frequency, or 1 when the frequency is unknown.
+func frequencyScale(freq float64) float64 {
+	if freq <= 0 {
+		return 1
+	}
+	return minFrequencyScale + (maxFrequencyScale-minFrequencyScale)*min(freq, 1)
 }
 
 // Verdict maps a score (0-100) to a human-readable strength label using
@@ -198,7 +265,12 @@ func lengthBonus(password string) int {
 
 // lengthBonusWith awards extra points for passwords that exceed minLength.
 func lengthBonusWith(password string, minLength int) int {
-	extra := len([]rune(password)) - minLength
+	return lengthBonusFor(len([]rune(password)), minLength)
+}
+
+// lengthBonusFor awards extra points for a length (in runes) beyond minLength.
+func lengthBonusFor(length, minLength int) int {
+	extra := length - minLength
 	if extra <= 0 {
 		return 0
 	}
@@ -211,12 +283,26 @@ func lengthBonusWith(password string, minLength int) int {
 
 // charsetBonus awards extra points for using multiple character set types.
 func charsetBonus(password string) int {
+	return charsetBonusWith(password, CharsetModelLinear)
+}
+
+// charsetBonusWith awards extra points for using multiple character set
+// types, following the growth curve selected by model.
+func charsetBonusWith(password string, model CharsetModel) int {
 	info, _ := entropy.AnalyzeCharsets(password)
+	return charsetBonusFor(info, model)
+}
+
+// charsetBonusFor awards extra points for the character set types in info.
+func charsetBonusFor(info entropy.CharsetInfo, model CharsetModel) int {
 	count := info.SetCount()
 
 	if count <= 1 {
 		return 0
 	}
+	if model == CharsetModelDiminishing && count < len(diminishingCharsetBonus) {
+		return diminishingCharsetBonus[count]
+	}
 	bonus := (count - 1) * BonusPerCharset
 	if bonus > MaxCharsetBonus {
 		bonus = MaxCharsetBonus
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/passphrase"
+	"github.com/rafaelsanzio/passcheck/internal/rules"
 )
 
 // ---------------------------------------------------------------------------
@@ -67,6 +69,39 @@ func TestCalculate_DictionaryPenalty(t *testing.T) {
 	}
 }
 
+func TestCalculateWithPassphrase_DictionaryFrequency(t *testing.T) {
+	// 64 bits → base 50; "ab" → no bonuses.
+	tests := []struct {
+		freq float64
+		want int
+	}{
+		{0, 35},   // unknown frequency: the usual 15
+		{1, 28},   // most frequent entry: 15 × 1.5 = 22.5
+		{0.1, 41}, // rare entry: 15 × 0.6 = 9
+		{0.5, 35}, // midpoint: 15 × 1.0 = 15
+		{2, 28},   // clamped to 1
+		{-1, 35},  // treated as unknown
+	}
+	for _, tt := range tests {
+		issues := IssueSet{Dictionary: []issue.Issue{{Frequency: tt.freq}}}
+		if got := CalculateWithPassphrase(64, "ab", issues, DefaultMinLength, nil, nil); got != tt.want {
+			t.Errorf("freq %v: got %d, want %d", tt.freq, got, tt.want)
+		}
+		if got := CalculateWithPassphrase(64, "ab", issues, DefaultMinLength, nil, &Weights{}); got != tt.want {
+			t.Errorf("freq %v with weights: got %d, want %d", tt.freq, got, tt.want)
+		}
+	}
+
+	// Passphrases carry no dictionary penalty regardless of frequency.
+	issues := IssueSet{Dictionary: []issue.Issue{{Frequency: 1}}}
+	info := &passphrase.Info{IsPassphrase: true}
+	withFreq := CalculateWithPassphrase(64, "ab", issues, DefaultMinLength, info, nil)
+	without := CalculateWithPassphrase(64, "ab", IssueSet{Dictionary: make([]issue.Issue, 1)}, DefaultMinLength, info, nil)
+	if withFreq != without {
+		t.Errorf("passphrase with frequency = %d, want %d", withFreq, without)
+	}
+}
+
 func TestCalculate_MixedPenalties(t *testing.T) {
 	// 80 bits → base 62.
 	// Password "ab" → no bonuses.
@@ -189,6 +224,60 @@ func TestCharsetBonus(t *testing.T) {
 	}
 }
 
+func TestCharsetBonusWith_Diminishing(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		expected int
+	}{
+		{"1 set", "abcdef", 0},
+		{"2 sets", "abcABC", 3},
+		{"3 sets", "abcABC123", 5},
+		{"4 sets", "abcABC123!", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := charsetBonusWith(tt.password, CharsetModelDiminishing); got != tt.expected {
+				t.Errorf("charsetBonusWith(%q, diminishing) = %d, want %d", tt.password, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalculateWithOptions_CharsetModel(t *testing.T) {
+	// Same length and entropy; the only difference is the fourth class.
+	threeClass, fourClass := "abcABC1234", "abcABC123!"
+	score := func(pw string, model CharsetModel) int {
+		return CalculateWithOptions(50, pw, IssueSet{}, Options{MinLength: 10, CharsetModel: model})
+	}
+
+	linearGain := score(fourClass, CharsetModelLinear) - score(threeClass, CharsetModelLinear)
+	dimGain := score(fourClass, CharsetModelDiminishing) - score(threeClass, CharsetModelDiminishing)
+
+	if linearGain != BonusPerCharset {
+		t.Errorf("linear: 4th class gain = %d, want %d", linearGain, BonusPerCharset)
+	}
+	if dimGain >= linearGain {
+		t.Errorf("diminishing: 4th class gain = %d, want less than linear gain %d", dimGain, linearGain)
+	}
+	if dimGain <= 0 {
+		t.Errorf("diminishing: 4th class gain = %d, want > 0", dimGain)
+	}
+}
+
+func TestCalculateProfile_MatchesCalculateWithOptions(t *testing.T) {
+	issues := IssueSet{Rules: []issue.Issue{issue.New(issue.CodeRuleNoSymbol, "x", issue.CategoryRule, issue.SeverityLow)}}
+	opts := Options{MinLength: 8, CharsetModel: CharsetModelDiminishing}
+	for _, pw := range []string{"", "abc", "Xk9mP2vR7nL4wQ", "Xk9$mP2vR7nL4wQ", "pässwörd"} {
+		want := CalculateWithOptions(40, pw, issues, opts)
+		got := CalculateProfile(40, rules.NewProfile(pw), issues, opts)
+		if got != want {
+			t.Errorf("CalculateProfile(%q) = %d, want %d", pw, got, want)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Verdict
 // ---------------------------------------------------------------------------
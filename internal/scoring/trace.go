@@ -0,0 +1,129 @@
+package scoring
+
+import (
+	"github.com/rafaelsanzio/passcheck/internal/entropy"
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/rules"
+)
+
+// Trace records each step of the scoring formula for one password, for
+// explaining a score to a reader. Its Score is the value returned by the
+// Calculate function given the same inputs.
+type Trace struct {
+	// Entropy is the entropy in bits the score was computed from.
+	Entropy float64
+
+	// Base is the entropy-derived base score, entropy × 100 / 128 scaled
+	// by the entropy weight. Only its integer part enters the score.
+	Base float64
+
+	// LengthBonus, CharsetBonus, and PassphraseBonus are the bonuses
+	// awarded for length beyond the minimum, character-set diversity, and
+	// a detected passphrase.
+	LengthBonus     int
+	CharsetBonus    int
+	PassphraseBonus int
+
+	// Penalties lists the points each issue costs, in evaluation order.
+	// Issues that cost nothing, such as dictionary matches in a
+	// passphrase, are included with zero points.
+	Penalties []Penalty
+
+	// Penalty is the total subtracted from the score: the sum of the
+	// penalties, truncated to an integer.
+	Penalty int
+
+	// Raw is int(Base) + bonuses − Penalty before clamping, and Score is
+	// Raw clamped to 0–100.
+	Raw   int
+	Score int
+}
+
+// Penalty is the cost of one issue in a [Trace].
+type Penalty struct {
+	Issue  issue.Issue
+	Points float64
+}
+
+// TraceWithOptions is like [CalculateWithOptions] but returns the full
+// trace of the computation.
+func TraceWithOptions(entropyBits float64, password string, issues IssueSet, opts Options) Trace {
+	cs, length := entropy.AnalyzeCharsets(password)
+	return trace(entropyBits, length, cs, issues, opts)
+}
+
+// TraceProfile is like [CalculateProfile] but returns the full trace of
+// the computation.
+func TraceProfile(entropyBits float64, p rules.Profile, issues IssueSet, opts Options) Trace {
+	return trace(entropyBits, p.Length, p.Charsets, issues, opts)
+}
+
+// trace implements the scoring formula given the password length in runes
+// and its character sets; calculate returns its Score.
+func trace(entropyBits float64, length int, cs entropy.CharsetInfo, issues IssueSet, opts Options) Trace {
+	passphraseInfo, weights := opts.Passphrase, opts.Weights
+	isPassphrase := passphraseInfo != nil && passphraseInfo.IsPassphrase
+
+	t := Trace{
+		Entropy:      entropyBits,
+		LengthBonus:  lengthBonusFor(length, opts.MinLength),
+		CharsetBonus: charsetBonusFor(cs, opts.CharsetModel),
+	}
+	// Add passphrase bonus for multi-word passphrases
+	if isPassphrase {
+		t.PassphraseBonus = BonusPassphrase
+	}
+
+	// --- Base score from entropy ---
+	baseEntropy := entropyBits * maxScoreBase / entropyFull
+
+	// --- Penalties ---
+	// Eliminate dictionary penalties for passphrases (dictionary words are expected and desired)
+	dictPenalty := PenaltyPerDictMatch
+	if isPassphrase {
+		dictPenalty = 0 // No dictionary penalties for passphrases
+	}
+
+	// Apply weights if provided
+	w := DefaultWeights()
+	if weights != nil {
+		t.Base, t.Penalty = weights.applyWeights(baseEntropy, issues, dictPenalty)
+		w = *weights
+	} else {
+		t.Base = baseEntropy
+		t.Penalty = len(issues.Rules)*PenaltyPerRule +
+			len(issues.Patterns)*PenaltyPerPattern +
+			int(dictionaryPenalty(issues.Dictionary, dictPenalty)) +
+			len(issues.Context)*PenaltyPerContext +
+			len(issues.HIBP)*PenaltyPerHIBP +
+			len(issues.History)*PenaltyPerHistory
+	}
+	t.Penalties = penalties(issues, dictPenalty, w)
+
+	t.Raw = int(t.Base) + t.LengthBonus + t.CharsetBonus + t.PassphraseBonus - t.Penalty
+	t.Score = clamp(t.Raw, 0, 100)
+	return t
+}
+
+// penalties returns the points each issue costs under w, with dictionary
+// issues costing dictPenalty points before frequency scaling.
+func penalties(issues IssueSet, dictPenalty int, w Weights) []Penalty {
+	var out []Penalty
+	add := func(list []issue.Issue, points func(issue.Issue) float64) {
+		for _, iss := range list {
+			out = append(out, Penalty{Issue: iss, Points: points(iss)})
+		}
+	}
+	flat := func(perIssue int, weight float64) func(issue.Issue) float64 {
+		return func(issue.Issue) float64 { return float64(perIssue) * w.getOrDefault(weight) }
+	}
+	add(issues.Rules, flat(PenaltyPerRule, w.RuleViolation))
+	add(issues.Patterns, flat(PenaltyPerPattern, w.PatternMatch))
+	add(issues.Dictionary, func(iss issue.Issue) float64 {
+		return float64(dictPenalty) * frequencyScale(iss.Frequency) * w.getOrDefault(w.DictionaryMatch)
+	})
+	add(issues.Context, flat(PenaltyPerContext, w.ContextMatch))
+	add(issues.HIBP, flat(PenaltyPerHIBP, w.HIBPBreach))
+	add(issues.History, flat(PenaltyPerHistory, w.ContextMatch))
+	return out
+}
@@ -0,0 +1,73 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/passphrase"
+)
+
+func TestTraceWithOptions_MatchesCalculate(t *testing.T) {
+	issues := IssueSet{
+		Rules:      make([]issue.Issue, 2),
+		Patterns:   make([]issue.Issue, 1),
+		Dictionary: []issue.Issue{{Frequency: 1}, {}},
+		Context:    make([]issue.Issue, 1),
+		HIBP:       make([]issue.Issue, 1),
+		History:    make([]issue.Issue, 1),
+	}
+	tests := []struct {
+		name    string
+		entropy float64
+		issues  IssueSet
+		opts    Options
+	}{
+		{"no issues", 90, IssueSet{}, Options{MinLength: 8}},
+		{"all categories", 200, issues, Options{MinLength: 8}},
+		{"weights", 200, issues, Options{Weights: &Weights{RuleViolation: 1.5, DictionaryMatch: 0.3, EntropyWeight: 0.9}}},
+		{"passphrase", 70, issues, Options{Passphrase: &passphrase.Info{IsPassphrase: true, WordCount: 4}}},
+		{"diminishing", 60, IssueSet{}, Options{CharsetModel: CharsetModelDiminishing}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const pw = "Xk9$mP2!vR7@nL4&"
+			tr := TraceWithOptions(tt.entropy, pw, tt.issues, tt.opts)
+			if want := CalculateWithOptions(tt.entropy, pw, tt.issues, tt.opts); tr.Score != want {
+				t.Errorf("Score = %d, want %d", tr.Score, want)
+			}
+			if got := int(tr.Base) + tr.LengthBonus + tr.CharsetBonus + tr.PassphraseBonus - tr.Penalty; got != tr.Raw {
+				t.Errorf("Raw = %d, want %d", tr.Raw, got)
+			}
+			if n := len(tt.issues.AllIssues()); len(tr.Penalties) != n {
+				t.Fatalf("len(Penalties) = %d, want %d", len(tr.Penalties), n)
+			}
+			var sum float64
+			for _, p := range tr.Penalties {
+				sum += p.Points
+			}
+			if int(sum+1e-9) != tr.Penalty {
+				t.Errorf("penalties sum to %.2f, Penalty = %d", sum, tr.Penalty)
+			}
+		})
+	}
+}
+
+func TestTraceWithOptions_PenaltyPoints(t *testing.T) {
+	issues := IssueSet{
+		Rules:      []issue.Issue{{Code: issue.CodeRuleTooShort}},
+		Dictionary: []issue.Issue{{Code: issue.CodeDictCommonPassword, Frequency: 1}},
+	}
+	tr := TraceWithOptions(40, "abc", issues, Options{})
+	want := []float64{PenaltyPerRule, PenaltyPerDictMatch * maxFrequencyScale}
+	for i, p := range tr.Penalties {
+		if p.Points != want[i] {
+			t.Errorf("%s: Points = %v, want %v", p.Issue.Code, p.Points, want[i])
+		}
+	}
+
+	// Dictionary matches in a passphrase cost nothing.
+	tr = TraceWithOptions(40, "abc", issues, Options{Passphrase: &passphrase.Info{IsPassphrase: true}})
+	if p := tr.Penalties[1]; p.Points != 0 || tr.PassphraseBonus != BonusPassphrase {
+		t.Errorf("passphrase: dictionary Points = %v, bonus = %d", p.Points, tr.PassphraseBonus)
+	}
+}
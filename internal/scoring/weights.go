@@ -9,7 +9,7 @@ type Weights struct {
 	RuleViolation   float64 // Multiplier for rule violation penalties
 	PatternMatch    float64 // Multiplier for pattern detection penalties
 	DictionaryMatch float64 // Multiplier for dictionary match penalties
-	ContextMatch    float64 // Multiplier for context detection penalties
+	ContextMatch    float64 // Multiplier for context and history penalties
 	HIBPBreach      float64 // Multiplier for HIBP breach penalties
 	EntropyWeight   float64 // Multiplier for entropy base score
 }
@@ -49,9 +49,10 @@ func (w Weights) applyWeights(baseEntropy float64, issues IssueSet, dictPenaltyP
 
 	weightedPenalty = int(float64(len(issues.Rules))*PenaltyPerRule*ruleWeight +
 		float64(len(issues.Patterns))*PenaltyPerPattern*patternWeight +
-		float64(len(issues.Dictionary))*float64(dictPenaltyPerIssue)*dictWeight +
+		dictionaryPenalty(issues.Dictionary, dictPenaltyPerIssue)*dictWeight +
 		float64(len(issues.Context))*PenaltyPerContext*contextWeight +
-		float64(len(issues.HIBP))*PenaltyPerHIBP*hibpWeight)
+		float64(len(issues.HIBP))*PenaltyPerHIBP*hibpWeight +
+		float64(len(issues.History))*PenaltyPerHistory*contextWeight)
 
 	return weightedBase, weightedPenalty
 }
@@ -0,0 +1,362 @@
+// Package yaml parses the small subset of YAML used by passcheck policy
+// files.
+//
+// Supported: block mappings and block sequences nested by indentation,
+// including mappings as sequence items ("- key: value" followed by the
+// item's other keys aligned with the first), flow sequences of scalars
+// ([a, b]), the empty flow mapping ({}), plain,
+// single-quoted, and double-quoted scalars, "#" comments, and a leading
+// "---" document marker. Scalars follow the YAML 1.2 core schema: null,
+// ~, true, false, integers, and floats are typed; everything else is a
+// string. Anchors, tags, multi-line scalars, and multiple documents are
+// rejected with an error rather than misread.
+//
+// Parsed documents are returned as map[string]any whose values are
+// map[string]any, []any, string, bool, int64, float64, or nil — the same
+// shapes encoding/json produces, so a document can be re-encoded as JSON.
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// line is a non-blank source line with comments removed.
+type line struct {
+	num    int // 1-based line number
+	indent int // leading spaces
+	text   string
+}
+
+// Parse parses a YAML document whose top level is a mapping. An empty
+// document yields an empty map.
+func Parse(data []byte) (map[string]any, error) {
+	lines, err := splitLines(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return map[string]any{}, nil
+	}
+	p := &parser{lines: lines}
+	if isSeqItem(lines[0].text) {
+		return nil, p.errorf(lines[0], "top level must be a mapping")
+	}
+	m, err := p.mapping(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(lines) {
+		return nil, p.errorf(lines[p.pos], "unexpected indentation")
+	}
+	return m, nil
+}
+
+type parser struct {
+	lines []line
+	pos   int
+}
+
+func (p *parser) errorf(l line, format string, args ...any) error {
+	return fmt.Errorf("yaml: line %d: %s", l.num, fmt.Sprintf(format, args...))
+}
+
+// mapping parses block mapping entries at exactly indent.
+func (p *parser) mapping(indent int) (map[string]any, error) {
+	m := map[string]any{}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, p.errorf(l, "unexpected indentation")
+		}
+		if isSeqItem(l.text) {
+			return nil, p.errorf(l, "unexpected sequence item in mapping")
+		}
+		key, rest, err := splitKey(l.text)
+		if err != nil {
+			return nil, p.errorf(l, "%v", err)
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf(l, "duplicate key %q", key)
+		}
+		p.pos++
+
+		if rest != "" {
+			v, err := scalar(rest)
+			if err != nil {
+				return nil, p.errorf(l, "%v", err)
+			}
+			m[key] = v
+			continue
+		}
+		m[key], err = p.nested(indent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// nested parses the block value of a key with an empty inline value: a
+// more indented mapping or sequence, or a sequence at the key's own
+// indentation. It returns nil when there is no block.
+func (p *parser) nested(parentIndent int) (any, error) {
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	next := p.lines[p.pos]
+	switch {
+	case next.indent > parentIndent && isSeqItem(next.text):
+		return p.sequence(next.indent)
+	case next.indent > parentIndent:
+		return p.mapping(next.indent)
+	case next.indent == parentIndent && isSeqItem(next.text):
+		return p.sequence(next.indent)
+	}
+	return nil, nil
+}
+
+// sequence parses block sequence items at exactly indent.
+func (p *parser) sequence(indent int) ([]any, error) {
+	seq := []any{}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent != indent || !isSeqItem(l.text) {
+			if l.indent > indent {
+				return nil, p.errorf(l, "unexpected indentation")
+			}
+			break
+		}
+		item := strings.TrimSpace(strings.TrimPrefix(l.text, "-"))
+		if _, _, err := splitKey(item); item != "" && err == nil {
+			// The item is a mapping whose first key follows the dash; read
+			// it as if the key started its own line at its column.
+			col := l.indent + len(l.text) - len(item)
+			p.lines[p.pos] = line{num: l.num, indent: col, text: item}
+			m, err := p.mapping(col)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, m)
+			continue
+		}
+		p.pos++
+		if item == "" {
+			v, err := p.nested(indent)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, v)
+			continue
+		}
+		v, err := scalar(item)
+		if err != nil {
+			return nil, p.errorf(l, "%v", err)
+		}
+		seq = append(seq, v)
+	}
+	return seq, nil
+}
+
+// isSeqItem reports whether text starts a block sequence item.
+func isSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitKey splits "key: value" into its key and trimmed value. Keys may be
+// plain or quoted.
+func splitKey(text string) (key, rest string, err error) {
+	if text[0] == '"' || text[0] == '\'' {
+		end := closingQuote(text, 0)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated quoted key")
+		}
+		k, err := scalar(text[:end+1])
+		if err != nil {
+			return "", "", err
+		}
+		after := text[end+1:]
+		if !strings.HasPrefix(after, ":") || (len(after) > 1 && after[1] != ' ') {
+			return "", "", fmt.Errorf("expected ':' after key")
+		}
+		return fmt.Sprint(k), strings.TrimSpace(after[1:]), nil
+	}
+	i := strings.Index(text, ": ")
+	if i < 0 {
+		if !strings.HasSuffix(text, ":") {
+			return "", "", fmt.Errorf("expected 'key: value'")
+		}
+		i = len(text) - 1
+	}
+	key = strings.TrimSpace(text[:i])
+	if key == "" {
+		return "", "", fmt.Errorf("empty key")
+	}
+	return key, strings.TrimSpace(text[i+1:]), nil
+}
+
+// scalar parses an inline value: a quoted or plain scalar, a flow
+// sequence of scalars, or the empty flow mapping.
+func scalar(s string) (any, error) {
+	switch {
+	case s == "{}":
+		return map[string]any{}, nil
+	case strings.HasPrefix(s, "{"):
+		return nil, fmt.Errorf("flow mappings are not supported")
+	case strings.HasPrefix(s, "["):
+		return flowSequence(s)
+	case s[0] == '"':
+		if closingQuote(s, 0) != len(s)-1 {
+			return nil, fmt.Errorf("invalid double-quoted scalar %s", s)
+		}
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid double-quoted scalar %s", s)
+		}
+		return v, nil
+	case s[0] == '\'':
+		if closingQuote(s, 0) != len(s)-1 {
+			return nil, fmt.Errorf("invalid single-quoted scalar %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case strings.ContainsRune("&*!|>%@`", rune(s[0])):
+		return nil, fmt.Errorf("unsupported YAML syntax %q", s)
+	}
+
+	switch s {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	if strings.ContainsAny(s[:1], "+-.0123456789") {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, nil
+		}
+	}
+	return s, nil
+}
+
+// flowSequence parses "[a, 'b', 3]" into its scalar items.
+func flowSequence(s string) ([]any, error) {
+	if !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("unterminated flow sequence")
+	}
+	body := strings.TrimSpace(s[1 : len(s)-1])
+	seq := []any{}
+	if body == "" {
+		return seq, nil
+	}
+	for start := 0; start <= len(body); {
+		end := start
+		for end < len(body) && body[end] != ',' {
+			if body[end] == '"' || body[end] == '\'' {
+				q := closingQuote(body, end)
+				if q < 0 {
+					return nil, fmt.Errorf("unterminated quoted scalar in flow sequence")
+				}
+				end = q
+			}
+			if body[end] == '[' || body[end] == '{' {
+				return nil, fmt.Errorf("nested flow collections are not supported")
+			}
+			end++
+		}
+		item := strings.TrimSpace(body[start:end])
+		if item == "" {
+			return nil, fmt.Errorf("empty item in flow sequence")
+		}
+		v, err := scalar(item)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, v)
+		start = end + 1
+	}
+	return seq, nil
+}
+
+// closingQuote returns the index of the quote closing the one at s[open],
+// honoring backslash escapes in double quotes and doubled quotes in single
+// quotes, or -1 if it is unterminated.
+func closingQuote(s string, open int) int {
+	q := s[open]
+	for i := open + 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case s[i] == q && q == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+// splitLines returns the non-blank lines of src with comments and
+// trailing whitespace removed.
+func splitLines(src string) ([]line, error) {
+	var out []line
+	for i, raw := range strings.Split(src, "\n") {
+		raw = strings.TrimRight(raw, " \t\r")
+		body := strings.TrimLeft(raw, " ")
+		l := line{num: i + 1, indent: len(raw) - len(body)}
+		if strings.HasPrefix(body, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed in indentation", l.num)
+		}
+		body = stripComment(body)
+		if body == "" {
+			continue
+		}
+		if len(out) == 0 && body == "---" {
+			continue
+		}
+		if body == "---" || body == "..." {
+			return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", l.num)
+		}
+		l.text = body
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+// stripComment removes a "#" comment that starts the line or follows
+// whitespace, ignoring "#" inside quoted scalars. A quote opens a scalar
+// only where one can start: at the beginning, or after ": ", "- ", "[",
+// or ",".
+func stripComment(s string) string {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimRight(s[:i], " \t")
+		case (c == '"' || c == '\'') && canOpenQuote(s, i):
+			if end := closingQuote(s, i); end > 0 {
+				i = end
+			}
+		}
+	}
+	return s
+}
+
+// canOpenQuote reports whether a quote at s[i] starts a quoted scalar.
+func canOpenQuote(s string, i int) bool {
+	prev := strings.TrimRight(s[:i], " ")
+	if prev == "" {
+		return true
+	}
+	last := prev[len(prev)-1]
+	if last == '[' || last == ',' {
+		return true
+	}
+	return len(prev) < i && (last == ':' || last == '-')
+}
@@ -0,0 +1,107 @@
+package yaml
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	src := `---
+# password policy
+min_length: 14          # NIST minimum is 8
+require_symbol: false
+locale: "pt-BR"
+note: 'it''s fine'
+pattern: a#b
+weight: 1.5
+empty:
+nothing: ~
+layouts: [qwerty, "azerty", 'dvorak']
+none: []
+map: {}
+words:
+  - acme
+  - "# not a comment"
+  - 42
+words_flush:
+- one
+- two
+weights:
+  dictionary_match: 2
+  nested:
+    deep: true
+bands:
+  - min_score: 0
+    label: "Weak: try again"
+  -   min_score: 60
+      tags:
+        - a
+      label: Fine
+  - plain
+`
+	got, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"min_length":     int64(14),
+		"require_symbol": false,
+		"locale":         "pt-BR",
+		"note":           "it's fine",
+		"pattern":        "a#b",
+		"weight":         1.5,
+		"empty":          nil,
+		"nothing":        nil,
+		"layouts":        []any{"qwerty", "azerty", "dvorak"},
+		"none":           []any{},
+		"map":            map[string]any{},
+		"words":          []any{"acme", "# not a comment", int64(42)},
+		"words_flush":    []any{"one", "two"},
+		"weights": map[string]any{
+			"dictionary_match": int64(2),
+			"nested":           map[string]any{"deep": true},
+		},
+		"bands": []any{
+			map[string]any{"min_score": int64(0), "label": "Weak: try again"},
+			map[string]any{"min_score": int64(60), "tags": []any{"a"}, "label": "Fine"},
+			"plain",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	for _, src := range []string{"", "\n# only a comment\n", "---\n"} {
+		got, err := Parse([]byte(src))
+		if err != nil || len(got) != 0 {
+			t.Errorf("Parse(%q) = %v, %v; want empty map", src, got, err)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"tab indentation":     "a:\n\tb: 1\n",
+		"duplicate key":       "a: 1\na: 2\n",
+		"bad indentation":     "a: 1\n  b: 2\n",
+		"top-level sequence":  "- a\n",
+		"missing colon":       "just text\n",
+		"anchor":              "a: &x 1\n",
+		"block scalar":        "a: |\n  text\n",
+		"flow mapping":        "a: {b: 1}\n",
+		"nested flow":         "a: [[1]]\n",
+		"unterminated string": "a: \"open\n",
+		"multiple documents":  "a: 1\n---\nb: 2\n",
+		"misaligned item key": "a:\n  - b: 1\n     c: 2\n",
+	}
+	for name, src := range tests {
+		if _, err := Parse([]byte(src)); err == nil {
+			t.Errorf("%s: expected error for %q", name, src)
+		} else if !strings.HasPrefix(err.Error(), "yaml: ") {
+			t.Errorf("%s: error %q lacks the yaml prefix", name, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package passcheck
+
+import "github.com/rafaelsanzio/passcheck/internal/feedback"
+
+// Message keys for the positive suggestions in Result.Suggestions, for use
+// in tables passed to [RegisterMessages]. Issue messages are keyed by
+// their issue code (e.g. [CodeRuleTooShort]).
+//
+// Translations for MessageGoodLength, the MessageCharsetDiversity keys,
+// and MessageHighEntropy may include the placeholder "{n}", replaced with
+// the number of characters, character types, or entropy bits
+// respectively. MessageCharsetDiversityEmoji and
+// MessageCharsetDiversityCJK replace MessageCharsetDiversity when the
+// password contains emoji or CJK characters.
+const (
+	MessageGoodLength            = feedback.PositiveGoodLength
+	MessageCharsetDiversity      = feedback.PositiveDiversity
+	MessageCharsetDiversityEmoji = feedback.PositiveDiversityEmoji
+	MessageCharsetDiversityCJK   = feedback.PositiveDiversityCJK
+	MessageNoPatterns            = feedback.PositiveNoPatterns
+	MessageNotCommon             = feedback.PositiveNotCommon
+	MessageHighEntropy           = feedback.PositiveHighEntropy
+)
+
+// Message keys for the reasons in Result.StrengthReasons. Translations for
+// MessageReasonLength, MessageReasonCharTypes, and MessageReasonEntropy may
+// include the placeholder "{n}", replaced with the number of characters,
+// character types, or entropy bits respectively.
+const (
+	MessageReasonLength       = feedback.ReasonLength
+	MessageReasonCharTypes    = feedback.ReasonCharTypes
+	MessageReasonNoDictionary = feedback.ReasonNoDictionary
+	MessageReasonNoPatterns   = feedback.ReasonNoPatterns
+	MessageReasonEntropy      = feedback.ReasonEntropy
+)
+
+// RegisterMessages registers translated messages for a BCP-47 locale tag
+// such as "pt-BR", selected through Config.Locale. Keys are issue codes
+// and the Message* constants; missing keys fall back to English. The
+// table is copied, and registering a locale again merges into and
+// overrides its earlier entries. Tags match case-insensitively.
+//
+// RegisterMessages is safe for concurrent use, but is typically called
+// once during program initialization.
+//
+// Example:
+//
+//	passcheck.RegisterMessages("pt-BR", map[string]string{
+//		passcheck.CodeRuleTooShort:  "A senha é muito curta",
+//		passcheck.MessageGoodLength: "Bom comprimento ({n} caracteres)",
+//	})
+func RegisterMessages(locale string, table map[string]string) {
+	feedback.RegisterMessages(locale, table)
+}
+
+// hasTranslation reports whether a translation is registered for key
+// under locale.
+func hasTranslation(locale, key string) bool {
+	_, ok := feedback.Localize(locale, key)
+	return ok
+}
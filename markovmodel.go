@@ -0,0 +1,38 @@
+package passcheck
+
+import (
+	"io"
+
+	"github.com/rafaelsanzio/passcheck/internal/entropy"
+)
+
+// MarkovModel holds character transition statistics learned from a
+// password corpus. Build it once with [LoadMarkovModel], assign it to
+// [Config.MarkovModel], and reuse it across checks; it is safe for
+// concurrent use.
+type MarkovModel = entropy.MarkovModel
+
+// ErrEmptyMarkovCorpus is returned by [LoadMarkovModel] when the corpus
+// holds no line of two or more characters.
+var ErrEmptyMarkovCorpus = entropy.ErrEmptyCorpus
+
+// LoadMarkovModel reads a newline-delimited password corpus from r and
+// learns how likely each character is to follow another. Lines are
+// lowercased and trailing whitespace is trimmed; blank lines and lines
+// starting with "#" are skipped.
+//
+// With the model set as [Config.MarkovModel], pattern-aware entropy scores
+// passwords whose transitions are common in the corpus as more predictable,
+// and passwords unlike it as less predictable.
+//
+//	f, err := os.Open("corpus.txt")
+//	if err != nil { /* handle */ }
+//	defer f.Close()
+//	model, err := passcheck.LoadMarkovModel(f)
+//	if err != nil { /* handle */ }
+//	cfg := passcheck.DefaultConfig()
+//	cfg.EntropyMode = passcheck.EntropyModePatternAware
+//	cfg.MarkovModel = model
+func LoadMarkovModel(r io.Reader) (*MarkovModel, error) {
+	return entropy.LoadMarkovModel(r)
+}
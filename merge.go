@@ -0,0 +1,133 @@
+package passcheck
+
+import (
+	"context"
+	"reflect"
+)
+
+// Merge returns a copy of c in which every field that is set in override
+// replaces the corresponding field of c, for layering a team policy over a
+// base policy. A field is set when it is not its zero value: true for
+// bools, non-zero for numbers and strings, non-nil for slices, pointers,
+// and functions.
+//
+// Merge cannot express "turn this off" — an override with
+// RequireSymbol: false leaves the base value unchanged. Use [Config.Apply]
+// with a [ConfigPatch] for that.
+func (c Config) Merge(override Config) Config {
+	dst := reflect.ValueOf(&c).Elem()
+	src := reflect.ValueOf(override)
+	for i := range src.NumField() {
+		if f := src.Field(i); !f.IsZero() {
+			dst.Field(i).Set(f)
+		}
+	}
+	return c
+}
+
+// ConfigPatch describes changes to a [Config] for [Config.Apply]. Each
+// field mirrors the Config field of the same name; a nil field leaves the
+// Config unchanged, so explicit zero values such as false can be applied:
+//
+//	noSymbols := false
+//	teamCfg := baseCfg.Apply(passcheck.ConfigPatch{RequireSymbol: &noSymbols})
+//
+// Fields whose Config type is already a slice, pointer, or function use
+// that type directly. Set a slice field to an empty, non-nil slice to
+// clear the list.
+type ConfigPatch struct {
+	MinLength       *int
+	MaxLength       *int
+	RequireUpper    *bool
+	RequireLower    *bool
+	RequireDigit    *bool
+	RequireSymbol   *bool
+	MinCharClasses  *int
+	MaxRepeats      *int
+	MinUniqueChars  *int
+	FlagNumericOnly *bool
+	CustomRules     []Rule
+
+	AllowWhitespace         *bool
+	PatternMinLength        *int
+	KeyboardLayouts         []string
+	ForbiddenPatterns       []string
+	ForbiddenPatternIsFatal *bool
+	DetectCurrentYear       *bool
+	CurrentYear             *int
+	MinEntropy              *float64
+	DisabledCategories      []string
+
+	MaxIssues         *int
+	IssueOrder        []string
+	SuppressAllIssues *bool
+	FriendlyMessages  *bool
+	Locale            *string
+
+	CustomPasswords     []string
+	PasswordSet         *PasswordSet
+	HotList             []string
+	CustomWords         []string
+	MaxCustomEntries    *int
+	ContextWords        []string
+	ContextMinWordLen   *int
+	PreviousPasswords   []string
+	MaxHistorySubstring *int
+	MinEditDistance     *int
+	CurrentPasswordHash *string
+	DisableLeet         *bool
+	DetectReversed      *bool
+	DetectSubstrings    *bool
+	FuzzyDictionary     *bool
+	DetectMangled       *bool
+	NormalizeUnicode    *bool
+
+	HIBPChecker interface {
+		Check(password string) (breached bool, count int, err error)
+	}
+	HIBPMinOccurrences *int
+	HIBPResult         *HIBPCheckResult
+	ConstantTimeMode   *bool
+
+	PassphraseMode     *bool
+	MinWords           *int
+	WordDictSize       *int
+	MinExecutionTimeMs *int
+	EntropyMode        *EntropyMode
+	MarkovModel        *MarkovModel
+	EmojiPoolSize      *int
+	GuessesPerSecond   *float64
+
+	PenaltyWeights    *PenaltyWeights
+	VerdictThresholds *VerdictThresholds
+	VerdictBands      []VerdictBand
+	CharsetBonusModel *CharsetBonusModel
+	RedactSensitive   *bool
+	KeySalt           []byte
+
+	OnResult  func(ctx context.Context, result Result)
+	OnIssue   func(ctx context.Context, iss Issue)
+	OnFailure func(ctx context.Context, result Result)
+	Observer  Observer
+
+	Parallelism *int
+}
+
+// Apply returns a copy of c with every non-nil field of patch applied.
+// The result is not validated; call [Config.Validate] before use.
+func (c Config) Apply(patch ConfigPatch) Config {
+	dst := reflect.ValueOf(&c).Elem()
+	src := reflect.ValueOf(patch)
+	for i := range src.NumField() {
+		f := src.Field(i)
+		if f.IsNil() {
+			continue
+		}
+		field := dst.FieldByName(src.Type().Field(i).Name)
+		if f.Kind() == reflect.Pointer && f.Type().Elem() == field.Type() {
+			f = f.Elem()
+		}
+		field.Set(f)
+	}
+	return c
+}
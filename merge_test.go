@@ -0,0 +1,92 @@
+package passcheck
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Merge(t *testing.T) {
+	base := DefaultConfig()
+	base.CustomWords = []string{"acme"}
+
+	merged := base.Merge(Config{
+		MinLength:      16,
+		RequireSymbol:  false, // zero: cannot turn the base value off
+		ContextWords:   []string{"teamx"},
+		PassphraseMode: true,
+	})
+	if merged.MinLength != 16 || !merged.PassphraseMode {
+		t.Errorf("override not applied: MinLength=%d PassphraseMode=%t", merged.MinLength, merged.PassphraseMode)
+	}
+	if !merged.RequireSymbol || merged.MaxRepeats != base.MaxRepeats {
+		t.Error("unset override fields should keep the base values")
+	}
+	if !reflect.DeepEqual(merged.CustomWords, []string{"acme"}) || !reflect.DeepEqual(merged.ContextWords, []string{"teamx"}) {
+		t.Errorf("lists = %v, %v", merged.CustomWords, merged.ContextWords)
+	}
+	if base.MinLength != 12 {
+		t.Error("Merge must not modify the receiver")
+	}
+	if !reflect.DeepEqual(base.Merge(Config{}), base) {
+		t.Error("merging an empty override should be a no-op")
+	}
+}
+
+func TestConfig_Apply(t *testing.T) {
+	base := DefaultConfig()
+	base.KeyboardLayouts = []string{"qwerty", "azerty"}
+
+	noSymbols, minLength, mode := false, 8, EntropyModeSimple
+	got := base.Apply(ConfigPatch{
+		RequireSymbol:   &noSymbols,
+		MinLength:       &minLength,
+		EntropyMode:     &mode,
+		KeyboardLayouts: []string{},
+		VerdictBands:    []VerdictBand{{MinScore: 0, Label: "Weak"}, {MinScore: 50, Label: "Strong"}},
+	})
+	if got.RequireSymbol || got.MinLength != 8 || got.EntropyMode != EntropyModeSimple {
+		t.Errorf("patch not applied: RequireSymbol=%t MinLength=%d EntropyMode=%q", got.RequireSymbol, got.MinLength, got.EntropyMode)
+	}
+	if got.KeyboardLayouts == nil || len(got.KeyboardLayouts) != 0 {
+		t.Errorf("empty slice should clear KeyboardLayouts, got %v", got.KeyboardLayouts)
+	}
+	if len(got.VerdictBands) != 2 || !got.RequireUpper || got.MaxRepeats != base.MaxRepeats {
+		t.Error("nil patch fields should keep the base values")
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if !base.RequireSymbol || base.MinLength != 12 {
+		t.Error("Apply must not modify the receiver")
+	}
+	if !reflect.DeepEqual(base.Apply(ConfigPatch{}), base) {
+		t.Error("applying an empty patch should be a no-op")
+	}
+}
+
+// TestConfigPatch_MirrorsConfig ensures ConfigPatch has a field for every
+// Config field, of the same type or a pointer to it.
+func TestConfigPatch_MirrorsConfig(t *testing.T) {
+	cfgType, patchType := reflect.TypeOf(Config{}), reflect.TypeOf(ConfigPatch{})
+	if cfgType.NumField() != patchType.NumField() {
+		t.Errorf("Config has %d fields, ConfigPatch %d", cfgType.NumField(), patchType.NumField())
+	}
+	for i := range cfgType.NumField() {
+		f := cfgType.Field(i)
+		p, ok := patchType.FieldByName(f.Name)
+		if !ok {
+			t.Errorf("ConfigPatch is missing %s", f.Name)
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Slice, reflect.Pointer, reflect.Func, reflect.Interface:
+			if p.Type != f.Type {
+				t.Errorf("ConfigPatch.%s is %v, want %v", f.Name, p.Type, f.Type)
+			}
+		default:
+			if p.Type != reflect.PointerTo(f.Type) {
+				t.Errorf("ConfigPatch.%s is %v, want *%v", f.Name, p.Type, f.Type)
+			}
+		}
+	}
+}
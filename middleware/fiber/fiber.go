@@ -87,13 +87,14 @@ func Fiber(cfg middleware.Config) fiber.Handler {
 }
 
 // extractPassword reads the password field from a Fiber context. Fiber uses
-// fasthttp (not net/http) so the standard DefaultHTTPExtractor cannot be reused.
+// fasthttp (not net/http) so the standard DefaultHTTPExtractor cannot be reused;
+// this mirrors its behavior, including the error for a malformed JSON body.
 func extractPassword(c *fiber.Ctx, field string) (string, error) {
 	ct := string(c.Request().Header.ContentType())
 	if strings.HasPrefix(strings.TrimSpace(ct), "application/json") {
 		var raw map[string]interface{}
 		if err := json.Unmarshal(c.Body(), &raw); err != nil {
-			return "", nil
+			return "", err
 		}
 		if v, ok := raw[field]; ok {
 			if s, ok := v.(string); ok {
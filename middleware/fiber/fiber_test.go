@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rafaelsanzio/passcheck"
 	"github.com/rafaelsanzio/passcheck/middleware"
 )
 
@@ -67,3 +69,86 @@ func TestFiber_StrongPassword_CallsNext(t *testing.T) {
 		t.Error("next handler should be called")
 	}
 }
+
+func TestFiber_FormField_CustomName(t *testing.T) {
+	app := fiber.New()
+	app.Post("/register", Fiber(middleware.Config{MinScore: 60, PasswordField: "new_password"}), func(c *fiber.Ctx) error {
+		return c.SendString("registered")
+	})
+
+	req := httptest.NewRequest("POST", "/register", strings.NewReader("new_password=123"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusBadRequest)
+	}
+	var res struct {
+		Error  string            `json:"error"`
+		Issues []json.RawMessage `json:"issues"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(res.Issues) == 0 {
+		t.Error("expected issues in rejection body")
+	}
+}
+
+func TestFiber_OnFailure(t *testing.T) {
+	var got []passcheck.Issue
+	cfg := middleware.Config{
+		MinScore:      60,
+		PasswordField: "pwd",
+		OnFailure: func(issues []passcheck.Issue) error {
+			got = issues
+			return nil
+		},
+	}
+	app := fiber.New()
+	app.Post("/register", Fiber(cfg), func(c *fiber.Ctx) error {
+		return c.SendString("registered")
+	})
+
+	req := httptest.NewRequest("POST", "/register", strings.NewReader(`{"pwd":"password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusBadRequest)
+	}
+	if len(got) == 0 {
+		t.Error("OnFailure should receive the issues")
+	}
+}
+
+func TestFiber_InvalidJSON_Returns400(t *testing.T) {
+	app := fiber.New()
+	app.Post("/register", Fiber(middleware.Config{}), func(c *fiber.Ctx) error {
+		return c.SendString("registered")
+	})
+
+	req := httptest.NewRequest("POST", "/register", strings.NewReader(`{"password":`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var res testResponseBody
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest || res.Error != "invalid request body" {
+		t.Errorf("got %d %q, want 400 %q like the net/http middleware", resp.StatusCode, res.Error, "invalid request body")
+	}
+}
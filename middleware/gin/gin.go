@@ -32,6 +32,8 @@ type responseBody struct {
 // Gin returns a Gin middleware that validates the request password using passcheck.
 // Password is extracted from the JSON body or form field using the default
 // [middleware.DefaultHTTPExtractor] (keyed by [middleware.Config.PasswordField]).
+// The request body is restored after extraction, so downstream handlers can
+// read or bind it again.
 //
 // If the password scores below [middleware.Config.MinScore], the middleware
 // responds with HTTP 400 JSON and calls c.Abort() so no further handlers run.
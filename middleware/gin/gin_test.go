@@ -3,8 +3,10 @@ package passcheckgin
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	ginfx "github.com/gin-gonic/gin"
@@ -64,3 +66,54 @@ func TestGin_StrongPassword_CallsNext(t *testing.T) {
 		t.Error("next handler should be called")
 	}
 }
+
+// TestGin_JSON_BodyRestored verifies downstream handlers can read the body
+// after the middleware extracted the password from it.
+func TestGin_JSON_BodyRestored(t *testing.T) {
+	r := ginfx.New()
+	var nextBody []byte
+	r.POST("/register", Gin(middleware.Config{MinScore: 60}), func(c *ginfx.Context) {
+		nextBody, _ = io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, "registered")
+	})
+
+	payload := []byte(`{"password":"MyC0mpl3x!P@ss2024","extra":"data"}`)
+	req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !bytes.Equal(nextBody, payload) {
+		t.Errorf("next handler body = %q, want %q", nextBody, payload)
+	}
+}
+
+func TestGin_FormField_CustomName(t *testing.T) {
+	r := ginfx.New()
+	r.POST("/register", Gin(middleware.Config{MinScore: 60, PasswordField: "new_password"}), func(c *ginfx.Context) {
+		c.String(http.StatusOK, "registered")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader("new_password=123"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	var res struct {
+		Error  string            `json:"error"`
+		Score  int               `json:"score"`
+		Issues []json.RawMessage `json:"issues"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(res.Issues) == 0 {
+		t.Error("expected issues in rejection body")
+	}
+}
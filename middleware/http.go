@@ -2,12 +2,16 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/rafaelsanzio/passcheck"
+	"github.com/rafaelsanzio/passcheck/internal/feedback"
 )
 
 // DefaultHTTPExtractor extracts the password from an *http.Request by checking
@@ -15,11 +19,18 @@ import (
 // The request body is read once and restored so the next handler can read it.
 // The password field name is taken from Config.PasswordField.
 func DefaultHTTPExtractor(cfg Config) Extractor {
-	return &httpExtractor{field: cfg.PasswordField}
+	return newHTTPExtractor(cfg)
+}
+
+// newHTTPExtractor returns the default extractor, which also extracts the
+// username when cfg.UsernameField is set.
+func newHTTPExtractor(cfg Config) *httpExtractor {
+	return &httpExtractor{field: cfg.PasswordField, usernameField: cfg.UsernameField}
 }
 
 type httpExtractor struct {
-	field string
+	field         string
+	usernameField string
 }
 
 func (e *httpExtractor) ExtractPassword(req interface{}) (string, error) {
@@ -27,52 +38,75 @@ func (e *httpExtractor) ExtractPassword(req interface{}) (string, error) {
 	if !ok {
 		return "", nil
 	}
+	password, _, err := e.extract(r)
+	return password, err
+}
+
+// extract returns the password and, when usernameField is set, the
+// username from r. Missing fields are returned as "".
+func (e *httpExtractor) extract(r *http.Request) (password, username string, err error) {
 	// Prefer JSON if Content-Type is application/json (e.g. application/json; charset=utf-8).
 	if strings.HasPrefix(strings.TrimSpace(r.Header.Get("Content-Type")), "application/json") {
 		return e.extractJSON(r)
 	}
 	// Form (including multipart).
-	return e.extractForm(r), nil
+	password, username = e.extractForm(r)
+	return password, username, nil
 }
 
-func (e *httpExtractor) extractForm(r *http.Request) string {
-	return r.FormValue(e.field)
+func (e *httpExtractor) extractForm(r *http.Request) (password, username string) {
+	if e.usernameField != "" {
+		username = r.FormValue(e.usernameField)
+	}
+	return r.FormValue(e.field), username
 }
 
-func (e *httpExtractor) extractJSON(r *http.Request) (string, error) {
+func (e *httpExtractor) extractJSON(r *http.Request) (password, username string, err error) {
 	if r.Body == nil {
-		return "", nil
+		return "", "", nil
 	}
 	body, readErr := io.ReadAll(r.Body)
 	if closeErr := r.Body.Close(); closeErr != nil && readErr == nil {
 		readErr = closeErr
 	}
 	if readErr != nil {
-		return "", readErr
+		return "", "", readErr
 	}
 	// Restore body for the next handler.
 	r.Body = io.NopCloser(bytes.NewReader(body))
 
 	var raw map[string]interface{}
 	if err := json.Unmarshal(body, &raw); err != nil {
-		return "", err
+		return "", "", err
 	}
-	if v, ok := raw[e.field]; ok {
+	if e.usernameField != "" {
+		username = stringField(raw, e.usernameField)
+	}
+	return stringField(raw, e.field), username, nil
+}
+
+// stringField returns raw[field] if it is a string, or "".
+func stringField(raw map[string]interface{}, field string) string {
+	if v, ok := raw[field]; ok {
 		if s, ok := v.(string); ok {
-			return s, nil
+			return s
 		}
 	}
-	return "", nil
+	return ""
 }
 
 // HTTP returns a net/http middleware that validates the request password
 // using passcheck. If the password is missing (and SkipIfEmpty is false),
 // or scores below MinScore, the middleware responds with 400 and does not
-// call next. Otherwise it calls next.ServeHTTP.
+// call next. When HIBPFailClosed is set and the breach check errors, it
+// responds with 503. Otherwise it calls next.ServeHTTP. The request's
+// context reaches a context-aware HIBP checker, so a client that goes
+// away cancels the breach lookup.
 //
 // Password is extracted from the request using the default extractor
 // (form value and JSON body; see [DefaultHTTPExtractor]). Use a custom
-// [Config] to set PasswordField, MinScore, or [passcheck.Config].
+// [Config] to set PasswordField, MinScore, or [passcheck.Config]. Set
+// UsernameField to also check the password against the submitted username.
 func HTTP(cfg Config, next http.Handler) http.Handler {
 	def := DefaultConfig()
 	if cfg.PasswordField == "" {
@@ -81,9 +115,18 @@ func HTTP(cfg Config, next http.Handler) http.Handler {
 	if cfg.MinScore == 0 {
 		cfg.MinScore = def.MinScore
 	}
-	extractor := DefaultHTTPExtractor(cfg)
+	extractor := newHTTPExtractor(cfg)
+	tracker := newFailureTracker(cfg)
+	failureKey := cfg.RepeatFailureKey
+	if failureKey == nil {
+		failureKey = clientIP
+	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		password, err := extractor.ExtractPassword(r)
+		pc := cfg.PasscheckConfig
+		if cfg.ConfigSelector != nil {
+			pc = cfg.ConfigSelector(r)
+		}
+		password, username, err := extractor.extract(r)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, "invalid request body")
 			return
@@ -93,31 +136,139 @@ func HTTP(cfg Config, next http.Handler) http.Handler {
 				next.ServeHTTP(w, r)
 				return
 			}
-			writeWeakPasswordResponse(w, 0, nil, "password is required")
+			writeWeakPasswordResponse(w, cfg, 0, nil, "password is required")
 			return
 		}
-		pc := cfg.PasscheckConfig
 		if verr := pc.Validate(); verr != nil {
 			pc = passcheck.DefaultConfig()
 		}
-		result, err := passcheck.CheckWithConfig(password, pc)
+		if username != "" {
+			// Clip so the shared config's backing array is never written.
+			pc.ContextWords = append(slices.Clip(pc.ContextWords), username)
+		}
+		var probe *hibpProbe
+		if cfg.HIBPFailClosed && pc.HIBPChecker != nil && pc.HIBPResult == nil {
+			probe = &hibpProbe{checker: pc.HIBPChecker}
+			pc.HIBPChecker = probe
+		}
+		result, err := passcheck.CheckWithContext(r.Context(), password, pc)
 		if err != nil {
 			writeError(w, http.StatusInternalServerError, "configuration error")
 			return
 		}
-		if result.Score < cfg.MinScore {
+		if probe != nil && probe.err != nil {
+			writeError(w, http.StatusServiceUnavailable, "breach check unavailable")
+			return
+		}
+		isUsername := username != "" && strings.EqualFold(password, username)
+		if isUsername && !slices.ContainsFunc(result.Issues, isContextWord) {
+			result.Issues = append([]passcheck.Issue{usernameIssue(password, pc.RedactSensitive)}, result.Issues...)
+		}
+		if result.Score < cfg.MinScore || isUsername {
 			if cfg.OnFailure != nil {
 				_ = cfg.OnFailure(result.Issues)
 			}
-			writeWeakPasswordResponse(w, result.Score, result.Issues, "password does not meet strength requirements")
+			if tracker != nil {
+				tracker.record(failureKey(r))
+			}
+			writeWeakPasswordResponse(w, cfg, result.Score, result.Issues, "password does not meet strength requirements")
 			return
 		}
 		next.ServeHTTP(w, r)
 	})
 }
 
-// writeWeakPasswordResponse sends a 400 JSON response with score and issues.
-func writeWeakPasswordResponse(w http.ResponseWriter, score int, issues []passcheck.Issue, message string) {
+// isContextWord reports whether iss is a CONTEXT_WORD issue.
+func isContextWord(iss passcheck.Issue) bool {
+	return iss.Code == passcheck.CodeContextWord
+}
+
+// usernameIssue is the CONTEXT_WORD issue for a password equal to the
+// submitted username. The context check reports none when the username is
+// shorter than ContextMinWordLen or the context category is disabled, but
+// the middleware still rejects the password and must say why.
+func usernameIssue(password string, redact bool) passcheck.Issue {
+	iss := passcheck.Issue{
+		Code:     passcheck.CodeContextWord,
+		Message:  "Password matches the username",
+		Category: "context",
+		Severity: passcheck.SeverityHigh,
+		Hint:     feedback.Hint(passcheck.CodeContextWord),
+		MatchEnd: utf8.RuneCountInString(password),
+	}
+	if !redact {
+		iss.Match = password
+	}
+	return iss
+}
+
+// hibpProbe wraps an HIBP checker and records the error from its last call.
+// passcheck deliberately swallows HIBP errors, so the middleware uses the
+// probe to observe them when HIBPFailClosed is set. A probe is created per
+// request and must not be shared between goroutines.
+//
+// The probe offers the constant-time and context-aware variants that
+// passcheck looks for, forwarding each to the wrapped checker when it has
+// that variant and falling back to the closest one it has otherwise, so
+// wrapping does not change which lookup runs.
+type hibpProbe struct {
+	checker interface {
+		Check(password string) (bool, int, error)
+	}
+	err error
+}
+
+// constantTimeChecker and contextChecker are the optional HIBP checker
+// interfaces that passcheck uses for Config.ConstantTimeMode and
+// [passcheck.CheckWithContext].
+type (
+	constantTimeChecker interface {
+		CheckConstantTime(password string) (bool, int, error)
+	}
+	contextChecker interface {
+		CheckContext(ctx context.Context, password string) (bool, int, error)
+		CheckConstantTimeContext(ctx context.Context, password string) (bool, int, error)
+	}
+)
+
+func (p *hibpProbe) Check(password string) (bool, int, error) {
+	return p.record(p.checker.Check(password))
+}
+
+func (p *hibpProbe) CheckConstantTime(password string) (bool, int, error) {
+	if ct, ok := p.checker.(constantTimeChecker); ok {
+		return p.record(ct.CheckConstantTime(password))
+	}
+	return p.Check(password)
+}
+
+func (p *hibpProbe) CheckContext(ctx context.Context, password string) (bool, int, error) {
+	if cc, ok := p.checker.(contextChecker); ok {
+		return p.record(cc.CheckContext(ctx, password))
+	}
+	return p.Check(password)
+}
+
+func (p *hibpProbe) CheckConstantTimeContext(ctx context.Context, password string) (bool, int, error) {
+	if cc, ok := p.checker.(contextChecker); ok {
+		return p.record(cc.CheckConstantTimeContext(ctx, password))
+	}
+	return p.CheckConstantTime(password)
+}
+
+// record stores err as the probe's last error and passes the result on.
+func (p *hibpProbe) record(breached bool, count int, err error) (bool, int, error) {
+	p.err = err
+	return breached, count, err
+}
+
+// writeWeakPasswordResponse sends a 400 JSON response with score and
+// issues, or delegates to cfg.ErrorEncoder when it is set.
+func writeWeakPasswordResponse(w http.ResponseWriter, cfg Config, score int, issues []passcheck.Issue, message string) {
+	if cfg.ErrorEncoder != nil {
+		cfg.ErrorEncoder(w, http.StatusBadRequest, issues)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusBadRequest)
 	body := weakPasswordBody{Error: message, Score: score, Issues: issues}
@@ -26,6 +26,9 @@
 package middleware
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/rafaelsanzio/passcheck"
 )
 
@@ -42,19 +45,89 @@ type Config struct {
 	// Used by the default extractor for form and JSON body. Default: "password".
 	PasswordField string
 
+	// UsernameField, when set, names the form or JSON field holding the
+	// username or email address submitted with the password. Its value is
+	// added to PasscheckConfig.ContextWords for that check (email addresses
+	// are split into their parts), and a password equal to it, ignoring
+	// case, is rejected whatever its score, with a CONTEXT_WORD issue. That
+	// issue is reported even when the username is shorter than
+	// ContextMinWordLen or the context category is disabled. It is
+	// supported by the net/http and Chi middleware. Default: "" (none).
+	UsernameField string
+
 	// OnFailure is an optional hook called when the password fails the policy.
 	// It receives the list of issues; the middleware still writes the 400 response.
 	// Use for logging, metrics, or custom side effects. Default: nil.
 	OnFailure func(issues []passcheck.Issue) error
 
+	// ErrorEncoder, when set, writes the 400 response for a missing or weak
+	// password in place of the built-in {"error","score","issues"} JSON body,
+	// e.g. to emit RFC 7807 application/problem+json. It receives the status
+	// code and the issues (nil for a missing password) and must write the
+	// headers and body itself. It runs after OnFailure. Other errors
+	// (invalid body, 503 breach check) keep the built-in encoding. It is
+	// supported by the net/http and Chi middleware. Default: nil.
+	ErrorEncoder func(w http.ResponseWriter, status int, issues []passcheck.Issue)
+
 	// SkipIfEmpty, when true, skips validation when the extracted password is empty
 	// and calls the next handler (useful for optional password fields). When false,
 	// an empty password is treated as a failed check. Default: false.
 	SkipIfEmpty bool
 
-	// PasscheckConfig is the configuration passed to passcheck.CheckWithConfig.
+	// HIBPFailClosed, when true, rejects the request with 503 and
+	// {"error":"breach check unavailable"} if the configured
+	// PasscheckConfig.HIBPChecker returns an error. When false, HIBP errors
+	// are ignored and the password is judged on the remaining checks.
+	//
+	// Failing closed guarantees that no breached password slips through
+	// during an HIBP outage, at the cost of availability: registrations and
+	// password changes are blocked for as long as the breach database is
+	// unreachable. Has no effect when PasscheckConfig.HIBPResult is set.
+	// Default: false (fail open).
+	HIBPFailClosed bool
+
+	// PasscheckConfig is the configuration passed to passcheck.CheckWithContext.
 	// If zero, [passcheck.DefaultConfig] is used.
 	PasscheckConfig passcheck.Config
+
+	// ConfigSelector, when set, chooses the passcheck configuration per request
+	// (e.g. by tenant header or path) and overrides PasscheckConfig. It runs
+	// before the password is extracted; an invalid returned config falls back
+	// to [passcheck.DefaultConfig]. Default: nil (use PasscheckConfig).
+	ConfigSelector func(r *http.Request) passcheck.Config
+
+	// OnRepeatedFailure is an optional hook called when the same key (by
+	// default the client IP) has been rejected for a weak password
+	// RepeatFailureThreshold times within RepeatFailureWindow, e.g. to
+	// alert on credential-stuffing style probing. It runs synchronously on
+	// the request goroutine, so keep it fast. Tracking is per middleware
+	// instance and in memory, capped at 10,000 keys with the oldest evicted
+	// first; it is supported by the net/http and Chi middleware.
+	// Default: nil (no tracking).
+	OnRepeatedFailure func(key string, failures int)
+
+	// RepeatFailureKey derives the tracking key from a request. Default:
+	// the host part of r.RemoteAddr; set it when behind a proxy or to key
+	// by account instead.
+	RepeatFailureKey func(r *http.Request) string
+
+	// RepeatFailureThreshold is the number of failures within the window
+	// that triggers OnRepeatedFailure. Default: DefaultRepeatFailureThreshold.
+	RepeatFailureThreshold int
+
+	// RepeatFailureWindow is the fixed window in which failures are
+	// counted; the count for a key restarts when its window expires.
+	// Default: DefaultRepeatFailureWindow.
+	RepeatFailureWindow time.Duration
+
+	// RepeatFailureCooldown is the minimum spacing between
+	// OnRepeatedFailure calls for the same key, so that continued failures
+	// do not cause an alert storm. It is independent of the window: a new
+	// window does not end a cooldown, and a cooldown does not reset the
+	// count. A negative value disables the cooldown, notifying on every
+	// failure at or above the threshold. Default: RepeatFailureWindow (at
+	// most one notification per key per window length).
+	RepeatFailureCooldown time.Duration
 }
 
 // DefaultConfig returns a config with recommended defaults.
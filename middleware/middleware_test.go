@@ -2,12 +2,15 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rafaelsanzio/passcheck"
 )
@@ -433,3 +436,365 @@ func TestHTTP_InvalidPasscheckConfig_FallsBackToDefault(t *testing.T) {
 		t.Error("next handler should be called when fallback config accepts password")
 	}
 }
+
+// erroringHIBP is an HIBP checker that always fails, simulating an outage.
+type erroringHIBP struct{}
+
+func (erroringHIBP) Check(string) (bool, int, error) {
+	return false, 0, errors.New("hibp unavailable")
+}
+
+// TestHTTP_HIBPError_FailOpenVsClosed verifies that HIBP errors are ignored
+// by default and rejected with 503 when HIBPFailClosed is set.
+func TestHTTP_HIBPError_FailOpenVsClosed(t *testing.T) {
+	tests := []struct {
+		name       string
+		failClosed bool
+		wantStatus int
+		wantNext   bool
+	}{
+		{"fail open", false, http.StatusOK, true},
+		{"fail closed", true, http.StatusServiceUnavailable, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := passcheck.DefaultConfig()
+			pc.HIBPChecker = erroringHIBP{}
+			cfg := Config{
+				MinScore:        60,
+				PasswordField:   "password",
+				HIBPFailClosed:  tt.failClosed,
+				PasscheckConfig: pc,
+			}
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := HTTP(cfg, next)
+
+			body := bytes.NewBufferString(`{"password":"Xk9$mP2!vR7@nL4&wQ"}`)
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if nextCalled != tt.wantNext {
+				t.Errorf("next called = %v, want %v", nextCalled, tt.wantNext)
+			}
+			if tt.failClosed {
+				var res map[string]string
+				if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if res["error"] != "breach check unavailable" {
+					t.Errorf("error = %q, want %q", res["error"], "breach check unavailable")
+				}
+			}
+		})
+	}
+}
+
+// constantTimeHIBP records whether a constant-time lookup was used.
+type constantTimeHIBP struct{ constantTime *bool }
+
+func (c constantTimeHIBP) Check(string) (bool, int, error) { return false, 0, nil }
+
+func (c constantTimeHIBP) CheckConstantTime(string) (bool, int, error) {
+	*c.constantTime = true
+	return false, 0, nil
+}
+
+// TestHTTP_HIBPFailClosed_KeepsConstantTime verifies that the fail-closed
+// probe does not hide the checker's constant-time lookup.
+func TestHTTP_HIBPFailClosed_KeepsConstantTime(t *testing.T) {
+	var used bool
+	pc := passcheck.DefaultConfig()
+	pc.HIBPChecker = constantTimeHIBP{constantTime: &used}
+	pc.ConstantTimeMode = true
+	handler := HTTP(Config{HIBPFailClosed: true, PasscheckConfig: pc}, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := bytes.NewBufferString(`{"password":"Xk9$mP2!vR7@nL4&wQ"}`)
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !used {
+		t.Error("ConstantTimeMode was dropped: CheckConstantTime not called")
+	}
+}
+
+// contextHIBP blocks until the lookup's context is done and records the
+// context error it saw.
+type contextHIBP struct{ err *error }
+
+func (c contextHIBP) Check(string) (bool, int, error) { return false, 0, nil }
+
+func (c contextHIBP) CheckContext(ctx context.Context, _ string) (bool, int, error) {
+	select {
+	case <-ctx.Done():
+		*c.err = ctx.Err()
+	case <-time.After(5 * time.Second):
+	}
+	return false, 0, *c.err
+}
+
+func (c contextHIBP) CheckConstantTimeContext(ctx context.Context, pw string) (bool, int, error) {
+	return c.CheckContext(ctx, pw)
+}
+
+// TestHTTP_RequestContextCancelsHIBP verifies that the request's context
+// reaches the breach lookup.
+func TestHTTP_RequestContextCancelsHIBP(t *testing.T) {
+	var seen error
+	pc := passcheck.DefaultConfig()
+	pc.HIBPChecker = contextHIBP{err: &seen}
+	handler := HTTP(Config{PasscheckConfig: pc}, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	body := bytes.NewBufferString(`{"password":"Xk9$mP2!vR7@nL4&wQ"}`)
+	req := httptest.NewRequest(http.MethodPost, "/", body).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !errors.Is(seen, context.DeadlineExceeded) {
+		t.Errorf("lookup context error = %v, want context.DeadlineExceeded", seen)
+	}
+}
+
+// TestHTTP_ConfigSelector_PerTenant verifies that ConfigSelector applies a
+// different policy per request based on a tenant header.
+func TestHTTP_ConfigSelector_PerTenant(t *testing.T) {
+	cfg := Config{
+		MinScore:      60,
+		PasswordField: "password",
+		ConfigSelector: func(r *http.Request) passcheck.Config {
+			pc := passcheck.DefaultConfig()
+			if r.Header.Get("X-Tenant") == "strict" {
+				pc.MinLength = 14
+			} else {
+				pc.MinLength = 8
+			}
+			return pc
+		},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := HTTP(cfg, next)
+
+	tests := []struct {
+		tenant     string
+		wantStatus int
+		wantShort  bool
+	}{
+		{"relaxed", http.StatusOK, false},
+		{"strict", http.StatusBadRequest, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tenant, func(t *testing.T) {
+			// 10 characters: satisfies MinLength 8, violates MinLength 14.
+			body := bytes.NewBufferString(`{"password":"Xk9$mP2!vR"}`)
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant", tt.tenant)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !tt.wantShort {
+				return
+			}
+			var res weakPasswordBody
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			found := false
+			for _, iss := range res.Issues {
+				if iss.Code == passcheck.CodeRuleTooShort {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected %s in issues, got %+v", passcheck.CodeRuleTooShort, res.Issues)
+			}
+		})
+	}
+}
+
+func TestHTTP_ErrorEncoder(t *testing.T) {
+	var calls []string
+	cfg := Config{
+		MinScore:  80,
+		OnFailure: func([]passcheck.Issue) error { calls = append(calls, "OnFailure"); return nil },
+		ErrorEncoder: func(w http.ResponseWriter, status int, issues []passcheck.Issue) {
+			calls = append(calls, "ErrorEncoder")
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"title":  "Weak password",
+				"status": status,
+				"issues": len(issues),
+			})
+		},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := HTTP(cfg, next)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"password":"weak"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", ct)
+	}
+	var body struct {
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Issues int    `json:"issues"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Title != "Weak password" || body.Status != http.StatusBadRequest || body.Issues == 0 {
+		t.Errorf("body = %+v, want the custom encoding with issues", body)
+	}
+	if strings.Join(calls, ",") != "OnFailure,ErrorEncoder" {
+		t.Errorf("calls = %v, want OnFailure before ErrorEncoder", calls)
+	}
+}
+
+func TestHTTP_ErrorEncoder_MissingPassword(t *testing.T) {
+	var gotStatus int
+	gotIssues := []passcheck.Issue{{}}
+	cfg := Config{
+		ErrorEncoder: func(w http.ResponseWriter, status int, issues []passcheck.Issue) {
+			gotStatus, gotIssues = status, issues
+			w.WriteHeader(status)
+		},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	rec := httptest.NewRecorder()
+	HTTP(cfg, next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if rec.Code != http.StatusBadRequest || gotStatus != http.StatusBadRequest {
+		t.Errorf("status = %d (encoder got %d), want %d", rec.Code, gotStatus, http.StatusBadRequest)
+	}
+	if gotIssues != nil {
+		t.Errorf("issues = %v, want nil for a missing password", gotIssues)
+	}
+}
+
+func TestHTTP_UsernameField(t *testing.T) {
+	const username = "Xk9$mP2!vR7@nL4&wQ"
+	words := make([]string, 1, 4)
+	words[0] = "acme"
+	pc := passcheck.DefaultConfig()
+	pc.ContextWords = words
+	cfg := Config{MinScore: 60, UsernameField: "username", PasscheckConfig: pc}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	handler := HTTP(cfg, next)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A strong password equal to the username is rejected.
+	rec := post(`{"username":"` + username + `","password":"` + strings.ToLower(username) + `"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("password = username: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	var body weakPasswordBody
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	var found bool
+	for _, iss := range body.Issues {
+		found = found || iss.Code == passcheck.CodeContextWord
+	}
+	if !found {
+		t.Errorf("issues = %+v, want %s", body.Issues, passcheck.CodeContextWord)
+	}
+
+	// The same password is accepted when the username differs.
+	if rec := post(`{"username":"alice@example.com","password":"` + username + `"}`); rec.Code != http.StatusOK {
+		t.Errorf("different username: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// Email parts become context words.
+	var issues []passcheck.Issue
+	withHook := cfg
+	withHook.MinScore = 100
+	withHook.OnFailure = func(got []passcheck.Issue) error { issues = got; return nil }
+	form := strings.NewReader("username=zorblax%40example.com&password=Zorblax%2399!kT")
+	req := httptest.NewRequest(http.MethodPost, "/", form)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	HTTP(withHook, next).ServeHTTP(httptest.NewRecorder(), req)
+	found = false
+	for _, iss := range issues {
+		found = found || iss.Code == passcheck.CodeContextWord
+	}
+	if !found {
+		t.Errorf("form email username: issues = %+v, want %s", issues, passcheck.CodeContextWord)
+	}
+
+	if got := words[:cap(words)][1]; got != "" {
+		t.Errorf("shared ContextWords backing array was modified: %q", got)
+	}
+}
+
+func TestHTTP_UsernameField_ReportsIssueWithoutContextCheck(t *testing.T) {
+	short := passcheck.DefaultConfig()
+	short.ContextMinWordLen = 20
+	disabled := passcheck.DefaultConfig()
+	disabled.DisabledCategories = []string{"context"}
+	for name, pc := range map[string]passcheck.Config{"short username": short, "context disabled": disabled} {
+		t.Run(name, func(t *testing.T) {
+			var issues []passcheck.Issue
+			cfg := Config{
+				MinScore:        1,
+				UsernameField:   "username",
+				PasscheckConfig: pc,
+				OnFailure:       func(got []passcheck.Issue) error { issues = got; return nil },
+			}
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+			body := `{"username":"Xk9$mP2!vR7@nL4&wQ","password":"Xk9$mP2!vR7@nL4&wQ"}`
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			HTTP(cfg, next).ServeHTTP(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			var n int
+			for _, iss := range issues {
+				if iss.Code == passcheck.CodeContextWord {
+					n++
+				}
+			}
+			if n != 1 {
+				t.Errorf("issues = %+v, want one %s", issues, passcheck.CodeContextWord)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Defaults for the repeated-failure tracker.
+const (
+	DefaultRepeatFailureThreshold = 5
+	DefaultRepeatFailureWindow    = 10 * time.Minute
+)
+
+// maxTrackedKeys is a hard cap on the keys the tracker holds. When a new
+// key would exceed it, keys whose window and cooldown have both expired
+// are swept; if that does not bring the map down to three quarters of the
+// cap, the keys with the oldest windows are evicted until it does, even if
+// they are still live. Each prune frees a quarter of the cap, so its cost
+// is amortized over the insertions that follow.
+const maxTrackedKeys = 10_000
+
+// failureTracker counts weak-password rejections per key in fixed windows
+// and reports keys that reach the threshold, at most once per cooldown.
+// The counting window and the cooldown are tracked independently: a new
+// window restarts the count but not the cooldown, and notifications during
+// a cooldown do not reset the count.
+type failureTracker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	notify    func(key string, failures int)
+	now       func() time.Time
+	limit     int // maxTrackedKeys, lowered in tests
+
+	mu   sync.Mutex
+	keys map[string]*failureState
+}
+
+type failureState struct {
+	windowStart  time.Time
+	failures     int
+	lastNotified time.Time // zero until the first notification
+}
+
+// newFailureTracker returns a tracker for cfg, or nil when
+// cfg.OnRepeatedFailure is not set.
+func newFailureTracker(cfg Config) *failureTracker {
+	if cfg.OnRepeatedFailure == nil {
+		return nil
+	}
+	t := &failureTracker{
+		threshold: cfg.RepeatFailureThreshold,
+		window:    cfg.RepeatFailureWindow,
+		cooldown:  cfg.RepeatFailureCooldown,
+		notify:    cfg.OnRepeatedFailure,
+		now:       time.Now,
+		limit:     maxTrackedKeys,
+		keys:      make(map[string]*failureState),
+	}
+	if t.threshold <= 0 {
+		t.threshold = DefaultRepeatFailureThreshold
+	}
+	if t.window <= 0 {
+		t.window = DefaultRepeatFailureWindow
+	}
+	switch {
+	case t.cooldown == 0:
+		t.cooldown = t.window
+	case t.cooldown < 0:
+		t.cooldown = 0
+	}
+	return t
+}
+
+// record counts one failure for key and calls the notification hook if the
+// key has reached the threshold and is not cooling down.
+func (t *failureTracker) record(key string) {
+	now := t.now()
+
+	t.mu.Lock()
+	st := t.keys[key]
+	if st == nil {
+		if len(t.keys) >= t.limit {
+			t.prune(now)
+		}
+		st = &failureState{windowStart: now}
+		t.keys[key] = st
+	}
+	if now.Sub(st.windowStart) >= t.window {
+		st.windowStart, st.failures = now, 0
+	}
+	st.failures++
+	failures := st.failures
+	fire := failures >= t.threshold &&
+		(st.lastNotified.IsZero() || now.Sub(st.lastNotified) >= t.cooldown)
+	if fire {
+		st.lastNotified = now
+	}
+	t.mu.Unlock()
+
+	if fire {
+		t.notify(key, failures)
+	}
+}
+
+// prune makes room for new keys as described on [maxTrackedKeys]: it
+// removes keys whose window and cooldown have both expired, then evicts
+// the keys with the oldest windows until at most three quarters of the cap
+// remain. t.mu must be held.
+func (t *failureTracker) prune(now time.Time) {
+	for k, st := range t.keys {
+		if now.Sub(st.windowStart) >= t.window && now.Sub(st.lastNotified) >= t.cooldown {
+			delete(t.keys, k)
+		}
+	}
+	keep := t.limit * 3 / 4
+	if len(t.keys) <= keep {
+		return
+	}
+	oldest := make([]string, 0, len(t.keys))
+	for k := range t.keys {
+		oldest = append(oldest, k)
+	}
+	slices.SortFunc(oldest, func(a, b string) int {
+		return t.keys[a].windowStart.Compare(t.keys[b].windowStart)
+	})
+	for _, k := range oldest[:len(oldest)-keep] {
+		delete(t.keys, k)
+	}
+}
+
+// clientIP is the default repeated-failure key: the host part of
+// r.RemoteAddr. Deployments behind a proxy should set RepeatFailureKey to
+// read the client address the proxy forwards.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
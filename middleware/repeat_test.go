@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for the failure tracker.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestTracker(threshold int, window, cooldown time.Duration) (*failureTracker, *fakeClock, *[]int) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var calls []int
+	tr := newFailureTracker(Config{
+		OnRepeatedFailure:      func(_ string, failures int) { calls = append(calls, failures) },
+		RepeatFailureThreshold: threshold,
+		RepeatFailureWindow:    window,
+		RepeatFailureCooldown:  cooldown,
+	})
+	tr.now = clock.now
+	return tr, clock, &calls
+}
+
+func TestFailureTracker_Cooldown(t *testing.T) {
+	tr, clock, calls := newTestTracker(3, time.Minute, 30*time.Second)
+
+	// 60 failures one second apart, all within one window: notified when
+	// the threshold is reached (t=2s) and again once the cooldown has
+	// passed (t=32s), not on every failure.
+	for i := 0; i < 60; i++ {
+		tr.record("10.0.0.1")
+		clock.advance(time.Second)
+	}
+	if want := []int{3, 33}; !equalInts(*calls, want) {
+		t.Fatalf("calls = %v, want %v", *calls, want)
+	}
+
+	// A new window (t=60s) restarts the count but not the cooldown: the
+	// threshold is reached at t=60s while still cooling down from t=32s.
+	tr.record("10.0.0.1")
+	tr.record("10.0.0.1")
+	tr.record("10.0.0.1")
+	if len(*calls) != 2 {
+		t.Fatalf("notified during the cooldown: %v", *calls)
+	}
+	clock.advance(2 * time.Second)
+	tr.record("10.0.0.1")
+	if want := []int{3, 33, 4}; !equalInts(*calls, want) {
+		t.Errorf("calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestFailureTracker_CooldownLongerThanWindow(t *testing.T) {
+	tr, clock, calls := newTestTracker(2, 10*time.Second, time.Minute)
+
+	// Windows roll over every 10s, but the cooldown still spaces alerts a
+	// minute apart.
+	for i := 0; i < 120; i++ {
+		tr.record("10.0.0.1")
+		clock.advance(time.Second)
+	}
+	if len(*calls) != 2 {
+		t.Errorf("got %d notifications over two minutes, want 2: %v", len(*calls), *calls)
+	}
+}
+
+func TestFailureTracker_DefaultCooldownIsWindow(t *testing.T) {
+	tr, clock, calls := newTestTracker(3, time.Minute, 0)
+
+	// Two minutes of failures one second apart: notified at t=2s and once
+	// more a window length later (t=62s), not on every failure.
+	for i := 0; i < 120; i++ {
+		tr.record("10.0.0.1")
+		clock.advance(time.Second)
+	}
+	if want := []int{3, 3}; !equalInts(*calls, want) {
+		t.Errorf("calls = %v, want %v", *calls, want)
+	}
+
+	tr = newFailureTracker(Config{OnRepeatedFailure: func(string, int) {}})
+	if tr.cooldown != DefaultRepeatFailureWindow {
+		t.Errorf("default cooldown = %v, want %v", tr.cooldown, DefaultRepeatFailureWindow)
+	}
+}
+
+func TestFailureTracker_NoCooldown(t *testing.T) {
+	tr, _, calls := newTestTracker(3, time.Minute, -1)
+	for i := 0; i < 5; i++ {
+		tr.record("k")
+	}
+	if want := []int{3, 4, 5}; !equalInts(*calls, want) {
+		t.Errorf("calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestFailureTracker_KeysAreIndependent(t *testing.T) {
+	tr, _, calls := newTestTracker(2, time.Minute, time.Hour)
+	tr.record("a")
+	tr.record("b")
+	tr.record("a")
+	tr.record("b")
+	tr.record("a")
+	if len(*calls) != 2 {
+		t.Errorf("got %d notifications, want one per key: %v", len(*calls), *calls)
+	}
+}
+
+func TestFailureTracker_CapEvictsOldestLiveKeys(t *testing.T) {
+	tr, clock, _ := newTestTracker(2, time.Hour, time.Hour)
+	tr.limit = 100
+
+	// 1000 distinct keys, all still inside their window: the map never
+	// grows past the cap, and the most recent keys survive.
+	for i := 0; i < 1000; i++ {
+		tr.record(fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+		clock.advance(time.Second)
+		if len(tr.keys) > tr.limit {
+			t.Fatalf("after %d keys, tracking %d, want at most %d", i+1, len(tr.keys), tr.limit)
+		}
+	}
+	if _, ok := tr.keys["10.0.3.231"]; !ok {
+		t.Error("most recent key was evicted")
+	}
+	if _, ok := tr.keys["10.0.0.0"]; ok {
+		t.Error("oldest key was not evicted")
+	}
+}
+
+func TestHTTP_OnRepeatedFailure(t *testing.T) {
+	var mu sync.Mutex
+	notified := map[string]int{}
+	cfg := DefaultConfig()
+	cfg.OnRepeatedFailure = func(key string, failures int) {
+		mu.Lock()
+		defer mu.Unlock()
+		notified[key]++
+	}
+	cfg.RepeatFailureThreshold = 3
+	cfg.RepeatFailureCooldown = time.Hour
+	h := HTTP(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	submit := func(addr, password string) int {
+		form := url.Values{"password": {password}}
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 20; i++ {
+		if code := submit("203.0.113.7:5000", "password"); code != http.StatusBadRequest {
+			t.Fatalf("weak password: status %d, want 400", code)
+		}
+	}
+	submit("198.51.100.2:6000", "password")
+	if code := submit("198.51.100.2:6000", "Xk9$mP2!vR7@nL4&wQ"); code != http.StatusOK {
+		t.Fatalf("strong password: status %d, want 200", code)
+	}
+
+	if notified["203.0.113.7"] != 1 {
+		t.Errorf("repeat offender notified %d times within the cooldown, want 1", notified["203.0.113.7"])
+	}
+	if notified["198.51.100.2"] != 0 {
+		t.Errorf("single failure should not notify, got %d", notified["198.51.100.2"])
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
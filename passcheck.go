@@ -31,7 +31,8 @@
 // [CheckIncrementalWithConfig]. Pass the previous result so the API can return
 // an [IncrementalDelta] indicating what changed; the UI can skip updates when
 // nothing changed. Debounce input (e.g. 100–300 ms) when calling on every
-// keystroke to keep the UI responsive.
+// keystroke to keep the UI responsive, or use [CheckIncrementalGeneration]
+// to abandon checks that a newer keystroke has made stale.
 //
 // # Security Considerations
 //
@@ -51,15 +52,23 @@
 package passcheck
 
 import (
+	"context"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
-	"github.com/rafaelsanzio/passcheck/internal/context"
+	contextcheck "github.com/rafaelsanzio/passcheck/internal/context"
 	"github.com/rafaelsanzio/passcheck/internal/dictionary"
 	"github.com/rafaelsanzio/passcheck/internal/entropy"
 	"github.com/rafaelsanzio/passcheck/internal/feedback"
 	"github.com/rafaelsanzio/passcheck/internal/hibpcheck"
+	"github.com/rafaelsanzio/passcheck/internal/history"
 	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/normalize"
 	"github.com/rafaelsanzio/passcheck/internal/passphrase"
 	"github.com/rafaelsanzio/passcheck/internal/patterns"
 	"github.com/rafaelsanzio/passcheck/internal/rules"
@@ -84,25 +93,48 @@ const (
 // Issue codes — stable identifiers for programmatic handling.
 // Consumers can switch on Code to react differently (e.g. "RULE_TOO_SHORT" vs "DICT_COMMON_PASSWORD").
 const (
-	CodeRuleTooShort        = issue.CodeRuleTooShort
-	CodeRuleNoUpper         = issue.CodeRuleNoUpper
-	CodeRuleNoLower         = issue.CodeRuleNoLower
-	CodeRuleNoDigit         = issue.CodeRuleNoDigit
-	CodeRuleNoSymbol        = issue.CodeRuleNoSymbol
-	CodeRuleWhitespace      = issue.CodeRuleWhitespace
-	CodeRuleControlChar     = issue.CodeRuleControlChar
-	CodeRuleRepeatedChars   = issue.CodeRuleRepeatedChars
-	CodePatternKeyboard     = issue.CodePatternKeyboard
-	CodePatternSequence     = issue.CodePatternSequence
-	CodePatternBlock        = issue.CodePatternBlock
-	CodePatternSubstitution = issue.CodePatternSubstitution
-	CodePatternDate         = issue.CodePatternDate
-	CodeDictCommonPassword  = issue.CodeDictCommonPassword
-	CodeDictLeetVariant     = issue.CodeDictLeetVariant
-	CodeDictCommonWord      = issue.CodeDictCommonWord
-	CodeDictCommonWordSub   = issue.CodeDictCommonWordSub
-	CodeHIBPBreached        = issue.CodeHIBPBreached
-	CodeContextWord         = issue.CodeContextWord
+	CodeRuleTooShort            = issue.CodeRuleTooShort
+	CodeRuleTooLong             = issue.CodeRuleTooLong
+	CodeRuleNoUpper             = issue.CodeRuleNoUpper
+	CodeRuleNoLower             = issue.CodeRuleNoLower
+	CodeRuleNoDigit             = issue.CodeRuleNoDigit
+	CodeRuleNoSymbol            = issue.CodeRuleNoSymbol
+	CodeRuleInsufficientClasses = issue.CodeRuleInsufficientClasses
+	CodeRuleWhitespace          = issue.CodeRuleWhitespace
+	CodeRuleControlChar         = issue.CodeRuleControlChar
+	CodeRuleRepeatedChars       = issue.CodeRuleRepeatedChars
+	CodeRuleNoAlphanumeric      = issue.CodeRuleNoAlphanumeric
+	CodeRuleLowDiversity        = issue.CodeRuleLowDiversity
+	CodeRuleNumericOnly         = issue.CodeRuleNumericOnly
+	CodeRuleLowEntropy          = issue.CodeRuleLowEntropy
+	CodePatternKeyboard         = issue.CodePatternKeyboard
+	CodePatternAdjacentWalk     = issue.CodePatternAdjacentWalk
+	CodePatternSequence         = issue.CodePatternSequence
+	CodePatternBlock            = issue.CodePatternBlock
+	CodePatternSubstitution     = issue.CodePatternSubstitution
+	CodePatternDate             = issue.CodePatternDate
+	CodePatternForbidden        = issue.CodePatternForbidden
+	CodePatternAffixed          = issue.CodePatternAffixed
+	CodePatternRepeatedWord     = issue.CodePatternRepeatedWord
+	CodePatternTemplateRepeat   = issue.CodePatternTemplateRepeat
+	CodePatternCaseTokenized    = issue.CodePatternCaseTokenized
+	CodePassphraseWeakWords     = issue.CodePassphraseWeakWords
+	CodeDictCommonPassword      = issue.CodeDictCommonPassword
+	CodeDictLeetVariant         = issue.CodeDictLeetVariant
+	CodeDictCommonWord          = issue.CodeDictCommonWord
+	CodeDictCommonWordSub       = issue.CodeDictCommonWordSub
+	CodeDictCapitalizedCommon   = issue.CodeDictCapitalizedCommon
+	CodeDictMirrored            = issue.CodeDictMirrored
+	CodeDictTrending            = issue.CodeDictTrending
+	CodeDictReversedWord        = issue.CodeDictReversedWord
+	CodeDictPasswordSubstring   = issue.CodeDictPasswordSubstring
+	CodeDictNearCommon          = issue.CodeDictNearCommon
+	CodeDictMangledCommon       = issue.CodeDictMangledCommon
+	CodeHIBPBreached            = issue.CodeHIBPBreached
+	CodeHistorySharedSubstring  = issue.CodeHistorySharedSubstring
+	CodeHistoryReuse            = issue.CodeHistoryReuse
+	CodeRuleTooSimilar          = issue.CodeRuleTooSimilar
+	CodeContextWord             = issue.CodeContextWord
 )
 
 // Checker performs password strength checks.
@@ -133,10 +165,27 @@ func NewChecker(cfg Config) (Checker, error) {
 
 // Issue represents a single finding from a password check.
 type Issue struct {
-	Code     string `json:"code"`     // Stable identifier (e.g. "RULE_TOO_SHORT", "DICT_COMMON_PASSWORD")
-	Message  string `json:"message"`  // Human-readable description
-	Category string `json:"category"` // "rule", "pattern", "dictionary"
-	Severity int    `json:"severity"` // 1 (low) – 3 (high)
+	Code     string   `json:"code"`     // Stable identifier (e.g. "RULE_TOO_SHORT", "DICT_COMMON_PASSWORD")
+	Message  string   `json:"message"`  // Human-readable description
+	Category string   `json:"category"` // "rule", "pattern", "dictionary"
+	Severity Severity `json:"severity"` // SeverityLow (1) – SeverityHigh (3)
+
+	// Hint is a stable snake_case remediation token for Code (e.g.
+	// "increase_length", "avoid_dictionary") for clients to translate.
+	// Codes that call for the same fix share a hint; unknown codes have
+	// none.
+	Hint string `json:"hint,omitempty"`
+
+	// Match is the offending substring of the password for pattern,
+	// dictionary, and context issues, and MatchStart/MatchEnd are its rune
+	// offsets [MatchStart, MatchEnd) in the password as passed in, so a UI
+	// can highlight it. Issues that do not refer to a substring (rules,
+	// breaches) leave all three zero. History issues set only the offsets:
+	// their substring is also part of a previous password. Match is
+	// cleared when Config.RedactSensitive is set; the offsets are kept.
+	Match      string `json:"match,omitempty"`
+	MatchStart int    `json:"match_start,omitempty"`
+	MatchEnd   int    `json:"match_end,omitempty"`
 }
 
 // Result holds the outcome of a password strength check.
@@ -149,7 +198,8 @@ type Result struct {
 	Verdict string `json:"verdict"`
 
 	// MeetsPolicy is true when the password satisfies all configured minimum
-	// requirements (length, character-set rules, repeat limits). A password can
+	// requirements (length, character-set rules, repeat limits, password
+	// history, and ForbiddenPatterns when ForbiddenPatternIsFatal). A password can
 	// meet policy and still have a low score if it relies on common patterns or
 	// dictionary words; conversely, failing policy means at least one hard
 	// requirement (e.g. MinLength, RequireUpper) was not satisfied.
@@ -165,12 +215,82 @@ type Result struct {
 	Issues []Issue `json:"issues"`
 
 	// Suggestions contains positive feedback about the password's
-	// strengths (e.g. "Good length", "No common patterns detected").
-	// Empty when the password has no notable strengths.
+	// strengths (e.g. "Good length", "No common patterns detected"). It is
+	// filled in alongside Issues: an issue withholds only the praise it
+	// contradicts, such as "No common patterns detected" when a pattern was
+	// found. Empty when the password has no notable strengths.
 	Suggestions []string `json:"suggestions"`
 
+	// StrengthReasons explains with numbers why a Strong or Very Strong
+	// password is strong, e.g. "20 characters", "4 character types", "No
+	// dictionary words", "~118 bits of entropy". Unlike Suggestions, it is
+	// always populated for Strong and Very Strong results; it is empty for
+	// weaker ones.
+	StrengthReasons []string `json:"strength_reasons"`
+
+	// Improvements lists concrete next steps that address the findings,
+	// e.g. "Add 4 more characters to reach 12", "Remove the sequence
+	// 'abcd'", "Replace the dictionary word 'dragon'". Steps quote only the
+	// offending part of the password, never all of it, and quote nothing
+	// when Config.RedactSensitive is set. Messages are in English. Empty
+	// when there is nothing to improve or Config.SuppressAllIssues is set.
+	Improvements []string `json:"improvements"`
+
 	// Entropy is the estimated entropy of the password in bits.
 	Entropy float64 `json:"entropy"`
+
+	// EntropyBreakdown itemizes how Entropy was derived, showing which
+	// factor (character pool, detected patterns, Markov analysis) moved it.
+	EntropyBreakdown EntropyBreakdown `json:"entropy_breakdown"`
+
+	// CrackTimeSeconds estimates how long an attacker needs to try every
+	// password with this entropy at Config.GuessesPerSecond, and
+	// CrackTimeDisplay renders it as "instant", "N seconds", "N minutes",
+	// "N hours", "N days", "N months", "N years", or "centuries". The
+	// estimate is only as good as the entropy it is derived from.
+	CrackTimeSeconds float64 `json:"crack_time_seconds"`
+	CrackTimeDisplay string  `json:"crack_time_display"`
+
+	// DetectedType is what the input appears to be: [TypePassword],
+	// [TypePassphrase], or [TypeToken] (a hex or base64 encoded string such
+	// as an API key). UIs can use it to adapt their guidance. It is
+	// advisory and does not affect scoring.
+	DetectedType string `json:"detected_type"`
+
+	// PolicyID identifies the configuration that produced this result; see
+	// [Config.PolicyID].
+	PolicyID string `json:"policy_id"`
+
+	// composition backs [Result.MissingComposition], policyIssues backs
+	// [Result.Violations], and weaknesses backs [Result.TopWeaknesses]; all
+	// are nil for results not produced by a check.
+	composition  *composition
+	policyIssues []Issue
+	weaknesses   []Weakness
+}
+
+// EntropyBreakdown itemizes an entropy estimate by calculation stage.
+// All values are in bits and, for character-based entropy,
+//
+//	FinalEntropy = BaseCharsetEntropy − PatternReduction + MarkovAdjustment
+//
+// In [EntropyModeSimple] only BaseCharsetEntropy and FinalEntropy are set
+// (and equal). For passphrases detected in PassphraseMode only FinalEntropy
+// is set, holding the word-based estimate.
+type EntropyBreakdown struct {
+	// BaseCharsetEntropy is the character-pool entropy (length × log2(pool)).
+	BaseCharsetEntropy float64 `json:"base_charset_entropy"`
+
+	// PatternReduction is the entropy removed for detected patterns
+	// (EntropyModeAdvanced and EntropyModePatternAware).
+	PatternReduction float64 `json:"pattern_reduction"`
+
+	// MarkovAdjustment is the entropy added (positive) or removed (negative)
+	// by Markov transition analysis (EntropyModePatternAware only).
+	MarkovAdjustment float64 `json:"markov_adjustment"`
+
+	// FinalEntropy equals Result.Entropy.
+	FinalEntropy float64 `json:"final_entropy"`
 }
 
 // IssueMessages returns the human-readable message for each issue, in order.
@@ -186,6 +306,29 @@ func (r Result) IssueMessages() []string {
 	return out
 }
 
+// FailedCodes returns the set of issue codes present in r.Issues, for
+// branching on specific findings without comparing messages. Only issues
+// that survived MaxIssues are included, and the set is empty when
+// Config.SuppressAllIssues is set.
+func (r Result) FailedCodes() map[string]bool {
+	codes := make(map[string]bool, len(r.Issues))
+	for _, iss := range r.Issues {
+		codes[iss.Code] = true
+	}
+	return codes
+}
+
+// Has reports whether r.Issues contains an issue with the given code, e.g.
+// result.Has(passcheck.CodeHIBPBreached).
+func (r Result) Has(code string) bool {
+	for _, iss := range r.Issues {
+		if iss.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
 // IncrementalDelta describes what changed between a previous check result and the
 // current one. Use it to avoid redundant UI updates when using [CheckIncrementalWithConfig].
 type IncrementalDelta struct {
@@ -224,61 +367,319 @@ func Check(password string) Result {
 // Passwords longer than [MaxPasswordLength] runes are truncated before
 // analysis to prevent excessive CPU usage.
 func CheckWithConfig(password string, cfg Config) (Result, error) {
+	return CheckWithContext(context.Background(), password, cfg)
+}
+
+// CheckWithContext evaluates a password like [CheckWithConfig] and passes
+// ctx to the configured OnResult, OnIssue, and OnFailure hooks, so caller
+// metadata such as a request ID or tenant reaches them without a side
+// channel. ctx also reaches an HIBPChecker that accepts one, as
+// *hibp.Client does, so cancelling it aborts the breach lookup; the check
+// then degrades as on any HIBP error, skipping the breach phase and
+// returning the other findings. The local phases are not cancellable.
+func CheckWithContext(ctx context.Context, password string, cfg Config) (Result, error) {
 	if err := cfg.Validate(); err != nil {
 		return Result{}, err
 	}
+	return checkValidated(ctx, password, cfg), nil
+}
+
+// CheckWithConfigContext is [CheckWithContext] under the name that pairs
+// with [CheckWithConfig]: ctx cancels the HIBP round-trip, which is then
+// skipped, and is passed to the result hooks.
+func CheckWithConfigContext(ctx context.Context, password string, cfg Config) (Result, error) {
+	return CheckWithContext(ctx, password, cfg)
+}
+
+// checkValidated evaluates password under cfg, which the caller has
+// already validated.
+func checkValidated(ctx context.Context, password string, cfg Config) Result {
+	result, _ := checkUnless(ctx, password, cfg, runOptions{shortInput: shortInputRunes})
+	return result
+}
+
+// checkUnless is like checkValidated but runs under run, abandoning the
+// check as soon as run.stop reports true and returning false. An abandoned
+// check calls no hooks.
+func checkUnless(ctx context.Context, password string, cfg Config, run runOptions) (Result, bool) {
 	start := time.Now()
 
+	f, ok := analyzeUnless(ctx, password, cfg, run)
+	if !ok {
+		return Result{}, false
+	}
+	result := f.result(cfg)
+
+	if cfg.ConstantTimeMode && cfg.MinExecutionTimeMs > 0 {
+		safemem.SleepRemaining(start, cfg.MinExecutionTimeMs)
+	}
+	if cfg.Observer != nil {
+		cfg.Observer.ObserveCheck(result, time.Since(start))
+	}
+	cfg.notify(ctx, result)
+	return result, true
+}
+
+// runOptions are per-call settings of the check pipeline that are not
+// part of Config.
+type runOptions struct {
+	// stop abandons the check as soon as it reports true; nil never stops.
+	stop func() bool
+
+	// shortInput is the rune count below which the pattern phase is
+	// reduced to the forbidden-pattern check, normally [shortInputRunes];
+	// 0 turns the fast path off.
+	shortInput int
+}
+
+// stopped reports whether the check should be abandoned.
+func (run runOptions) stopped() bool {
+	return run.stop != nil && run.stop()
+}
+
+// notify invokes the configured result hooks.
+func (cfg Config) notify(ctx context.Context, result Result) {
+	if cfg.OnResult != nil {
+		cfg.OnResult(ctx, result)
+	}
+	if cfg.OnIssue != nil {
+		for _, iss := range result.Issues {
+			cfg.OnIssue(ctx, iss)
+		}
+	}
+	if cfg.OnFailure != nil && !result.MeetsPolicy {
+		cfg.OnFailure(ctx, result)
+	}
+}
+
+// findings holds everything collected while scanning a password: the
+// per-phase issues, the rule profile, entropy, and passphrase detection.
+// Turning findings into a [Result] needs no access to the password, which
+// is what allows [DetailedFindings.ScoreUnder] to re-score cheaply.
+type findings struct {
+	issues       scoring.IssueSet
+	profile      rules.Profile
+	entropy      float64
+	breakdown    entropy.Breakdown
+	passphrase   *passphrase.Info
+	suggestions  []string
+	detectedType string
+
+	// custom holds the Config.CustomRules issues, also in issues.Rules,
+	// for re-scoring.
+	custom []issue.Issue
+
+	// tooLong marks a password rejected by Config.MaxLength, whose only
+	// finding is RULE_TOO_LONG.
+	tooLong bool
+}
+
+// analyze runs every scanning phase over password under cfg.
+func analyze(password string, cfg Config) findings {
+	f, _ := analyzeUnless(context.Background(), password, cfg, runOptions{shortInput: shortInputRunes})
+	return f
+}
+
+// analyzeUnless is like analyze but runs under run, consulting run.stop
+// between phases and returning false as soon as it reports true. ctx is
+// passed to the breach check.
+func analyzeUnless(ctx context.Context, password string, cfg Config, run runOptions) (findings, bool) {
+	if run.stopped() {
+		return findings{}, false
+	}
 	// Enforce maximum length to bound algorithmic complexity.
 	pw := truncate(password)
+	// A password over MaxLength is rejected without further analysis.
+	if cfg.categoryEnabled(CategoryRule) {
+		n := utf8.RuneCountInString(pw)
+		if tooLong := rules.CheckMaxLength(n, ruleOptions(cfg)); tooLong != nil {
+			return findings{
+				issues:       scoring.IssueSet{Rules: tooLong},
+				profile:      rules.Profile{Length: n},
+				detectedType: TypePassword,
+				tooLong:      true,
+			}, true
+		}
+	}
+	// scan is what the pattern, dictionary, and entropy phases see; offsets
+	// map its spans back onto pw.
+	scan, offsets := pw, []int(nil)
+	if cfg.NormalizeUnicode {
+		scan, offsets = normalize.Fold(pw)
+	}
 
 	// Collect issues by category for weighted scoring.
 	opts := configToInternal(cfg)
-	issueSet := scoring.IssueSet{
-		Rules:      rules.CheckWith(pw, opts.rules),
-		Patterns:   patterns.CheckWith(pw, opts.patterns),
-		Dictionary: dictionary.CheckWith(pw, opts.dictionary),
-		Context:    context.CheckWith(pw, opts.context),
-		HIBP:       hibpcheck.CheckWith(password, opts.hibp),
+	profile := rules.NewProfile(pw)
+	// Disabled categories are skipped, not filtered afterwards.
+	var issueSet scoring.IssueSet
+	if cfg.categoryEnabled(CategoryRule) {
+		issueSet.Rules = rules.CheckProfile(profile, opts.rules)
+	}
+	switch {
+	case !cfg.categoryEnabled(CategoryPattern):
+		// A fatal forbidden pattern is policy, not a heuristic, so it
+		// still applies with the pattern category disabled.
+		if cfg.ForbiddenPatternIsFatal {
+			issueSet.Patterns = patterns.CheckForbidden(scan, opts.patterns.Forbidden)
+		}
+	case isShortInput(scan, cfg, run.shortInput):
+		issueSet.Patterns = patterns.CheckForbidden(scan, opts.patterns.Forbidden)
+	default:
+		issueSet.Patterns = patterns.CheckWith(scan, opts.patterns)
+	}
+	if cfg.categoryEnabled(CategoryDictionary) {
+		issueSet.Dictionary = dictionary.CheckWith(scan, opts.dictionary)
+	}
+	if cfg.categoryEnabled(CategoryContext) {
+		issueSet.Context = contextcheck.CheckWith(pw, opts.context)
+	}
+	// The breach check may call a remote service; skip it for stale checks.
+	if run.stopped() {
+		return findings{}, false
+	}
+	if cfg.categoryEnabled(CategoryBreach) {
+		opts.hibp.Context = ctx
+		issueSet.HIBP = hibpcheck.CheckWith(password, opts.hibp)
+	}
+	if cfg.categoryEnabled(CategoryHistory) {
+		issueSet.History = history.CheckWith(pw, opts.history)
+	}
+
+	issueSet.Dictionary = dropAffixedWords(issueSet.Dictionary, issueSet.Patterns)
+	issueSet.Patterns = dropCaseTokenized(issueSet.Patterns, issueSet.Dictionary)
+	issueSet.Patterns = dropMangled(issueSet.Patterns, issueSet.Dictionary)
+	if run.stopped() {
+		return findings{}, false
+	}
+
+	// Passphrase detection uses the original input; entropy uses the truncated form.
+	var detected *passphrase.Info
+	if cfg.PassphraseMode {
+		info := passphrase.DetectWith(password, passphrase.Options{
+			MinWords:      cfg.MinWords,
+			IsCommon:      dictionary.IsPasswordToken,
+			NormalizeLeet: !cfg.DisableLeet,
+		})
+		detected = &info
+	}
+
+	// Calculate entropy (word-based entropy if a passphrase was detected)
+	breakdown, passphraseInfo := calculateEntropy(scan, cfg, detected, issueSet.Patterns)
+	e := breakdown.Final
+	mapSpans(issueSet.Patterns, offsets)
+	mapSpans(issueSet.Dictionary, offsets)
+	var custom []issue.Issue
+	if cfg.categoryEnabled(CategoryRule) {
+		issueSet.Rules = append(issueSet.Rules, rules.CheckEntropy(e, opts.rules)...)
+		custom = checkCustomRules(pw, cfg.CustomRules)
+		attachMatches(custom, []rune(pw))
+		issueSet.Rules = append(issueSet.Rules, custom...)
+	}
+	if detected != nil && cfg.categoryEnabled(CategoryPattern) {
+		issueSet.Patterns = append(issueSet.Patterns, passphrase.CheckWeakWords(*detected, cfg.MinWords)...)
+	}
+
+	// Detectors work on lowercased copies; take matches from the original.
+	// History issues get none: their substring is part of an old password.
+	runes := []rune(pw)
+	for _, issues := range [][]issue.Issue{issueSet.Patterns, issueSet.Dictionary, issueSet.Context} {
+		attachMatches(issues, runes)
 	}
 
-	// Calculate entropy and detect passphrase (word-based entropy if applicable)
-	e, passphraseInfo := calculateEntropy(password, pw, cfg, issueSet.Patterns)
+	return findings{
+		issues:     issueSet,
+		profile:    profile,
+		entropy:    e,
+		breakdown:  breakdown,
+		passphrase: passphraseInfo,
+		// Positive feedback for the password's strengths.
+		suggestions:  feedback.GeneratePositiveLocale(pw, issueSet, e, cfg.Locale),
+		detectedType: detectType(pw, cfg, detected),
+		custom:       custom,
+	}, true
+}
 
-	// Weighted scoring
-	score := scoring.CalculateWithPassphrase(e, pw, issueSet, cfg.MinLength, passphraseInfo, mapWeights(cfg.PenaltyWeights))
+// result scores the findings and assembles the public Result under cfg.
+func (f findings) result(cfg Config) Result {
+	// Weighted scoring, including the trending and forbidden-pattern caps.
+	score := f.score(cfg)
+	fatal := cfg.ForbiddenPatternIsFatal && containsCode(f.issues.Patterns, issue.CodePatternForbidden)
 
-	// Verdict — use custom thresholds when provided, otherwise built-in defaults.
+	// Verdict — custom bands win over custom thresholds, which win over the
+	// built-in defaults.
 	verdict := resolveVerdict(score, cfg.VerdictThresholds)
+	if cfg.VerdictBands != nil {
+		verdict = bandVerdict(score, cfg.VerdictBands)
+	}
 
 	// Feedback engine: dedup, prioritize, limit issues.
-	refined := feedback.Refine(issueSet, cfg.MaxIssues)
-
-	// Positive feedback for the password's strengths.
-	suggestions := feedback.GeneratePositive(pw, issueSet, e)
+	refined := feedback.RefineOrdered(f.issues, cfg.MaxIssues, cfg.Locale, cfg.IssueOrder)
 
 	// Convert internal issues to public Issue type.
 	issues := toPublicIssues(refined, cfg.RedactSensitive)
+	if cfg.SuppressAllIssues {
+		issues, refined = []Issue{}, nil
+	}
+	// A registered translation wins over the English friendly explanation.
+	if cfg.FriendlyMessages && len(issues) > 0 && !hasTranslation(cfg.Locale, issues[0].Code) {
+		if msg, ok := feedback.FriendlyMessage(issues[0].Code); ok {
+			issues[0].Message = msg
+		}
+	}
+	weaknesses := f.weaknesses(cfg, score, refined, issues)
 
+	suggestions := f.suggestions
 	if suggestions == nil {
 		suggestions = []string{}
 	}
+	// Improvements name the findings, so they are suppressed with them.
+	var improvements []string
+	if !cfg.SuppressAllIssues {
+		improvements = feedback.Improve(f.profile.Length, f.issues, feedback.ImproveOptions{
+			MinLength: cfg.MinLength,
+			MaxLength: cfg.MaxLength,
+			Redact:    cfg.RedactSensitive,
+		})
+	}
+	if improvements == nil {
+		improvements = []string{}
+	}
+	reasons := []string{}
+	if verdict == VerdictStrong || verdict == VerdictVeryStrong {
+		reasons = feedback.StrengthReasons(f.profile.Length, f.profile.Charsets.SetCount(), f.issues, f.entropy, cfg.Locale)
+	}
 
 	// MeetsPolicy: all configured hard requirements are satisfied when there
-	// are no RULE_* violations (length, charset, repeat limits).
-	meetsPolicy := len(issueSet.Rules) == 0
+	// are no RULE_* violations (length, charset, repeat limits), no
+	// history findings (HISTORY_*, RULE_TOO_SIMILAR), and no fatal forbidden
+	// pattern.
+	meetsPolicy := len(f.issues.Rules) == 0 && len(f.issues.History) == 0 && !fatal
+	crackSeconds, crackDisplay := crackTime(f.entropy, cfg.GuessesPerSecond)
 
-	if cfg.ConstantTimeMode && cfg.MinExecutionTimeMs > 0 {
-		safemem.SleepRemaining(start, cfg.MinExecutionTimeMs)
-	}
 	return Result{
-		Score:       score,
-		Verdict:     verdict,
-		MeetsPolicy: meetsPolicy,
-		Issues:      issues,
-		Suggestions: suggestions,
-		Entropy:     e,
-	}, nil
+		Score:           score,
+		Verdict:         verdict,
+		MeetsPolicy:     meetsPolicy,
+		Issues:          issues,
+		Suggestions:     suggestions,
+		StrengthReasons: reasons,
+		Improvements:    improvements,
+		Entropy:         f.entropy,
+		EntropyBreakdown: EntropyBreakdown{
+			BaseCharsetEntropy: f.breakdown.BaseCharset,
+			PatternReduction:   f.breakdown.PatternReduction,
+			MarkovAdjustment:   f.breakdown.MarkovAdjustment,
+			FinalEntropy:       f.breakdown.Final,
+		},
+		CrackTimeSeconds: crackSeconds,
+		CrackTimeDisplay: crackDisplay,
+		DetectedType:     f.detectedType,
+		PolicyID:         cfg.PolicyID(),
+		composition:      &composition{length: f.profile.Length, charsets: f.profile.Charsets},
+		policyIssues:     toPublicIssues(policyIssues(f.issues, cfg.Locale), cfg.RedactSensitive),
+		weaknesses:       weaknesses,
+	}
 }
 
 // CheckBytes evaluates password strength from a mutable byte slice
@@ -307,24 +708,44 @@ func CheckBytesWithConfig(password []byte, cfg Config) (Result, error) {
 	return CheckWithConfig(s, cfg)
 }
 
+// Entropy returns the estimated entropy of password in bits under the given
+// mode, without running the rest of a check. It matches Result.Entropy of
+// [CheckWithConfig] under [DefaultConfig] with EntropyMode set to mode:
+// passwords are truncated to [MaxPasswordLength] runes, the empty password
+// has zero entropy, and the advanced and pattern-aware modes run pattern
+// detection to discount keyboard walks, sequences, and other patterns. An
+// empty or unknown mode is treated as [EntropyModeSimple].
+//
+// Entropy is much cheaper than a full check, which makes it suitable for
+// telemetry or for sorting candidate passwords.
+func Entropy(password string, mode EntropyMode) float64 {
+	cfg := DefaultConfig()
+	cfg.EntropyMode = mode
+	pw := truncate(password)
+	var patternIssues []issue.Issue
+	if mode == EntropyModeAdvanced || mode == EntropyModePatternAware {
+		patternIssues = patterns.CheckWith(pw, configToInternal(cfg).patterns)
+	}
+	b, _ := calculateEntropy(pw, cfg, nil, patternIssues)
+	return b.Final
+}
+
 // calculateEntropy computes entropy for a password, using word-based entropy
 // for passphrases when PassphraseMode is enabled, otherwise character-based entropy
 // with the configured EntropyMode (simple, advanced, or pattern-aware).
-// Returns the entropy value and passphrase info (nil if not a passphrase).
-func calculateEntropy(password, pw string, cfg Config, patternIssues []issue.Issue) (float64, *passphrase.Info) {
-	// Passphrase detection uses the original input; entropy uses the truncated form.
+// detected is the passphrase detection result, or nil when PassphraseMode is off.
+// Returns the entropy breakdown and passphrase info (nil if not a passphrase).
+// For passphrases only Final is set, holding the word-based entropy.
+func calculateEntropy(pw string, cfg Config, detected *passphrase.Info, patternIssues []issue.Issue) (entropy.Breakdown, *passphrase.Info) {
 	// Handle passphrase mode first (word-based entropy)
-	if cfg.PassphraseMode {
-		info := passphrase.Detect(password, cfg.MinWords)
-		if info.IsPassphrase {
-			dictSize := cfg.WordDictSize
-			if dictSize < 2 {
-				dictSize = passphrase.DefaultWordDictSize
-			}
-			return passphrase.CalculateWordEntropy(info.WordCount, dictSize), &info
+	if detected != nil && detected.IsPassphrase {
+		dictSize := cfg.WordDictSize
+		if dictSize < 2 {
+			dictSize = passphrase.DefaultWordDictSize
 		}
-		// Not a passphrase, fall through to character-based entropy
+		return entropy.Breakdown{Final: passphrase.CalculateWordEntropy(detected.WordCount, dictSize)}, detected
 	}
+	// Not a passphrase: fall through to character-based entropy
 
 	// Character-based entropy with mode selection
 	entropyMode := string(cfg.EntropyMode)
@@ -333,7 +754,8 @@ func calculateEntropy(password, pw string, cfg Config, patternIssues []issue.Iss
 		// avoid surprising callers who construct Config{} by hand.
 		entropyMode = string(EntropyModeSimple)
 	}
-	return entropy.CalculateWithMode(pw, entropyMode, patternIssues), nil
+	opts := entropy.Options{CountWhitespace: cfg.whitespaceAllowed(), Markov: cfg.MarkovModel, EmojiPool: cfg.EmojiPoolSize}
+	return entropy.CalculateBreakdownWith(pw, entropyMode, patternIssues, opts), nil
 }
 
 // CheckIncremental evaluates the strength of a password using the default
@@ -372,6 +794,49 @@ func CheckIncrementalWithConfig(password string, previous *Result, cfg Config) (
 	return result, delta, nil
 }
 
+// CheckIncrementalGeneration is like [CheckIncrementalWithConfig] but
+// supports cooperative cancellation of stale checks, so that a burst of
+// keystrokes or a paste does not pay for a full check of every
+// intermediate value.
+//
+// gen identifies this call and latest holds the newest generation
+// requested. The check is abandoned, before it starts or between its
+// phases, as soon as latest exceeds gen; it then returns previous (or
+// the zero Result when previous is nil), a delta with all Changed fields
+// false, and a nil error, and calls no hooks. A nil latest never
+// abandons the check. Returns an error if the configuration is invalid.
+//
+// Give each input event the next value of a shared counter:
+//
+//	var latest atomic.Uint64
+//
+//	func onPasswordChange(password string, last *passcheck.Result) {
+//		gen := latest.Add(1)
+//		go func() {
+//			result, delta, _ := passcheck.CheckIncrementalGeneration(password, last, cfg, gen, &latest)
+//			if gen != latest.Load() {
+//				return // superseded; a newer check will report
+//			}
+//			if delta.ScoreChanged || delta.IssuesChanged {
+//				updateMeter(result)
+//			}
+//		}()
+//	}
+func CheckIncrementalGeneration(password string, previous *Result, cfg Config, gen uint64, latest *atomic.Uint64) (Result, IncrementalDelta, error) {
+	if err := cfg.Validate(); err != nil {
+		return Result{}, IncrementalDelta{}, err
+	}
+	stale := func() bool { return latest != nil && latest.Load() > gen }
+	result, ok := checkUnless(context.Background(), password, cfg, runOptions{stop: stale, shortInput: shortInputRunes})
+	if !ok {
+		if previous == nil {
+			return Result{}, IncrementalDelta{}, nil
+		}
+		return *previous, IncrementalDelta{}, nil
+	}
+	return result, incrementalDeltaFrom(previous, result), nil
+}
+
 // incrementalDeltaFrom builds an IncrementalDelta by comparing curr to previous.
 // When previous is nil, all Changed fields are true.
 func incrementalDeltaFrom(previous *Result, curr Result) IncrementalDelta {
@@ -419,6 +884,92 @@ func truncate(password string) string {
 	return string(runes[:MaxPasswordLength])
 }
 
+// mapSpans maps the spans of issues found in a normalized copy of the
+// password back onto the password, given the normalization offsets. Nil
+// offsets leave the spans unchanged.
+func mapSpans(issues []issue.Issue, offsets []int) {
+	if offsets == nil {
+		return
+	}
+	for i, iss := range issues {
+		if iss.End > iss.Start && iss.Start >= 0 && iss.End < len(offsets) {
+			issues[i].Start, issues[i].End = offsets[iss.Start], offsets[iss.End]
+		}
+	}
+}
+
+// attachMatches sets each issue's Match to the runes it spans, leaving
+// issues without a valid span untouched.
+func attachMatches(issues []issue.Issue, runes []rune) {
+	for i, iss := range issues {
+		if iss.End > iss.Start && iss.Start >= 0 && iss.End <= len(runes) {
+			issues[i].Match = string(runes[iss.Start:iss.End])
+		}
+	}
+}
+
+// currentYear returns the year the current-year check compares against,
+// or 0 when the check is disabled.
+func currentYear(cfg Config) int {
+	switch {
+	case !cfg.DetectCurrentYear:
+		return 0
+	case cfg.CurrentYear > 0:
+		return cfg.CurrentYear
+	}
+	return time.Now().Year()
+}
+
+// compilePatterns compiles each expression, skipping invalid ones (which
+// Validate already rejects). Returns nil if the input is nil or empty.
+func compilePatterns(exprs []string) []*regexp.Regexp {
+	if len(exprs) == 0 {
+		return nil
+	}
+	out := make([]*regexp.Regexp, 0, len(exprs))
+	for _, expr := range exprs {
+		if re, err := compilePattern(expr); err == nil {
+			out = append(out, re)
+		}
+	}
+	return out
+}
+
+// compilePattern compiles expr, reusing the result of an earlier call.
+// Validate compiles every ForbiddenPatterns entry through it, so the
+// check that follows finds them already compiled; a *regexp.Regexp is
+// safe for concurrent use.
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	compiledPatterns.mu.Lock()
+	re, ok := compiledPatterns.res[expr]
+	compiledPatterns.mu.Unlock()
+	if ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.mu.Lock()
+	if len(compiledPatterns.res) >= maxCompiledPatterns {
+		clear(compiledPatterns.res)
+	}
+	compiledPatterns.res[expr] = re
+	compiledPatterns.mu.Unlock()
+	return re, nil
+}
+
+// maxCompiledPatterns bounds the expressions whose compiled form is
+// remembered. Policies list a handful of patterns; the cache is simply
+// cleared when more are seen.
+const maxCompiledPatterns = 256
+
+// compiledPatterns remembers recently compiled ForbiddenPatterns.
+var compiledPatterns = struct {
+	mu  sync.Mutex
+	res map[string]*regexp.Regexp
+}{res: make(map[string]*regexp.Regexp)}
+
 // toLowerSlice returns a new slice with every string lowercased.
 // Returns nil if the input is nil or empty.
 func toLowerSlice(ss []string) []string {
@@ -461,42 +1012,185 @@ type internalOptions struct {
 	rules      rules.Options
 	patterns   patterns.Options
 	dictionary dictionary.Options
-	context    context.Options
+	context    contextcheck.Options
 	hibp       hibpcheck.Options
+	history    history.Options
 }
 
 // configToInternal maps the public Config to internal package option structs.
 func configToInternal(cfg Config) internalOptions {
+	dict := dictionary.Options{
+		CustomPasswords:  toLowerSlice(cfg.CustomPasswords),
+		HotList:          toLowerSlice(cfg.HotList),
+		PasswordSet:      cfg.PasswordSet,
+		CustomWords:      toLowerSlice(cfg.CustomWords),
+		DisableLeet:      cfg.DisableLeet,
+		DetectReversed:   cfg.DetectReversed,
+		DetectSubstrings: cfg.DetectSubstrings,
+		MinSubstringLen:  cfg.MinLength,
+		Fuzzy:            cfg.FuzzyDictionary,
+		Mangled:          cfg.DetectMangled,
+		ConstantTime:     cfg.ConstantTimeMode,
+	}
 	return internalOptions{
-		rules: rules.Options{
-			MinLength:     cfg.MinLength,
-			RequireUpper:  cfg.RequireUpper,
-			RequireLower:  cfg.RequireLower,
-			RequireDigit:  cfg.RequireDigit,
-			RequireSymbol: cfg.RequireSymbol,
-			MaxRepeats:    cfg.MaxRepeats,
-		},
+		rules: ruleOptions(cfg),
 		patterns: patterns.Options{
 			KeyboardMinLen: cfg.PatternMinLength,
 			SequenceMinLen: cfg.PatternMinLength,
+			Layouts:        cfg.KeyboardLayouts,
+			Forbidden:      compilePatterns(cfg.ForbiddenPatterns),
+			CurrentYear:    currentYear(cfg),
+			IsCommonWord:   func(w string) bool { return dictionary.IsCommon(w, dict) },
 		},
-		dictionary: dictionary.Options{
-			CustomPasswords: toLowerSlice(cfg.CustomPasswords),
-			CustomWords:     toLowerSlice(cfg.CustomWords),
-			DisableLeet:     cfg.DisableLeet,
-			ConstantTime:    cfg.ConstantTimeMode,
-		},
-		context: context.Options{
+		dictionary: dict,
+		context: contextcheck.Options{
 			ContextWords: cfg.ContextWords,
+			MinWordLen:   cfg.ContextMinWordLen,
 		},
 		hibp: hibpcheck.Options{
 			Checker:        cfg.HIBPChecker,
 			MinOccurrences: cfg.HIBPMinOccurrences,
 			Result:         mapHIBPResult(cfg.HIBPResult),
+			ConstantTime:   cfg.ConstantTimeMode,
+		},
+		history: history.Options{
+			Previous:           cfg.PreviousPasswords,
+			MaxSharedSubstring: cfg.MaxHistorySubstring,
+			MinEditDistance:    minEditDistance(cfg),
+			CurrentHash:        cfg.CurrentPasswordHash,
 		},
 	}
 }
 
+// minEditDistance returns cfg.MinEditDistance, or DefaultMinEditDistance
+// when it is zero.
+func minEditDistance(cfg Config) int {
+	if cfg.MinEditDistance == 0 {
+		return DefaultMinEditDistance
+	}
+	return cfg.MinEditDistance
+}
+
+// ruleOptions maps the public Config to rule check options.
+func ruleOptions(cfg Config) rules.Options {
+	return rules.Options{
+		MinLength:       cfg.MinLength,
+		MaxLength:       cfg.MaxLength,
+		RequireUpper:    cfg.RequireUpper,
+		RequireLower:    cfg.RequireLower,
+		RequireDigit:    cfg.RequireDigit,
+		RequireSymbol:   cfg.RequireSymbol,
+		MinCharClasses:  cfg.MinCharClasses,
+		MaxRepeats:      cfg.MaxRepeats,
+		MinUniqueChars:  cfg.MinUniqueChars,
+		FlagNumericOnly: cfg.FlagNumericOnly,
+		MinEntropy:      cfg.MinEntropy,
+		AllowWhitespace: cfg.whitespaceAllowed(),
+	}
+}
+
+// okayMax returns the highest score that still maps to the "Okay" verdict;
+// higher scores are "Strong" or better.
+func okayMax(t *VerdictThresholds) int {
+	if t == nil {
+		return scoring.ThresholdOkay
+	}
+	return t.OkayMax
+}
+
+// weakMax returns the highest score that still maps to the "Weak" verdict.
+func weakMax(t *VerdictThresholds) int {
+	if t == nil {
+		return scoring.ThresholdWeak
+	}
+	return t.WeakMax
+}
+
+// trendingCap returns the highest score a HotList match may keep: the top
+// of the "Weak" verdict, or with VerdictBands the top of the weak band,
+// the one just below the middle (the second of five, the first of three
+// or four). A single band leaves nothing to cap.
+func trendingCap(cfg Config) int {
+	bands := cfg.VerdictBands
+	if bands == nil {
+		return weakMax(cfg.VerdictThresholds)
+	}
+	weak := max((len(bands)+1)/2-2, 0)
+	if weak+1 >= len(bands) {
+		return 100
+	}
+	return bands[weak+1].MinScore - 1
+}
+
+// shortInputRunes bounds the inputs that skip pattern detection: no
+// built-in detector matches fewer than four runes (dates, repeated blocks,
+// affixed words, and case patterns all need at least four), and keyboard
+// and sequence runs need PatternMinLength.
+const shortInputRunes = 4
+
+// isShortInput reports whether pw is shorter than limit runes and too
+// short for any built-in pattern detector under cfg, so the pattern phase
+// can be reduced to the forbidden-pattern check without changing the
+// result. This is a fast path for meters that check every keystroke; the
+// other phases, including entropy and its Markov adjustment, still run
+// since they affect the score even for one-character inputs.
+func isShortInput(pw string, cfg Config, limit int) bool {
+	return utf8.RuneCountInString(pw) < min(limit, cfg.PatternMinLength)
+}
+
+// dropAffixedWords removes dictionary word matches covering the same span
+// as a PATTERN_AFFIXED finding, so "dragon123" is reported and penalized
+// once as an affixed word rather than again as a common word.
+func dropAffixedWords(dict, pats []issue.Issue) []issue.Issue {
+	var out []issue.Issue
+	for _, d := range dict {
+		if d.Code == issue.CodeDictCommonWord && slices.ContainsFunc(pats, func(p issue.Issue) bool {
+			return p.Code == issue.CodePatternAffixed && p.Start == d.Start && p.End == d.End
+		}) {
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// dropCaseTokenized removes a PATTERN_CASE_TOKENIZED finding when a
+// dictionary issue already covers the password's letters, so "Password1"
+// or "password1A" is reported and penalized once, as a dictionary match.
+// DICT_CAPITALIZED_COMMON carries no span and always covers them.
+func dropCaseTokenized(pats, dict []issue.Issue) []issue.Issue {
+	return slices.DeleteFunc(pats, func(p issue.Issue) bool {
+		return p.Code == issue.CodePatternCaseTokenized && slices.ContainsFunc(dict, func(d issue.Issue) bool {
+			return d.Code == issue.CodeDictCapitalizedCommon || d.Start < p.End && p.Start < d.End
+		})
+	})
+}
+
+// dropMangled removes PATTERN_AFFIXED and PATTERN_SUBSTITUTION findings
+// inside the span of a DICT_MANGLED_COMMON match, so "Password!1" is
+// penalized once, as a mangled common password, like [dropAffixedWords]
+// does for a word that is only reported because of its affixes.
+func dropMangled(pats, dict []issue.Issue) []issue.Issue {
+	return slices.DeleteFunc(pats, func(p issue.Issue) bool {
+		if p.Code != issue.CodePatternAffixed && p.Code != issue.CodePatternSubstitution {
+			return false
+		}
+		return slices.ContainsFunc(dict, func(d issue.Issue) bool {
+			return d.Code == issue.CodeDictMangledCommon && d.Start <= p.Start && p.End <= d.End
+		})
+	})
+}
+
+// containsCode reports whether any issue in issues has the given code.
+func containsCode(issues []issue.Issue, code string) bool {
+	for _, iss := range issues {
+		if iss.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
 // resolveVerdict maps score to a verdict string, honoring custom thresholds
 // when provided and falling back to the built-in scoring defaults when t is nil.
 func resolveVerdict(score int, t *VerdictThresholds) string {
@@ -506,6 +1200,19 @@ func resolveVerdict(score int, t *VerdictThresholds) string {
 	return scoring.VerdictWith(score, t.VeryWeakMax, t.WeakMax, t.OkayMax, t.StrongMax)
 }
 
+// bandVerdict returns the label of the last band whose MinScore is at most
+// score. bands must have passed validateVerdictBands.
+func bandVerdict(score int, bands []VerdictBand) string {
+	label := bands[0].Label
+	for _, b := range bands[1:] {
+		if score < b.MinScore {
+			break
+		}
+		label = b.Label
+	}
+	return label
+}
+
 // toPublicIssues converts internal issues to the public Issue type.
 // If redact is true, it masks potential password substrings in messages.
 func toPublicIssues(refined []issue.Issue, redact bool) []Issue {
@@ -519,10 +1226,16 @@ func toPublicIssues(refined []issue.Issue, redact bool) []Issue {
 			msg = redactMessage(msg)
 		}
 		out[i] = Issue{
-			Code:     iss.Code,
-			Message:  msg,
-			Category: iss.Category,
-			Severity: iss.Severity,
+			Code:       iss.Code,
+			Message:    msg,
+			Category:   iss.Category,
+			Severity:   Severity(iss.Severity),
+			Hint:       feedback.Hint(iss.Code),
+			MatchStart: iss.Start,
+			MatchEnd:   iss.End,
+		}
+		if !redact {
+			out[i].Match = iss.Match
 		}
 	}
 	return out
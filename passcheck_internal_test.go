@@ -15,6 +15,7 @@ func TestConstantSynchronization(t *testing.T) {
 		internal string
 	}{
 		{"CodeRuleTooShort", CodeRuleTooShort, issue.CodeRuleTooShort},
+		{"CodeRuleTooLong", CodeRuleTooLong, issue.CodeRuleTooLong},
 		{"CodeRuleNoUpper", CodeRuleNoUpper, issue.CodeRuleNoUpper},
 		{"CodeRuleNoLower", CodeRuleNoLower, issue.CodeRuleNoLower},
 		{"CodeRuleNoDigit", CodeRuleNoDigit, issue.CodeRuleNoDigit},
@@ -22,7 +23,11 @@ func TestConstantSynchronization(t *testing.T) {
 		{"CodeRuleWhitespace", CodeRuleWhitespace, issue.CodeRuleWhitespace},
 		{"CodeRuleControlChar", CodeRuleControlChar, issue.CodeRuleControlChar},
 		{"CodeRuleRepeatedChars", CodeRuleRepeatedChars, issue.CodeRuleRepeatedChars},
+		{"CodeRuleNoAlphanumeric", CodeRuleNoAlphanumeric, issue.CodeRuleNoAlphanumeric},
+		{"CodeRuleLowDiversity", CodeRuleLowDiversity, issue.CodeRuleLowDiversity},
+		{"CodeRuleLowEntropy", CodeRuleLowEntropy, issue.CodeRuleLowEntropy},
 		{"CodePatternKeyboard", CodePatternKeyboard, issue.CodePatternKeyboard},
+		{"CodePatternAdjacentWalk", CodePatternAdjacentWalk, issue.CodePatternAdjacentWalk},
 		{"CodePatternSequence", CodePatternSequence, issue.CodePatternSequence},
 		{"CodePatternBlock", CodePatternBlock, issue.CodePatternBlock},
 		{"CodePatternDate", CodePatternDate, issue.CodePatternDate},
@@ -31,7 +36,25 @@ func TestConstantSynchronization(t *testing.T) {
 		{"CodeDictLeetVariant", CodeDictLeetVariant, issue.CodeDictLeetVariant},
 		{"CodeDictCommonWord", CodeDictCommonWord, issue.CodeDictCommonWord},
 		{"CodeDictCommonWordSub", CodeDictCommonWordSub, issue.CodeDictCommonWordSub},
+		{"CodeDictCapitalizedCommon", CodeDictCapitalizedCommon, issue.CodeDictCapitalizedCommon},
+		{"CodeDictMirrored", CodeDictMirrored, issue.CodeDictMirrored},
+		{"CodeDictTrending", CodeDictTrending, issue.CodeDictTrending},
+		{"CodeDictReversedWord", CodeDictReversedWord, issue.CodeDictReversedWord},
+		{"CodeDictPasswordSubstring", CodeDictPasswordSubstring, issue.CodeDictPasswordSubstring},
+		{"CodeDictNearCommon", CodeDictNearCommon, issue.CodeDictNearCommon},
+		{"CodeDictMangledCommon", CodeDictMangledCommon, issue.CodeDictMangledCommon},
+		{"CodePassphraseWeakWords", CodePassphraseWeakWords, issue.CodePassphraseWeakWords},
 		{"CodeHIBPBreached", CodeHIBPBreached, issue.CodeHIBPBreached},
+		{"CodeHistorySharedSubstring", CodeHistorySharedSubstring, issue.CodeHistorySharedSubstring},
+		{"CodeHistoryReuse", CodeHistoryReuse, issue.CodeHistoryReuse},
+		{"CodeRuleTooSimilar", CodeRuleTooSimilar, issue.CodeRuleTooSimilar},
+		{"CodeRuleNumericOnly", CodeRuleNumericOnly, issue.CodeRuleNumericOnly},
+		{"CodeRuleInsufficientClasses", CodeRuleInsufficientClasses, issue.CodeRuleInsufficientClasses},
+		{"CodePatternForbidden", CodePatternForbidden, issue.CodePatternForbidden},
+		{"CodePatternAffixed", CodePatternAffixed, issue.CodePatternAffixed},
+		{"CodePatternRepeatedWord", CodePatternRepeatedWord, issue.CodePatternRepeatedWord},
+		{"CodePatternTemplateRepeat", CodePatternTemplateRepeat, issue.CodePatternTemplateRepeat},
+		{"CodePatternCaseTokenized", CodePatternCaseTokenized, issue.CodePatternCaseTokenized},
 		{"CodeContextWord", CodeContextWord, issue.CodeContextWord},
 		{"VerdictVeryWeak", VerdictVeryWeak, scoring.Verdict(0)},
 		{"VerdictWeak", VerdictWeak, scoring.Verdict(scoring.ThresholdWeak)},
@@ -68,7 +91,7 @@ func TestToPublicIssues(t *testing.T) {
 		if iss.Code != internal[0].Code ||
 			iss.Message != internal[0].Message ||
 			iss.Category != internal[0].Category ||
-			iss.Severity != internal[0].Severity {
+			iss.Severity != Severity(internal[0].Severity) {
 			t.Errorf("conversion mismatch: got %+v, want %+v", iss, internal[0])
 		}
 	})
@@ -139,3 +162,12 @@ func TestResolveVerdict_DefaultAndCustomThresholds(t *testing.T) {
 		}
 	})
 }
+
+func TestBandVerdict(t *testing.T) {
+	bands := []VerdictBand{{0, "Weak"}, {50, "Fair"}, {80, "Strong"}}
+	for score, want := range map[int]string{0: "Weak", 49: "Weak", 50: "Fair", 79: "Fair", 80: "Strong", 100: "Strong"} {
+		if got := bandVerdict(score, bands); got != want {
+			t.Errorf("bandVerdict(%d) = %q, want %q", score, got, want)
+		}
+	}
+}
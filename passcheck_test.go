@@ -1,8 +1,15 @@
 package passcheck
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -333,6 +340,14 @@ func TestConfig_Validate(t *testing.T) {
 		{"MinExecutionTimeMs=-1", func(c *Config) { c.MinExecutionTimeMs = -1 }, true},
 		{"MinExecutionTimeMs=0", func(c *Config) { c.MinExecutionTimeMs = 0 }, false},
 		{"MinExecutionTimeMs=10", func(c *Config) { c.MinExecutionTimeMs = 10 }, false},
+		{"MaxCustomEntries=-1", func(c *Config) { c.MaxCustomEntries = -1 }, true},
+		{"ContextMinWordLen=-1", func(c *Config) { c.ContextMinWordLen = -1 }, true},
+		{"ContextMinWordLen=2", func(c *Config) { c.ContextMinWordLen = 2 }, false},
+		{"MaxCustomEntries within cap", func(c *Config) {
+			c.MaxCustomEntries = 2
+			c.CustomPasswords = []string{"acme2024"}
+			c.CustomWords = []string{"acme"}
+		}, false},
 	}
 
 	for _, tt := range tests {
@@ -760,6 +775,87 @@ func TestCheckIncrementalWithConfig(t *testing.T) {
 	})
 }
 
+func TestCheckIncrementalGeneration(t *testing.T) {
+	cfg := DefaultConfig()
+	password := "Xk9$mP2!vR7@nL4&wQzB"
+
+	t.Run("Current_MatchesCheckIncrementalWithConfig", func(t *testing.T) {
+		var latest atomic.Uint64
+		gen := latest.Add(1)
+		got, gotDelta, err := CheckIncrementalGeneration(password, nil, cfg, gen, &latest)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want, wantDelta, _ := CheckIncrementalWithConfig(password, nil, cfg)
+		if !reflect.DeepEqual(got, want) || gotDelta != wantDelta {
+			t.Errorf("got %+v %+v, want %+v %+v", got, gotDelta, want, wantDelta)
+		}
+	})
+
+	t.Run("NilLatest_NeverAbandons", func(t *testing.T) {
+		got, _, _ := CheckIncrementalGeneration(password, nil, cfg, 0, nil)
+		want, _ := CheckWithConfig(password, cfg)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("Superseded_ReturnsPrevious", func(t *testing.T) {
+		var latest atomic.Uint64
+		stale := latest.Add(1)
+		latest.Add(1)
+
+		var calls int
+		hooked := cfg
+		hooked.OnResult = func(context.Context, Result) { calls++ }
+
+		previous, _ := CheckWithConfig("password", cfg)
+		got, delta, err := CheckIncrementalGeneration(password, &previous, hooked, stale, &latest)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, previous) {
+			t.Errorf("got %+v, want previous %+v", got, previous)
+		}
+		if delta != (IncrementalDelta{}) {
+			t.Errorf("delta = %+v, want all false", delta)
+		}
+		if calls != 0 {
+			t.Errorf("OnResult called %d times for an abandoned check", calls)
+		}
+
+		got, _, _ = CheckIncrementalGeneration(password, nil, cfg, stale, &latest)
+		if !reflect.DeepEqual(got, Result{}) {
+			t.Errorf("nil previous: got %+v, want zero Result", got)
+		}
+	})
+
+	t.Run("SupersededMidCheck", func(t *testing.T) {
+		var latest atomic.Uint64
+		gen := latest.Add(1)
+		bumping := cfg
+		// A newer keystroke arrives while the breach check runs.
+		bumping.HIBPChecker = hibpCheckerFunc(func(string) (bool, int, error) {
+			latest.Add(1)
+			return false, 0, nil
+		})
+		got, delta, _ := CheckIncrementalGeneration(password, nil, bumping, gen, &latest)
+		if !reflect.DeepEqual(got, Result{}) || delta != (IncrementalDelta{}) {
+			t.Errorf("got %+v %+v, want abandoned check", got, delta)
+		}
+	})
+
+	t.Run("InvalidConfig_ReturnsError", func(t *testing.T) {
+		if _, _, err := CheckIncrementalGeneration(password, nil, Config{}, 0, nil); err == nil {
+			t.Error("expected error for invalid config")
+		}
+	})
+}
+
+type hibpCheckerFunc func(password string) (bool, int, error)
+
+func (f hibpCheckerFunc) Check(password string) (bool, int, error) { return f(password) }
+
 // mockHIBP implements the HIBPChecker interface for tests (no hibp import).
 type mockHIBP struct {
 	breached bool
@@ -1058,6 +1154,31 @@ func TestCheckWithConfig_PassphraseMode(t *testing.T) {
 	})
 }
 
+func TestCheckWithConfig_PassphraseWeakWords(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PassphraseMode = true
+	cfg.MinWords = 4
+	cfg.RequireSymbol = false
+	cfg.RequireDigit = false
+	cfg.RequireUpper = false
+
+	degenerate, err := CheckWithConfig("aaaa bbbb cccc dddd", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasCode(degenerate.Issues, CodePassphraseWeakWords) {
+		t.Errorf("expected %s, got %+v", CodePassphraseWeakWords, degenerate.Issues)
+	}
+
+	genuine, _ := CheckWithConfig("correct-horse-battery-staple", cfg)
+	if hasCode(genuine.Issues, CodePassphraseWeakWords) {
+		t.Errorf("unexpected %s for genuine passphrase", CodePassphraseWeakWords)
+	}
+	if degenerate.Score >= genuine.Score {
+		t.Errorf("degenerate passphrase score %d should be below genuine %d", degenerate.Score, genuine.Score)
+	}
+}
+
 func TestCheckWithConfig_EntropyMode(t *testing.T) {
 	t.Run("AcceptanceCriteria_PatternedVsRandom", func(t *testing.T) {
 		// Acceptance criteria: "qwerty123456" has lower entropy than "Xk9$mP2!vR7@nL4"
@@ -1152,7 +1273,7 @@ func TestCheckWithConfig_EntropyMode(t *testing.T) {
 
 	t.Run("AllModes_ProgressiveReduction", func(t *testing.T) {
 		// Patterned password should show progressive entropy reduction across modes
-		password := "qwerty123456"
+		password := "Asdfgh12345678"
 
 		modes := []struct {
 			name EntropyMode
@@ -1614,182 +1735,1753 @@ func TestCheckWithConfig_PenaltyWeights(t *testing.T) {
 	})
 }
 
-// --- Fuzz tests ---
-
-func FuzzCheck(f *testing.F) {
-	// Seed corpus with representative inputs.
-	f.Add("")
-	f.Add("password")
-	f.Add("Xk9$mP2!vR7@nL4&wQzB")
-	f.Add("qwerty123")
-	f.Add("p@$$w0rd")
-	f.Add("aB3!aB3!aB3!")
-	f.Add("🔒🔑✨🎉密码パスワード")
-	f.Add(strings.Repeat("a", 2000))
-	f.Add("   \t\n\r")
-	f.Add("\x00\x01\x02\x03")
-
-	validVerdicts := map[string]bool{
-		VerdictVeryWeak:   true,
-		VerdictWeak:       true,
-		VerdictOkay:       true,
-		VerdictStrong:     true,
-		VerdictVeryStrong: true,
+func TestCheckWithConfig_CharsetBonusModel(t *testing.T) {
+	score := func(password string, model CharsetBonusModel) int {
+		t.Helper()
+		cfg := DefaultConfig()
+		cfg.CharsetBonusModel = model
+		result, err := CheckWithConfig(password, cfg)
+		if err != nil {
+			t.Fatalf("CheckWithConfig: %v", err)
+		}
+		return result.Score
 	}
 
-	f.Fuzz(func(t *testing.T, password string) {
-		result := Check(password)
+	// Linear bonuses are 6 (3 classes) and 9 (4 classes); diminishing are
+	// 5 and 6. Everything else about the score is model-independent.
+	tests := []struct {
+		name     string
+		password string
+		wantDiff int // linear score − diminishing score
+	}{
+		{"3 classes", "Xk9mP2vR7nL4wQ", 1},
+		{"4 classes", "Xk9$mP2vR7nL4wQ", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linear := score(tt.password, CharsetBonusLinear)
+			diminishing := score(tt.password, CharsetBonusDiminishing)
+			if got := linear - diminishing; got != tt.wantDiff {
+				t.Errorf("linear (%d) − diminishing (%d) = %d, want %d", linear, diminishing, got, tt.wantDiff)
+			}
+			if def := score(tt.password, ""); def != linear {
+				t.Errorf("empty model score = %d, want linear score %d", def, linear)
+			}
+		})
+	}
+}
 
-		if result.Score < 0 || result.Score > 100 {
-			t.Errorf("score out of range [0,100]: %d for input %q", result.Score, password)
-		}
-		if !validVerdicts[result.Verdict] {
-			t.Errorf("invalid verdict %q for input %q", result.Verdict, password)
-		}
-		if result.Entropy < 0 {
-			t.Errorf("negative entropy %.2f for input %q", result.Entropy, password)
-		}
-		for i, iss := range result.Issues {
-			if iss.Message == "" {
-				t.Errorf("empty issue message at index %d for input %q", i, password)
+func TestCheck_NoAlphanumeric(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{"all spaces", "                "},
+		{"all punctuation", "!@#$%^&*()_+-=[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Check(tt.password)
+			var found *Issue
+			for i := range result.Issues {
+				if result.Issues[i].Code == CodeRuleNoAlphanumeric {
+					found = &result.Issues[i]
+				}
 			}
-		}
-		for i, s := range result.Suggestions {
-			if s == "" {
-				t.Errorf("empty suggestion at index %d for input %q", i, password)
+			if found == nil {
+				t.Fatalf("expected %s issue, got %v", CodeRuleNoAlphanumeric, result.Issues)
+			}
+			if found.Severity != 3 {
+				t.Errorf("severity = %d, want 3 (high)", found.Severity)
+			}
+			if result.MeetsPolicy {
+				t.Error("MeetsPolicy should be false for a password with no letters or digits")
+			}
+		})
+	}
+
+	t.Run("AlphanumericNotFlagged", func(t *testing.T) {
+		result := Check("Xk9$mP2!vR7@nL4&wQ")
+		for _, iss := range result.Issues {
+			if iss.Code == CodeRuleNoAlphanumeric {
+				t.Errorf("unexpected %s issue", CodeRuleNoAlphanumeric)
 			}
 		}
 	})
 }
 
-func FuzzCheckBytes(f *testing.F) {
-	f.Add([]byte("password"))
-	f.Add([]byte("Xk9$mP2!vR7@nL4&wQzB"))
-	f.Add([]byte{})
-	f.Add([]byte{0xFF, 0xFE, 0xFD})
-
-	f.Fuzz(func(t *testing.T, password []byte) {
-		result := CheckBytes(password)
-
-		if result.Score < 0 || result.Score > 100 {
-			t.Errorf("score out of range: %d", result.Score)
+func TestCheck_CapitalizedCommon(t *testing.T) {
+	for _, pw := range []string{"Password", "Dragon"} {
+		res := Check(pw)
+		found := false
+		for _, iss := range res.Issues {
+			if iss.Code == CodeDictCapitalizedCommon {
+				found = true
+			}
 		}
-		// Input must be zeroed after call.
-		if !safemem.IsZeroed(password) {
-			t.Error("CheckBytes did not zero the input")
+		if !found {
+			t.Errorf("Check(%q): expected %s, got %+v", pw, CodeDictCapitalizedCommon, res.Issues)
 		}
-	})
+	}
 }
 
-// --- Benchmarks ---
+func TestConfig_Validate_MaxCustomEntries(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxCustomEntries = 2
+	cfg.CustomPasswords = []string{"acme2024", "acme2025"}
+	cfg.CustomWords = []string{"acme"}
 
-func BenchmarkCheck(b *testing.B) {
-	passwords := []struct {
-		name string
-		pw   string
-	}{
-		{"empty", ""},
-		{"short", "abc"},
-		{"common", "password"},
-		{"medium_12", "MyP@ss1234!x"},
-		{"strong_20", "Xk9$mP2!vR7@nL4&wQzB"},
-		{"long_100", strings.Repeat("aB3!", 25)},
-		{"long_1000", strings.Repeat("aB3!", 250)},
-		{"unicode", "Пароль密码パスワード🔒✨"},
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Validate() error = %v, want ErrInvalidConfig", err)
+	}
+	for _, want := range []string{"MaxCustomEntries", "LoadPasswordList", "hibp.NewBloomChecker"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
 	}
+}
 
-	for _, p := range passwords {
-		b.Run(p.name, func(b *testing.B) {
-			for i := 0; i < b.N; i++ {
-				Check(p.pw)
-			}
-		})
+func TestCheck_Mirrored(t *testing.T) {
+	res := Check("passworddrowssap")
+	if !hasCode(res.Issues, CodeDictMirrored) {
+		t.Errorf("expected %s for mirrored password, got %+v", CodeDictMirrored, res.Issues)
+	}
+	res = Check("Xk9$mP2!vR7@nL4&wQ")
+	if hasCode(res.Issues, CodeDictMirrored) {
+		t.Errorf("unexpected %s for random password", CodeDictMirrored)
 	}
 }
 
-func BenchmarkCheckBytes(b *testing.B) {
-	pw := []byte("Xk9$mP2!vR7@nL4&wQzB")
+func TestCheckWithConfig_HotList(t *testing.T) {
+	const pw = "Xk9$mP2!vR7@nL4&wQ"
 
-	for i := 0; i < b.N; i++ {
-		// Allocate fresh buffer each iteration since CheckBytes zeros it.
-		buf := make([]byte, len(pw))
-		copy(buf, pw)
-		CheckBytes(buf)
+	base, _ := CheckWithConfig(pw, DefaultConfig())
+	if base.Verdict != VerdictVeryStrong {
+		t.Fatalf("baseline verdict = %q, want %q", base.Verdict, VerdictVeryStrong)
 	}
-}
 
-func TestRedactSensitive(t *testing.T) {
-	t.Run("RedactionEnabled", func(t *testing.T) {
-		cfg := DefaultConfig()
-		cfg.RedactSensitive = true
-		cfg.CustomWords = []string{"password"}
+	cfg := DefaultConfig()
+	cfg.HotList = []string{"xk9$mp2!vr7@nl4&wq"}
+	res, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Issues) == 0 || res.Issues[0].Code != CodeDictTrending {
+		t.Errorf("expected %s as top issue, got %+v", CodeDictTrending, res.Issues)
+	}
+	if res.Verdict != VerdictWeak && res.Verdict != VerdictVeryWeak {
+		t.Errorf("verdict = %q (score %d), want at most %q", res.Verdict, res.Score, VerdictWeak)
+	}
 
-		// Trigger a dictionary match
-		result, err := CheckWithConfig("mypassword123!", cfg)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
+	// Custom thresholds move the cap with them.
+	cfg.VerdictThresholds = &VerdictThresholds{VeryWeakMax: 10, WeakMax: 20, OkayMax: 30, StrongMax: 40}
+	res, _ = CheckWithConfig(pw, cfg)
+	if res.Score > 20 {
+		t.Errorf("score = %d, want <= WeakMax 20", res.Score)
+	}
 
-		found := false
-		for _, iss := range result.Issues {
-			if iss.Code == CodeDictCommonWord {
-				found = true
-				if strings.Contains(iss.Message, "'password'") {
-					t.Errorf("expected redacted message, got: %q", iss.Message)
-				}
-				if !strings.Contains(iss.Message, "'***'") {
-					t.Errorf("expected redacted message to contain '***', got: %q", iss.Message)
-				}
-			}
-		}
-		if !found {
-			t.Error("expected dictionary issue to be found")
+	// So do custom bands: the weak band is the one below the middle.
+	cfg.VerdictThresholds = nil
+	for _, tt := range []struct {
+		bands []VerdictBand
+		want  string
+	}{
+		{[]VerdictBand{{0, "Weak"}, {30, "Fair"}, {80, "Strong"}}, "Weak"},
+		{[]VerdictBand{{0, "F"}, {20, "D"}, {40, "C"}, {60, "B"}, {80, "A"}}, "D"},
+	} {
+		cfg.VerdictBands = tt.bands
+		res, _ = CheckWithConfig(pw, cfg)
+		if res.Verdict != tt.want {
+			t.Errorf("bands %v: verdict = %q (score %d), want %q", tt.bands, res.Verdict, res.Score, tt.want)
 		}
-	})
+	}
+	cfg.VerdictBands = nil
 
-	t.Run("RedactionDisabled", func(t *testing.T) {
-		cfg := DefaultConfig()
-		cfg.RedactSensitive = false
-		cfg.CustomWords = []string{"password"}
+	// Not in the hot list: unaffected.
+	res, _ = CheckWithConfig("Qz7#wT4^bN1*", cfg)
+	if hasCode(res.Issues, CodeDictTrending) {
+		t.Errorf("unexpected %s for password not in hot list", CodeDictTrending)
+	}
+}
 
-		result, err := CheckWithConfig("mypassword123!", cfg)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
+func TestCheckWithContext_Hooks(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-42")
 
-		found := false
-		for _, iss := range result.Issues {
-			if iss.Code == CodeDictCommonWord {
-				found = true
-				if !strings.Contains(iss.Message, "'password'") {
-					t.Errorf("expected full message, got: %q", iss.Message)
-				}
-			}
-		}
-		if !found {
-			t.Error("expected dictionary issue to be found")
+	var gotResult, gotFailure string
+	var issues int
+	cfg := DefaultConfig()
+	cfg.OnResult = func(ctx context.Context, _ Result) { gotResult, _ = ctx.Value(ctxKey{}).(string) }
+	cfg.OnIssue = func(ctx context.Context, _ Issue) {
+		if ctx.Value(ctxKey{}) == "req-42" {
+			issues++
 		}
-	})
+	}
+	cfg.OnFailure = func(ctx context.Context, _ Result) { gotFailure, _ = ctx.Value(ctxKey{}).(string) }
+
+	res, err := CheckWithContext(ctx, "abc", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotResult != "req-42" || gotFailure != "req-42" {
+		t.Errorf("context value not passed to hooks: OnResult=%q OnFailure=%q", gotResult, gotFailure)
+	}
+	if issues != len(res.Issues) {
+		t.Errorf("OnIssue called %d times, want %d", issues, len(res.Issues))
+	}
+
+	gotFailure = ""
+	if res, _ := CheckWithContext(ctx, "Xk9$mP2!vR7@nL4&wQ", cfg); !res.MeetsPolicy || gotFailure != "" {
+		t.Errorf("OnFailure should not run for a password meeting policy")
+	}
+
+	want, _ := CheckWithConfig("abc", DefaultConfig())
+	if res.Score != want.Score {
+		t.Errorf("hooks changed the score: %d vs %d", res.Score, want.Score)
+	}
 }
 
-func BenchmarkCheck_VeryLong(b *testing.B) {
+// blockingChecker is an HIBP checker whose context-aware lookups block
+// until their context is done; its plain Check reports a breach.
+type blockingChecker struct{ calls atomic.Int32 }
 
-	// Ensure the truncation cap keeps performance bounded.
-	pw := strings.Repeat("aB3!xY7@", 200) // 1600 chars → truncated to 1024
-	for i := 0; i < b.N; i++ {
-		Check(pw)
+func (b *blockingChecker) Check(string) (bool, int, error) { return true, 100, nil }
+
+func (b *blockingChecker) CheckContext(ctx context.Context, _ string) (bool, int, error) {
+	b.calls.Add(1)
+	<-ctx.Done()
+	return false, 0, ctx.Err()
+}
+
+func (b *blockingChecker) CheckConstantTimeContext(ctx context.Context, pw string) (bool, int, error) {
+	return b.CheckContext(ctx, pw)
+}
+
+func TestCheckWithContext_CancelsHIBP(t *testing.T) {
+	checker := &blockingChecker{}
+	cfg := DefaultConfig()
+	cfg.HIBPChecker = checker
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	res, err := CheckWithContext(ctx, "password1", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checker.calls.Load() != 1 {
+		t.Fatalf("CheckContext called %d times, want 1", checker.calls.Load())
+	}
+	if hasCode(res.Issues, CodeHIBPBreached) {
+		t.Error("a cancelled breach lookup should be skipped")
+	}
+	if !hasCode(res.Issues, CodeDictCommonPassword) {
+		t.Errorf("the other phases should still report: %v", res.Issues)
 	}
 }
 
-func BenchmarkCheckWithConfig_Default(b *testing.B) {
+func TestCheckWithConfigContext(t *testing.T) {
+	type ctxKey struct{}
+	checker := &blockingChecker{}
+	var seen any
 	cfg := DefaultConfig()
-	pw := "Xk9$mP2!vR7@nL4&wQzB"
-	for i := 0; i < b.N; i++ {
-		_, _ = CheckWithConfig(pw, cfg)
+	cfg.HIBPChecker = checker
+	cfg.OnResult = func(ctx context.Context, _ Result) { seen = ctx.Value(ctxKey{}) }
+
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), ctxKey{}, "req-42"), 20*time.Millisecond)
+	defer cancel()
+	res, err := CheckWithConfigContext(ctx, "password1", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checker.calls.Load() != 1 || hasCode(res.Issues, CodeHIBPBreached) {
+		t.Errorf("cancelled breach lookup: %d calls, issues %v", checker.calls.Load(), res.Issues)
+	}
+	if seen != "req-42" {
+		t.Errorf("OnResult saw context value %v, want req-42", seen)
+	}
+
+	if _, err := CheckWithConfigContext(ctx, "password1", Config{}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("invalid config: err = %v, want ErrInvalidConfig", err)
 	}
 }
 
-func BenchmarkCheckWithConfig_ConstantTimeMode(b *testing.B) {
+// recordingObserver records every ObserveCheck call.
+type recordingObserver struct {
+	mu        sync.Mutex
+	results   []Result
+	durations []time.Duration
+}
+
+func (o *recordingObserver) ObserveCheck(result Result, d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.results = append(o.results, result)
+	o.durations = append(o.durations, d)
+}
+
+func TestCheckWithConfig_Observer(t *testing.T) {
+	obs := &recordingObserver{}
+	cfg := DefaultConfig()
+	cfg.Observer = obs
+
+	res, _ := CheckWithConfig("password", cfg)
+	long := strings.Repeat("Xk9$mP2!vR7@", MaxPasswordLength/12+10)
+	truncated, _ := CheckWithConfig(long, cfg)
+	if _, err := CheckBatch([]string{"abc", "Xk9$mP2!vR7@nL4&wQ"}, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(obs.results) != 4 {
+		t.Fatalf("ObserveCheck called %d times, want 4", len(obs.results))
+	}
+	if !reflect.DeepEqual(obs.results[0], res) || !reflect.DeepEqual(obs.results[1], truncated) {
+		t.Error("observer should receive the returned results")
+	}
+	for i, d := range obs.durations {
+		if d <= 0 {
+			t.Errorf("duration %d = %v, want > 0", i, d)
+		}
+	}
+
+	if _, err := CheckWithConfig("password", Config{Observer: obs}); err == nil || len(obs.results) != 4 {
+		t.Error("observer should not be called for an invalid config")
+	}
+}
+
+func TestCheck_IssueMatchOffsets(t *testing.T) {
+	find := func(issues []Issue, code string) Issue {
+		t.Helper()
+		for _, iss := range issues {
+			if iss.Code == code {
+				return iss
+			}
+		}
+		t.Fatalf("no %s issue in %+v", code, issues)
+		return Issue{}
+	}
+	tests := []struct {
+		name     string
+		password string
+		code     string
+		match    string
+		start    int
+		end      int
+	}{
+		{"dictionary word keeps original case", "zzMyDragonzz", CodeDictCommonWord, "Dragon", 4, 10},
+		{"leet dictionary word", "zzDr@g0nzz", CodeDictCommonWordSub, "Dr@g0n", 2, 8},
+		{"keyboard after multibyte runes", "ééQwertyzz", CodePatternKeyboard, "Qwerty", 2, 8},
+		{"sequence", "Zx!Vm9abcdefK", CodePatternSequence, "abcdef", 6, 12},
+		{"whole common password", "password", CodeDictCommonPassword, "password", 0, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MaxIssues = 0
+			res, _ := CheckWithConfig(tt.password, cfg)
+			iss := find(res.Issues, tt.code)
+			if iss.Match != tt.match || iss.MatchStart != tt.start || iss.MatchEnd != tt.end {
+				t.Errorf("got %q [%d, %d), want %q [%d, %d)", iss.Match, iss.MatchStart, iss.MatchEnd, tt.match, tt.start, tt.end)
+			}
+			if got := string([]rune(tt.password)[iss.MatchStart:iss.MatchEnd]); got != iss.Match {
+				t.Errorf("offsets select %q, Match is %q", got, iss.Match)
+			}
+		})
+	}
+
+	t.Run("context word", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ContextWords = []string{"alice"}
+		res, _ := CheckWithConfig("xxAl1ce2024!", cfg)
+		iss := find(res.Issues, CodeContextWord)
+		if iss.Match != "Al1ce" || iss.MatchStart != 2 || iss.MatchEnd != 7 {
+			t.Errorf("got %q [%d, %d)", iss.Match, iss.MatchStart, iss.MatchEnd)
+		}
+	})
+
+	t.Run("rule issues have no match", func(t *testing.T) {
+		iss := find(Check("abc").Issues, CodeRuleTooShort)
+		if iss.Match != "" || iss.MatchStart != 0 || iss.MatchEnd != 0 {
+			t.Errorf("rule issue has match %q [%d, %d)", iss.Match, iss.MatchStart, iss.MatchEnd)
+		}
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.RedactSensitive = true
+		res, _ := CheckWithConfig("zzMyDragonzz", cfg)
+		iss := find(res.Issues, CodeDictCommonWord)
+		if iss.Match != "" || iss.MatchStart != 4 || iss.MatchEnd != 10 {
+			t.Errorf("redacted: got %q [%d, %d), want empty match with offsets", iss.Match, iss.MatchStart, iss.MatchEnd)
+		}
+	})
+}
+
+func TestCheckWithConfig_DetectReversed(t *testing.T) {
+	res := Check("Nogard#2468xyz")
+	if !hasCode(res.Issues, CodeDictReversedWord) {
+		t.Errorf("expected %s by default, got %+v", CodeDictReversedWord, res.Issues)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DetectReversed = false
+	res, _ = CheckWithConfig("Nogard#2468xyz", cfg)
+	if hasCode(res.Issues, CodeDictReversedWord) {
+		t.Errorf("unexpected %s with DetectReversed=false", CodeDictReversedWord)
+	}
+}
+
+func TestCheckWithConfig_DetectSubstrings(t *testing.T) {
+	cfg := NISTConfig()
+	if res, _ := CheckWithConfig("2345678x", cfg); hasCode(res.Issues, CodeDictPasswordSubstring) {
+		t.Errorf("unexpected %s with DetectSubstrings=false", CodeDictPasswordSubstring)
+	}
+
+	cfg.DetectSubstrings = true
+	res, err := CheckWithConfig("assword12", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasCode(res.Issues, CodeDictPasswordSubstring) {
+		t.Errorf("expected %s, got %v", CodeDictPasswordSubstring, res.Issues)
+	}
+
+	// Passwords shorter than MinLength are not checked.
+	cfg.MinLength = 10
+	if res, _ := CheckWithConfig("assword12", cfg); hasCode(res.Issues, CodeDictPasswordSubstring) {
+		t.Errorf("unexpected %s below MinLength", CodeDictPasswordSubstring)
+	}
+}
+
+func TestCheckWithConfig_FuzzyDictionary(t *testing.T) {
+	cfg := DefaultConfig()
+	if res, _ := CheckWithConfig("Qwerrty#2468", cfg); hasCode(res.Issues, CodeDictNearCommon) {
+		t.Errorf("unexpected %s with FuzzyDictionary=false", CodeDictNearCommon)
+	}
+
+	cfg.FuzzyDictionary = true
+	res, err := CheckWithConfig("passw0rd1", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasCode(res.Issues, CodeDictNearCommon) {
+		t.Errorf("expected %s, got %v", CodeDictNearCommon, res.Issues)
+	}
+}
+
+func TestCheckWithConfig_DetectMangled(t *testing.T) {
+	cfg := DefaultConfig()
+	if res, _ := CheckWithConfig("Dragon2024!", cfg); hasCode(res.Issues, CodeDictMangledCommon) {
+		t.Errorf("unexpected %s with DetectMangled=false", CodeDictMangledCommon)
+	}
+
+	cfg.DetectMangled = true
+	res, err := CheckWithConfig("Dragon2024!", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasCode(res.Issues, CodeDictMangledCommon) {
+		t.Errorf("expected %s, got %v", CodeDictMangledCommon, res.Issues)
+	}
+
+	// The mangled match replaces the affix and substitution findings for
+	// the same span, so the base word is penalized once.
+	for _, pw := range []string{"Password!1", "monkey12!", "Dragon2024!"} {
+		res, _ := CheckWithConfig(pw, cfg)
+		if !hasCode(res.Issues, CodeDictMangledCommon) {
+			t.Errorf("%q: expected %s, got %v", pw, CodeDictMangledCommon, res.Issues)
+		}
+		for _, code := range []string{CodePatternAffixed, CodePatternSubstitution} {
+			if hasCode(res.Issues, code) {
+				t.Errorf("%q: unexpected %s alongside %s: %v", pw, code, CodeDictMangledCommon, res.Issues)
+			}
+		}
+	}
+}
+
+func TestCheckWithConfig_PreviousPasswords(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PreviousPasswords = []string{"Tr0ub4dor&3-Summer2023"}
+	cfg.MaxHistorySubstring = 8
+
+	rotations := []string{"Tr0ub4dor&3-Summer2024", "tr0ub4dor&3-winter!9", "2025#Tr0ub4dor&3"}
+	for _, pw := range rotations {
+		result, err := CheckWithConfig(pw, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hasCode(result.Issues, CodeHistorySharedSubstring) {
+			t.Errorf("%q: expected %s, got %v", pw, CodeHistorySharedSubstring, result.Issues)
+		}
+		if result.MeetsPolicy {
+			t.Errorf("%q: MeetsPolicy = true, want false for a history match", pw)
+		}
+		for _, iss := range result.Issues {
+			if iss.Category == CategoryHistory && iss.Match != "" {
+				t.Errorf("%q: %s Match = %q, want it empty", pw, iss.Code, iss.Match)
+			}
+		}
+	}
+
+	result, err := CheckWithConfig("Xk9$mP2!vR7@nL4&wQ", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasCode(result.Issues, CodeHistorySharedSubstring) {
+		t.Errorf("unrelated password reported %s", CodeHistorySharedSubstring)
+	}
+
+	cfg.MaxHistorySubstring = -1
+	if _, err := CheckWithConfig("Xk9$mP2!vR7@nL4&wQ", cfg); err == nil {
+		t.Error("expected error for negative MaxHistorySubstring")
+	}
+}
+
+func TestCheckWithConfig_ForbiddenPatternIsFatal(t *testing.T) {
+	const pw = "Zq7#Acme$wR9!kLm2@vN5"
+	cfg := DefaultConfig()
+	cfg.ForbiddenPatterns = []string{`(?i)acme`}
+
+	result, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasCode(result.Issues, CodePatternForbidden) {
+		t.Fatalf("expected %s, got %v", CodePatternForbidden, result.Issues)
+	}
+	if result.Score == 0 || !result.MeetsPolicy {
+		t.Fatalf("non-fatal match: score = %d, MeetsPolicy = %v; want a normal result", result.Score, result.MeetsPolicy)
+	}
+
+	cfg.ForbiddenPatternIsFatal = true
+	result, err = CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != 0 || result.Verdict != VerdictVeryWeak || result.MeetsPolicy {
+		t.Errorf("fatal match: score = %d, verdict = %q, MeetsPolicy = %v; want 0, %q, false",
+			result.Score, result.Verdict, result.MeetsPolicy, VerdictVeryWeak)
+	}
+	if !hasCode(result.Issues, CodePatternForbidden) {
+		t.Errorf("fatal match should still report %s", CodePatternForbidden)
+	}
+
+	clean, _ := CheckWithConfig("Zq7#Bxyz$wR9!kLm2@vN5", cfg)
+	if clean.Score == 0 || !clean.MeetsPolicy {
+		t.Errorf("non-matching password was failed: %+v", clean)
+	}
+
+	cfg.ForbiddenPatterns = []string{`(`}
+	if _, err := CheckWithConfig(pw, cfg); err == nil {
+		t.Error("expected error for an invalid forbidden pattern")
+	}
+}
+
+func TestCheckWithConfig_ForbiddenPatternDisabledCategory(t *testing.T) {
+	const pw = "Zq7#Acme$wR9!kLm2@vN5"
+	cfg := DefaultConfig()
+	cfg.ForbiddenPatterns = []string{`(?i)acme`}
+	cfg.DisabledCategories = []string{CategoryPattern}
+
+	// A non-fatal pattern is skipped with the rest of the category.
+	if result, _ := CheckWithConfig(pw, cfg); hasCode(result.Issues, CodePatternForbidden) {
+		t.Errorf("non-fatal: unexpected %s with the pattern category disabled", CodePatternForbidden)
+	}
+
+	// A fatal one is policy and still applies.
+	cfg.ForbiddenPatternIsFatal = true
+	result, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Score != 0 || result.MeetsPolicy || !hasCode(result.Issues, CodePatternForbidden) {
+		t.Errorf("fatal: score = %d, MeetsPolicy = %v, issues = %v; want 0, false, %s",
+			result.Score, result.MeetsPolicy, result.Issues, CodePatternForbidden)
+	}
+}
+
+func TestCompilePattern_ReusesValidatedPattern(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ForbiddenPatterns = []string{`(?i)compile-once-[0-9]+`}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	validated, err := compilePattern(cfg.ForbiddenPatterns[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if got := configToInternal(cfg).patterns.Forbidden[0]; got != validated {
+			t.Fatal("check recompiled a pattern already compiled by Validate")
+		}
+	}
+}
+
+func TestCheckWithConfig_Locale(t *testing.T) {
+	RegisterMessages("pt-BR", map[string]string{
+		CodeRuleTooShort: "A senha é muito curta",
+		MessageNotCommon: "Não encontrada em listas de senhas comuns",
+	})
+
+	cfg := DefaultConfig()
+	cfg.Locale = "pt-BR"
+	result, err := CheckWithConfig("Zq7#wR9!", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, iss := range result.Issues {
+		if iss.Code == CodeRuleTooShort {
+			found = true
+			if iss.Message != "A senha é muito curta" {
+				t.Errorf("message = %q, want the pt-BR translation", iss.Message)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected %s, got %v", CodeRuleTooShort, result.Issues)
+	}
+	if !containsString(result.Suggestions, "Não encontrada em listas de senhas comuns") {
+		t.Errorf("suggestions not translated: %v", result.Suggestions)
+	}
+
+	cfg.Locale = "de"
+	result, _ = CheckWithConfig("Zq7#wR9!", cfg)
+	for _, iss := range result.Issues {
+		if iss.Code == CodeRuleTooShort && iss.Message == "A senha é muito curta" {
+			t.Error("unregistered locale should fall back to English")
+		}
+	}
+}
+
+func containsString(ss []string, want string) bool {
+	for _, s := range ss {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestResult_MissingComposition(t *testing.T) {
+	cfg := DefaultConfig()
+
+	// 9 characters with lowercase and digits: missing uppercase and a
+	// symbol, and short of MinLength 12 by three characters — two of
+	// which the class additions already provide.
+	result, err := CheckWithConfig("zqxwvkt42", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := result.MissingComposition(cfg)
+	want := []string{"1 uppercase letter", "1 symbol", "1 more character"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingComposition = %q, want %q", got, want)
+	}
+
+	short, _ := CheckWithConfig("Zq7#", cfg)
+	if got := short.MissingComposition(cfg); !reflect.DeepEqual(got, []string{"8 more characters"}) {
+		t.Errorf("length only: got %q", got)
+	}
+
+	ok, _ := CheckWithConfig("Zq7#wR9!kLm2", cfg)
+	if got := ok.MissingComposition(cfg); got != nil {
+		t.Errorf("compliant password: got %q, want nil", got)
+	}
+
+	relaxed := cfg
+	relaxed.RequireSymbol = false
+	if got := result.MissingComposition(relaxed); !reflect.DeepEqual(got, []string{"1 uppercase letter", "2 more characters"}) {
+		t.Errorf("relaxed config: got %q", got)
+	}
+
+	if got := (Result{}).MissingComposition(cfg); got != nil {
+		t.Errorf("zero Result: got %q, want nil", got)
+	}
+}
+
+func TestResult_FailedCodesAndHas(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxIssues = 0
+	result, err := CheckWithConfig("qwerty", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes := result.FailedCodes()
+	if len(codes) == 0 {
+		t.Fatal("expected failed codes for a weak password")
+	}
+	for _, iss := range result.Issues {
+		if !codes[iss.Code] || !result.Has(iss.Code) {
+			t.Errorf("code %s missing from FailedCodes/Has", iss.Code)
+		}
+	}
+	for _, code := range []string{CodeRuleTooShort, CodePatternKeyboard, CodeDictCommonPassword} {
+		if !result.Has(code) {
+			t.Errorf("Has(%s) = false, want true", code)
+		}
+	}
+	if result.Has(CodeHIBPBreached) || codes[CodeHIBPBreached] {
+		t.Error("unexpected HIBP code")
+	}
+
+	empty := Result{}
+	if got := empty.FailedCodes(); got == nil || len(got) != 0 {
+		t.Errorf("FailedCodes on empty result = %v, want empty non-nil set", got)
+	}
+	if empty.Has(CodeRuleTooShort) {
+		t.Error("Has on empty result = true")
+	}
+}
+
+func TestCheckWithConfig_DetectCurrentYear(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DetectCurrentYear = true
+	cfg.CurrentYear = 2031
+
+	hasRecency := func(r Result) bool {
+		for _, iss := range r.Issues {
+			if iss.Code == CodePatternDate && strings.Contains(iss.Message, "current year") {
+				return true
+			}
+		}
+		return false
+	}
+
+	present, _ := CheckWithConfig("Vq#8zLm2031!", cfg)
+	if !hasRecency(present) {
+		t.Errorf("expected a current-year issue, got %v", present.Issues)
+	}
+	absent, _ := CheckWithConfig("Vq#8zLm!xKw", cfg)
+	if hasRecency(absent) {
+		t.Errorf("unexpected current-year issue: %v", absent.Issues)
+	}
+
+	cfg.DetectCurrentYear = false
+	if r, _ := CheckWithConfig("Vq#8zLm2031!", cfg); hasRecency(r) {
+		t.Error("current-year note reported while disabled")
+	}
+
+	cfg.CurrentYear = -1
+	if _, err := CheckWithConfig("Vq#8zLm2031!", cfg); err == nil {
+		t.Error("expected error for negative CurrentYear")
+	}
+}
+
+func TestCheckWithConfig_MinEntropy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MinLength = 8
+	cfg.RequireUpper, cfg.RequireLower, cfg.RequireDigit, cfg.RequireSymbol = false, false, false, false
+	cfg.EntropyMode = EntropyModePatternAware
+	cfg.MinEntropy = 50
+
+	random, err := CheckWithConfig("k#9Qv!2z", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if random.Has(CodeRuleLowEntropy) || !random.MeetsPolicy {
+		t.Errorf("random 8-char password (%.1f bits) should pass: %v", random.Entropy, random.Issues)
+	}
+
+	repeated, _ := CheckWithConfig("aaaaaaaaaaaaaaaaaaaa", cfg)
+	if !repeated.Has(CodeRuleLowEntropy) || repeated.MeetsPolicy {
+		t.Errorf("20 repeated chars (%.1f bits) should fail: %v", repeated.Entropy, repeated.Issues)
+	}
+
+	// The threshold applies to the final entropy after pattern reduction.
+	simple := cfg
+	simple.EntropyMode = EntropyModeSimple
+	walk, _ := CheckWithConfig("qwertyuiopasdf", simple)
+	advanced, _ := CheckWithConfig("qwertyuiopasdf", cfg)
+	if walk.Has(CodeRuleLowEntropy) == advanced.Has(CodeRuleLowEntropy) {
+		t.Errorf("keyboard walk: simple %.1f bits vs advanced %.1f bits should straddle the threshold",
+			walk.Entropy, advanced.Entropy)
+	}
+
+	cfg.MinEntropy = -1
+	if _, err := CheckWithConfig("k#9Qv!2z", cfg); err == nil {
+		t.Error("expected error for negative MinEntropy")
+	}
+}
+
+func TestCheckWithConfig_DisabledCategories(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DisabledCategories = []string{CategoryRule, CategoryPattern}
+
+	r, err := CheckWithConfig("password1", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, iss := range r.Issues {
+		if iss.Category == CategoryRule || iss.Category == CategoryPattern {
+			t.Errorf("disabled category produced %s (%s)", iss.Code, iss.Category)
+		}
+	}
+	if !hasCode(r.Issues, CodeDictCommonPassword) {
+		t.Errorf("dictionary checks should still run: %v", r.Issues)
+	}
+	if !r.MeetsPolicy {
+		t.Error("with rules disabled there is nothing to violate the policy")
+	}
+
+	// Disabled phases add no score penalty.
+	full, _ := CheckWithConfig("Tr0ub4dor", DefaultConfig())
+	noRules := DefaultConfig()
+	noRules.DisabledCategories = []string{CategoryRule}
+	relaxed, _ := CheckWithConfig("Tr0ub4dor", noRules)
+	if !hasCode(full.Issues, CodeRuleTooShort) || relaxed.Score <= full.Score {
+		t.Errorf("score without rules = %d, want above %d", relaxed.Score, full.Score)
+	}
+
+	cfg.DisabledCategories = []string{"composition"}
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("unknown category: Validate() = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestResult_Violations(t *testing.T) {
+	cfg := DefaultConfig()
+
+	// Meets every rule but contains a keyboard walk and a common word:
+	// advisory findings only.
+	advisory, err := CheckWithConfig("Qwerty#Dragon93x", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(advisory.Issues) == 0 {
+		t.Fatal("expected advisory issues")
+	}
+	if v := advisory.Violations(cfg); len(v) != 0 || !advisory.MeetsPolicy {
+		t.Errorf("advisory-only result: violations %v, MeetsPolicy %v", v, advisory.MeetsPolicy)
+	}
+
+	// Breaks rules; violations are complete even when Issues is limited.
+	limited := cfg
+	limited.MaxIssues = 1
+	breaching, _ := CheckWithConfig("qwerty", limited)
+	v := breaching.Violations(limited)
+	if breaching.MeetsPolicy || len(v) < 4 {
+		t.Fatalf("violations = %v, want at least too-short and three missing classes", v)
+	}
+	for _, iss := range v {
+		if iss.Category != "rule" {
+			t.Errorf("advisory issue %s reported as a violation", iss.Code)
+		}
+	}
+
+	suppressed := limited
+	suppressed.SuppressAllIssues = true
+	if r, _ := CheckWithConfig("qwerty", suppressed); len(r.Violations(suppressed)) != len(v) {
+		t.Error("SuppressAllIssues should not hide violations")
+	}
+
+	// Forbidden patterns breach policy only when fatal.
+	forbidden := cfg
+	forbidden.ForbiddenPatterns = []string{`(?i)acme`}
+	r, _ := CheckWithConfig("Zq7#Acme$wR9!kLm2@vN5", forbidden)
+	if len(r.Violations(forbidden)) != 0 {
+		t.Errorf("non-fatal forbidden pattern reported as violation: %v", r.Violations(forbidden))
+	}
+	forbidden.ForbiddenPatternIsFatal = true
+	r, _ = CheckWithConfig("Zq7#Acme$wR9!kLm2@vN5", forbidden)
+	if v := r.Violations(forbidden); len(v) != 1 || v[0].Code != CodePatternForbidden {
+		t.Errorf("fatal forbidden pattern: violations = %v", v)
+	}
+
+	if v := (Result{}).Violations(cfg); v != nil {
+		t.Errorf("zero Result: violations = %v, want nil", v)
+	}
+}
+
+func TestResult_StrengthReasons(t *testing.T) {
+	r := Check("Xk9$mP2!vR7@nL4&wQzB")
+	if r.Verdict != VerdictVeryStrong {
+		t.Fatalf("verdict = %q, want %q", r.Verdict, VerdictVeryStrong)
+	}
+	for _, want := range []string{"20 characters", "4 character types", "No dictionary words"} {
+		if !containsString(r.StrengthReasons, want) {
+			t.Errorf("StrengthReasons = %q, missing %q", r.StrengthReasons, want)
+		}
+	}
+	bits := fmt.Sprintf("~%.0f bits of entropy", r.Entropy)
+	if !containsString(r.StrengthReasons, bits) {
+		t.Errorf("StrengthReasons = %q, missing %q", r.StrengthReasons, bits)
+	}
+
+	weak := Check("password")
+	if weak.StrengthReasons == nil || len(weak.StrengthReasons) != 0 {
+		t.Errorf("weak password: StrengthReasons = %#v, want empty non-nil", weak.StrengthReasons)
+	}
+}
+
+func TestResult_Improvements(t *testing.T) {
+	r := Check("dragonabcd")
+	for _, want := range []string{"Add 2 more characters to reach 12", "Add an uppercase letter", "Remove the sequence 'abcd'", "Replace the dictionary word 'dragon'"} {
+		if !containsString(r.Improvements, want) {
+			t.Errorf("Improvements = %q, missing %q", r.Improvements, want)
+		}
+	}
+
+	whole := Check("password")
+	for _, step := range whole.Improvements {
+		if strings.Contains(step, "password'") {
+			t.Errorf("step reveals the whole password: %q", step)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.RedactSensitive = true
+	redacted, _ := CheckWithConfig("dragonabcd", cfg)
+	for _, step := range redacted.Improvements {
+		if strings.Contains(step, "'") {
+			t.Errorf("RedactSensitive: step quotes a token: %q", step)
+		}
+	}
+
+	strong := Check("Xk9$mP2!vR7@nL4&wQzB")
+	if strong.Improvements == nil || len(strong.Improvements) != 0 {
+		t.Errorf("strong password: Improvements = %#v, want empty non-nil", strong.Improvements)
+	}
+}
+
+func TestCheck_PatternAffixed(t *testing.T) {
+	for _, pw := range []string{"dragon123", "123dragon", "dragon!"} {
+		r := Check(pw)
+		if !r.Has(CodePatternAffixed) {
+			t.Errorf("Check(%q): expected %s, got %v", pw, CodePatternAffixed, r.Issues)
+		}
+		if r.Has(CodeDictCommonWord) {
+			t.Errorf("Check(%q): the affixed word should not also be reported as %s", pw, CodeDictCommonWord)
+		}
+	}
+
+	// The dictionary word match is dropped only where it overlaps the affix core.
+	df, err := CheckDetailed("Hello1", DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, iss := range df.Dictionary {
+		if iss.Code == CodeDictCommonWord {
+			t.Errorf("Dictionary findings still contain %s: %s", iss.Code, iss.Message)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledCategories = []string{CategoryPattern}
+	r, err := CheckWithConfig("dragon123", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Has(CodeDictCommonWord) {
+		t.Errorf("with patterns disabled, expected %s, got %v", CodeDictCommonWord, r.Issues)
+	}
+}
+
+func TestResult_IssueHints(t *testing.T) {
+	r := Check("password")
+	for _, iss := range r.Issues {
+		if iss.Hint == "" {
+			t.Errorf("%s: empty Hint", iss.Code)
+		}
+	}
+	for _, iss := range r.Issues {
+		if iss.Code == CodeRuleTooShort && iss.Hint != "increase_length" {
+			t.Errorf("RULE_TOO_SHORT hint = %q, want increase_length", iss.Hint)
+		}
+		if iss.Code == CodeDictCommonPassword && iss.Hint != "avoid_dictionary" {
+			t.Errorf("DICT_COMMON_PASSWORD hint = %q, want avoid_dictionary", iss.Hint)
+		}
+	}
+}
+
+func TestCheckWithConfig_AllowWhitespace(t *testing.T) {
+	const pw = "Tr0mbone correct Xylophage 9!"
+	check := func(cfg Config) Result {
+		t.Helper()
+		r, err := CheckWithConfig(pw, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	allow, deny := true, false
+
+	def := check(DefaultConfig())
+	if !def.Has(CodeRuleWhitespace) {
+		t.Fatalf("default config should report %s", CodeRuleWhitespace)
+	}
+
+	cfg := DefaultConfig()
+	cfg.AllowWhitespace = &allow
+	r := check(cfg)
+	if r.Has(CodeRuleWhitespace) {
+		t.Errorf("AllowWhitespace: unexpected %s", CodeRuleWhitespace)
+	}
+	if r.Entropy <= def.Entropy {
+		t.Errorf("AllowWhitespace: entropy %.2f should exceed %.2f", r.Entropy, def.Entropy)
+	}
+	if ctl, _ := CheckWithConfig("Tr0mbone correct\x00Xylophage 9!", cfg); !ctl.Has(CodeRuleControlChar) {
+		t.Errorf("AllowWhitespace: control characters should still be reported")
+	}
+
+	// PassphraseMode allows whitespace unless AllowWhitespace is false.
+	cfg = DefaultConfig()
+	cfg.PassphraseMode = true
+	if check(cfg).Has(CodeRuleWhitespace) {
+		t.Errorf("PassphraseMode: unexpected %s", CodeRuleWhitespace)
+	}
+	cfg.AllowWhitespace = &deny
+	if !check(cfg).Has(CodeRuleWhitespace) {
+		t.Errorf("PassphraseMode with AllowWhitespace=false should report %s", CodeRuleWhitespace)
+	}
+}
+
+func TestCheckWithConfig_MarkovModel(t *testing.T) {
+	corpus := "qwerty\nqwertyuiop\nqwerty123\nqwer1234\nqwertz\n"
+	model, err := LoadMarkovModel(strings.NewReader(corpus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.EntropyMode = EntropyModePatternAware
+	builtin, _ := CheckWithConfig("wertyqwerty12", cfg)
+	cfg.MarkovModel = model
+	trained, _ := CheckWithConfig("wertyqwerty12", cfg)
+	if trained.Entropy >= builtin.Entropy {
+		t.Errorf("entropy with a qwerty-trained model = %.2f, want below built-in %.2f", trained.Entropy, builtin.Entropy)
+	}
+
+	if _, err := LoadMarkovModel(strings.NewReader("\n")); !errors.Is(err, ErrEmptyMarkovCorpus) {
+		t.Errorf("empty corpus: err = %v, want ErrEmptyMarkovCorpus", err)
+	}
+}
+
+func TestCheck_PatternRepeatedWord(t *testing.T) {
+	for _, pw := range []string{"dragon dragon dragon", "Tiger-Lake-tiger-9!"} {
+		if r := Check(pw); !r.Has(CodePatternRepeatedWord) {
+			t.Errorf("Check(%q): expected %s, got %v", pw, CodePatternRepeatedWord, r.Issues)
+		}
+	}
+
+	// Repeated words add no entropy in passphrase mode.
+	cfg := DefaultConfig()
+	cfg.PassphraseMode = true
+	cfg.MinWords = 2
+	repeated, _ := CheckWithConfig("correct horse correct horse", cfg)
+	distinct, _ := CheckWithConfig("correct horse", cfg)
+	if repeated.Entropy != distinct.Entropy {
+		t.Errorf("entropy with repeated words = %.2f, want %.2f", repeated.Entropy, distinct.Entropy)
+	}
+}
+
+func TestCheckWithConfig_VerdictBands(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.VerdictBands = []VerdictBand{{MinScore: 0, Label: "Weak"}, {MinScore: 50, Label: "Fair"}, {MinScore: 80, Label: "Strong"}}
+	// Bands take precedence over thresholds.
+	cfg.VerdictThresholds = &VerdictThresholds{VeryWeakMax: 10, WeakMax: 20, OkayMax: 30, StrongMax: 40}
+
+	for _, pw := range []string{"password", "Xk9$mP2!vR7@nL4&wQ"} {
+		r, err := CheckWithConfig(pw, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "Weak"
+		switch {
+		case r.Score >= 80:
+			want = "Strong"
+		case r.Score >= 50:
+			want = "Fair"
+		}
+		if r.Verdict != want {
+			t.Errorf("%q: score %d verdict = %q, want %q", pw, r.Score, r.Verdict, want)
+		}
+	}
+
+	invalid := map[string][]VerdictBand{
+		"empty":         {},
+		"not from zero": {{MinScore: 10, Label: "Weak"}},
+		"unsorted":      {{MinScore: 0, Label: "Weak"}, {MinScore: 60, Label: "Good"}, {MinScore: 40, Label: "Fair"}},
+		"duplicate":     {{MinScore: 0, Label: "Weak"}, {MinScore: 0, Label: "Fair"}},
+		"above 100":     {{MinScore: 0, Label: "Weak"}, {MinScore: 101, Label: "Good"}},
+		"empty label":   {{MinScore: 0, Label: "Weak"}, {MinScore: 50, Label: " "}},
+	}
+	for name, bands := range invalid {
+		cfg.VerdictBands = bands
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("%s: Validate() = %v, want ErrInvalidConfig", name, err)
+		}
+	}
+}
+
+func TestCheckWithConfig_LeetPassphrase(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PassphraseMode = true
+	pw := "C0rr3ct-H0rs3-B@tt3ry-St@pl3"
+
+	r, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.DetectedType != TypePassphrase {
+		t.Errorf("DetectedType = %q, want %q", r.DetectedType, TypePassphrase)
+	}
+	plain, _ := CheckWithConfig("correct-horse-battery-staple", cfg)
+	if r.Entropy != plain.Entropy {
+		t.Errorf("leet passphrase entropy = %.2f, want word-based %.2f", r.Entropy, plain.Entropy)
+	}
+
+	cfg.DisableLeet = true
+	noLeet, _ := CheckWithConfig(pw, cfg)
+	if noLeet.Entropy == plain.Entropy {
+		t.Error("with DisableLeet the leet words should not be recovered")
+	}
+}
+
+func TestCheckWithConfig_MinCharClasses(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MinCharClasses = 3
+
+	r, _ := CheckWithConfig("zqxwvktjmbXR", cfg)
+	if !r.Has(CodeRuleInsufficientClasses) || r.MeetsPolicy {
+		t.Errorf("2 classes: issues %v, meets policy %t", r.Issues, r.MeetsPolicy)
+	}
+	for _, code := range []string{CodeRuleNoUpper, CodeRuleNoLower, CodeRuleNoDigit, CodeRuleNoSymbol} {
+		if r.Has(code) {
+			t.Errorf("Require* issue %s reported with MinCharClasses set", code)
+		}
+	}
+	if got, want := r.MissingComposition(cfg), []string{"1 more character type"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingComposition = %q, want %q", got, want)
+	}
+
+	r, _ = CheckWithConfig("zqxwvktjmbXR7", cfg)
+	if r.Has(CodeRuleInsufficientClasses) || !r.MeetsPolicy {
+		t.Errorf("3 classes without a symbol: issues %v, meets policy %t", r.Issues, r.MeetsPolicy)
+	}
+	if got := r.MissingComposition(cfg); got != nil {
+		t.Errorf("MissingComposition = %q, want nil", got)
+	}
+
+	for _, n := range []int{-1, 5} {
+		cfg.MinCharClasses = n
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("Validate(MinCharClasses=%d) = %v, want ErrInvalidConfig", n, err)
+		}
+	}
+}
+
+func TestCheckWithConfig_MinUniqueChars(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxRepeats = 20
+	r, _ := CheckWithConfig("Aaaaaaaaaaaa1!", cfg)
+	if r.Has(CodeRuleLowDiversity) || !r.MeetsPolicy {
+		t.Fatalf("without MinUniqueChars: issues %v, meets policy %t", r.Issues, r.MeetsPolicy)
+	}
+
+	cfg.MinUniqueChars = 8
+	r, _ = CheckWithConfig("Aaaaaaaaaaaa1!", cfg)
+	if !r.Has(CodeRuleLowDiversity) || r.MeetsPolicy {
+		t.Errorf("MinUniqueChars=8: issues %v, meets policy %t", r.Issues, r.MeetsPolicy)
+	}
+	if r, _ := CheckWithConfig("Xk9$mP2!vR7@nL4&wQ", cfg); r.Has(CodeRuleLowDiversity) {
+		t.Errorf("diverse password flagged: %v", r.Issues)
+	}
+
+	cfg.MinUniqueChars = -1
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Validate(MinUniqueChars=-1) = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestCheckWithConfig_ContextMinWordLen(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ContextWords = []string{"HP"}
+
+	r, err := CheckWithConfig("Xk9$hpR7@nL4&wQz", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Has(CodeContextWord) {
+		t.Error("two-character context word should be ignored by default")
+	}
+
+	cfg.ContextMinWordLen = 2
+	r, err = CheckWithConfig("Xk9$hpR7@nL4&wQz", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Has(CodeContextWord) {
+		t.Errorf("expected %s for 'hp' with ContextMinWordLen=2, got %v", CodeContextWord, r.Issues)
+	}
+}
+
+func TestCheckWithConfig_MinEditDistance(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PreviousPasswords = []string{"Tr0ub4dor&3-Summer2024"}
+
+	// The default MinEditDistance applies once PreviousPasswords is set.
+	r, err := CheckWithConfig("Tr0ub4dor&3-Summer2025", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Has(CodeRuleTooSimilar) || r.MeetsPolicy {
+		t.Errorf("expected %s and MeetsPolicy=false, got %v", CodeRuleTooSimilar, r.Issues)
+	}
+
+	cfg.MinEditDistance = 1
+	if r, _ := CheckWithConfig("Tr0ub4dor&3-Summer2025", cfg); r.Has(CodeRuleTooSimilar) {
+		t.Errorf("distance 1 should pass MinEditDistance=1, got %v", r.Issues)
+	}
+
+	cfg.MinEditDistance = -1
+	if _, err := CheckWithConfig("Tr0ub4dor&3-Summer2025", cfg); err == nil {
+		t.Error("expected error for negative MinEditDistance")
+	}
+}
+
+func TestCheckWithConfig_FlagNumericOnly(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RequireUpper, cfg.RequireLower, cfg.RequireSymbol = false, false, false
+
+	r, err := CheckWithConfig("482915736201", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Has(CodeRuleNumericOnly) || r.MeetsPolicy {
+		t.Errorf("expected %s and MeetsPolicy=false, got %v", CodeRuleNumericOnly, r.Issues)
+	}
+
+	cfg.FlagNumericOnly = false
+	if r, _ := CheckWithConfig("482915736201", cfg); r.Has(CodeRuleNumericOnly) {
+		t.Errorf("FlagNumericOnly=false should not report %s, got %v", CodeRuleNumericOnly, r.Issues)
+	}
+	if r, _ := CheckWithConfig("48291573620x", DefaultConfig()); r.Has(CodeRuleNumericOnly) {
+		t.Errorf("password with a letter reported %s", CodeRuleNumericOnly)
+	}
+}
+
+func TestCheckWithConfig_NormalizeUnicode(t *testing.T) {
+	cfg := DefaultConfig()
+	plain := cfg
+	cfg.NormalizeUnicode = true
+
+	for _, pw := range []string{
+		"p\u0430ssword",           // Cyrillic а
+		"ｐａｓｓｗｏｒｄ",                // fullwidth
+		"\u0420\u0410SSW\u041ERD", // Cyrillic Р, А, О
+	} {
+		if r, _ := CheckWithConfig(pw, plain); r.Has(CodeDictCommonPassword) {
+			t.Errorf("%q: %s without NormalizeUnicode", pw, CodeDictCommonPassword)
+		}
+		r, _ := CheckWithConfig(pw, cfg)
+		if !r.Has(CodeDictCommonPassword) {
+			t.Errorf("%q: expected %s with NormalizeUnicode, got %+v", pw, CodeDictCommonPassword, r.Issues)
+		}
+	}
+
+	// Spans and matches refer to the password as typed, even after a
+	// combining accent shortened the normalized form.
+	pw := "Xe\u0301k9$dr\u0430gon!Q"
+	r, _ := CheckWithConfig(pw, cfg)
+	var found bool
+	for _, iss := range r.Issues {
+		if iss.Code == CodeDictCommonWord {
+			found = true
+			if want := "dr\u0430gon"; iss.Match != want {
+				t.Errorf("Match = %q, want %q", iss.Match, want)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected %s for %q, got %+v", CodeDictCommonWord, pw, r.Issues)
+	}
+}
+
+func TestCheck_TemplateRepeat(t *testing.T) {
+	r := Check("Abc123!Xyz456!")
+	var found *Issue
+	for i := range r.Issues {
+		if r.Issues[i].Code == CodePatternTemplateRepeat {
+			found = &r.Issues[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected %s, got %v", CodePatternTemplateRepeat, r.Issues)
+	}
+	if found.Match != "Abc123!Xyz456!" {
+		t.Errorf("Match = %q, want the whole password", found.Match)
+	}
+	if Check("Xk9$mP2!vR7@nL4&wQzB").Has(CodePatternTemplateRepeat) {
+		t.Error("random password should not be flagged as a template repeat")
+	}
+}
+
+func TestEntropy_MatchesCheck(t *testing.T) {
+	passwords := []string{
+		"",
+		"password",
+		"qwerty123456",
+		"Xk9$mP2!vR7@nL4&wQzB",
+		"correct horse battery staple",
+		strings.Repeat("aB3$", 300), // beyond MaxPasswordLength
+	}
+	for _, mode := range []EntropyMode{EntropyModeSimple, EntropyModeAdvanced, EntropyModePatternAware} {
+		cfg := DefaultConfig()
+		cfg.EntropyMode = mode
+		for _, pw := range passwords {
+			want, err := CheckWithConfig(pw, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Entropy(pw, mode); got != want.Entropy {
+				t.Errorf("Entropy(%q, %s) = %v, want %v", pw, mode, got, want.Entropy)
+			}
+		}
+	}
+}
+
+func TestEntropy_Modes(t *testing.T) {
+	if got := Entropy("", EntropyModeAdvanced); got != 0 {
+		t.Errorf("Entropy(\"\") = %v, want 0", got)
+	}
+	simple := Entropy("qwertyuiop", EntropyModeSimple)
+	if advanced := Entropy("qwertyuiop", EntropyModeAdvanced); advanced >= simple {
+		t.Errorf("advanced entropy %v should be below simple %v for a keyboard walk", advanced, simple)
+	}
+	if got := Entropy("qwertyuiop", ""); got != simple {
+		t.Errorf("empty mode = %v, want simple %v", got, simple)
+	}
+}
+
+func TestCheckWithConfig_EmojiPoolSize(t *testing.T) {
+	pw := "🔒🔑✨🎉密码"
+	cfg := DefaultConfig()
+	cfg.EntropyMode = EntropyModeSimple
+	def, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 4*math.Log2(DefaultEmojiPoolSize) + 2*math.Log2(3000); math.Abs(def.Entropy-want) > 0.01 {
+		t.Errorf("Entropy = %.2f, want %.2f", def.Entropy, want)
+	}
+
+	cfg.EmojiPoolSize = 50
+	small, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if small.Entropy >= def.Entropy {
+		t.Errorf("EmojiPoolSize 50: entropy %.2f should be below the default %.2f", small.Entropy, def.Entropy)
+	}
+
+	cfg.EmojiPoolSize = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an error for negative EmojiPoolSize")
+	}
+}
+
+func TestCheckWithConfig_IssueOrder(t *testing.T) {
+	const pw = "password1"
+	cfg := DefaultConfig()
+	cfg.MaxIssues = 0
+	def, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Issues[0].Category == CategoryRule {
+		t.Fatalf("default order should put a more severe issue first: %v", def.Issues)
+	}
+
+	cfg.IssueOrder = []string{CategoryRule}
+	ordered, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ordered.Issues) != len(def.Issues) {
+		t.Fatalf("reordering changed the issue count: %d, want %d", len(ordered.Issues), len(def.Issues))
+	}
+	inRules := true
+	for _, iss := range ordered.Issues {
+		if iss.Category != CategoryRule {
+			inRules = false
+		} else if !inRules {
+			t.Errorf("rule issue %s listed after another category: %v", iss.Code, ordered.Issues)
+		}
+	}
+	if ordered.Score != def.Score || ordered.Verdict != def.Verdict || ordered.MeetsPolicy != def.MeetsPolicy {
+		t.Errorf("IssueOrder changed the outcome: score %d, want %d", ordered.Score, def.Score)
+	}
+
+	cfg.MaxIssues = 1
+	limited, _ := CheckWithConfig(pw, cfg)
+	if len(limited.Issues) != 1 || limited.Issues[0].Category != CategoryRule {
+		t.Errorf("MaxIssues should apply after reordering: %v", limited.Issues)
+	}
+
+	cfg.IssueOrder = []string{"composition"}
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("unknown category: Validate() = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestCheckWithConfig_EmojiPerGrapheme(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EntropyMode = EntropyModeSimple
+	base, err := CheckWithConfig("Password1!", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perEmoji := math.Log2(DefaultEmojiPoolSize)
+
+	// One emoji adds its own bits instead of enlarging the pool of every
+	// other character.
+	withEmoji, err := CheckWithConfig("Password1!👍", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := base.Entropy + perEmoji; math.Abs(withEmoji.Entropy-want) > 0.01 {
+		t.Errorf("Password1!👍: entropy %.2f, want %.2f", withEmoji.Entropy, want)
+	}
+	if withEmoji.Verdict == VerdictStrong || withEmoji.Verdict == VerdictVeryStrong {
+		t.Errorf("Password1!👍: score %d (%s), want below Strong", withEmoji.Score, withEmoji.Verdict)
+	}
+
+	// A family emoji joined with U+200D is a single grapheme.
+	family, err := CheckWithConfig("👨\u200D👩\u200D👧\u200D👦", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(family.Entropy-perEmoji) > 0.01 {
+		t.Errorf("family emoji: entropy %.2f, want %.2f", family.Entropy, perEmoji)
+	}
+}
+
+func TestCheckWithConfig_SuppressAllIssues(t *testing.T) {
+	const pw = "password123"
+
+	unlimited := DefaultConfig()
+	unlimited.MaxIssues = 0
+	resUnlimited, _ := CheckWithConfig(pw, unlimited)
+	if len(resUnlimited.Issues) == 0 {
+		t.Fatal("MaxIssues=0 should return all issues (no limit)")
+	}
+
+	suppressed := DefaultConfig()
+	suppressed.SuppressAllIssues = true
+	resSuppressed, _ := CheckWithConfig(pw, suppressed)
+	if resSuppressed.Issues == nil || len(resSuppressed.Issues) != 0 {
+		t.Errorf("SuppressAllIssues: Issues = %#v, want non-nil empty slice", resSuppressed.Issues)
+	}
+	if resSuppressed.Improvements == nil || len(resSuppressed.Improvements) != 0 {
+		t.Errorf("SuppressAllIssues: Improvements = %#v, want non-nil empty slice", resSuppressed.Improvements)
+	}
+
+	// Findings still drive scoring.
+	resDefault, _ := CheckWithConfig(pw, DefaultConfig())
+	if resSuppressed.Score != resDefault.Score || resSuppressed.Verdict != resDefault.Verdict {
+		t.Errorf("SuppressAllIssues changed scoring: got %d/%s, want %d/%s",
+			resSuppressed.Score, resSuppressed.Verdict, resDefault.Score, resDefault.Verdict)
+	}
+	if resSuppressed.MeetsPolicy != resDefault.MeetsPolicy {
+		t.Error("SuppressAllIssues should not change MeetsPolicy")
+	}
+}
+
+func TestCheckWithConfig_FriendlyMessages(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FriendlyMessages = true
+
+	for _, pw := range []string{"abc", "password", "qwerty123"} {
+		friendly, err := CheckWithConfig(pw, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		concise, _ := CheckWithConfig(pw, DefaultConfig())
+		if len(friendly.Issues) == 0 || len(friendly.Issues) != len(concise.Issues) {
+			t.Fatalf("%q: issue count mismatch: %d vs %d", pw, len(friendly.Issues), len(concise.Issues))
+		}
+		if friendly.Issues[0].Code != concise.Issues[0].Code {
+			t.Errorf("%q: top code = %s, want %s", pw, friendly.Issues[0].Code, concise.Issues[0].Code)
+		}
+		if friendly.Issues[0].Message == concise.Issues[0].Message {
+			t.Errorf("%q: friendly message should differ from %q", pw, concise.Issues[0].Message)
+		}
+		if len(friendly.Issues[0].Message) <= len(concise.Issues[0].Message) {
+			t.Errorf("%q: friendly message should be longer: %q", pw, friendly.Issues[0].Message)
+		}
+		for i := 1; i < len(friendly.Issues); i++ {
+			if friendly.Issues[i] != concise.Issues[i] {
+				t.Errorf("%q: only the top issue should change; issue %d = %+v", pw, i, friendly.Issues[i])
+			}
+		}
+		if friendly.Score != concise.Score {
+			t.Errorf("%q: FriendlyMessages changed score", pw)
+		}
+	}
+}
+
+func TestCheckWithConfig_EntropyBreakdown(t *testing.T) {
+	const pw = "qwerty123abc"
+	tests := []struct {
+		mode          EntropyMode
+		wantReduction bool
+		wantMarkov    bool
+	}{
+		{EntropyModeSimple, false, false},
+		{EntropyModeAdvanced, true, false},
+		{EntropyModePatternAware, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.EntropyMode = tt.mode
+			res, err := CheckWithConfig(pw, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := res.EntropyBreakdown
+			if b.FinalEntropy != res.Entropy {
+				t.Errorf("FinalEntropy = %v, want Entropy %v", b.FinalEntropy, res.Entropy)
+			}
+			if got := b.PatternReduction != 0; got != tt.wantReduction {
+				t.Errorf("PatternReduction = %v, want non-zero=%v", b.PatternReduction, tt.wantReduction)
+			}
+			if got := b.MarkovAdjustment != 0; got != tt.wantMarkov {
+				t.Errorf("MarkovAdjustment = %v, want non-zero=%v", b.MarkovAdjustment, tt.wantMarkov)
+			}
+			if tt.mode == EntropyModeSimple && b.BaseCharsetEntropy != b.FinalEntropy {
+				t.Errorf("simple mode: BaseCharsetEntropy %v != FinalEntropy %v", b.BaseCharsetEntropy, b.FinalEntropy)
+			}
+		})
+	}
+}
+
+func TestCheckWithConfig_KeyboardLayouts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.KeyboardLayouts = []string{KeyboardLayoutQWERTY, KeyboardLayoutAZERTY}
+	res, err := CheckWithConfig("azertyuiop", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, iss := range res.Issues {
+		if iss.Code == CodePatternKeyboard && strings.Contains(iss.Message, "azertyuiop") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected AZERTY keyboard pattern, got %+v", res.Issues)
+	}
+
+	cfg.KeyboardLayouts = []string{"colemak"}
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("unknown layout: Validate() = %v, want ErrInvalidConfig", err)
+	}
+}
+
+// --- Fuzz tests ---
+
+func FuzzCheck(f *testing.F) {
+	// Seed corpus with representative inputs.
+	f.Add("")
+	f.Add("password")
+	f.Add("Xk9$mP2!vR7@nL4&wQzB")
+	f.Add("qwerty123")
+	f.Add("p@$$w0rd")
+	f.Add("aB3!aB3!aB3!")
+	f.Add("🔒🔑✨🎉密码パスワード")
+	f.Add(strings.Repeat("a", 2000))
+	f.Add("   \t\n\r")
+	f.Add("\x00\x01\x02\x03")
+
+	validVerdicts := map[string]bool{
+		VerdictVeryWeak:   true,
+		VerdictWeak:       true,
+		VerdictOkay:       true,
+		VerdictStrong:     true,
+		VerdictVeryStrong: true,
+	}
+
+	f.Fuzz(func(t *testing.T, password string) {
+		result := Check(password)
+
+		if result.Score < 0 || result.Score > 100 {
+			t.Errorf("score out of range [0,100]: %d for input %q", result.Score, password)
+		}
+		if !validVerdicts[result.Verdict] {
+			t.Errorf("invalid verdict %q for input %q", result.Verdict, password)
+		}
+		if result.Entropy < 0 {
+			t.Errorf("negative entropy %.2f for input %q", result.Entropy, password)
+		}
+		for i, iss := range result.Issues {
+			if iss.Message == "" {
+				t.Errorf("empty issue message at index %d for input %q", i, password)
+			}
+		}
+		for i, s := range result.Suggestions {
+			if s == "" {
+				t.Errorf("empty suggestion at index %d for input %q", i, password)
+			}
+		}
+	})
+}
+
+func FuzzCheckBytes(f *testing.F) {
+	f.Add([]byte("password"))
+	f.Add([]byte("Xk9$mP2!vR7@nL4&wQzB"))
+	f.Add([]byte{})
+	f.Add([]byte{0xFF, 0xFE, 0xFD})
+
+	f.Fuzz(func(t *testing.T, password []byte) {
+		result := CheckBytes(password)
+
+		if result.Score < 0 || result.Score > 100 {
+			t.Errorf("score out of range: %d", result.Score)
+		}
+		// Input must be zeroed after call.
+		if !safemem.IsZeroed(password) {
+			t.Error("CheckBytes did not zero the input")
+		}
+	})
+}
+
+// --- Benchmarks ---
+
+func BenchmarkCheck(b *testing.B) {
+	passwords := []struct {
+		name string
+		pw   string
+	}{
+		{"empty", ""},
+		{"short", "abc"},
+		{"common", "password"},
+		{"medium_12", "MyP@ss1234!x"},
+		{"strong_20", "Xk9$mP2!vR7@nL4&wQzB"},
+		{"long_100", strings.Repeat("aB3!", 25)},
+		{"long_1000", strings.Repeat("aB3!", 250)},
+		{"unicode", "Пароль密码パスワード🔒✨"},
+	}
+
+	for _, p := range passwords {
+		b.Run(p.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Check(p.pw)
+			}
+		})
+	}
+}
+
+func BenchmarkCheck_ShortInput(b *testing.B) {
+	for _, fast := range []bool{true, false} {
+		b.Run(fmt.Sprintf("fast_path=%t", fast), func(b *testing.B) {
+			run := runOptions{}
+			if fast {
+				run.shortInput = shortInputRunes
+			}
+			cfg := DefaultConfig()
+			for i := 0; i < b.N; i++ {
+				checkUnless(context.Background(), "abc", cfg, run)
+			}
+		})
+	}
+}
+
+func BenchmarkCheckBytes(b *testing.B) {
+	pw := []byte("Xk9$mP2!vR7@nL4&wQzB")
+
+	for i := 0; i < b.N; i++ {
+		// Allocate fresh buffer each iteration since CheckBytes zeros it.
+		buf := make([]byte, len(pw))
+		copy(buf, pw)
+		CheckBytes(buf)
+	}
+}
+
+func TestRedactSensitive(t *testing.T) {
+	t.Run("RedactionEnabled", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.RedactSensitive = true
+		cfg.CustomWords = []string{"password"}
+
+		// Trigger a dictionary match
+		result, err := CheckWithConfig("mypassword123!", cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		found := false
+		for _, iss := range result.Issues {
+			if iss.Code == CodeDictCommonWord {
+				found = true
+				if strings.Contains(iss.Message, "'password'") {
+					t.Errorf("expected redacted message, got: %q", iss.Message)
+				}
+				if !strings.Contains(iss.Message, "'***'") {
+					t.Errorf("expected redacted message to contain '***', got: %q", iss.Message)
+				}
+			}
+		}
+		if !found {
+			t.Error("expected dictionary issue to be found")
+		}
+	})
+
+	t.Run("RedactionDisabled", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.RedactSensitive = false
+		cfg.CustomWords = []string{"password"}
+
+		result, err := CheckWithConfig("mypassword123!", cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		found := false
+		for _, iss := range result.Issues {
+			if iss.Code == CodeDictCommonWord {
+				found = true
+				if !strings.Contains(iss.Message, "'password'") {
+					t.Errorf("expected full message, got: %q", iss.Message)
+				}
+			}
+		}
+		if !found {
+			t.Error("expected dictionary issue to be found")
+		}
+	})
+}
+
+func BenchmarkCheck_VeryLong(b *testing.B) {
+
+	// Ensure the truncation cap keeps performance bounded.
+	pw := strings.Repeat("aB3!xY7@", 200) // 1600 chars → truncated to 1024
+	for i := 0; i < b.N; i++ {
+		Check(pw)
+	}
+}
+
+func BenchmarkCheckWithConfig_Default(b *testing.B) {
+	cfg := DefaultConfig()
+	pw := "Xk9$mP2!vR7@nL4&wQzB"
+	for i := 0; i < b.N; i++ {
+		_, _ = CheckWithConfig(pw, cfg)
+	}
+}
+
+func BenchmarkCheckWithConfig_LargeCustomPasswords(b *testing.B) {
+	cfg := DefaultConfig()
+	cfg.CustomPasswords = make([]string, 50_000)
+	for i := range cfg.CustomPasswords {
+		cfg.CustomPasswords[i] = fmt.Sprintf("acme-%06d", i)
+	}
+	pw := "Xk9$mP2!vR7@nL4&wQzB"
+	for i := 0; i < b.N; i++ {
+		_, _ = CheckWithConfig(pw, cfg)
+	}
+}
+
+func BenchmarkCheckWithConfig_ConstantTimeMode(b *testing.B) {
 	cfg := DefaultConfig()
 	cfg.ConstantTimeMode = true
 	pw := "Xk9$mP2!vR7@nL4&wQzB"
@@ -1797,3 +3489,107 @@ func BenchmarkCheckWithConfig_ConstantTimeMode(b *testing.B) {
 		_, _ = CheckWithConfig(pw, cfg)
 	}
 }
+
+func TestCheckWithConfig_MaxLength(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxLength = 16
+	res, err := CheckWithConfig("Xk9$mP2!vR7@nL4&wQ", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Score != 0 || res.MeetsPolicy {
+		t.Errorf("Score = %d, MeetsPolicy = %t; want 0, false", res.Score, res.MeetsPolicy)
+	}
+	if len(res.Issues) != 1 || res.Issues[0].Code != CodeRuleTooLong {
+		t.Fatalf("Issues = %v, want only %s", res.Issues, CodeRuleTooLong)
+	}
+	if want := "Remove 2 characters to stay within 16"; !slices.Contains(res.Improvements, want) {
+		t.Errorf("Improvements = %v, want %q", res.Improvements, want)
+	}
+
+	// A password within the limit is analyzed as usual.
+	if res, _ := CheckWithConfig("Xk9$mP2!vR7@nL4&", cfg); hasCode(res.Issues, CodeRuleTooLong) || res.Score == 0 {
+		t.Errorf("within MaxLength: score %d, issues %v", res.Score, res.Issues)
+	}
+
+	cfg.MaxLength = cfg.MinLength - 1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected a Validate error for MaxLength below MinLength")
+	}
+}
+
+func TestCheckWithConfig_CaseTokenized(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxIssues = 0
+	res, err := CheckWithConfig("Zqvkxmtr7$", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasCode(res.Issues, CodePatternCaseTokenized) {
+		t.Errorf("expected %s, got %v", CodePatternCaseTokenized, res.Issues)
+	}
+
+	// A dictionary match on the same letters is reported once.
+	for _, pw := range []string{"password1A", "Password"} {
+		res, _ := CheckWithConfig(pw, cfg)
+		if hasCode(res.Issues, CodePatternCaseTokenized) {
+			t.Errorf("%q: unexpected %s alongside dictionary issues %v", pw, CodePatternCaseTokenized, res.Issues)
+		}
+	}
+}
+
+func TestCheckWithConfig_ShortInputFastPath(t *testing.T) {
+	forbidden := DefaultConfig()
+	forbidden.ForbiddenPatterns = []string{"(?i)ab"}
+	custom := DefaultConfig()
+	custom.CustomPasswords = []string{"xyz"}
+	custom.EntropyMode = EntropyModePatternAware
+	minPattern := DefaultConfig()
+	minPattern.PatternMinLength = 3
+	configs := map[string]Config{
+		"default":            DefaultConfig(),
+		"nist":               NISTConfig(),
+		"enterprise":         EnterpriseConfig(),
+		"forbidden":          forbidden,
+		"custom+markov":      custom,
+		"pattern min length": minPattern,
+	}
+	inputs := []string{"", "a", "ab", "abc", "aB1", "!!!", "mia", "aaa", "xyz", "qwe", "123", "€😀", "abcd"}
+
+	check := func(pw string, cfg Config, fast bool) Result {
+		t.Helper()
+		if err := cfg.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		run := runOptions{}
+		if fast {
+			run.shortInput = shortInputRunes
+		}
+		r, _ := checkUnless(context.Background(), pw, cfg, run)
+		return r
+	}
+	for name, cfg := range configs {
+		for _, pw := range inputs {
+			fast, full := check(pw, cfg, true), check(pw, cfg, false)
+			if fast.Score != full.Score || fast.Verdict != full.Verdict || fast.Entropy != full.Entropy || !reflect.DeepEqual(fast.Issues, full.Issues) {
+				t.Errorf("%s, %q: fast path (%d %s %v) differs from full path (%d %s %v)",
+					name, pw, fast.Score, fast.Verdict, fast.Issues, full.Score, full.Verdict, full.Issues)
+			}
+		}
+	}
+}
+
+func TestCheckWithConfig_SuggestionsAlongsideIssues(t *testing.T) {
+	res, err := CheckWithConfig("password1234567890ABC!", DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Issues) == 0 {
+		t.Fatal("expected issues")
+	}
+	for _, want := range []string{"Good length (22 characters)", "Good character diversity (4 of 4 character types)"} {
+		if !slices.Contains(res.Suggestions, want) {
+			t.Errorf("Suggestions = %v, want %q alongside issues", res.Suggestions, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package passcheck
+
+import (
+	"io"
+
+	"github.com/rafaelsanzio/passcheck/internal/dictionary"
+)
+
+// PasswordSet is an immutable set of blocklisted passwords with O(1)
+// lookups. Build it once with [LoadPasswordList], assign it to
+// [Config.PasswordSet], and reuse it across checks; it is safe for
+// concurrent use.
+type PasswordSet = dictionary.PasswordSet
+
+// LoadPasswordList reads a newline-delimited password blocklist from r
+// and builds a [PasswordSet]. Entries are lowercased (matching is
+// case-insensitive) and trailing whitespace is trimmed. Blank lines and
+// lines starting with "#" are skipped.
+//
+// Entries of a ranked list may carry a breach frequency after a tab
+// ("123456\t23547453"). A match on such an entry scales the dictionary
+// penalty from half to one and a half times the usual amount, by the
+// entry's frequency relative to the most frequent entry on a log scale.
+// Entries without a frequency, and the built-in list, cost the usual
+// amount.
+//
+//	f, err := os.Open("blocklist.txt")
+//	if err != nil { /* handle */ }
+//	defer f.Close()
+//	set, err := passcheck.LoadPasswordList(f)
+//	if err != nil { /* handle */ }
+//	cfg := passcheck.DefaultConfig()
+//	cfg.PasswordSet = set
+func LoadPasswordList(r io.Reader) (*PasswordSet, error) {
+	return dictionary.LoadPasswordList(r)
+}
+
+// LoadPasswordListGzip is like [LoadPasswordList] but reads a
+// gzip-compressed list (e.g. a .txt.gz file). It returns an error if r is
+// not a valid gzip stream.
+func LoadPasswordListGzip(r io.Reader) (*PasswordSet, error) {
+	return dictionary.LoadPasswordListGzip(r)
+}
+
+// LoadWordList reads a newline-delimited word list from r, applying the
+// same line rules as [LoadPasswordList], and returns the words lowercased
+// in file order, ready to assign to [Config.CustomWords].
+func LoadWordList(r io.Reader) ([]string, error) {
+	return dictionary.LoadWordList(r)
+}
+
+// LoadWordListGzip is like [LoadWordList] but reads a gzip-compressed
+// list. It returns an error if r is not a valid gzip stream.
+func LoadWordListGzip(r io.Reader) ([]string, error) {
+	return dictionary.LoadWordListGzip(r)
+}
@@ -0,0 +1,90 @@
+package passcheck
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+)
+
+func TestCheckWithConfig_PasswordSet(t *testing.T) {
+	set, err := LoadPasswordList(strings.NewReader("# team blocklist\nZorblax-Quintet-77\n"))
+	if err != nil {
+		t.Fatalf("LoadPasswordList: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	without, _ := CheckWithConfig("Zorblax-Quintet-77", cfg)
+	cfg.PasswordSet = set
+	with, _ := CheckWithConfig("Zorblax-Quintet-77", cfg)
+
+	if hasCode(without.Issues, CodeDictCommonPassword) {
+		t.Fatalf("baseline should not flag the password: %+v", without.Issues)
+	}
+	if !hasCode(with.Issues, CodeDictCommonPassword) {
+		t.Errorf("PasswordSet entry should be flagged as %s, got %+v", CodeDictCommonPassword, with.Issues)
+	}
+	if with.Score >= without.Score {
+		t.Errorf("score with PasswordSet = %d, want below %d", with.Score, without.Score)
+	}
+}
+
+func TestCheckWithConfig_PasswordSetWithCustomPasswords(t *testing.T) {
+	set, _ := LoadPasswordList(strings.NewReader("zorblax-quintet-77\n"))
+	cfg := DefaultConfig()
+	cfg.PasswordSet = set
+	cfg.CustomPasswords = []string{"Glimmer-Otter-42"}
+
+	for _, pw := range []string{"Zorblax-Quintet-77", "Glimmer-Otter-42"} {
+		res, _ := CheckWithConfig(pw, cfg)
+		if !hasCode(res.Issues, CodeDictCommonPassword) {
+			t.Errorf("%q: expected %s, got %+v", pw, CodeDictCommonPassword, res.Issues)
+		}
+	}
+}
+
+func TestCheckWithConfig_PasswordSetFrequency(t *testing.T) {
+	// Both entries use the same characters, so only the frequency differs.
+	set, err := LoadPasswordList(strings.NewReader("Zorblax-Quintet-77\t5000000\nQuintet-Zorblax-77\t3\n"))
+	if err != nil {
+		t.Fatalf("LoadPasswordList: %v", err)
+	}
+	cfg := DefaultConfig()
+	cfg.PasswordSet = set
+
+	frequent, _ := CheckWithConfig("Zorblax-Quintet-77", cfg)
+	rare, _ := CheckWithConfig("Quintet-Zorblax-77", cfg)
+	if !hasCode(frequent.Issues, CodeDictCommonPassword) || !hasCode(rare.Issues, CodeDictCommonPassword) {
+		t.Fatalf("expected %s for both entries", CodeDictCommonPassword)
+	}
+	if frequent.Score >= rare.Score {
+		t.Errorf("frequent entry score = %d, want below rare entry score %d", frequent.Score, rare.Score)
+	}
+}
+
+func TestLoadWordListGzip_CustomWords(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte("Zorblax\r\nQuintet\r\n"))
+	_ = zw.Close()
+
+	words, err := LoadWordListGzip(&buf)
+	if err != nil {
+		t.Fatalf("LoadWordListGzip: %v", err)
+	}
+	cfg := DefaultConfig()
+	cfg.CustomWords = words
+	res, _ := CheckWithConfig("myZorblax#2024x", cfg)
+	if !hasCode(res.Issues, CodeDictCommonWord) {
+		t.Errorf("expected %s from gzip word list, got %+v", CodeDictCommonWord, res.Issues)
+	}
+}
+
+func hasCode(issues []Issue, code string) bool {
+	for _, iss := range issues {
+		if iss.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -38,6 +38,8 @@ func NISTConfig() Config {
 		PatternMinLength: 99, // Effectively disabled (very high threshold)
 		MaxIssues:        5,
 		EntropyMode:      EntropyModeAdvanced,
+		DetectReversed:   true,
+		FlagNumericOnly:  false, // All-digit passwords are allowed (no composition rules)
 	}
 }
 
@@ -83,6 +85,8 @@ func PCIDSSConfig() Config {
 		PatternMinLength: 4,
 		MaxIssues:        5,
 		EntropyMode:      EntropyModeAdvanced,
+		DetectReversed:   true,
+		FlagNumericOnly:  true,
 	}
 }
 
@@ -126,6 +130,8 @@ func OWASPConfig() Config {
 		PatternMinLength: 4,
 		MaxIssues:        5,
 		EntropyMode:      EntropyModeAdvanced,
+		DetectReversed:   true,
+		FlagNumericOnly:  true,
 	}
 }
 
@@ -172,6 +178,8 @@ func EnterpriseConfig() Config {
 		PatternMinLength: 3,  // More aggressive pattern detection
 		MaxIssues:        10, // Show more issues for comprehensive feedback
 		EntropyMode:      EntropyModeAdvanced,
+		DetectReversed:   true,
+		FlagNumericOnly:  true,
 	}
 }
 
@@ -218,5 +226,7 @@ func UserFriendlyConfig() Config {
 		PatternMinLength: 5, // Less aggressive pattern detection
 		MaxIssues:        3, // Fewer issues shown
 		EntropyMode:      EntropyModeAdvanced,
+		DetectReversed:   true,
+		FlagNumericOnly:  true,
 	}
 }
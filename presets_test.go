@@ -339,6 +339,63 @@ func TestPresetStrictnessOrder(t *testing.T) {
 	}
 }
 
+// TestConfig_StricterThan verifies hard-requirement comparison between configs.
+func TestConfig_StricterThan(t *testing.T) {
+	enterprise := EnterpriseConfig()
+	nist := NISTConfig()
+
+	if !enterprise.StricterThan(nist) {
+		t.Error("Enterprise should be stricter than NIST")
+	}
+	if nist.StricterThan(enterprise) {
+		t.Error("NIST should not be stricter than Enterprise")
+	}
+	if !nist.StricterThan(nist) {
+		t.Error("a config should be stricter than or equal to itself")
+	}
+
+	// Stricter in one dimension but looser in another is not stricter.
+	mixed := EnterpriseConfig()
+	mixed.RequireSymbol = false
+	if mixed.StricterThan(PCIDSSConfig()) {
+		t.Error("config without RequireSymbol should not be stricter than PCI-DSS")
+	}
+
+	looserRepeats := EnterpriseConfig()
+	looserRepeats.MaxRepeats = 5
+	if looserRepeats.StricterThan(enterprise) {
+		t.Error("higher MaxRepeats should not be stricter")
+	}
+
+	entropyFloor := NISTConfig()
+	entropyFloor.MinEntropy = 40
+	if !entropyFloor.StricterThan(nist) || nist.StricterThan(entropyFloor) {
+		t.Error("a higher MinEntropy should be stricter")
+	}
+
+	// MinCharClasses guarantees a class count but no particular class.
+	threeOfFour := PCIDSSConfig()
+	threeOfFour.MinCharClasses = 3
+	if threeOfFour.StricterThan(OWASPConfig()) {
+		t.Error("3 of 4 classes should not be stricter than requiring upper, lower, and digit")
+	}
+	if !PCIDSSConfig().StricterThan(threeOfFour) {
+		t.Error("requiring all four classes should be stricter than 3 of 4")
+	}
+	allFour := threeOfFour
+	allFour.MinCharClasses = 4
+	if !allFour.StricterThan(PCIDSSConfig()) || !allFour.StricterThan(threeOfFour) {
+		t.Error("4 of 4 classes should be stricter than any Require* combination")
+	}
+
+	// Score-based settings are ignored.
+	weighted := NISTConfig()
+	weighted.PenaltyWeights = &PenaltyWeights{DictionaryMatch: 3}
+	if !nist.StricterThan(weighted) || !weighted.StricterThan(nist) {
+		t.Error("PenaltyWeights should not affect StricterThan")
+	}
+}
+
 // TestAllPresetsValid verifies all presets return valid configurations
 func TestAllPresetsValid(t *testing.T) {
 	presets := map[string]Config{
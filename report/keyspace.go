@@ -0,0 +1,40 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/rafaelsanzio/passcheck"
+)
+
+// keyspaceLine describes the search space implied by r's entropy and the
+// time to exhaust it:
+//
+//	≈ 2^52 guesses; ~5 days at 10^10/s
+//
+// The time is r.CrackTimeDisplay. The guess rate it assumes, the
+// Config.GuessesPerSecond of the check, is recovered from
+// r.CrackTimeSeconds and left out when it cannot be (e.g. for a Result
+// built by hand).
+func keyspaceLine(r passcheck.Result) string {
+	line := fmt.Sprintf("≈ 2^%d guesses", int(math.Round(r.Entropy)))
+	if r.CrackTimeDisplay == "" {
+		return line
+	}
+	line += "; ~" + r.CrackTimeDisplay
+	if rate := math.Pow(2, r.Entropy) / r.CrackTimeSeconds; rate > 0 && !math.IsInf(rate, 0) {
+		line += " at " + formatRate(rate) + "/s"
+	}
+	return line
+}
+
+// formatRate renders a guess rate as a power of ten ("10^10") when it is
+// one, and in short %g form ("2.5e+09") otherwise.
+func formatRate(rate float64) string {
+	exp := math.Round(math.Log10(rate))
+	if math.Abs(rate-math.Pow(10, exp)) <= 1e-9*rate {
+		return fmt.Sprintf("10^%d", int(exp))
+	}
+	return strconv.FormatFloat(rate, 'g', 3, 64)
+}
@@ -0,0 +1,188 @@
+// Package report renders a passcheck [passcheck.Result] as a human-readable
+// report: the same score meter, verdict, entropy, issues, and strengths the
+// passcheck CLI prints, as plain or colorized text or as Markdown (e.g. for
+// a CI artifact or a pull request comment).
+//
+//	result, _ := passcheck.CheckWithConfig(password, cfg)
+//	err := report.Write(os.Stdout, result, report.ReportOptions{Color: true})
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rafaelsanzio/passcheck"
+)
+
+// Format selects the layout of a report.
+type Format int
+
+// Report formats accepted in [ReportOptions.Format].
+const (
+	// FormatText is the plain-text layout of the passcheck CLI, colorized
+	// with ANSI escape codes when ReportOptions.Color is set.
+	FormatText Format = iota
+
+	// FormatMarkdown renders the report as a Markdown section. Color is
+	// ignored.
+	FormatMarkdown
+)
+
+// ReportOptions configures [Write]. The zero value writes an uncolored,
+// non-verbose text report.
+type ReportOptions struct {
+	// Format selects the layout (default: FormatText).
+	Format Format
+
+	// Color adds ANSI colors to the meter, verdict, and list markers of a
+	// text report. Leave it unset when the output is not a terminal.
+	Color bool
+
+	// Verbose adds the entropy breakdown and the keyspace estimate with its
+	// crack time, and counts the issues in their heading. It does not
+	// change which issues are listed: run the check with
+	// Config.MaxIssues = 0 to report all of them.
+	Verbose bool
+}
+
+// Write writes a report of r to w in the format selected by opts and
+// returns the first write error, if any.
+func Write(w io.Writer, r passcheck.Result, opts ReportOptions) error {
+	ew := &errWriter{w: w}
+	switch opts.Format {
+	case FormatMarkdown:
+		writeMarkdown(ew, r, opts)
+	default:
+		writeText(ew, r, opts)
+	}
+	return ew.err
+}
+
+// writeText writes the plain or colorized text report.
+func writeText(w io.Writer, r passcheck.Result, opts ReportOptions) {
+	useColor := opts.Color
+
+	// Score line with visual meter.
+	_, _ = fmt.Fprintf(w, "Score:   %s\n", scoreMeter(r.Score, useColor))
+
+	// Verdict with color.
+	verdict := r.Verdict
+	if useColor {
+		verdict = colorize(r.Verdict, verdictColor(r.Verdict))
+	}
+	_, _ = fmt.Fprintf(w, "Verdict: %s\n", verdict)
+
+	if opts.Verbose {
+		_, _ = fmt.Fprintf(w, "Entropy: %.2f bits\n", r.Entropy)
+		b := r.EntropyBreakdown
+		_, _ = fmt.Fprintf(w, "  Base charset:      %.2f bits\n", b.BaseCharsetEntropy)
+		_, _ = fmt.Fprintf(w, "  Pattern reduction: %.2f bits\n", b.PatternReduction)
+		_, _ = fmt.Fprintf(w, "  Markov adjustment: %+.2f bits\n", b.MarkovAdjustment)
+		_, _ = fmt.Fprintf(w, "Keyspace: %s\n", keyspaceLine(r))
+	} else {
+		_, _ = fmt.Fprintf(w, "Entropy: %.1f bits\n", r.Entropy)
+	}
+
+	if len(r.Issues) > 0 {
+		if opts.Verbose {
+			_, _ = fmt.Fprintf(w, "\nIssues (%d):\n", len(r.Issues))
+		} else {
+			_, _ = fmt.Fprintln(w, "\nIssues:")
+		}
+		for _, iss := range r.Issues {
+			marker := "  - "
+			if useColor {
+				marker = "  " + colorize("-", ansiRed) + " "
+			}
+			_, _ = fmt.Fprintf(w, "%s%s\n", marker, iss.Message)
+		}
+	}
+
+	if len(r.Suggestions) > 0 {
+		_, _ = fmt.Fprintln(w, "\nStrengths:")
+		for _, s := range r.Suggestions {
+			marker := "  + "
+			if useColor {
+				marker = "  " + colorize("+", ansiGreen) + " "
+			}
+			_, _ = fmt.Fprintf(w, "%s%s\n", marker, s)
+		}
+	}
+
+	if len(r.Issues) == 0 && len(r.Suggestions) == 0 {
+		_, _ = fmt.Fprintln(w, "\nNo issues found.")
+	}
+}
+
+// writeMarkdown writes the report as a Markdown section.
+func writeMarkdown(w io.Writer, r passcheck.Result, opts ReportOptions) {
+	_, _ = fmt.Fprintln(w, "## Password report")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "- **Score:** `%s`\n", scoreMeter(r.Score, false))
+	_, _ = fmt.Fprintf(w, "- **Verdict:** %s\n", markdownEscape(r.Verdict))
+
+	if opts.Verbose {
+		b := r.EntropyBreakdown
+		_, _ = fmt.Fprintf(w, "- **Entropy:** %.2f bits\n", r.Entropy)
+		_, _ = fmt.Fprintf(w, "  - Base charset: %.2f bits\n", b.BaseCharsetEntropy)
+		_, _ = fmt.Fprintf(w, "  - Pattern reduction: %.2f bits\n", b.PatternReduction)
+		_, _ = fmt.Fprintf(w, "  - Markov adjustment: %+.2f bits\n", b.MarkovAdjustment)
+		_, _ = fmt.Fprintf(w, "- **Keyspace:** %s\n", markdownEscape(keyspaceLine(r)))
+	} else {
+		_, _ = fmt.Fprintf(w, "- **Entropy:** %.1f bits\n", r.Entropy)
+	}
+
+	if len(r.Issues) > 0 {
+		if opts.Verbose {
+			_, _ = fmt.Fprintf(w, "\n### Issues (%d)\n\n", len(r.Issues))
+		} else {
+			_, _ = fmt.Fprint(w, "\n### Issues\n\n")
+		}
+		for _, iss := range r.Issues {
+			_, _ = fmt.Fprintf(w, "- %s\n", markdownEscape(iss.Message))
+		}
+	}
+
+	if len(r.Suggestions) > 0 {
+		_, _ = fmt.Fprint(w, "\n### Strengths\n\n")
+		for _, s := range r.Suggestions {
+			_, _ = fmt.Fprintf(w, "- %s\n", markdownEscape(s))
+		}
+	}
+
+	if len(r.Issues) == 0 && len(r.Suggestions) == 0 {
+		_, _ = fmt.Fprint(w, "\nNo issues found.\n")
+	}
+}
+
+// markdownReplacer backslash-escapes the characters that Markdown would
+// otherwise interpret inside list items. Every interpolated text field is
+// escaped: issue messages quote fragments of the password, and verdicts
+// may be custom VerdictBands labels.
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `#`, `\#`, `|`, `\|`, `~`, `\~`,
+)
+
+// markdownEscape returns s safe to embed as Markdown text.
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(s)
+}
+
+// errWriter wraps an io.Writer and records the first write error. Once an
+// error is recorded all subsequent writes are no-ops, so a report can be
+// written with a chain of fmt.Fprintf calls and checked once at the end.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
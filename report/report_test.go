@@ -0,0 +1,245 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rafaelsanzio/passcheck"
+)
+
+// ---------------------------------------------------------------------------
+// Write
+// ---------------------------------------------------------------------------
+
+func check(t *testing.T, password string) passcheck.Result {
+	t.Helper()
+	r, err := passcheck.CheckWithConfig(password, passcheck.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestWrite_Text(t *testing.T) {
+	r := check(t, "password")
+	var buf bytes.Buffer
+	if err := Write(&buf, r, ReportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Score:   [", "Verdict: " + r.Verdict, "Entropy:", "Issues:", "  - " + r.Issues[0].Message} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\033[") || strings.Contains(out, "Keyspace") {
+		t.Errorf("default report should be uncolored and not verbose:\n%s", out)
+	}
+}
+
+func TestWrite_ColorAndVerbose(t *testing.T) {
+	r := check(t, "password")
+	var buf bytes.Buffer
+	if err := Write(&buf, r, ReportOptions{Color: true, Verbose: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"\033[", "Base charset:", "Keyspace: ≈ 2^", " at 10^10/s", "Issues ("} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWrite_VerboseUsesConfiguredGuessRate(t *testing.T) {
+	cfg := passcheck.DefaultConfig()
+	cfg.GuessesPerSecond = 1e4
+	r, err := passcheck.CheckWithConfig("Xk9$mP2!vR7@nL4&", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []Format{FormatText, FormatMarkdown} {
+		var buf bytes.Buffer
+		if err := Write(&buf, r, ReportOptions{Format: format, Verbose: true}); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		if !strings.Contains(out, r.CrackTimeDisplay) {
+			t.Errorf("format %v: output missing crack time %q:\n%s", format, r.CrackTimeDisplay, out)
+		}
+		if strings.Contains(out, "10^10/s") {
+			t.Errorf("format %v: output assumes the default guess rate:\n%s", format, out)
+		}
+	}
+}
+
+func TestWrite_Markdown(t *testing.T) {
+	r := check(t, "password")
+	r.Issues = append(r.Issues, passcheck.Issue{Message: "Contains 'a*b_c'"})
+	var buf bytes.Buffer
+	if err := Write(&buf, r, ReportOptions{Format: FormatMarkdown, Color: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"## Password report", "- **Verdict:** " + r.Verdict, "### Issues", `- Contains 'a\*b\_c'`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\033[") {
+		t.Errorf("Markdown report should not contain ANSI codes:\n%s", out)
+	}
+}
+
+func TestWrite_MarkdownEscapesVerdict(t *testing.T) {
+	r := passcheck.Result{Score: 20, Verdict: "*Weak* <b>"}
+	var buf bytes.Buffer
+	if err := Write(&buf, r, ReportOptions{Format: FormatMarkdown}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if want := `- **Verdict:** \*Weak\* \<b\>`; !strings.Contains(out, want) {
+		t.Errorf("output missing %q:\n%s", want, out)
+	}
+}
+
+func TestWrite_NoIssues(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatMarkdown} {
+		var buf bytes.Buffer
+		if err := Write(&buf, passcheck.Result{Score: 100, Verdict: "Very Strong"}, ReportOptions{Format: format}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "No issues found.") {
+			t.Errorf("format %d: output missing 'No issues found.':\n%s", format, buf.String())
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWrite_Error(t *testing.T) {
+	if err := Write(failingWriter{}, check(t, "password"), ReportOptions{}); err == nil || err.Error() != "disk full" {
+		t.Errorf("Write error = %v, want the writer's error", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// keyspace
+// ---------------------------------------------------------------------------
+
+func TestKeyspaceLine(t *testing.T) {
+	tests := []struct {
+		r    passcheck.Result
+		want string
+	}{
+		{passcheck.Result{Entropy: 40.4}, "≈ 2^40 guesses"},
+		{passcheck.Result{Entropy: 52, CrackTimeSeconds: math.Pow(2, 52) / 1e10, CrackTimeDisplay: "5 days"}, "≈ 2^52 guesses; ~5 days at 10^10/s"},
+		{passcheck.Result{Entropy: 30.2, CrackTimeSeconds: math.Pow(2, 30.2) / 2.5e9, CrackTimeDisplay: "instant"}, "≈ 2^30 guesses; ~instant at 2.5e+09/s"},
+		{passcheck.Result{Entropy: 2000, CrackTimeSeconds: math.MaxFloat64, CrackTimeDisplay: "centuries"}, "≈ 2^2000 guesses; ~centuries"},
+	}
+	for _, tt := range tests {
+		if got := keyspaceLine(tt.r); got != tt.want {
+			t.Errorf("keyspaceLine(%+v) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// color helpers
+// ---------------------------------------------------------------------------
+
+func TestVerdictColor(t *testing.T) {
+	tests := []struct {
+		verdict string
+		want    string
+	}{
+		{"Very Weak", ansiRed + ansiBold},
+		{"Weak", ansiRed},
+		{"Okay", ansiYellow},
+		{"Strong", ansiGreen},
+		{"Very Strong", ansiGreen + ansiBold},
+		{"Unknown", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.verdict, func(t *testing.T) {
+			if got := verdictColor(tt.verdict); got != tt.want {
+				t.Errorf("verdictColor(%q) = %q, want %q", tt.verdict, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreColor(t *testing.T) {
+	tests := []struct {
+		score int
+		want  string
+	}{
+		{0, ansiRed + ansiBold},
+		{20, ansiRed + ansiBold},
+		{21, ansiRed},
+		{40, ansiRed},
+		{41, ansiYellow},
+		{60, ansiYellow},
+		{61, ansiGreen},
+		{80, ansiGreen},
+		{81, ansiGreen + ansiBold},
+		{100, ansiGreen + ansiBold},
+	}
+	for _, tt := range tests {
+		if got := scoreColor(tt.score); got != tt.want {
+			t.Errorf("scoreColor(%d) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestScoreMeter_NoColor(t *testing.T) {
+	meter := scoreMeter(80, false)
+	if !strings.Contains(meter, "80/100") {
+		t.Errorf("meter should contain '80/100': %s", meter)
+	}
+	if !strings.Contains(meter, "████████") {
+		t.Errorf("meter should have 8 filled blocks: %s", meter)
+	}
+	if !strings.Contains(meter, "░░") {
+		t.Errorf("meter should have 2 empty blocks: %s", meter)
+	}
+}
+
+func TestScoreMeter_WithColor(t *testing.T) {
+	meter := scoreMeter(80, true)
+	if !strings.Contains(meter, "\033[") {
+		t.Error("colored meter should contain ANSI codes")
+	}
+	if !strings.Contains(meter, "80/100") {
+		t.Errorf("meter should contain score: %s", meter)
+	}
+}
+
+func TestScoreMeter_Zero(t *testing.T) {
+	meter := scoreMeter(0, false)
+	if !strings.Contains(meter, "0/100") {
+		t.Errorf("zero meter should show 0/100: %s", meter)
+	}
+	if !strings.Contains(meter, "░░░░░░░░░░") {
+		t.Errorf("zero meter should be all empty: %s", meter)
+	}
+}
+
+func TestScoreMeter_Full(t *testing.T) {
+	meter := scoreMeter(100, false)
+	if !strings.Contains(meter, "██████████") {
+		t.Errorf("full meter should be all filled: %s", meter)
+	}
+}
+
+func TestColorize(t *testing.T) {
+	result := colorize("hello", ansiRed)
+	if result != ansiRed+"hello"+ansiReset {
+		t.Errorf("colorize: got %q", result)
+	}
+}
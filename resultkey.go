@@ -0,0 +1,106 @@
+package passcheck
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// processKey is the random HMAC key used by [ResultKey] when
+// Config.KeySalt is empty. It is generated once per process.
+var (
+	processKeyOnce sync.Once
+	processKey     []byte
+)
+
+// ResultKey returns a non-reversible key identifying the pair (password,
+// cfg), suitable for caching check results or correlating telemetry
+// without storing the password.
+//
+// The key is an HMAC-SHA256 over the password and a fingerprint of cfg,
+// keyed with cfg.KeySalt (or a random per-process value when KeySalt is
+// empty). Because the hash is keyed, keys cannot be brute-forced offline
+// by anyone who does not hold the key, and keys from different deployments
+// cannot be correlated. The same password checked under a different
+// configuration yields a different key, with one exception: a
+// HIBPChecker contributes only its type, so checkers of the same type
+// backed by different breach data share keys. Keep separate caches (or
+// KeySalt values) for them.
+//
+// ResultKey is intended for cache and telemetry keys only. It is not a
+// password hash: do not use it to store or verify credentials.
+func ResultKey(password string, cfg Config) string {
+	return cacheKey(password, cfg)
+}
+
+// cacheKey implements [ResultKey].
+func cacheKey(password string, cfg Config) string {
+	key := cfg.KeySalt
+	if len(key) == 0 {
+		key = randomProcessKey()
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(configFingerprint(cfg)))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomProcessKey returns the per-process HMAC key, generating it on
+// first use.
+func randomProcessKey() []byte {
+	processKeyOnce.Do(func() {
+		processKey = make([]byte, 32)
+		if _, err := rand.Read(processKey); err != nil {
+			panic(fmt.Sprintf("passcheck: generate result key: %v", err))
+		}
+	})
+	return processKey
+}
+
+// configFingerprint returns a stable string describing the fields of cfg
+// that influence a check result: the serialized policy (see
+// [Config.MarshalJSON]) plus the runtime fields that change results.
+// Pointer fields contribute their values, never their addresses, so equal
+// configs built separately share a fingerprint. PasswordSet and MarkovModel
+// contribute the digests of the lists they were loaded from, and
+// HIBPChecker its type; KeySalt, the hooks, and the Observer are excluded.
+func configFingerprint(cfg Config) string {
+	rules := make([]string, len(cfg.CustomRules))
+	for i, r := range cfg.CustomRules {
+		rules[i] = ruleIdentity(r)
+	}
+	// Every field is plain data, so encoding cannot fail.
+	b, _ := json.Marshal(struct {
+		Policy              configJSON
+		CustomRules         []string
+		HIBPChecker         string
+		HIBPResult          *HIBPCheckResult
+		PasswordSet         string
+		MarkovModel         string
+		PreviousPasswords   []string
+		CurrentPasswordHash string
+	}{
+		Policy:              toConfigJSON(cfg),
+		CustomRules:         rules,
+		HIBPChecker:         checkerType(cfg.HIBPChecker),
+		HIBPResult:          cfg.HIBPResult,
+		PasswordSet:         cfg.PasswordSet.Digest(),
+		MarkovModel:         cfg.MarkovModel.Digest(),
+		PreviousPasswords:   cfg.PreviousPasswords,
+		CurrentPasswordHash: cfg.CurrentPasswordHash,
+	})
+	return string(b)
+}
+
+// checkerType returns the dynamic type of c, or "" when c is nil.
+func checkerType(c any) string {
+	if c == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", c)
+}
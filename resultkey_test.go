@@ -0,0 +1,137 @@
+package passcheck
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestResultKey_Deterministic(t *testing.T) {
+	cfg := DefaultConfig()
+	a := ResultKey("Tr0ub4dor&3", cfg)
+	b := ResultKey("Tr0ub4dor&3", cfg)
+	if a != b {
+		t.Errorf("ResultKey not deterministic within a process: %q vs %q", a, b)
+	}
+	if len(a) != 64 {
+		t.Errorf("len(ResultKey) = %d, want 64 hex chars", len(a))
+	}
+}
+
+func TestResultKey_Distinguishes(t *testing.T) {
+	cfg := DefaultConfig()
+	base := ResultKey("Tr0ub4dor&3", cfg)
+
+	if ResultKey("Tr0ub4dor&4", cfg) == base {
+		t.Error("different passwords should yield different keys")
+	}
+
+	other := DefaultConfig()
+	other.MinLength = 16
+	if ResultKey("Tr0ub4dor&3", other) == base {
+		t.Error("different configs should yield different keys")
+	}
+
+	salted := DefaultConfig()
+	salted.KeySalt = []byte("deployment-secret")
+	if ResultKey("Tr0ub4dor&3", salted) == base {
+		t.Error("KeySalt should change the key")
+	}
+
+	hooked := DefaultConfig()
+	hooked.OnResult = func(context.Context, Result) {}
+	if ResultKey("Tr0ub4dor&3", hooked) != base {
+		t.Error("result hooks should not change the key")
+	}
+}
+
+func TestResultKey_KeySaltStable(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.KeySalt = []byte("deployment-secret")
+	got := ResultKey("password", cfg)
+	if got != ResultKey("password", cfg) {
+		t.Fatal("salted key not deterministic")
+	}
+	cfg.KeySalt = []byte("another-secret")
+	if ResultKey("password", cfg) == got {
+		t.Error("different salts should yield different keys")
+	}
+}
+
+func TestResultKey_NonReversible(t *testing.T) {
+	cfg := DefaultConfig()
+	// Passwords with non-hex letters so a match cannot occur by chance.
+	for _, pw := range []string{"password", "hunter2xyz", "qwertyuiop"} {
+		key := ResultKey(pw, cfg)
+		for i := 0; i+3 <= len(pw); i++ {
+			if sub := pw[i : i+3]; strings.Contains(key, sub) {
+				t.Errorf("ResultKey(%q) = %q contains password substring %q", pw, key, sub)
+			}
+		}
+	}
+}
+
+func TestResultKey_EqualConfigsShareKey(t *testing.T) {
+	build := func() Config {
+		cfg := DefaultConfig()
+		allow := true
+		cfg.AllowWhitespace = &allow
+		cfg.VerdictThresholds = &VerdictThresholds{VeryWeakMax: 10, WeakMax: 30, OkayMax: 50, StrongMax: 70}
+		cfg.PenaltyWeights = &PenaltyWeights{RuleViolation: 2}
+		cfg.CustomRules = []Rule{RegexRule("ORG_CODENAME", "Do not use project codenames", regexp.MustCompile(`(?i)bluebird`), false)}
+		return cfg
+	}
+	a, b := build(), build()
+	if ResultKey("Tr0ub4dor&3", a) != ResultKey("Tr0ub4dor&3", b) {
+		t.Error("equal configs built separately should yield the same key")
+	}
+
+	b.VerdictThresholds.StrongMax = 80
+	if ResultKey("Tr0ub4dor&3", a) == ResultKey("Tr0ub4dor&3", b) {
+		t.Error("a changed VerdictThresholds value should change the key")
+	}
+	b = build()
+	b.CustomRules = []Rule{RegexRule("ORG_CODENAME", "Do not use project codenames", regexp.MustCompile(`(?i)nightjar`), false)}
+	if ResultKey("Tr0ub4dor&3", a) == ResultKey("Tr0ub4dor&3", b) {
+		t.Error("a different custom rule should change the key")
+	}
+	b = build()
+	b.PreviousPasswords = []string{"Tr0ub4dor&2"}
+	if ResultKey("Tr0ub4dor&3", a) == ResultKey("Tr0ub4dor&3", b) {
+		t.Error("PreviousPasswords should change the key")
+	}
+}
+
+func TestResultKey_ListContents(t *testing.T) {
+	load := func(list string) *PasswordSet {
+		t.Helper()
+		set, err := LoadPasswordList(strings.NewReader(list))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return set
+	}
+	a, b := DefaultConfig(), DefaultConfig()
+	a.PasswordSet = load("zorblax-quintet-77\n")
+	b.PasswordSet = load("glimmer-otter-42\n")
+	if ResultKey("Zorblax-Quintet-77", a) == ResultKey("Zorblax-Quintet-77", b) {
+		t.Error("PasswordSets of the same size with different entries should yield different keys")
+	}
+	b.PasswordSet = load("zorblax-quintet-77\n")
+	if ResultKey("Zorblax-Quintet-77", a) != ResultKey("Zorblax-Quintet-77", b) {
+		t.Error("PasswordSets loaded from the same list should share a key")
+	}
+
+	a.MarkovModel, _ = LoadMarkovModel(strings.NewReader("qwerty\n"))
+	b.MarkovModel, _ = LoadMarkovModel(strings.NewReader("zxcvbn\n"))
+	if ResultKey("Zorblax-Quintet-77", a) == ResultKey("Zorblax-Quintet-77", b) {
+		t.Error("MarkovModels of the same size trained on different corpora should yield different keys")
+	}
+
+	a.HIBPChecker = &mockHIBP{}
+	b.HIBPChecker = hibpCheckerFunc(func(string) (bool, int, error) { return false, 0, nil })
+	if ResultKey("Zorblax-Quintet-77", a) == ResultKey("Zorblax-Quintet-77", b) {
+		t.Error("HIBPCheckers of different types should yield different keys")
+	}
+}
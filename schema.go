@@ -0,0 +1,135 @@
+package passcheck
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// issueCodes lists every exported issue code, in declaration order, for
+// the Code enum of [ResultJSONSchema].
+var issueCodes = []string{
+	CodeRuleTooShort,
+	CodeRuleTooLong,
+	CodeRuleNoUpper,
+	CodeRuleNoLower,
+	CodeRuleNoDigit,
+	CodeRuleNoSymbol,
+	CodeRuleInsufficientClasses,
+	CodeRuleWhitespace,
+	CodeRuleControlChar,
+	CodeRuleRepeatedChars,
+	CodeRuleNoAlphanumeric,
+	CodeRuleLowDiversity,
+	CodeRuleNumericOnly,
+	CodeRuleLowEntropy,
+	CodePatternKeyboard,
+	CodePatternAdjacentWalk,
+	CodePatternSequence,
+	CodePatternBlock,
+	CodePatternSubstitution,
+	CodePatternDate,
+	CodePatternForbidden,
+	CodePatternAffixed,
+	CodePatternRepeatedWord,
+	CodePatternTemplateRepeat,
+	CodePatternCaseTokenized,
+	CodePassphraseWeakWords,
+	CodeDictCommonPassword,
+	CodeDictLeetVariant,
+	CodeDictCommonWord,
+	CodeDictCommonWordSub,
+	CodeDictCapitalizedCommon,
+	CodeDictMirrored,
+	CodeDictTrending,
+	CodeDictReversedWord,
+	CodeDictPasswordSubstring,
+	CodeDictNearCommon,
+	CodeDictMangledCommon,
+	CodeHIBPBreached,
+	CodeHistorySharedSubstring,
+	CodeHistoryReuse,
+	CodeRuleTooSimilar,
+	CodeContextWord,
+}
+
+// resultSchema is the encoded schema, built once.
+var resultSchema = sync.OnceValue(func() []byte {
+	str := map[string]any{"type": "string"}
+	num := map[string]any{"type": "number"}
+	strs := map[string]any{"type": []string{"array", "null"}, "items": str}
+	schema := map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         "https://github.com/rafaelsanzio/passcheck/result.schema.json",
+		"title":       "passcheck Result",
+		"description": "The JSON encoding of passcheck.Result.",
+		"type":        "object",
+		"required": []string{
+			"score", "verdict", "meets_policy", "issues", "suggestions",
+			"strength_reasons", "improvements", "entropy", "entropy_breakdown",
+			"crack_time_seconds", "crack_time_display", "detected_type", "policy_id",
+		},
+		"properties": map[string]any{
+			"score":        map[string]any{"type": "integer", "minimum": 0, "maximum": 100},
+			"verdict":      map[string]any{"type": "string", "description": `"Very Weak", "Weak", "Okay", "Strong", or "Very Strong", or a Config.VerdictBands label.`},
+			"meets_policy": map[string]any{"type": "boolean"},
+			"issues": map[string]any{
+				"type":  []string{"array", "null"},
+				"items": map[string]any{"$ref": "#/$defs/issue"},
+			},
+			"suggestions":      strs,
+			"strength_reasons": strs,
+			"improvements":     strs,
+			"entropy":          num,
+			"entropy_breakdown": map[string]any{
+				"type":     "object",
+				"required": []string{"base_charset_entropy", "pattern_reduction", "markov_adjustment", "final_entropy"},
+				"properties": map[string]any{
+					"base_charset_entropy": num,
+					"pattern_reduction":    num,
+					"markov_adjustment":    num,
+					"final_entropy":        num,
+				},
+			},
+			"crack_time_seconds": num,
+			"crack_time_display": str,
+			"detected_type":      map[string]any{"enum": []string{TypePassword, TypePassphrase, TypeToken}},
+			"policy_id":          str,
+		},
+		"$defs": map[string]any{
+			"issue": map[string]any{
+				"type":     "object",
+				"required": []string{"code", "message", "category", "severity"},
+				"properties": map[string]any{
+					"code":    map[string]any{"enum": issueCodes},
+					"message": str,
+					"category": map[string]any{"enum": []string{
+						CategoryRule, CategoryPattern, CategoryDictionary,
+						CategoryContext, CategoryBreach, CategoryHistory,
+					}},
+					"severity":    map[string]any{"type": "integer", "minimum": int(SeverityLow), "maximum": int(SeverityHigh)},
+					"hint":        str,
+					"match":       str,
+					"match_start": map[string]any{"type": "integer", "minimum": 0},
+					"match_end":   map[string]any{"type": "integer", "minimum": 0},
+				},
+			},
+		},
+	}
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		panic("passcheck: encode result schema: " + err.Error())
+	}
+	return b
+})
+
+// ResultJSONSchema returns a JSON Schema (draft 2020-12) describing the
+// JSON encoding of [Result], for generating typed client models. The code
+// property of an issue is an enum of the exported Code constants; codes
+// reported by Config.CustomRules are not part of it.
+//
+// The schema is generated from the package itself, so it changes only when
+// Result does; the returned slice is a fresh copy on every call.
+func ResultJSONSchema() []byte {
+	b := resultSchema()
+	return append([]byte(nil), b...)
+}
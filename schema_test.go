@@ -0,0 +1,104 @@
+package passcheck
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResultJSONSchema(t *testing.T) {
+	var schema struct {
+		Schema     string                     `json:"$schema"`
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       struct {
+			Issue struct {
+				Properties map[string]struct {
+					Enum []string `json:"enum"`
+				} `json:"properties"`
+			} `json:"issue"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(ResultJSONSchema(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if schema.Schema != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("$schema = %q", schema.Schema)
+	}
+
+	// Every JSON field of Result and Issue is described.
+	resultKeys := jsonKeys(reflect.TypeOf(Result{}))
+	if got := slices.Sorted(maps.Keys(schema.Properties)); !slices.Equal(got, resultKeys) {
+		t.Errorf("Result properties = %v, want %v", got, resultKeys)
+	}
+	if got := slices.Sorted(slices.Values(schema.Required)); !slices.Equal(got, resultKeys) {
+		t.Errorf("required = %v, want %v", got, resultKeys)
+	}
+	if got, want := slices.Sorted(maps.Keys(schema.Defs.Issue.Properties)), jsonKeys(reflect.TypeOf(Issue{})); !slices.Equal(got, want) {
+		t.Errorf("Issue properties = %v, want %v", got, want)
+	}
+
+	codes := schema.Defs.Issue.Properties["code"].Enum
+	for _, pw := range []string{"password", "qwerty123", "aaaa", "Xk9$mP2!vR7@nL4&wQ", "482915736201"} {
+		for _, iss := range Check(pw).Issues {
+			if !slices.Contains(codes, iss.Code) {
+				t.Errorf("code %s of %q is missing from the schema", iss.Code, pw)
+			}
+		}
+	}
+}
+
+func TestResultJSONSchema_CodesMatchConstants(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "passcheck.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				if strings.HasPrefix(name.Name, "Code") {
+					want = append(want, name.Name)
+				}
+			}
+		}
+	}
+	if len(want) != len(issueCodes) {
+		t.Errorf("issueCodes has %d entries, passcheck.go declares %d Code constants: %v", len(issueCodes), len(want), want)
+	}
+	if len(slices.Compact(slices.Sorted(slices.Values(issueCodes)))) != len(issueCodes) {
+		t.Error("issueCodes has duplicates")
+	}
+}
+
+func TestResultJSONSchema_ReturnsCopy(t *testing.T) {
+	b := ResultJSONSchema()
+	b[0] = 'x'
+	if ResultJSONSchema()[0] != '{' {
+		t.Error("modifying the returned slice changed the schema")
+	}
+}
+
+// jsonKeys returns the sorted JSON field names of struct type t.
+func jsonKeys(t reflect.Type) []string {
+	var keys []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.IsExported() && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,32 @@
+package passcheck
+
+import (
+	"strconv"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// Severity ranks how serious an [Issue] is; higher is more critical. It is
+// encoded in JSON as its number; use [Severity.String] for a readable form.
+type Severity int
+
+// Severity levels.
+const (
+	SeverityLow    Severity = issue.SeverityLow  // rule violations (length, charset, etc.)
+	SeverityMedium Severity = issue.SeverityMed  // pattern detection (keyboard, sequence, block)
+	SeverityHigh   Severity = issue.SeverityHigh // dictionary, context, breach, and history matches
+)
+
+// String returns "low", "medium", or "high", or "Severity(n)" for values
+// outside the defined levels.
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	}
+	return "Severity(" + strconv.Itoa(int(s)) + ")"
+}
@@ -0,0 +1,45 @@
+package passcheck
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSeverity_String(t *testing.T) {
+	tests := map[Severity]string{
+		SeverityLow:    "low",
+		SeverityMedium: "medium",
+		SeverityHigh:   "high",
+		Severity(0):    "Severity(0)",
+		Severity(7):    "Severity(7)",
+	}
+	for s, want := range tests {
+		if got := s.String(); got != want {
+			t.Errorf("Severity(%d).String() = %q, want %q", int(s), got, want)
+		}
+	}
+}
+
+func TestSeverity_IssuesUseConstants(t *testing.T) {
+	r := Check("password")
+	for _, iss := range r.Issues {
+		switch iss.Severity {
+		case SeverityLow, SeverityMedium, SeverityHigh:
+		default:
+			t.Errorf("%s: severity %d is not a defined level", iss.Code, iss.Severity)
+		}
+	}
+
+	// JSON keeps the numeric encoding.
+	data, err := json.Marshal(Issue{Code: CodeRuleTooShort, Severity: SeverityLow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["severity"] != float64(1) {
+		t.Errorf("severity encoded as %v, want 1", decoded["severity"])
+	}
+}
@@ -0,0 +1,52 @@
+package passcheck
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/rafaelsanzio/passcheck/internal/safemem"
+)
+
+// CheckUTF16 evaluates password strength from a mutable UTF-16 byte buffer
+// using a custom configuration, for passwords handed over by Windows APIs
+// without a lossy conversion on the caller side.
+//
+// A leading byte order mark selects little- or big-endian decoding and is
+// not part of the password; without one, buf is decoded as UTF-16LE.
+// Unpaired surrogates and a trailing odd byte decode to U+FFFD rather than
+// failing the check. Like [CheckBytesWithConfig], buf and the intermediate
+// decoding buffers are zeroed before the check runs; the caller should
+// not reuse buf after this call.
+//
+// Returns an error if the configuration is invalid.
+func CheckUTF16(buf []byte, cfg Config) (Result, error) {
+	s := decodeUTF16(buf)
+	safemem.Zero(buf)
+	return CheckWithConfig(s, cfg)
+}
+
+// decodeUTF16 decodes buf as UTF-16, honoring a leading byte order mark
+// and defaulting to little-endian, and zeroes its working buffers.
+func decodeUTF16(buf []byte) string {
+	var order binary.ByteOrder = binary.LittleEndian
+	switch {
+	case len(buf) >= 2 && buf[0] == 0xFF && buf[1] == 0xFE:
+		buf = buf[2:]
+	case len(buf) >= 2 && buf[0] == 0xFE && buf[1] == 0xFF:
+		order, buf = binary.BigEndian, buf[2:]
+	}
+
+	units := make([]uint16, len(buf)/2)
+	for i := range units {
+		units[i] = order.Uint16(buf[2*i:])
+	}
+	runes := utf16.Decode(units)
+	clear(units)
+	if len(buf)%2 != 0 {
+		runes = append(runes, utf8.RuneError)
+	}
+	s := string(runes)
+	clear(runes)
+	return s
+}
@@ -0,0 +1,66 @@
+package passcheck
+
+import (
+	"testing"
+	"unicode/utf16"
+
+	"github.com/rafaelsanzio/passcheck/internal/safemem"
+)
+
+// utf16LE encodes s as UTF-16LE.
+func utf16LE(s string) []byte {
+	var buf []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		buf = append(buf, byte(u), byte(u>>8))
+	}
+	return buf
+}
+
+func TestCheckUTF16(t *testing.T) {
+	const pw = "Xk9$mP2!vR7@nL4&wQ€😀"
+	want, err := CheckWithConfig(pw, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	le := utf16LE(pw)
+	be := make([]byte, len(le))
+	for i := 0; i < len(le); i += 2 {
+		be[i], be[i+1] = le[i+1], le[i]
+	}
+	for name, buf := range map[string][]byte{
+		"LE without BOM": le,
+		"LE with BOM":    append([]byte{0xFF, 0xFE}, le...),
+		"BE with BOM":    append([]byte{0xFE, 0xFF}, be...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := CheckUTF16(buf, DefaultConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Score != want.Score || got.Entropy != want.Entropy {
+				t.Errorf("score %d, entropy %.2f; want %d, %.2f", got.Score, got.Entropy, want.Score, want.Entropy)
+			}
+			if !safemem.IsZeroed(buf) {
+				t.Error("input buffer was not zeroed")
+			}
+		})
+	}
+}
+
+func TestCheckUTF16_Invalid(t *testing.T) {
+	// An unpaired high surrogate and a trailing odd byte become U+FFFD.
+	buf := append(utf16LE("password"), 0x00, 0xD8, 'x')
+	if got := decodeUTF16(buf); got != "password��" {
+		t.Errorf("decodeUTF16 = %q, want two replacement characters", got)
+	}
+	if _, err := CheckUTF16(buf, DefaultConfig()); err != nil {
+		t.Errorf("CheckUTF16 with invalid UTF-16: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.MinLength = 0
+	if _, err := CheckUTF16(utf16LE("password"), cfg); err == nil {
+		t.Error("expected a config error")
+	}
+}
@@ -0,0 +1,49 @@
+package passcheck
+
+import (
+	"github.com/rafaelsanzio/passcheck/internal/feedback"
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+	"github.com/rafaelsanzio/passcheck/internal/scoring"
+)
+
+// Violations returns the issues that breach configured policy, as opposed
+// to advisory findings about patterns, dictionary words, or entropy
+// estimates: rule violations (RULE_*, including RULE_LOW_ENTROPY),
+// password-history findings (HISTORY_*), and PATTERN_FORBIDDEN matches
+// when cfg.ForbiddenPatternIsFatal is set. cfg should be the configuration
+// the password was checked with.
+//
+// Unlike Result.Issues, the list is neither deduplicated against advisory
+// findings nor limited by MaxIssues or SuppressAllIssues, so gating code
+// sees every breach. It is empty exactly when MeetsPolicy is true, and
+// nil for a Result that was not produced by a check.
+func (r Result) Violations(cfg Config) []Issue {
+	var out []Issue
+	for _, iss := range r.policyIssues {
+		if iss.Code == CodePatternForbidden && !cfg.ForbiddenPatternIsFatal {
+			continue
+		}
+		out = append(out, iss)
+	}
+	return out
+}
+
+// policyIssues returns the findings that can breach policy — rule
+// violations, history findings, and forbidden-pattern matches — with
+// messages translated for locale.
+func policyIssues(set scoring.IssueSet, locale string) []issue.Issue {
+	var out []issue.Issue
+	out = append(out, set.Rules...)
+	out = append(out, set.History...)
+	for _, iss := range set.Patterns {
+		if iss.Code == issue.CodePatternForbidden {
+			out = append(out, iss)
+		}
+	}
+	for i := range out {
+		if msg, ok := feedback.Localize(locale, out[i].Code); ok {
+			out[i].Message = msg
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package passcheck
+
+import (
+	"slices"
+
+	"github.com/rafaelsanzio/passcheck/internal/issue"
+)
+
+// Weakness is one reported issue together with the score it costs, as
+// returned by [Result.TopWeaknesses].
+type Weakness struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// EstimatedGain is how many points Score would rise if the issue were
+	// fixed and nothing else changed: the difference between the score
+	// re-computed without the issue and the actual score. It accounts for
+	// penalty weights, clamping at 100, and the trending and fatal
+	// forbidden-pattern caps, but not for knock-on effects of the fix
+	// such as a longer password or changed entropy.
+	EstimatedGain int `json:"estimated_gain"`
+}
+
+// TopWeaknesses returns up to n of the issues in r.Issues ranked by how
+// much fixing each would raise the score, largest gain first, for coaching
+// UIs that present a prioritized plan. Issues with equal gains keep their
+// order in r.Issues. n <= 0 returns every issue.
+//
+// Only the reported issues are ranked, so the list is bounded by
+// Config.MaxIssues and empty with SuppressAllIssues. It is nil for a Result
+// that was not produced by a check.
+func (r Result) TopWeaknesses(n int) []Weakness {
+	if n <= 0 || n > len(r.weaknesses) {
+		n = len(r.weaknesses)
+	}
+	return slices.Clone(r.weaknesses[:n])
+}
+
+// weaknesses pairs each refined issue with its public form and its
+// estimated gain under cfg, ranked by gain. score is the findings' score.
+func (f findings) weaknesses(cfg Config, score int, refined []issue.Issue, public []Issue) []Weakness {
+	out := make([]Weakness, len(refined))
+	for i, iss := range refined {
+		out[i] = Weakness{
+			Code:          public[i].Code,
+			Message:       public[i].Message,
+			EstimatedGain: f.without(iss).score(cfg) - score,
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Weakness) int { return b.EstimatedGain - a.EstimatedGain })
+	return out
+}
+
+// without returns a copy of the findings with the first occurrence of iss
+// removed from its category. Issues are matched on everything but the
+// message, which refinement may have localized.
+func (f findings) without(iss issue.Issue) findings {
+	iss.Message = ""
+	same := func(other issue.Issue) bool {
+		other.Message = ""
+		return other == iss
+	}
+	for _, list := range []*[]issue.Issue{
+		&f.issues.Rules, &f.issues.Patterns, &f.issues.Dictionary,
+		&f.issues.Context, &f.issues.HIBP, &f.issues.History,
+	} {
+		if i := slices.IndexFunc(*list, same); i >= 0 {
+			*list = slices.Delete(slices.Clone(*list), i, i+1)
+			return f
+		}
+	}
+	return f
+}
@@ -0,0 +1,106 @@
+package passcheck
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResult_TopWeaknesses(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxIssues = 0
+	r, err := CheckWithConfig("password1", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := r.TopWeaknesses(0)
+	if len(all) != len(r.Issues) {
+		t.Fatalf("got %d weaknesses, want one per issue (%d)", len(all), len(r.Issues))
+	}
+	for i, w := range all {
+		if !r.Has(w.Code) {
+			t.Errorf("weakness %s is not among the issues", w.Code)
+		}
+		if w.EstimatedGain < 0 || i > 0 && w.EstimatedGain > all[i-1].EstimatedGain {
+			t.Errorf("weaknesses not ranked by gain: %+v", all)
+		}
+	}
+	if top := r.TopWeaknesses(2); !slices.Equal(top, all[:2]) {
+		t.Errorf("TopWeaknesses(2) = %+v, want %+v", top, all[:2])
+	}
+	if got := r.TopWeaknesses(100); len(got) != len(all) {
+		t.Errorf("TopWeaknesses(100) returned %d, want %d", len(got), len(all))
+	}
+}
+
+func TestResult_TopWeaknesses_Gain(t *testing.T) {
+	const pw = "Xk9$mP2!vR7@nL4&wQ"
+	clean, err := CheckWithConfig(pw, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A trending password is capped at Weak; fixing that issue restores
+	// the score it would otherwise have.
+	cfg := DefaultConfig()
+	cfg.HotList = []string{pw}
+	r, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := r.TopWeaknesses(1)
+	if len(top) != 1 || top[0].Code != CodeDictTrending {
+		t.Fatalf("TopWeaknesses(1) = %+v, want %s", top, CodeDictTrending)
+	}
+	if want := clean.Score - r.Score; top[0].EstimatedGain != want {
+		t.Errorf("EstimatedGain = %d, want %d", top[0].EstimatedGain, want)
+	}
+	if top[0].Message != r.Issues[0].Message {
+		t.Errorf("Message = %q, want the issue's %q", top[0].Message, r.Issues[0].Message)
+	}
+}
+
+func TestResult_TopWeaknesses_Localized(t *testing.T) {
+	RegisterMessages("xx-Weakness", map[string]string{
+		CodePatternKeyboard: "Padrão de teclado",
+	})
+	const pw = "qwertyXk9$mP2!vR7"
+	english, err := CheckWithConfig(pw, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.Locale = "xx-Weakness"
+	localized, err := CheckWithConfig(pw, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gain := func(r Result) int {
+		for _, w := range r.TopWeaknesses(0) {
+			if w.Code == CodePatternKeyboard {
+				return w.EstimatedGain
+			}
+		}
+		t.Fatalf("no %s weakness in %+v", CodePatternKeyboard, r.Issues)
+		return 0
+	}
+	if g := gain(localized); g == 0 || g != gain(english) {
+		t.Errorf("localized gain = %d, want the English gain %d (> 0)", g, gain(english))
+	}
+}
+
+func TestResult_TopWeaknesses_Empty(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SuppressAllIssues = true
+	r, err := CheckWithConfig("password1", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.TopWeaknesses(3); len(got) != 0 {
+		t.Errorf("with SuppressAllIssues: %+v, want none", got)
+	}
+	if got := (Result{}).TopWeaknesses(3); got != nil {
+		t.Errorf("zero Result: %+v, want nil", got)
+	}
+}